@@ -8,6 +8,7 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/analytics/cmd/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/analytics/cmd/prices"
 	"github.com/wormhole-foundation/wormhole-explorer/analytics/cmd/service"
+	"github.com/wormhole-foundation/wormhole-explorer/analytics/cmd/unknowntokens"
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
 
@@ -55,6 +56,12 @@ func addBackfiller(root *cobra.Command) {
 	}
 	addPricesCommand(prices)
 	root.AddCommand(prices)
+
+	unknownTokens := &cobra.Command{
+		Use: "unknown-tokens",
+	}
+	addUnknownTokensBackfillCommand(unknownTokens)
+	root.AddCommand(unknownTokens)
 }
 
 func addVaaCountCommand(parent *cobra.Command) {
@@ -190,6 +197,7 @@ func addVaaVolumeCommand(parent *cobra.Command) {
 func addPricesCommand(parent *cobra.Command) {
 	addHistoryPrices(parent)
 	addVaasPrices(parent)
+	addRecomputeVolume(parent)
 }
 
 func addHistoryPrices(parent *cobra.Command) {
@@ -289,3 +297,111 @@ func addVaasPrices(parent *cobra.Command) {
 
 	parent.AddCommand(vaasPricesCmd)
 }
+
+func addUnknownTokensBackfillCommand(parent *cobra.Command) {
+	var cfg unknowntokens.Backfill
+	backfillCmd := &cobra.Command{
+		Use:   "backfill",
+		Short: "Backfill the volume of the unknown tokens mapped to a coingecko id",
+		Run: func(_ *cobra.Command, _ []string) {
+			unknowntokens.RunBackfill(cfg)
+		},
+	}
+
+	//mongo flags
+	backfillCmd.Flags().StringVar(&cfg.MongoUri, "mongo-uri", "", "Mongo connection")
+	backfillCmd.Flags().StringVar(&cfg.MongoDb, "mongo-database", "", "Mongo database")
+	backfillCmd.Flags().Int64Var(&cfg.PageSize, "page-size", 1000, "number of documents retrieved at a time")
+
+	//p2p-network flag
+	backfillCmd.Flags().StringVar(&cfg.P2PNetwork, "p2p-network", "", "P2P network")
+	backfillCmd.MarkFlagRequired("p2p-network")
+
+	//notional url flags
+	backfillCmd.Flags().StringVar(&cfg.NotionalUrl, "notional-url", "", "Notional URL")
+	backfillCmd.MarkFlagRequired("notional-url")
+
+	//vaa-payload-parser-url flag
+	backfillCmd.Flags().StringVar(&cfg.VaaPayloadParserUrl, "vaa-payload-parser-url", "", "VAA payload parser URL")
+	backfillCmd.MarkFlagRequired("vaa-payload-parser-url")
+
+	//influx flags
+	backfillCmd.Flags().StringVar(&cfg.InfluxUrl, "influx-url", "", "Influx URL")
+	backfillCmd.Flags().StringVar(&cfg.InfluxToken, "influx-token", "", "Influx token")
+	backfillCmd.Flags().StringVar(&cfg.InfluxOrganization, "influx-organization", "", "Influx organization")
+	backfillCmd.Flags().StringVar(&cfg.InfluxBucketInfinite, "influx-bucket-infinite", "", "Influx bucket with infinite retention")
+
+	// dry-run flag
+	backfillCmd.Flags().BoolVar(&cfg.DryRun, "dry-run", false, "compute the volume without writing it")
+
+	parent.AddCommand(backfillCmd)
+}
+
+func addRecomputeVolume(parent *cobra.Command) {
+	var cfg prices.RecomputeVolume
+	var start, end string
+	var tokenChainID uint16
+	recomputeVolumeCmd := &cobra.Command{
+		Use:   "recompute-volume",
+		Short: "Recompute the transfer prices and volume of a token with the historical prices",
+		Run: func(_ *cobra.Command, _ []string) {
+			cfg.TokenChain = sdk.ChainID(tokenChainID)
+			tokenAddress, err := sdk.StringToAddress(cfg.TokenAddress)
+			if err != nil {
+				log.Fatal("Failed to parse token address: ", err)
+			}
+			cfg.TokenAddress = tokenAddress.String()
+			st, err := time.Parse(time.RFC3339, start)
+			if err != nil {
+				log.Fatal("Failed to parse start: ", err)
+			}
+			cfg.StartTime = st
+			et, err := time.Parse(time.RFC3339, end)
+			if err != nil {
+				log.Fatal("Failed to parse end: ", err)
+			}
+			cfg.EndTime = et
+			prices.RunRecomputeVolume(cfg)
+		},
+	}
+
+	//mongo flags
+	recomputeVolumeCmd.Flags().StringVar(&cfg.MongoUri, "mongo-uri", "", "Mongo connection")
+	recomputeVolumeCmd.Flags().StringVar(&cfg.MongoDb, "mongo-database", "", "Mongo database")
+	recomputeVolumeCmd.Flags().Int64Var(&cfg.PageSize, "page-size", 1000, "number of documents retrieved at a time")
+
+	//p2p-network flag
+	recomputeVolumeCmd.Flags().StringVar(&cfg.P2PNetwork, "p2p-network", "", "P2P network")
+	recomputeVolumeCmd.MarkFlagRequired("p2p-network")
+
+	//notional url flags
+	recomputeVolumeCmd.Flags().StringVar(&cfg.NotionalUrl, "notional-url", "", "Notional URL")
+	recomputeVolumeCmd.MarkFlagRequired("notional-url")
+
+	//vaa-payload-parser-url flag
+	recomputeVolumeCmd.Flags().StringVar(&cfg.VaaPayloadParserUrl, "vaa-payload-parser-url", "", "VAA payload parser URL")
+	recomputeVolumeCmd.MarkFlagRequired("vaa-payload-parser-url")
+
+	//influx flags
+	recomputeVolumeCmd.Flags().StringVar(&cfg.InfluxUrl, "influx-url", "", "Influx URL")
+	recomputeVolumeCmd.Flags().StringVar(&cfg.InfluxToken, "influx-token", "", "Influx token")
+	recomputeVolumeCmd.Flags().StringVar(&cfg.InfluxOrganization, "influx-organization", "", "Influx organization")
+	recomputeVolumeCmd.Flags().StringVar(&cfg.InfluxBucketInfinite, "influx-bucket-infinite", "", "Influx bucket with infinite retention")
+
+	// token flags
+	recomputeVolumeCmd.Flags().Uint16Var(&tokenChainID, "token-chain", 0, "token chain id")
+	recomputeVolumeCmd.MarkFlagRequired("token-chain")
+	recomputeVolumeCmd.Flags().StringVar(&cfg.TokenAddress, "token-address", "", "token address in wormhole format")
+	recomputeVolumeCmd.MarkFlagRequired("token-address")
+
+	// start and end flags
+	recomputeVolumeCmd.Flags().StringVar(&start, "start", "", "start timestamp in RFC3339 format")
+	recomputeVolumeCmd.MarkFlagRequired("start")
+	recomputeVolumeCmd.Flags().StringVar(&end, "end", "", "end timestamp in RFC3339 format")
+	recomputeVolumeCmd.MarkFlagRequired("end")
+
+	// dry-run flag
+	recomputeVolumeCmd.Flags().BoolVar(&cfg.DryRun, "dry-run", false, "report the USD deltas without writing them")
+
+	parent.AddCommand(recomputeVolumeCmd)
+}
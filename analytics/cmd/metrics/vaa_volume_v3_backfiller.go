@@ -56,7 +56,7 @@ func RunBackFillerVaaVolumeV3(vaasBsonFile, outputFile, pricesFile, vaaPayloadPa
 	if err != nil {
 		loggerInstance.Fatal("failed to create parse vaa api client")
 	}
-	tokenResolver := token.NewTokenResolver(parserVAAAPIClient, loggerInstance)
+	tokenResolver := token.NewTokenResolver(parserVAAAPIClient, domain.NewProtocolRegistry(p2pNetwork), loggerInstance)
 	tokenProvider := domain.NewTokenProvider(p2pNetwork)
 	loggerInstance.Info("loading historical prices...")
 	priceCache := prices.NewCoinPricesCache(pricesFile)
@@ -176,9 +176,8 @@ func processVaa(ctx context.Context, workersPool chan struct{}, vaaData vaaChanD
 		return
 	}
 
-	m := &metric.Metric{}
 	dummyParams := &metric.Params{Vaa: &vaa.VAA{}}
-	vaaVolumeV3Point := m.MakePointVaaVolumeV3(point, dummyParams, parsedPayload)
+	vaaVolumeV3Point := metric.MakePointVaaVolumeV3(logger, point, dummyParams, parsedPayload)
 	data := lpChanData{
 		vaaId: vaaData.vaa.ID,
 		lp:    convertPointToLineProtocol(vaaVolumeV3Point),
@@ -38,7 +38,7 @@ func RunVaaVolumeFromFile(inputFile, outputFile, pricesFile, vaaPayloadParserURL
 	}
 
 	// create a token resolver
-	tokenResolver := token.NewTokenResolver(parserVAAAPIClient, logger)
+	tokenResolver := token.NewTokenResolver(parserVAAAPIClient, domain.NewProtocolRegistry(p2pNetwork), logger)
 
 	// create a token provider
 	tokenProvider := domain.NewTokenProvider(p2pNetwork)
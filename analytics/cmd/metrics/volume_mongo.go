@@ -43,7 +43,7 @@ func RunVaaVolumeFromMongo(mongoUri, mongoDb, outputFile, pricesFile, vaaPayload
 	}
 
 	// create a token resolver
-	tokenResolver := token.NewTokenResolver(parserVAAAPIClient, logger)
+	tokenResolver := token.NewTokenResolver(parserVAAAPIClient, domain.NewProtocolRegistry(p2pNetwork), logger)
 
 	// create a token provider
 	tokenProvider := domain.NewTokenProvider(p2pNetwork)
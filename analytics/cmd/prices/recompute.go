@@ -0,0 +1,282 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+	"github.com/shopspring/decimal"
+	"github.com/wormhole-foundation/wormhole-explorer/analytics/cmd/token"
+	"github.com/wormhole-foundation/wormhole-explorer/analytics/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/analytics/metric"
+	"github.com/wormhole-foundation/wormhole-explorer/analytics/metric/sink"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/parser"
+	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
+	apiPrices "github.com/wormhole-foundation/wormhole-explorer/common/prices"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// RecomputeVolume contains the configuration of the volume recomputation.
+type RecomputeVolume struct {
+	MongoUri             string
+	MongoDb              string
+	PageSize             int64
+	P2PNetwork           string
+	NotionalUrl          string
+	VaaPayloadParserUrl  string
+	InfluxUrl            string
+	InfluxToken          string
+	InfluxOrganization   string
+	InfluxBucketInfinite string
+	TokenChain           sdk.ChainID
+	TokenAddress         string
+	StartTime            time.Time
+	EndTime              time.Time
+	DryRun               bool
+}
+
+// recomputeReport accumulates the result of a volume recomputation.
+type recomputeReport struct {
+	processed int
+	changed   int
+	failed    int
+	oldUsd    decimal.Decimal
+	newUsd    decimal.Decimal
+}
+
+// RunRecomputeVolume recomputes the USD value of the transfers of a token in a date range with the historical prices.
+//
+// The `transferPrices` documents and the volume points of every transfer are written again. The volume points keep
+// the timestamp generated for the VAA, so they overwrite the previous ones and the command can be run several times.
+func RunRecomputeVolume(cfg RecomputeVolume) {
+
+	ctx := context.Background()
+
+	// build logger
+	logger := logger.New("wormhole-explorer-analytics")
+
+	logger.Info("starting wormhole-explorer-analytics volume recomputation ...",
+		zap.Uint16("tokenChain", uint16(cfg.TokenChain)),
+		zap.String("tokenAddress", cfg.TokenAddress),
+		zap.Time("start", cfg.StartTime),
+		zap.Time("end", cfg.EndTime),
+		zap.Bool("dryRun", cfg.DryRun))
+
+	//setup DB connection
+	db, err := dbutil.Connect(ctx, logger, cfg.MongoUri, cfg.MongoDb, false)
+	if err != nil {
+		logger.Fatal("Failed to connect MongoDB", zap.Error(err))
+	}
+	defer db.DisconnectWithTimeout(10 * time.Second)
+
+	// create a parserVAAAPIClient
+	parserVAAAPIClient, err := parser.NewParserVAAAPIClient(5, cfg.VaaPayloadParserUrl, logger)
+	if err != nil {
+		logger.Fatal("failed to create parse vaa api client")
+	}
+
+	// create a token provider with the mapped tokens
+	tokenProvider := domain.NewTokenProvider(cfg.P2PNetwork)
+	if err := repository.NewTokenRepository(db.Database, logger).LoadTokenMappings(ctx, tokenProvider); err != nil {
+		logger.Fatal("Failed to load token mappings", zap.Error(err))
+	}
+	tokenMeta, ok := tokenProvider.GetTokenByAddress(cfg.TokenChain, cfg.TokenAddress)
+	if !ok {
+		logger.Fatal("Token not found")
+	}
+
+	var metricsSink sink.Sink
+	if !cfg.DryRun {
+		metricsSink = sink.NewInfluxSink(influxdb2.NewClient(cfg.InfluxUrl, cfg.InfluxToken), cfg.InfluxOrganization, cfg.InfluxBucketInfinite, "", "")
+		defer metricsSink.Close()
+	}
+
+	r := &volumeRecomputer{
+		cfg:            cfg,
+		vaaRepository:  repository.NewVaaRepository(db.Database, logger),
+		transferPrices: db.Database.Collection(repository.TransferPrices),
+		tokenResolver:  token.NewTokenResolver(parserVAAAPIClient, domain.NewProtocolRegistry(cfg.P2PNetwork), logger),
+		tokenProvider:  tokenProvider,
+		tokenMeta:      tokenMeta,
+		pricesApi:      apiPrices.NewPricesApi(cfg.NotionalUrl, logger),
+		sink:           metricsSink,
+		logger:         logger,
+	}
+
+	result, err := recomputePages(ctx, logger, r.findPage, r.recompute)
+	if err != nil {
+		logger.Fatal("Failed to get transfer prices",
+			zap.Int("processed", result.processed),
+			zap.Int("failed", result.failed),
+			zap.Error(err))
+	}
+
+	logger.Info("Volume recomputation summary",
+		zap.Bool("dryRun", cfg.DryRun),
+		zap.Int("processed", result.processed),
+		zap.Int("changed", result.changed),
+		zap.Int("failed", result.failed),
+		zap.String("oldUsd", result.oldUsd.String()),
+		zap.String("newUsd", result.newUsd.String()),
+		zap.String("deltaUsd", result.newUsd.Sub(result.oldUsd).String()))
+
+	logger.Info("finished wormhole-explorer-analytics volume recomputation")
+}
+
+// recomputePages recomputes the transfers page by page until there are no transfers left.
+// The transfers that cannot be recomputed are counted as failed, while failing to get a page stops
+// the recomputation and returns the report of the transfers processed so far.
+func recomputePages(
+	ctx context.Context,
+	logger *zap.Logger,
+	findPage func(ctx context.Context, lastID string) ([]metric.TransferPriceDoc, error),
+	recompute func(ctx context.Context, doc *metric.TransferPriceDoc) (decimal.Decimal, error),
+) (recomputeReport, error) {
+
+	result := recomputeReport{oldUsd: decimal.Zero, newUsd: decimal.Zero}
+	var lastID string
+	for {
+		page, err := findPage(ctx, lastID)
+		if err != nil {
+			return result, err
+		}
+		if len(page) == 0 {
+			return result, nil
+		}
+		for _, doc := range page {
+			result.processed++
+			newUsd, err := recompute(ctx, &doc)
+			if err != nil {
+				logger.Error("Failed to recompute volume", zap.String("vaaId", doc.ID), zap.Error(err))
+				result.failed++
+				continue
+			}
+			oldUsd, err := decimal.NewFromString(doc.UsdAmount)
+			if err != nil {
+				oldUsd = decimal.Zero
+			}
+			result.oldUsd = result.oldUsd.Add(oldUsd)
+			result.newUsd = result.newUsd.Add(newUsd)
+			if !oldUsd.Equal(newUsd) {
+				result.changed++
+				logger.Info("Transfer volume changed",
+					zap.String("vaaId", doc.ID),
+					zap.String("oldUsd", oldUsd.String()),
+					zap.String("newUsd", newUsd.String()),
+					zap.String("deltaUsd", newUsd.Sub(oldUsd).String()))
+			}
+		}
+		lastID = page[len(page)-1].ID
+		logger.Info("Processing page", zap.String("lastId", lastID), zap.Int("size", len(page)))
+	}
+}
+
+type volumeRecomputer struct {
+	cfg            RecomputeVolume
+	vaaRepository  *repository.VaaRepository
+	transferPrices *mongo.Collection
+	tokenResolver  *token.TokenResolver
+	tokenProvider  *domain.TokenProvider
+	tokenMeta      *domain.TokenMetadata
+	pricesApi      *apiPrices.PricesApi
+	sink           sink.Sink
+	logger         *zap.Logger
+}
+
+// findPage returns a page of the transfer prices of the token in the date range sorted by VAA id.
+func (r *volumeRecomputer) findPage(ctx context.Context, lastID string) ([]metric.TransferPriceDoc, error) {
+	filter := bson.D{
+		{Key: "tokenChain", Value: uint16(r.cfg.TokenChain)},
+		{Key: "tokenAddress", Value: r.cfg.TokenAddress},
+		{Key: "timestamp", Value: bson.D{
+			{Key: "$gte", Value: r.cfg.StartTime},
+			{Key: "$lt", Value: r.cfg.EndTime},
+		}},
+	}
+	if lastID != "" {
+		filter = append(filter, bson.E{Key: "_id", Value: bson.D{{Key: "$gt", Value: lastID}}})
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(r.cfg.PageSize)
+	cur, err := r.transferPrices.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var docs []metric.TransferPriceDoc
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// recompute writes the transfer price and the volume points of a transfer with the historical price
+// of the token, and returns the new USD amount.
+func (r *volumeRecomputer) recompute(ctx context.Context, doc *metric.TransferPriceDoc) (decimal.Decimal, error) {
+
+	vaaDoc, err := r.vaaRepository.FindById(ctx, doc.ID)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	vaa, err := sdk.Unmarshal(vaaDoc.Vaa)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	transferredToken, err := r.tokenResolver.GetTransferredTokenByVaa(ctx, vaa)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if transferredToken == nil {
+		return decimal.Zero, errors.New("transferred token not found")
+	}
+
+	price, err := r.pricesApi.GetPriceByTime(ctx, r.tokenMeta.CoingeckoID, vaa.Timestamp)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	transferPrice := metric.MakeTransferPriceDoc(vaa, price, transferredToken.Clone(), r.tokenMeta)
+	newUsd, err := decimal.NewFromString(transferPrice.UsdAmount)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	point, err := metric.MakePointForVaaVolume(&metric.MakePointForVaaVolumeParams{
+		Logger: r.logger,
+		Vaa:    vaa,
+		TokenPriceFunc: func(_ string, _ time.Time) (decimal.Decimal, error) {
+			return price, nil
+		},
+		Metrics:          metrics.NewNoopMetrics(),
+		TransferredToken: transferredToken.Clone(),
+		TokenProvider:    r.tokenProvider,
+	})
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if point == nil {
+		return decimal.Zero, errors.New("volume point not generated")
+	}
+
+	if r.cfg.DryRun {
+		return newUsd, nil
+	}
+
+	params := &metric.Params{Vaa: vaa, VaaIsSigned: true}
+	points := []*write.Point{point, metric.MakePointVaaVolumeV3(r.logger, point, params, transferredToken)}
+	if err := r.sink.WritePoints(ctx, sink.BucketInfinite, points...); err != nil {
+		return decimal.Zero, err
+	}
+
+	_, err = r.transferPrices.UpdateByID(ctx, doc.ID, bson.M{"$set": transferPrice})
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return newUsd, nil
+}
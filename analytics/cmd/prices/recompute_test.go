@@ -0,0 +1,78 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/analytics/metric"
+	"go.uber.org/zap"
+)
+
+// pagesMock returns the pages in order and then an empty page, or err after the last page when it is set.
+type pagesMock struct {
+	pages   [][]metric.TransferPriceDoc
+	err     error
+	lastIDs []string
+}
+
+func (m *pagesMock) findPage(_ context.Context, lastID string) ([]metric.TransferPriceDoc, error) {
+	m.lastIDs = append(m.lastIDs, lastID)
+	if len(m.pages) == 0 {
+		return nil, m.err
+	}
+	page := m.pages[0]
+	m.pages = m.pages[1:]
+	return page, nil
+}
+
+func TestRecomputePages(t *testing.T) {
+	pages := &pagesMock{pages: [][]metric.TransferPriceDoc{
+		{
+			{ID: "2/a/1", UsdAmount: "10"},
+			{ID: "2/a/2", UsdAmount: "20"},
+		},
+		{
+			{ID: "2/a/3", UsdAmount: "30"},
+			{ID: "2/a/4", UsdAmount: "invalid"},
+		},
+	}}
+	newUsd := map[string]decimal.Decimal{
+		"2/a/1": decimal.NewFromInt(10),
+		"2/a/2": decimal.NewFromInt(25),
+		"2/a/4": decimal.NewFromInt(5),
+	}
+	recompute := func(_ context.Context, doc *metric.TransferPriceDoc) (decimal.Decimal, error) {
+		usd, ok := newUsd[doc.ID]
+		if !ok {
+			return decimal.Zero, errors.New("price not found")
+		}
+		return usd, nil
+	}
+
+	result, err := recomputePages(context.Background(), zap.NewNop(), pages.findPage, recompute)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"", "2/a/2", "2/a/4"}, pages.lastIDs)
+	assert.Equal(t, 4, result.processed)
+	assert.Equal(t, 2, result.changed)
+	assert.Equal(t, 1, result.failed)
+	assert.Equal(t, "30", result.oldUsd.String())
+	assert.Equal(t, "40", result.newUsd.String())
+}
+
+func TestRecomputePages_FindPageFailure(t *testing.T) {
+	pages := &pagesMock{
+		pages: [][]metric.TransferPriceDoc{{{ID: "2/a/1", UsdAmount: "10"}}},
+		err:   errors.New("mongo unavailable"),
+	}
+	recompute := func(_ context.Context, _ *metric.TransferPriceDoc) (decimal.Decimal, error) {
+		return decimal.NewFromInt(10), nil
+	}
+
+	result, err := recomputePages(context.Background(), zap.NewNop(), pages.findPage, recompute)
+	assert.Equal(t, pages.err, err)
+	assert.Equal(t, []string{"", "2/a/1"}, pages.lastIDs)
+	assert.Equal(t, 1, result.processed)
+}
@@ -59,7 +59,7 @@ func RunVaasPrices(cfg VaasPrices) {
 	vaaRepository := repository.NewVaaRepository(db.Database, logger)
 
 	// create a token resolver
-	tokenResolver := token.NewTokenResolver(parserVAAAPIClient, logger)
+	tokenResolver := token.NewTokenResolver(parserVAAAPIClient, domain.NewProtocolRegistry(cfg.P2PNetwork), logger)
 
 	// create a token provider
 	tokenProvider := domain.NewTokenProvider(cfg.P2PNetwork)
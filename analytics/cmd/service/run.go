@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -22,6 +23,7 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/analytics/http/vaa"
 	"github.com/wormhole-foundation/wormhole-explorer/analytics/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/analytics/metric"
+	"github.com/wormhole-foundation/wormhole-explorer/analytics/metric/sink"
 	"github.com/wormhole-foundation/wormhole-explorer/analytics/queue"
 	wormscanNotionalCache "github.com/wormhole-foundation/wormhole-explorer/common/client/cache/notional"
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/parser"
@@ -30,6 +32,7 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	health "github.com/wormhole-foundation/wormhole-explorer/common/health"
 	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
 )
@@ -67,9 +70,19 @@ func Run() {
 	}
 
 	// create influxdb client.
-	logger.Info("initializing InfluxDB client...")
-	influxCli := newInfluxClient(config.InfluxUrl, config.InfluxToken)
-	influxCli.Options().SetBatchSize(100)
+	var influxCli influxdb2.Client
+	if config.IsInfluxMetricsSink() {
+		logger.Info("initializing InfluxDB client...")
+		influxCli = newInfluxClient(config.InfluxUrl, config.InfluxToken)
+		influxCli.Options().SetBatchSize(100)
+	}
+
+	// create the metrics sink.
+	logger.Info("initializing metrics sink...", zap.String("sink", config.MetricsSink))
+	metricsSink, err := newMetricsSink(config, influxCli)
+	if err != nil {
+		logger.Fatal("failed to create metrics sink", zap.Error(err))
+	}
 
 	// get health check functions.
 	logger.Info("creating health check functions...")
@@ -96,15 +109,18 @@ func Run() {
 	}
 
 	// create a token resolver
-	tokenResolver := token.NewTokenResolver(parserVAAAPIClient, logger)
+	tokenResolver := token.NewTokenResolver(parserVAAAPIClient, domain.NewProtocolRegistry(config.P2pNetwork), logger)
 
 	// create a token provider
 	tokenProvider := domain.NewTokenProvider(config.P2pNetwork)
 
+	// keep the tokens mapped from the database up to date
+	tokenRepository := repository.NewTokenRepository(db.Database, logger)
+	go tokenRepository.RefreshTokenMappings(rootCtx, tokenProvider, time.Duration(config.TokenMappingsRefreshInterval)*time.Second)
+
 	// create a metrics instance
 	logger.Info("initializing metrics instance...")
-	metric, err := metric.New(rootCtx, db.Database, influxCli, config.InfluxOrganization, config.InfluxBucketInfinite,
-		config.InfluxBucket30Days, config.InfluxBucket24Hours, notionalCache, metrics, tokenResolver.GetTransferredTokenByVaa, tokenProvider, logger)
+	metric, err := metric.New(rootCtx, db.Database, metricsSink, notionalCache, metrics, tokenResolver.GetTransferredTokenByVaa, tokenProvider, logger)
 	if err != nil {
 		logger.Fatal("failed to create metrics instance", zap.Error(err))
 	}
@@ -219,6 +235,22 @@ func newInfluxClient(url, token string) influxdb2.Client {
 	return influxdb2.NewClient(url, token)
 }
 
+func newMetricsSink(cfg *config.Configuration, influxCli influxdb2.Client) (sink.Sink, error) {
+	switch cfg.MetricsSink {
+	case config.MetricsSinkInflux:
+		return sink.NewInfluxSink(influxCli, cfg.InfluxOrganization, cfg.InfluxBucketInfinite, cfg.InfluxBucket30Days, cfg.InfluxBucket24Hours), nil
+	case config.MetricsSinkFile:
+		if cfg.MetricsSinkFilePath == "" {
+			return nil, errors.New("METRICS_SINK_FILE_PATH is required for the FILE metrics sink")
+		}
+		return sink.NewFileSink(cfg.MetricsSinkFilePath)
+	case config.MetricsSinkMemory:
+		return sink.NewMemorySink(), nil
+	default:
+		return nil, fmt.Errorf("unknown metrics sink %s", cfg.MetricsSink)
+	}
+}
+
 func newHealthChecks(
 	ctx context.Context,
 	config *config.Configuration,
@@ -234,9 +266,11 @@ func newHealthChecks(
 	healthChecks := []health.Check{
 		health.SQS(awsConfig, config.PipelineSQSUrl),
 		health.SQS(awsConfig, config.NotificationsSQSUrl),
-		health.Influx(influxCli),
 		health.Mongo(db),
 	}
+	if influxCli != nil {
+		healthChecks = append(healthChecks, health.Influx(influxCli))
+	}
 	return healthChecks, nil
 }
 
@@ -13,8 +13,16 @@ import (
 	"go.uber.org/zap"
 )
 
+// errUnresolvedTokenAddress is returned by createToken when the token address cannot be decoded.
+var errUnresolvedTokenAddress = errors.New("unresolved token address")
+
 type UnknownTokenErr struct {
 	detail string
+	// TokenChain and TokenAddress are only set when the token address of the VAA cannot be resolved,
+	// they are empty when the VAA is not a valid transfer (e.g.: invalid chain, empty amount).
+	// TokenAddress is in the native format of the token chain.
+	TokenChain   sdk.ChainID
+	TokenAddress string
 }
 
 func (e *UnknownTokenErr) Error() string {
@@ -38,16 +46,23 @@ type TransferredToken struct {
 	TokenAddress sdk.Address
 	TokenChain   sdk.ChainID
 	Amount       *big.Int
+	// Fee is the amount of the token paid to the relayer, nil when the transfer has no fee.
+	Fee        *big.Int
+	FeeAddress string
+	FeeChain   sdk.ChainID
 }
 
 func (t *TransferredToken) Clone() *TransferredToken {
 	if t == nil {
 		return nil
 	}
-	var amount *big.Int
+	var amount, fee *big.Int
 	if t.Amount != nil {
 		amount = new(big.Int).Set(t.Amount)
 	}
+	if t.Fee != nil {
+		fee = new(big.Int).Set(t.Fee)
+	}
 	return &TransferredToken{
 		AppId:        t.AppId,
 		AppIDs:       t.AppIDs,
@@ -56,20 +71,27 @@ func (t *TransferredToken) Clone() *TransferredToken {
 		TokenAddress: t.TokenAddress,
 		TokenChain:   t.TokenChain,
 		Amount:       amount,
+		Fee:          fee,
+		FeeAddress:   t.FeeAddress,
+		FeeChain:     t.FeeChain,
 	}
 }
 
 type GetTransferredTokenByVaa func(context.Context, *sdk.VAA) (*TransferredToken, error)
 
 type TokenResolver struct {
-	client parser.ParserVAAAPIClient
-	logger *zap.Logger
+	client   parser.ParserVAAAPIClient
+	registry *domain.ProtocolRegistry
+	logger   *zap.Logger
 }
 
-func NewTokenResolver(client parser.ParserVAAAPIClient, logger *zap.Logger) *TokenResolver {
+// NewTokenResolver creates a new TokenResolver.
+// The registry is used to classify the transfers whose parsed payload has no appId.
+func NewTokenResolver(client parser.ParserVAAAPIClient, registry *domain.ProtocolRegistry, logger *zap.Logger) *TokenResolver {
 	return &TokenResolver{
-		client: client,
-		logger: logger,
+		client:   client,
+		registry: registry,
+		logger:   logger,
 	}
 }
 
@@ -101,12 +123,23 @@ func (r *TokenResolver) GetTransferredTokenByVaa(ctx context.Context, vaa *sdk.V
 		return nil, nil
 	}
 
+	if len(result.StandardizedProperties.AppIds) == 0 && r.registry != nil {
+		if protocol, ok := r.registry.GetProtocolByEmitter(vaa.EmitterChain, vaa.EmitterAddress.String()); ok {
+			result.StandardizedProperties.AppIds = []string{protocol.AppID}
+		}
+	}
+
 	token, err := createToken(result, vaa.EmitterChain)
 	if err != nil {
 		r.logger.Debug("Creating transferred token",
 			zap.String("vaaId", vaa.MessageID()),
 			zap.Error(err))
-		return nil, &UnknownTokenErr{detail: err.Error()}
+		unknownTokenErr := &UnknownTokenErr{detail: err.Error()}
+		if errors.Is(err, errUnresolvedTokenAddress) {
+			unknownTokenErr.TokenChain = result.StandardizedProperties.TokenChain
+			unknownTokenErr.TokenAddress = result.StandardizedProperties.TokenAddress
+		}
+		return nil, unknownTokenErr
 	}
 
 	return token, err
@@ -138,8 +171,8 @@ func createToken(p *parser.ParseVaaWithStandarizedPropertiesdResponse, emitterCh
 	if err != nil {
 		tokenParsedPayload, err := parseTokenPayload(p.ParsedPayload)
 		if err != nil {
-			return nil, fmt.Errorf("cannot decode token with tokenChain [%d] tokenAddress [%s] to hex. %v",
-				p.StandardizedProperties.TokenChain, p.StandardizedProperties.TokenAddress, err)
+			return nil, fmt.Errorf("%w: cannot decode token with tokenChain [%d] tokenAddress [%s] to hex. %v",
+				errUnresolvedTokenAddress, p.StandardizedProperties.TokenChain, p.StandardizedProperties.TokenAddress, err)
 		} else {
 			addressHex = *tokenParsedPayload.TokenAddress
 		}
@@ -147,7 +180,7 @@ func createToken(p *parser.ParseVaaWithStandarizedPropertiesdResponse, emitterCh
 
 	address, err := sdk.StringToAddress(addressHex)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", errUnresolvedTokenAddress, err)
 	}
 
 	n := new(big.Int)
@@ -156,6 +189,14 @@ func createToken(p *parser.ParseVaaWithStandarizedPropertiesdResponse, emitterCh
 		return nil, fmt.Errorf("amount [%s] is not a number", p.StandardizedProperties.Amount)
 	}
 
+	// The fee is optional, it is ignored when it is not a number
+	var fee *big.Int
+	if p.StandardizedProperties.Fee != "" {
+		if f, ok := new(big.Int).SetString(p.StandardizedProperties.Fee, 10); ok {
+			fee = f
+		}
+	}
+
 	appId := domain.AppIdUnkonwn
 	if len(p.StandardizedProperties.AppIds) > 0 {
 		appId = p.StandardizedProperties.AppIds[0]
@@ -169,6 +210,9 @@ func createToken(p *parser.ParseVaaWithStandarizedPropertiesdResponse, emitterCh
 		TokenAddress: address,
 		TokenChain:   p.StandardizedProperties.TokenChain,
 		Amount:       n,
+		Fee:          fee,
+		FeeAddress:   p.StandardizedProperties.FeeAddress,
+		FeeChain:     p.StandardizedProperties.FeeChain,
 	}, nil
 }
 
@@ -0,0 +1,74 @@
+package token
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/parser"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestCreateToken_Fee(t *testing.T) {
+	var tests = []struct {
+		name           string
+		fee            string
+		feeAddress     string
+		feeChain       sdk.ChainID
+		wantFee        *big.Int
+		wantFeeAddress string
+		wantFeeChain   sdk.ChainID
+	}{
+		{
+			name:           "fee paid to a relayer",
+			fee:            "1500000",
+			feeAddress:     "0xrelayer",
+			feeChain:       sdk.ChainIDSolana,
+			wantFee:        big.NewInt(1_500_000),
+			wantFeeAddress: "0xrelayer",
+			wantFeeChain:   sdk.ChainIDSolana,
+		},
+		{
+			name:    "transfer without fee",
+			fee:     "",
+			wantFee: nil,
+		},
+		{
+			name:    "fee is not a number",
+			fee:     "0x10",
+			wantFee: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &parser.ParseVaaWithStandarizedPropertiesdResponse{
+				StandardizedProperties: parser.StandardizedProperties{
+					AppIds:       []string{"PORTAL_TOKEN_BRIDGE"},
+					ToChain:      sdk.ChainIDSolana,
+					TokenChain:   sdk.ChainIDEthereum,
+					TokenAddress: "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48",
+					Amount:       "100000000",
+					Fee:          tc.fee,
+					FeeAddress:   tc.feeAddress,
+					FeeChain:     tc.feeChain,
+				},
+			}
+
+			token, err := createToken(p, sdk.ChainIDEthereum)
+			assert.Nil(t, err)
+			assert.Equal(t, big.NewInt(100_000_000), token.Amount)
+			assert.Equal(t, tc.wantFee, token.Fee)
+			assert.Equal(t, tc.wantFeeAddress, token.FeeAddress)
+			assert.Equal(t, tc.wantFeeChain, token.FeeChain)
+
+			// the fee of a cloned token is not shared with the original token.
+			clone := token.Clone()
+			assert.Equal(t, token, clone)
+			if token.Fee != nil {
+				clone.Fee.SetInt64(0)
+				assert.Equal(t, tc.wantFee, token.Fee)
+			}
+		})
+	}
+}
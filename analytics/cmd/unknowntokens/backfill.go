@@ -0,0 +1,256 @@
+package unknowntokens
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+	"github.com/shopspring/decimal"
+	"github.com/wormhole-foundation/wormhole-explorer/analytics/cmd/token"
+	"github.com/wormhole-foundation/wormhole-explorer/analytics/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/analytics/metric"
+	"github.com/wormhole-foundation/wormhole-explorer/analytics/metric/sink"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/parser"
+	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
+	apiPrices "github.com/wormhole-foundation/wormhole-explorer/common/prices"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// volumeMeasurements are the measurements rewritten by the unknown tokens backfill.
+var volumeMeasurements = []string{metric.VaaVolumeMeasurement, "vaa_volume_v3"}
+
+// Backfill contains the configuration of the unknown tokens backfill.
+type Backfill struct {
+	MongoUri             string
+	MongoDb              string
+	PageSize             int64
+	P2PNetwork           string
+	NotionalUrl          string
+	VaaPayloadParserUrl  string
+	InfluxUrl            string
+	InfluxToken          string
+	InfluxOrganization   string
+	InfluxBucketInfinite string
+	DryRun               bool
+}
+
+// RunBackfill recomputes the volume of the transfers of the unknown tokens that were mapped to a coingecko id.
+//
+// For every mapped token, the volume points written while the token was unknown are deleted and written again
+// with the historical price of the token. Then, the token is marked as backfilled.
+func RunBackfill(cfg Backfill) {
+
+	ctx := context.Background()
+
+	// build logger
+	logger := logger.New("wormhole-explorer-analytics")
+
+	logger.Info("starting wormhole-explorer-analytics unknown tokens backfill ...", zap.Bool("dryRun", cfg.DryRun))
+
+	//setup DB connection
+	db, err := dbutil.Connect(ctx, logger, cfg.MongoUri, cfg.MongoDb, false)
+	if err != nil {
+		logger.Fatal("Failed to connect MongoDB", zap.Error(err))
+	}
+	defer db.DisconnectWithTimeout(10 * time.Second)
+
+	// create a parserVAAAPIClient
+	parserVAAAPIClient, err := parser.NewParserVAAAPIClient(5, cfg.VaaPayloadParserUrl, logger)
+	if err != nil {
+		logger.Fatal("failed to create parse vaa api client")
+	}
+
+	// create the token provider with the mapped tokens
+	tokenRepository := repository.NewTokenRepository(db.Database, logger)
+	tokenProvider := domain.NewTokenProvider(cfg.P2PNetwork)
+	if err := tokenRepository.LoadTokenMappings(ctx, tokenProvider); err != nil {
+		logger.Fatal("Failed to load token mappings", zap.Error(err))
+	}
+
+	influxCli := influxdb2.NewClient(cfg.InfluxUrl, cfg.InfluxToken)
+	b := &unknownTokensBackfiller{
+		cfg:             cfg,
+		tokenRepository: tokenRepository,
+		vaaRepository:   repository.NewVaaRepository(db.Database, logger),
+		transferPrices:  db.Database.Collection(repository.TransferPrices),
+		tokenResolver:   token.NewTokenResolver(parserVAAAPIClient, domain.NewProtocolRegistry(cfg.P2PNetwork), logger),
+		tokenProvider:   tokenProvider,
+		pricesApi:       apiPrices.NewPricesApi(cfg.NotionalUrl, logger),
+		influxCli:       influxCli,
+		sink:            sink.NewInfluxSink(influxCli, cfg.InfluxOrganization, cfg.InfluxBucketInfinite, "", ""),
+		logger:          logger,
+	}
+	defer b.sink.Close()
+
+	tokens, err := tokenRepository.FindUnknownTokens(ctx, repository.UnknownTokenStatusMapped, 0, 0)
+	if err != nil {
+		logger.Fatal("Failed to get mapped unknown tokens", zap.Error(err))
+	}
+
+	for _, t := range tokens {
+		log := logger.With(zap.Uint16("tokenChain", uint16(t.TokenChain)), zap.String("tokenAddress", t.TokenAddress))
+		count, err := b.backfillToken(ctx, &t)
+		if err != nil {
+			log.Error("Failed to backfill unknown token", zap.Error(err))
+			continue
+		}
+		log.Info("Backfilled unknown token", zap.Int("transfers", count))
+	}
+
+	logger.Info("finished wormhole-explorer-analytics unknown tokens backfill")
+}
+
+type unknownTokensBackfiller struct {
+	cfg             Backfill
+	tokenRepository *repository.TokenRepository
+	vaaRepository   *repository.VaaRepository
+	transferPrices  *mongo.Collection
+	tokenResolver   *token.TokenResolver
+	tokenProvider   *domain.TokenProvider
+	pricesApi       *apiPrices.PricesApi
+	influxCli       influxdb2.Client
+	sink            sink.Sink
+	logger          *zap.Logger
+}
+
+// backfilledTransfer is a transfer of an unknown token whose volume was computed again.
+type backfilledTransfer struct {
+	vaa              *sdk.VAA
+	transferredToken *token.TransferredToken
+	points           []*write.Point
+}
+
+// backfillToken rewrites the volume points of the transfers of a mapped token.
+//
+// The points are only replaced when the volume of every transfer can be computed, otherwise
+// the token is left as mapped so that the backfill can be retried.
+func (b *unknownTokensBackfiller) backfillToken(ctx context.Context, t *repository.UnknownTokenDoc) (int, error) {
+
+	tokenMeta, ok := b.tokenProvider.GetTokenByAddress(t.TokenChain, t.TokenAddress)
+	if !ok {
+		return 0, fmt.Errorf("token mapping not found")
+	}
+
+	// compute the volume of every transfer
+	var transfers []backfilledTransfer
+	var start, end time.Time
+	var lastID string
+	for {
+		page, err := b.tokenRepository.FindUnknownTokenTransfers(ctx, t.TokenChain, t.TokenAddress, lastID, b.cfg.PageSize)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get transfers: %w", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		for _, transfer := range page {
+			bt, err := b.computeVolume(ctx, transfer.ID, tokenMeta)
+			if err != nil {
+				return 0, fmt.Errorf("failed to compute volume of vaa %s: %w", transfer.ID, err)
+			}
+			if start.IsZero() || transfer.Timestamp.Before(start) {
+				start = transfer.Timestamp
+			}
+			if transfer.Timestamp.After(end) {
+				end = transfer.Timestamp
+			}
+			transfers = append(transfers, *bt)
+		}
+		lastID = page[len(page)-1].ID
+	}
+
+	if b.cfg.DryRun || len(transfers) == 0 {
+		return len(transfers), nil
+	}
+
+	// delete the points written while the token was unknown.
+	// Those points were written at the VAA timestamp, so the range is bounded by the transfers.
+	for _, measurement := range volumeMeasurements {
+		predicate := fmt.Sprintf(`_measurement="%s" AND token_chain="%d" AND token_address="%s"`,
+			measurement, t.TokenChain, t.TokenAddress)
+		err := b.influxCli.DeleteAPI().DeleteWithName(ctx, b.cfg.InfluxOrganization, b.cfg.InfluxBucketInfinite,
+			start, end.Add(time.Millisecond), predicate)
+		if err != nil {
+			return 0, fmt.Errorf("failed to delete %s points: %w", measurement, err)
+		}
+	}
+
+	// write the new points and the transfer prices
+	for _, bt := range transfers {
+		if err := b.sink.WritePoints(ctx, sink.BucketInfinite, bt.points...); err != nil {
+			return 0, fmt.Errorf("failed to write points of vaa %s: %w", bt.vaa.MessageID(), err)
+		}
+		err := metric.UpsertTransferPrices(ctx, b.logger, bt.vaa, b.transferPrices, b.historicalPrice, bt.transferredToken, b.tokenProvider)
+		if err != nil {
+			return 0, fmt.Errorf("failed to upsert transfer prices of vaa %s: %w", bt.vaa.MessageID(), err)
+		}
+	}
+
+	if err := b.tokenRepository.UpdateUnknownTokenStatus(ctx, t.TokenChain, t.TokenAddress, repository.UnknownTokenStatusBackfilled); err != nil {
+		return 0, fmt.Errorf("failed to update status: %w", err)
+	}
+	if err := b.tokenRepository.DeleteUnknownTokenTransfers(ctx, t.TokenChain, t.TokenAddress); err != nil {
+		return 0, fmt.Errorf("failed to delete transfers: %w", err)
+	}
+	return len(transfers), nil
+}
+
+// computeVolume builds the volume points of a transfer.
+func (b *unknownTokensBackfiller) computeVolume(ctx context.Context, vaaID string, tokenMeta *domain.TokenMetadata) (*backfilledTransfer, error) {
+
+	vaaDoc, err := b.vaaRepository.FindById(ctx, vaaID)
+	if err != nil {
+		return nil, err
+	}
+	vaa, err := sdk.Unmarshal(vaaDoc.Vaa)
+	if err != nil {
+		return nil, err
+	}
+	transferredToken, err := b.tokenResolver.GetTransferredTokenByVaa(ctx, vaa)
+	if err != nil {
+		return nil, err
+	}
+	if transferredToken == nil {
+		return nil, fmt.Errorf("transferred token not found")
+	}
+
+	point, err := metric.MakePointForVaaVolume(&metric.MakePointForVaaVolumeParams{
+		Logger: b.logger,
+		Vaa:    vaa,
+		TokenPriceFunc: func(_ string, timestamp time.Time) (decimal.Decimal, error) {
+			return b.historicalPrice("", tokenMeta.CoingeckoID, timestamp)
+		},
+		Metrics:          metrics.NewNoopMetrics(),
+		TransferredToken: transferredToken.Clone(),
+		TokenProvider:    b.tokenProvider,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if point == nil {
+		return nil, fmt.Errorf("notional price not found")
+	}
+
+	params := &metric.Params{Vaa: vaa, VaaIsSigned: true}
+	return &backfilledTransfer{
+		vaa:              vaa,
+		transferredToken: transferredToken,
+		points:           []*write.Point{point, metric.MakePointVaaVolumeV3(b.logger, point, params, transferredToken)},
+	}, nil
+}
+
+// historicalPrice returns the price of a token at the given time.
+func (b *unknownTokensBackfiller) historicalPrice(_, coinGeckoID string, timestamp time.Time) (decimal.Decimal, error) {
+	price, err := b.pricesApi.GetPriceByTime(context.Background(), coinGeckoID, timestamp)
+	if err != nil {
+		return decimal.NewFromInt(0), err
+	}
+	return price, nil
+}
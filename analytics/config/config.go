@@ -7,6 +7,13 @@ import (
 	"github.com/sethvargo/go-envconfig"
 )
 
+// metrics sink types.
+const (
+	MetricsSinkInflux = "INFLUX"
+	MetricsSinkFile   = "FILE"
+	MetricsSinkMemory = "MEMORY"
+)
+
 // Configuration represents the application configuration with the default values.
 type Configuration struct {
 	Environment             string `env:"ENVIRONMENT,required"`
@@ -19,6 +26,8 @@ type Configuration struct {
 	AwsRegion               string `env:"AWS_REGION"`
 	PipelineSQSUrl          string `env:"PIPELINE_SQS_URL"`
 	NotificationsSQSUrl     string `env:"NOTIFICATIONS_SQS_URL"`
+	MetricsSink             string `env:"METRICS_SINK,default=INFLUX"`
+	MetricsSinkFilePath     string `env:"METRICS_SINK_FILE_PATH"`
 	InfluxUrl               string `env:"INFLUX_URL"`
 	InfluxToken             string `env:"INFLUX_TOKEN"`
 	InfluxOrganization      string `env:"INFLUX_ORGANIZATION"`
@@ -34,6 +43,8 @@ type Configuration struct {
 	CacheChannel            string `env:"CACHE_CHANNEL,required"`
 	VaaPayloadParserURL     string `env:"VAA_PAYLOAD_PARSER_URL, required"`
 	VaaPayloadParserTimeout int64  `env:"VAA_PAYLOAD_PARSER_TIMEOUT, required"`
+	// TokenMappingsRefreshInterval is the interval in seconds to reload the token mappings from the database.
+	TokenMappingsRefreshInterval int64 `env:"TOKEN_MAPPINGS_REFRESH_INTERVAL,default=300"`
 }
 
 // New creates a configuration with the values from .env file and environment variables.
@@ -48,6 +59,11 @@ func New(ctx context.Context) (*Configuration, error) {
 	return &configuration, nil
 }
 
+// IsInfluxMetricsSink check if the metrics are written to InfluxDB.
+func (c *Configuration) IsInfluxMetricsSink() bool {
+	return c.MetricsSink == MetricsSinkInflux
+}
+
 // IsQueueConsumer check if consumer mode is QUEUE.
 func (c *Configuration) IsQueueConsumer() bool {
 	return c.ConsumerMode == "QUEUE"
@@ -40,6 +40,27 @@ func (c *Consumer) Start(ctx context.Context) {
 				continue
 			}
 
+			// push latency metrics of redeemed vaas.
+			if event.Redeem != nil {
+				redeem := &metric.Redeem{
+					VaaID:       event.ID,
+					SourceChain: sdk.ChainID(event.ChainID),
+					TargetChain: sdk.ChainID(event.Redeem.TargetChainID),
+					TxHash:      event.Redeem.TxHash,
+					Timestamp:   event.Redeem.Timestamp,
+				}
+				err := c.pushMetric(ctx, &metric.Params{TrackID: event.TrackID, Redeem: redeem})
+				if err != nil {
+					msg.Failed()
+					c.metrics.IncUnprocessedMessage(chainID, event.Source, msg.Retry())
+					continue
+				}
+				msg.Done()
+				c.logger.Debug("Pushed redeem metric", zap.String("id", event.ID))
+				c.metrics.IncProcessedMessage(chainID, event.Source, msg.Retry())
+				continue
+			}
+
 			// unmarshal vaa.
 			vaa, err := sdk.Unmarshal(event.Vaa)
 			if err != nil {
@@ -0,0 +1,158 @@
+package metric
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+	"github.com/shopspring/decimal"
+	"github.com/wormhole-foundation/wormhole-explorer/analytics/cmd/token"
+	"github.com/wormhole-foundation/wormhole-explorer/analytics/metric/sink"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+const VaaFeeMeasurement = "vaa_fee"
+
+// relayerUnknown is the relayer tag of the fees without a fee address.
+const relayerUnknown = "unknown"
+
+// MakePointForVaaFeeParams contains input parameters for the function `MakePointForVaaFee`
+type MakePointForVaaFeeParams struct {
+
+	// Vaa is the VAA for which we want to compute the fee metric
+	Vaa *sdk.VAA
+
+	// TokenPriceFunc returns the price of the given token at the specified timestamp.
+	TokenPriceFunc func(tokenID string, timestamp time.Time) (decimal.Decimal, error)
+
+	// Logger is an optional parameter, in case the caller wants additional visibility.
+	Logger *zap.Logger
+
+	// TransferredToken is the token that was transferred in the VAA.
+	TransferredToken *token.TransferredToken
+
+	// TokenProvider is used to obtain token metadata.
+	TokenProvider *domain.TokenProvider
+}
+
+// MakePointForVaaFee builds the InfluxDB fee metric for a given VAA.
+//
+// The fee is paid in the transferred token, so it is valued with the notional price of the token.
+// Some VAAs will not generate a measurement (e.g.: transfers without fee or tokens without metadata),
+// so the caller must always check whether the returned point is nil.
+func MakePointForVaaFee(params *MakePointForVaaFeeParams) (*write.Point, error) {
+
+	t := params.TransferredToken
+	if t == nil || t.Fee == nil || t.Fee.Sign() <= 0 {
+		return nil, nil
+	}
+
+	tokenMeta, ok := params.TokenProvider.GetTokenByAddress(t.TokenChain, t.TokenAddress.String())
+	if !ok {
+		return nil, nil
+	}
+
+	notionalUSD, err := params.TokenPriceFunc(tokenMeta.GetTokenID(), params.Vaa.Timestamp)
+	if err != nil {
+		if params.Logger != nil {
+			params.Logger.Warn("Failed to obtain notional for the fee of this token",
+				zap.String("vaaId", params.Vaa.MessageID()),
+				zap.String("tokenAddress", t.TokenAddress.String()),
+				zap.Uint16("tokenChain", uint16(t.TokenChain)),
+				zap.Error(err),
+			)
+		}
+		return nil, nil
+	}
+
+	// Normalize the fee to 8 decimals, the same way as the amount of the volume metric
+	fee := new(big.Int).Set(t.Fee)
+	if tokenMeta.Decimals < 8 {
+		var factor big.Int
+		factor.Exp(big.NewInt(10), big.NewInt(int64(8-tokenMeta.Decimals)), nil)
+		fee.Mul(fee, &factor)
+	}
+
+	// Convert the notional value to an integer with an implicit precision of 8 decimals
+	notionalBigInt := notionalUSD.
+		Truncate(8).
+		Mul(decimal.NewFromInt(1e8)).
+		BigInt()
+
+	// Calculate the fee in USD, with an implicit precision of 8 decimals
+	var feeUSD big.Int
+	feeUSD.Mul(fee, notionalBigInt)
+	feeUSD.Div(&feeUSD, big.NewInt(1e8))
+
+	// The fee is usually paid on the target chain
+	feeChain := t.FeeChain
+	if feeChain == sdk.ChainIDUnset {
+		feeChain = t.ToChain
+	}
+	relayer := t.FeeAddress
+	if relayer == "" {
+		relayer = relayerUnknown
+	}
+
+	point := influxdb2.NewPointWithMeasurement(VaaFeeMeasurement).
+		AddTag("app_id", t.AppId).
+		AddTag("emitter_chain", fmt.Sprintf("%d", params.Vaa.EmitterChain)).
+		AddTag("fee_chain", fmt.Sprintf("%d", feeChain)).
+		AddTag("relayer", relayer).
+		AddTag("token_chain", fmt.Sprintf("%d", t.TokenChain)).
+		AddTag("token_address", t.TokenAddress.String()).
+		AddField("symbol", tokenMeta.Symbol.String()).
+		// Fee in tokens, integer, 8 decimals of precision
+		AddField("fee", fee.Uint64()).
+		// Token price at the time the VAA was emitted, integer, 8 decimals of precision
+		AddField("notional", notionalBigInt.Uint64()).
+		// Fee in USD, integer, 8 decimals of precision
+		AddField("fee_usd", feeUSD.Uint64()).
+		SetTime(generateUniqueTimestamp(params.Vaa))
+
+	return point, nil
+}
+
+// feeMeasurement creates a new point for the `vaa_fee` measurement.
+func (m *Metric) feeMeasurement(ctx context.Context, params *Params, token *token.TransferredToken) error {
+
+	point, err := MakePointForVaaFee(&MakePointForVaaFeeParams{
+		Vaa: params.Vaa,
+		TokenPriceFunc: func(tokenID string, timestamp time.Time) (decimal.Decimal, error) {
+			priceData, err := m.notionalCache.Get(tokenID)
+			if err != nil {
+				return decimal.NewFromInt(0), err
+			}
+			return priceData.NotionalUsd, nil
+		},
+		Logger:           m.logger,
+		TransferredToken: token,
+		TokenProvider:    m.tokenProvider,
+	})
+	if err != nil {
+		return err
+	}
+	if point == nil {
+		return nil
+	}
+
+	err = m.sink.WritePoints(ctx, sink.BucketInfinite, point)
+	if err != nil {
+		m.metrics.IncFailedMeasurement(VaaFeeMeasurement)
+		return err
+	}
+	m.metrics.IncSuccessfulMeasurement(VaaFeeMeasurement)
+
+	m.logger.Debug("Wrote a data point for the fee metric",
+		zap.String("vaaId", params.Vaa.MessageID()),
+		zap.String("trackId", params.TrackID),
+		zap.Any("tags", point.TagList()),
+		zap.Any("fields", point.FieldList()),
+	)
+	return nil
+}
@@ -0,0 +1,159 @@
+package metric
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/analytics/cmd/token"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestMakePointForVaaFee(t *testing.T) {
+	timestamp := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	vaa := &sdk.VAA{
+		EmitterChain:   sdk.ChainIDEthereum,
+		EmitterAddress: sdk.Address{1},
+		Sequence:       5,
+		Timestamp:      timestamp,
+	}
+	sixDecimals := sdk.Address{6}
+	eighteenDecimals := sdk.Address{18}
+	unlisted := sdk.Address{99}
+
+	tokenProvider := domain.NewTokenProvider(domain.P2pMainNet)
+	tokenProvider.SetMappedTokens([]domain.TokenMetadata{
+		{TokenChain: sdk.ChainIDEthereum, TokenAddress: sixDecimals.String(), Symbol: "SIX", CoingeckoID: "six", Decimals: 6},
+		{TokenChain: sdk.ChainIDEthereum, TokenAddress: eighteenDecimals.String(), Symbol: "EIGHTEEN", CoingeckoID: "eighteen", Decimals: 18},
+	})
+	prices := map[string]decimal.Decimal{
+		"2/" + sixDecimals.String():      decimal.RequireFromString("2.5"),
+		"2/" + eighteenDecimals.String(): decimal.NewFromInt(3000),
+	}
+	tokenPriceFunc := func(tokenID string, _ time.Time) (decimal.Decimal, error) {
+		price, ok := prices[tokenID]
+		if !ok {
+			return decimal.Zero, errors.New("price not found")
+		}
+		return price, nil
+	}
+
+	var tests = []struct {
+		name       string
+		token      *token.TransferredToken
+		price      func(string, time.Time) (decimal.Decimal, error)
+		wantTags   map[string]string
+		wantFields map[string]interface{}
+	}{
+		{
+			name: "fee of a token with less than 8 decimals",
+			token: &token.TransferredToken{
+				AppId:        domain.AppIdPortalTokenBridge,
+				TokenChain:   sdk.ChainIDEthereum,
+				TokenAddress: sixDecimals,
+				ToChain:      sdk.ChainIDSolana,
+				Fee:          big.NewInt(1_500_000),
+				FeeAddress:   "0xrelayer",
+				FeeChain:     sdk.ChainIDArbitrum,
+			},
+			wantTags: map[string]string{
+				"app_id":        domain.AppIdPortalTokenBridge,
+				"emitter_chain": "2",
+				"fee_chain":     "23",
+				"relayer":       "0xrelayer",
+				"token_chain":   "2",
+				"token_address": sixDecimals.String(),
+			},
+			wantFields: map[string]interface{}{
+				"symbol":   "SIX",
+				"fee":      uint64(150_000_000),
+				"notional": uint64(250_000_000),
+				"fee_usd":  uint64(375_000_000),
+			},
+		},
+		{
+			name: "fee paid on the target chain to an unknown relayer",
+			token: &token.TransferredToken{
+				AppId:        domain.AppIdPortalTokenBridge,
+				TokenChain:   sdk.ChainIDEthereum,
+				TokenAddress: eighteenDecimals,
+				ToChain:      sdk.ChainIDSolana,
+				Fee:          big.NewInt(50_000_000),
+			},
+			wantTags: map[string]string{
+				"app_id":        domain.AppIdPortalTokenBridge,
+				"emitter_chain": "2",
+				"fee_chain":     "1",
+				"relayer":       relayerUnknown,
+				"token_chain":   "2",
+				"token_address": eighteenDecimals.String(),
+			},
+			wantFields: map[string]interface{}{
+				"symbol":   "EIGHTEEN",
+				"fee":      uint64(50_000_000),
+				"notional": uint64(300_000_000_000),
+				"fee_usd":  uint64(150_000_000_000),
+			},
+		},
+		{
+			name:  "not a transfer",
+			token: nil,
+		},
+		{
+			name:  "transfer without fee",
+			token: &token.TransferredToken{TokenChain: sdk.ChainIDEthereum, TokenAddress: sixDecimals},
+		},
+		{
+			name:  "transfer with zero fee",
+			token: &token.TransferredToken{TokenChain: sdk.ChainIDEthereum, TokenAddress: sixDecimals, Fee: big.NewInt(0)},
+		},
+		{
+			name:  "token without metadata",
+			token: &token.TransferredToken{TokenChain: sdk.ChainIDEthereum, TokenAddress: unlisted, Fee: big.NewInt(1)},
+		},
+		{
+			name:  "token without price",
+			token: &token.TransferredToken{TokenChain: sdk.ChainIDEthereum, TokenAddress: sixDecimals, Fee: big.NewInt(1)},
+			price: func(string, time.Time) (decimal.Decimal, error) {
+				return decimal.Zero, errors.New("price not found")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			price := tokenPriceFunc
+			if tc.price != nil {
+				price = tc.price
+			}
+			point, err := MakePointForVaaFee(&MakePointForVaaFeeParams{
+				Vaa:              vaa,
+				TokenPriceFunc:   price,
+				TransferredToken: tc.token,
+				TokenProvider:    tokenProvider,
+			})
+			assert.Nil(t, err)
+			if tc.wantTags == nil {
+				assert.Nil(t, point)
+				return
+			}
+
+			assert.Equal(t, VaaFeeMeasurement, point.Name())
+			assert.Equal(t, timestamp.Add(5*time.Nanosecond), point.Time())
+			tags := make(map[string]string)
+			for _, tag := range point.TagList() {
+				tags[tag.Key] = tag.Value
+			}
+			assert.Equal(t, tc.wantTags, tags)
+			fields := make(map[string]interface{})
+			for _, field := range point.FieldList() {
+				fields[field.Key] = field.Value
+			}
+			assert.Equal(t, tc.wantFields, fields)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package metric
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+	"github.com/wormhole-foundation/wormhole-explorer/analytics/metric/sink"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+const VaaLatencyMeasurement = "vaa_latency"
+
+// LatencyTimestamps contains the timestamps of the stages of a cross-chain message.
+type LatencyTimestamps struct {
+	// SourceTx is the timestamp of the transaction that emitted the message.
+	SourceTx *time.Time
+	// Vaa is the timestamp of the VAA.
+	Vaa *time.Time
+	// IndexedAt is the time the VAA was indexed by fly.
+	IndexedAt *time.Time
+	// Redeem is the timestamp of the transaction that redeemed the VAA on the target chain.
+	Redeem time.Time
+}
+
+// MakePointForVaaLatencyParams contains input parameters for the function `MakePointForVaaLatency`
+type MakePointForVaaLatencyParams struct {
+	SourceChain sdk.ChainID
+	TargetChain sdk.ChainID
+	AppID       string
+	Timestamps  LatencyTimestamps
+}
+
+// MakePointForVaaLatency builds the latency metric of a redeemed VAA.
+//
+// Every field is the latency of a stage in milliseconds. A field is only added when both
+// timestamps of the stage are known and in order.
+// It returns nil when no field can be computed.
+func MakePointForVaaLatency(params *MakePointForVaaLatencyParams) *write.Point {
+
+	appID := params.AppID
+	if appID == "" {
+		appID = domain.AppIdUnkonwn
+	}
+
+	point := influxdb2.NewPointWithMeasurement(VaaLatencyMeasurement).
+		AddTag("source_chain", fmt.Sprintf("%d", params.SourceChain)).
+		AddTag("target_chain", fmt.Sprintf("%d", params.TargetChain)).
+		AddTag("app_id", appID).
+		SetTime(params.Timestamps.Redeem)
+
+	ts := params.Timestamps
+	redeem := &ts.Redeem
+	fields := 0
+	addField := func(name string, from, to *time.Time) {
+		if from == nil || to == nil || from.IsZero() || to.Before(*from) {
+			return
+		}
+		point.AddField(name, to.Sub(*from).Milliseconds())
+		fields++
+	}
+	addField("source_to_vaa_ms", ts.SourceTx, ts.Vaa)
+	addField("vaa_to_indexed_ms", ts.Vaa, ts.IndexedAt)
+	addField("vaa_to_redeem_ms", ts.Vaa, redeem)
+	addField("total_ms", ts.SourceTx, redeem)
+
+	if fields == 0 {
+		return nil
+	}
+	return point
+}
+
+// latencyMeasurement creates a new point for the `vaa_latency` measurement.
+func (m *Metric) latencyMeasurement(ctx context.Context, params *Params) error {
+
+	id := params.Redeem.VaaID
+	timestamps, appID, err := m.findLatencyData(ctx, id)
+	if err != nil {
+		m.logger.Error("Failed to obtain latency data for this VAA",
+			zap.String("trackId", params.TrackID),
+			zap.String("vaaId", id),
+			zap.Error(err))
+		return err
+	}
+	timestamps.Redeem = params.Redeem.Timestamp
+
+	point := MakePointForVaaLatency(&MakePointForVaaLatencyParams{
+		SourceChain: params.Redeem.SourceChain,
+		TargetChain: params.Redeem.TargetChain,
+		AppID:       appID,
+		Timestamps:  *timestamps,
+	})
+	if point == nil {
+		m.logger.Debug("Not enough data to compute the latency of this VAA",
+			zap.String("trackId", params.TrackID),
+			zap.String("vaaId", id))
+		return nil
+	}
+
+	err = m.sink.WritePoints(ctx, sink.BucketInfinite, point)
+	if err != nil {
+		m.metrics.IncFailedMeasurement(VaaLatencyMeasurement)
+		return err
+	}
+	m.metrics.IncSuccessfulMeasurement(VaaLatencyMeasurement)
+
+	m.logger.Debug("Wrote a data point for the latency metric",
+		zap.String("vaaId", id),
+		zap.String("trackId", params.TrackID),
+		zap.Any("fields", point.FieldList()))
+	return nil
+}
+
+// findLatencyData gets the timestamps of the source transaction and the VAA, and the appId of a VAA.
+func (m *Metric) findLatencyData(ctx context.Context, id string) (*LatencyTimestamps, string, error) {
+
+	var vaaDoc struct {
+		Timestamp *time.Time `bson:"timestamp"`
+		IndexedAt *time.Time `bson:"indexedAt"`
+	}
+	opts := options.FindOne().SetProjection(bson.D{{Key: "timestamp", Value: 1}, {Key: "indexedAt", Value: 1}})
+	err := m.db.Collection(repository.Vaas).FindOne(ctx, bson.M{"_id": id}, opts).Decode(&vaaDoc)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, "", err
+	}
+
+	var txDoc struct {
+		OriginTx *struct {
+			Timestamp *time.Time `bson:"timestamp"`
+		} `bson:"originTx"`
+	}
+	opts = options.FindOne().SetProjection(bson.D{{Key: "originTx.timestamp", Value: 1}})
+	err = m.db.Collection(repository.GlobalTransactions).FindOne(ctx, bson.M{"_id": id}, opts).Decode(&txDoc)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, "", err
+	}
+
+	var parsedVaaDoc struct {
+		AppIDs []string `bson:"appIds"`
+	}
+	opts = options.FindOne().SetProjection(bson.D{{Key: "appIds", Value: 1}})
+	err = m.db.Collection(repository.ParsedVaa).FindOne(ctx, bson.M{"_id": id}, opts).Decode(&parsedVaaDoc)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, "", err
+	}
+
+	timestamps := LatencyTimestamps{
+		Vaa:       vaaDoc.Timestamp,
+		IndexedAt: vaaDoc.IndexedAt,
+	}
+	if txDoc.OriginTx != nil {
+		timestamps.SourceTx = txDoc.OriginTx.Timestamp
+	}
+	var appID string
+	if len(parsedVaaDoc.AppIDs) > 0 {
+		appID = parsedVaaDoc.AppIDs[0]
+	}
+	return &timestamps, appID, nil
+}
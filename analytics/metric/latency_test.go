@@ -0,0 +1,104 @@
+package metric
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestMakePointForVaaLatency(t *testing.T) {
+	sourceTx := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	vaa := sourceTx.Add(15 * time.Minute)
+	indexedAt := vaa.Add(2 * time.Second)
+	redeem := vaa.Add(time.Minute)
+
+	var tests = []struct {
+		name       string
+		timestamps LatencyTimestamps
+		want       map[string]interface{}
+	}{
+		{
+			name:       "all stages",
+			timestamps: LatencyTimestamps{SourceTx: &sourceTx, Vaa: &vaa, IndexedAt: &indexedAt, Redeem: redeem},
+			want: map[string]interface{}{
+				"source_to_vaa_ms":  int64(15 * 60 * 1000),
+				"vaa_to_indexed_ms": int64(2000),
+				"vaa_to_redeem_ms":  int64(60 * 1000),
+				"total_ms":          int64(16 * 60 * 1000),
+			},
+		},
+		{
+			name:       "without source transaction",
+			timestamps: LatencyTimestamps{Vaa: &vaa, IndexedAt: &indexedAt, Redeem: redeem},
+			want: map[string]interface{}{
+				"vaa_to_indexed_ms": int64(2000),
+				"vaa_to_redeem_ms":  int64(60 * 1000),
+			},
+		},
+		{
+			name:       "redeem before the vaa",
+			timestamps: LatencyTimestamps{SourceTx: &sourceTx, Vaa: &vaa, Redeem: sourceTx.Add(time.Minute)},
+			want: map[string]interface{}{
+				"source_to_vaa_ms": int64(15 * 60 * 1000),
+				"total_ms":         int64(60 * 1000),
+			},
+		},
+		{
+			name:       "zero source transaction",
+			timestamps: LatencyTimestamps{SourceTx: &time.Time{}, Redeem: redeem},
+			want:       nil,
+		},
+		{
+			name:       "only the redeem",
+			timestamps: LatencyTimestamps{Redeem: redeem},
+			want:       nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			point := MakePointForVaaLatency(&MakePointForVaaLatencyParams{
+				SourceChain: sdk.ChainIDEthereum,
+				TargetChain: sdk.ChainIDSolana,
+				AppID:       domain.AppIdPortalTokenBridge,
+				Timestamps:  tt.timestamps,
+			})
+			if tt.want == nil {
+				assert.Nil(t, point)
+				return
+			}
+			assert.NotNil(t, point)
+			assert.Equal(t, VaaLatencyMeasurement, point.Name())
+			assert.Equal(t, tt.timestamps.Redeem, point.Time())
+
+			fields := make(map[string]interface{})
+			for _, f := range point.FieldList() {
+				fields[f.Key] = f.Value
+			}
+			assert.Equal(t, tt.want, fields)
+		})
+	}
+}
+
+func TestMakePointForVaaLatency_Tags(t *testing.T) {
+	vaa := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	point := MakePointForVaaLatency(&MakePointForVaaLatencyParams{
+		SourceChain: sdk.ChainIDEthereum,
+		TargetChain: sdk.ChainIDSolana,
+		Timestamps:  LatencyTimestamps{Vaa: &vaa, Redeem: vaa.Add(time.Minute)},
+	})
+
+	tags := make(map[string]string)
+	for _, tag := range point.TagList() {
+		tags[tag.Key] = tag.Value
+	}
+	// the app id is unknown when the VAA was not parsed.
+	assert.Equal(t, map[string]string{
+		"source_chain": "2",
+		"target_chain": "1",
+		"app_id":       domain.AppIdUnkonwn,
+	}, tags)
+}
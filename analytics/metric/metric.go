@@ -2,19 +2,21 @@ package metric
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"strconv"
 	"time"
 
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
-	"github.com/influxdata/influxdb-client-go/v2/api"
 	"github.com/influxdata/influxdb-client-go/v2/api/write"
 	"github.com/shopspring/decimal"
 	"github.com/wormhole-foundation/wormhole-explorer/analytics/cmd/token"
 	"github.com/wormhole-foundation/wormhole-explorer/analytics/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/analytics/metric/sink"
 	wormscanNotionalCache "github.com/wormhole-foundation/wormhole-explorer/common/client/cache/notional"
 	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
@@ -26,19 +28,22 @@ const (
 	VaaAllMessagesMeasurement = "vaa_count_all_messages"
 )
 
+// unknownTokenRecorder persists the transfers of the tokens that are not in the token list.
+type unknownTokenRecorder interface {
+	RecordUnknownToken(ctx context.Context, tokenChain sdk.ChainID, tokenAddress, vaaID string, timestamp time.Time) error
+}
+
 // Metric definition.
 type Metric struct {
 	db *mongo.Database
 	// transferPrices contains the notional price for each token bridge transfer.
 	transferPrices           *mongo.Collection
-	influxCli                influxdb2.Client
-	apiBucketInfinite        api.WriteAPIBlocking
-	apiBucket30Days          api.WriteAPIBlocking
-	apiBucket24Hours         api.WriteAPIBlocking
+	sink                     sink.Sink
 	notionalCache            wormscanNotionalCache.NotionalLocalCacheReadable
 	metrics                  metrics.Metrics
 	getTransferredTokenByVaa token.GetTransferredTokenByVaa
 	tokenProvider            *domain.TokenProvider
+	tokenRepository          unknownTokenRecorder
 	logger                   *zap.Logger
 }
 
@@ -46,11 +51,7 @@ type Metric struct {
 func New(
 	ctx context.Context,
 	db *mongo.Database,
-	influxCli influxdb2.Client,
-	organization string,
-	bucketInifite string,
-	bucket30Days string,
-	bucket24Hours string,
+	sink sink.Sink,
 	notionalCache wormscanNotionalCache.NotionalLocalCacheReadable,
 	metrics metrics.Metrics,
 	getTransferredTokenByVaa token.GetTransferredTokenByVaa,
@@ -58,23 +59,16 @@ func New(
 	logger *zap.Logger,
 ) (*Metric, error) {
 
-	apiBucketInfinite := influxCli.WriteAPIBlocking(organization, bucketInifite)
-	apiBucket30Days := influxCli.WriteAPIBlocking(organization, bucket30Days)
-	apiBucket24Hours := influxCli.WriteAPIBlocking(organization, bucket24Hours)
-	apiBucket24Hours.EnableBatching()
-
 	m := Metric{
 		db:                       db,
 		transferPrices:           db.Collection("transferPrices"),
-		influxCli:                influxCli,
-		apiBucketInfinite:        apiBucketInfinite,
-		apiBucket24Hours:         apiBucket24Hours,
-		apiBucket30Days:          apiBucket30Days,
+		sink:                     sink,
 		logger:                   logger,
 		notionalCache:            notionalCache,
 		metrics:                  metrics,
 		getTransferredTokenByVaa: getTransferredTokenByVaa,
 		tokenProvider:            tokenProvider,
+		tokenRepository:          repository.NewTokenRepository(db, logger),
 	}
 	return &m, nil
 }
@@ -82,7 +76,11 @@ func New(
 // Push implement MetricPushFunc definition.
 func (m *Metric) Push(ctx context.Context, params *Params) error {
 
-	var err1, err2, err3, err4 error
+	if params.Redeem != nil {
+		return m.latencyMeasurement(ctx, params)
+	}
+
+	var err1, err2, err3, err4, err5 error
 
 	isVaaSigned := params.VaaIsSigned
 
@@ -96,6 +94,10 @@ func (m *Metric) Push(ctx context.Context, params *Params) error {
 
 		transferredToken, err := m.getTransferredTokenByVaa(ctx, params.Vaa)
 		if err != nil {
+			var unknownTokenErr *token.UnknownTokenErr
+			if errors.As(err, &unknownTokenErr) && unknownTokenErr.TokenAddress != "" {
+				m.recordUnresolvedToken(ctx, params, unknownTokenErr)
+			}
 			if !token.IsUnknownTokenErr(err) {
 				m.logger.Error("Failed to obtain transferred token for this VAA",
 					zap.String("trackId", params.TrackID),
@@ -109,6 +111,8 @@ func (m *Metric) Push(ctx context.Context, params *Params) error {
 
 			if isVaaSigned {
 				err3 = m.volumeMeasurement(ctx, params, transferredToken.Clone())
+
+				err5 = m.feeMeasurement(ctx, params, transferredToken.Clone())
 			}
 
 			err4 = UpsertTransferPrices(
@@ -138,8 +142,8 @@ func (m *Metric) Push(ctx context.Context, params *Params) error {
 	}
 
 	//TODO if we had go 1.20, we could just use `errors.Join(err1, err2, err3, ...)` here.
-	if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
-		return fmt.Errorf("err1=%w, err2=%w, err3=%w err4=%w", err1, err2, err3, err4)
+	if err1 != nil || err2 != nil || err3 != nil || err4 != nil || err5 != nil {
+		return fmt.Errorf("err1=%w, err2=%w, err3=%w err4=%w err5=%w", err1, err2, err3, err4, err5)
 	}
 
 	if params.Vaa.EmitterChain != sdk.ChainIDPythNet {
@@ -152,19 +156,9 @@ func (m *Metric) Push(ctx context.Context, params *Params) error {
 	return nil
 }
 
-// Close influx client.
+// Close the metrics sink.
 func (m *Metric) Close() {
-
-	const flushTimeout = 5 * time.Second
-
-	// wait a bounded amount of time for all buckets to flush
-	ctx, cancelFunc := context.WithTimeout(context.Background(), flushTimeout)
-	m.apiBucket24Hours.Flush(ctx)
-	m.apiBucket30Days.Flush(ctx)
-	m.apiBucketInfinite.Flush(ctx)
-	cancelFunc()
-
-	m.influxCli.Close()
+	m.sink.Close()
 }
 
 // vaaCountMeasurement creates a new point for the `vaa_count` measurement.
@@ -186,8 +180,8 @@ func (m *Metric) vaaCountMeasurement(ctx context.Context, p *Params) error {
 		return nil
 	}
 
-	// Write the point to influx
-	err = m.apiBucket30Days.WritePoint(ctx, point)
+	// Write the point to the sink
+	err = m.sink.WritePoints(ctx, sink.Bucket30Days, point)
 	if err != nil {
 		m.logger.Error("Failed to write metric",
 			zap.String("measurement", point.Name()),
@@ -224,8 +218,8 @@ func (m *Metric) vaaCountAllMessagesMeasurement(ctx context.Context, params *Par
 		AddField("count", 1).
 		SetTime(generateUniqueTimestamp(params.Vaa))
 
-	// Write the point to influx
-	err := m.apiBucket24Hours.WritePoint(ctx, point)
+	// Write the point to the sink
+	err := m.sink.WritePoints(ctx, sink.Bucket24Hours, point)
 	if err != nil {
 		m.logger.Error("Failed to write metric",
 			zap.String("measurement", VaaAllMessagesMeasurement),
@@ -243,6 +237,11 @@ func (m *Metric) vaaCountAllMessagesMeasurement(ctx context.Context, params *Par
 // volumeMeasurement creates a new point for the `vaa_volume_v2` measurement.
 func (m *Metric) volumeMeasurement(ctx context.Context, params *Params, token *token.TransferredToken) error {
 
+	// Keep track of the tokens without metadata, their volume is backfilled once they are mapped.
+	if _, ok := m.tokenProvider.GetTokenByAddress(token.TokenChain, token.TokenAddress.String()); !ok {
+		m.recordUnknownToken(ctx, params, token.TokenChain, token.TokenAddress.String())
+	}
+
 	// Generate a data point for the volume metric
 	p := MakePointForVaaVolumeParams{
 		Logger: m.logger,
@@ -269,10 +268,10 @@ func (m *Metric) volumeMeasurement(ctx context.Context, params *Params, token *t
 		return nil
 	}
 
-	vaaVolumeV3point := m.MakePointVaaVolumeV3(point, params, token)
+	vaaVolumeV3point := MakePointVaaVolumeV3(m.logger, point, params, token)
 
-	// Write the point to influx
-	err = m.apiBucketInfinite.WritePoint(ctx, point, vaaVolumeV3point)
+	// Write the point to the sink
+	err = m.sink.WritePoints(ctx, sink.BucketInfinite, point, vaaVolumeV3point)
 	if err != nil {
 		m.metrics.IncFailedMeasurement(VaaVolumeMeasurement)
 		return err
@@ -289,7 +288,52 @@ func (m *Metric) volumeMeasurement(ctx context.Context, params *Params, token *t
 	return nil
 }
 
-func (m *Metric) MakePointVaaVolumeV3(vaaVolumeV2Point *write.Point, params *Params, transferredToken *token.TransferredToken) *write.Point {
+// recordUnresolvedToken persists the token of a VAA whose token address could not be resolved.
+// The native address is normalized to the 32 bytes hex format used by the token list, the tokens
+// whose address cannot be normalized are not recorded.
+func (m *Metric) recordUnresolvedToken(ctx context.Context, params *Params, unknownTokenErr *token.UnknownTokenErr) {
+	tokenAddress, err := normalizeTokenAddress(unknownTokenErr.TokenChain, unknownTokenErr.TokenAddress)
+	if err != nil {
+		m.logger.Warn("Cannot normalize the address of the unknown token",
+			zap.String("trackId", params.TrackID),
+			zap.String("vaaId", params.Vaa.MessageID()),
+			zap.Uint16("tokenChain", uint16(unknownTokenErr.TokenChain)),
+			zap.String("tokenAddress", unknownTokenErr.TokenAddress),
+			zap.Error(err))
+		return
+	}
+	m.recordUnknownToken(ctx, params, unknownTokenErr.TokenChain, tokenAddress)
+}
+
+// normalizeTokenAddress converts a native token address to the 32 bytes hex format.
+func normalizeTokenAddress(tokenChain sdk.ChainID, nativeAddress string) (string, error) {
+	addressHex, err := domain.DecodeNativeAddressToHex(tokenChain, nativeAddress)
+	if err != nil {
+		addressHex = nativeAddress
+	}
+	address, err := sdk.StringToAddress(addressHex)
+	if err != nil {
+		return "", err
+	}
+	return address.String(), nil
+}
+
+// recordUnknownToken persists a transfer of a token that is not in the token list.
+// Failures are logged and do not interrupt the processing of the VAA.
+func (m *Metric) recordUnknownToken(ctx context.Context, params *Params, tokenChain sdk.ChainID, tokenAddress string) {
+	err := m.tokenRepository.RecordUnknownToken(ctx, tokenChain, tokenAddress, params.Vaa.MessageID(), params.Vaa.Timestamp)
+	if err != nil {
+		m.logger.Error("Failed to record unknown token",
+			zap.String("trackId", params.TrackID),
+			zap.String("vaaId", params.Vaa.MessageID()),
+			zap.Uint16("tokenChain", uint16(tokenChain)),
+			zap.String("tokenAddress", tokenAddress),
+			zap.Error(err))
+	}
+}
+
+// MakePointVaaVolumeV3 builds the vaa_volume_v3 data point from the vaa volume point, with a tag for each appId.
+func MakePointVaaVolumeV3(logger *zap.Logger, vaaVolumeV2Point *write.Point, params *Params, transferredToken *token.TransferredToken) *write.Point {
 
 	point := influxdb2.NewPointWithMeasurement("vaa_volume_v3")
 
@@ -319,7 +363,7 @@ func (m *Metric) MakePointVaaVolumeV3(vaaVolumeV2Point *write.Point, params *Par
 	point.AddTag("size", strconv.Itoa(len(transferredToken.AppIDs)))
 
 	if len(transferredToken.AppIDs) > 3 {
-		m.logger.Warn("Too many appIDs.",
+		logger.Warn("Too many appIDs.",
 			zap.String("vaaId", params.Vaa.MessageID()),
 			zap.String("trackId", params.TrackID),
 			zap.String("appIDs", fmt.Sprintf("%v", transferredToken.AppIDs)))
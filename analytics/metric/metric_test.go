@@ -0,0 +1,172 @@
+package metric
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/analytics/cmd/token"
+	"github.com/wormhole-foundation/wormhole-explorer/analytics/internal/metrics"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+type recordedToken struct {
+	tokenChain   sdk.ChainID
+	tokenAddress string
+	vaaID        string
+	timestamp    time.Time
+}
+
+type unknownTokenRecorderMock struct {
+	records []recordedToken
+	err     error
+}
+
+func (m *unknownTokenRecorderMock) RecordUnknownToken(_ context.Context, tokenChain sdk.ChainID, tokenAddress, vaaID string, timestamp time.Time) error {
+	m.records = append(m.records, recordedToken{tokenChain: tokenChain, tokenAddress: tokenAddress, vaaID: vaaID, timestamp: timestamp})
+	return m.err
+}
+
+func newTestMetric(recorder unknownTokenRecorder, err error) *Metric {
+	return &Metric{
+		metrics: metrics.NewNoopMetrics(),
+		getTransferredTokenByVaa: func(context.Context, *sdk.VAA) (*token.TransferredToken, error) {
+			return nil, err
+		},
+		tokenRepository: recorder,
+		logger:          zap.NewNop(),
+	}
+}
+
+func TestPush_RecordUnknownToken(t *testing.T) {
+	timestamp := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	vaa := &sdk.VAA{
+		EmitterChain:   sdk.ChainIDEthereum,
+		EmitterAddress: sdk.Address{1},
+		Sequence:       1,
+		Timestamp:      timestamp,
+	}
+
+	var tests = []struct {
+		name    string
+		err     error
+		wantErr bool
+		want    []recordedToken
+	}{
+		{
+			name: "evm token address",
+			err: &token.UnknownTokenErr{
+				TokenChain:   sdk.ChainIDEthereum,
+				TokenAddress: "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2",
+			},
+			want: []recordedToken{{
+				tokenChain:   sdk.ChainIDEthereum,
+				tokenAddress: "000000000000000000000000c02aaa39b223fe8d0a0e5c4f27ead9083c756cc2",
+				vaaID:        vaa.MessageID(),
+				timestamp:    timestamp,
+			}},
+		},
+		{
+			name: "solana token address",
+			err: &token.UnknownTokenErr{
+				TokenChain:   sdk.ChainIDSolana,
+				TokenAddress: "So11111111111111111111111111111111111111112",
+			},
+			want: []recordedToken{{
+				tokenChain:   sdk.ChainIDSolana,
+				tokenAddress: "069b8857feab8184fb687f634618c035dac439dc1aeb3b5598a0f00000000001",
+				vaaID:        vaa.MessageID(),
+				timestamp:    timestamp,
+			}},
+		},
+		{
+			name: "invalid transfer",
+			err:  &token.UnknownTokenErr{},
+		},
+		{
+			name: "token address cannot be normalized",
+			err: &token.UnknownTokenErr{
+				TokenChain:   sdk.ChainIDSui,
+				TokenAddress: "0x2::sui::SUI",
+			},
+		},
+		{
+			name:    "not an unknown token error",
+			err:     errors.New("parser unavailable"),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := &unknownTokenRecorderMock{}
+			m := newTestMetric(recorder, tc.err)
+
+			err := m.Push(context.Background(), &Params{TrackID: "test", Vaa: vaa})
+			if tc.wantErr {
+				assert.Equal(t, tc.err, err)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.want, recorder.records)
+		})
+	}
+}
+
+func TestPush_RecordUnknownTokenFailure(t *testing.T) {
+	recorder := &unknownTokenRecorderMock{err: errors.New("mongo unavailable")}
+	m := newTestMetric(recorder, &token.UnknownTokenErr{
+		TokenChain:   sdk.ChainIDEthereum,
+		TokenAddress: "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2",
+	})
+
+	// failing to record the token does not interrupt the processing of the VAA.
+	err := m.Push(context.Background(), &Params{TrackID: "test", Vaa: &sdk.VAA{EmitterChain: sdk.ChainIDEthereum}})
+	assert.Nil(t, err)
+	assert.Len(t, recorder.records, 1)
+}
+
+func TestMakePointVaaVolumeV3(t *testing.T) {
+	var tests = []struct {
+		name   string
+		appIDs []string
+		want   map[string]string
+	}{
+		{
+			name:   "single appId",
+			appIDs: []string{"PORTAL_TOKEN_BRIDGE"},
+			want:   map[string]string{"app_id_1": "PORTAL_TOKEN_BRIDGE", "app_id_2": "none", "app_id_3": "none", "size": "1"},
+		},
+		{
+			name:   "too many appIds",
+			appIDs: []string{"CONNECT", "PORTAL_TOKEN_BRIDGE", "MAYAN", "GENERIC_RELAYER"},
+			want:   map[string]string{"app_id_1": "CONNECT", "app_id_4": "GENERIC_RELAYER", "size": "4"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v2Point := influxdb2.NewPointWithMeasurement(VaaVolumeMeasurement).
+				AddTag("app_id", tc.appIDs[0]).
+				AddField("volume", uint64(100)).
+				SetTime(time.Unix(1700000000, 0))
+			params := &Params{TrackID: "test", Vaa: &sdk.VAA{EmitterChain: sdk.ChainIDEthereum}}
+
+			point := MakePointVaaVolumeV3(zap.NewNop(), v2Point, params, &token.TransferredToken{AppIDs: tc.appIDs})
+
+			tags := make(map[string]string)
+			for _, tag := range point.TagList() {
+				tags[tag.Key] = tag.Value
+			}
+			assert.NotContains(t, tags, "app_id")
+			for key, value := range tc.want {
+				assert.Equal(t, value, tags[key])
+			}
+			assert.Equal(t, v2Point.Time(), point.Time())
+		})
+	}
+}
@@ -81,32 +81,9 @@ func UpsertTransferPrices(
 		return nil
 	}
 
-	// Compute the amount with decimals
-	var exp int32
-	if tokenMeta.Decimals > 8 {
-		exp = 8
-	} else {
-		exp = int32(tokenMeta.Decimals)
-	}
-	tokenAmount := decimal.NewFromBigInt(transferredToken.Amount, -exp)
-
-	// Compute the amount in USD
-	usdAmount := tokenAmount.Mul(notionalUSD)
-
 	// Upsert the `TransferPrices` collection
 	update := bson.M{
-		"$set": TransferPriceDoc{
-			ID:             vaa.MessageID(),
-			Timestamp:      vaa.Timestamp,
-			Symbol:         tokenMeta.Symbol.String(),
-			SymbolPriceUsd: notionalUSD.Truncate(8).String(),
-			TokenAmount:    tokenAmount.Truncate(8).String(),
-			UsdAmount:      usdAmount.Truncate(8).String(),
-			TokenChain:     uint16(transferredToken.TokenChain),
-			TokenAddress:   transferredToken.TokenAddress.String(),
-			CoinGeckoID:    tokenMeta.CoingeckoID,
-			UpdatedAt:      time.Now(),
-		},
+		"$set": MakeTransferPriceDoc(vaa, notionalUSD, transferredToken, tokenMeta),
 	}
 	_, err = transferPrices.UpdateByID(
 		ctx,
@@ -120,3 +97,37 @@ func UpsertTransferPrices(
 
 	return nil
 }
+
+// MakeTransferPriceDoc builds the `transferPrices` document of a transfer given the price of the token.
+func MakeTransferPriceDoc(
+	vaa *sdk.VAA,
+	notionalUSD decimal.Decimal,
+	transferredToken *token.TransferredToken,
+	tokenMeta *domain.TokenMetadata,
+) *TransferPriceDoc {
+
+	// Compute the amount with decimals
+	var exp int32
+	if tokenMeta.Decimals > 8 {
+		exp = 8
+	} else {
+		exp = int32(tokenMeta.Decimals)
+	}
+	tokenAmount := decimal.NewFromBigInt(transferredToken.Amount, -exp)
+
+	// Compute the amount in USD
+	usdAmount := tokenAmount.Mul(notionalUSD)
+
+	return &TransferPriceDoc{
+		ID:             vaa.MessageID(),
+		Timestamp:      vaa.Timestamp,
+		Symbol:         tokenMeta.Symbol.String(),
+		SymbolPriceUsd: notionalUSD.Truncate(8).String(),
+		TokenAmount:    tokenAmount.Truncate(8).String(),
+		UsdAmount:      usdAmount.Truncate(8).String(),
+		TokenChain:     uint16(transferredToken.TokenChain),
+		TokenAddress:   transferredToken.TokenAddress.String(),
+		CoinGeckoID:    tokenMeta.CoingeckoID,
+		UpdatedAt:      time.Now(),
+	}
+}
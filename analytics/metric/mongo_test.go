@@ -0,0 +1,82 @@
+package metric
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/analytics/cmd/token"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestMakeTransferPriceDoc(t *testing.T) {
+	timestamp := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	vaa := &sdk.VAA{
+		EmitterChain:   sdk.ChainIDEthereum,
+		EmitterAddress: sdk.Address{1},
+		Sequence:       7,
+		Timestamp:      timestamp,
+	}
+	tokenAddress, _ := sdk.StringToAddress("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+
+	var tests = []struct {
+		name            string
+		amount          int64
+		decimals        int64
+		price           string
+		wantTokenAmount string
+		wantUsdAmount   string
+		wantPrice       string
+	}{
+		{
+			name:            "token with less than 8 decimals",
+			amount:          12_345_678,
+			decimals:        6,
+			price:           "0.9998",
+			wantTokenAmount: "12.345678",
+			wantUsdAmount:   "12.34320886",
+			wantPrice:       "0.9998",
+		},
+		{
+			// the amounts of the VAAs are normalized to 8 decimals.
+			name:            "token with more than 8 decimals",
+			amount:          150_000_000,
+			decimals:        18,
+			price:           "2250.123456789",
+			wantTokenAmount: "1.5",
+			wantUsdAmount:   "3375.18518518",
+			wantPrice:       "2250.12345678",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			transferredToken := &token.TransferredToken{
+				TokenChain:   sdk.ChainIDEthereum,
+				TokenAddress: tokenAddress,
+				Amount:       big.NewInt(tc.amount),
+			}
+			tokenMeta := &domain.TokenMetadata{
+				TokenChain:   sdk.ChainIDEthereum,
+				TokenAddress: tokenAddress.String(),
+				Symbol:       domain.Symbol("TKN"),
+				CoingeckoID:  "token",
+				Decimals:     tc.decimals,
+			}
+
+			doc := MakeTransferPriceDoc(vaa, decimal.RequireFromString(tc.price), transferredToken, tokenMeta)
+			assert.Equal(t, vaa.MessageID(), doc.ID)
+			assert.Equal(t, timestamp, doc.Timestamp)
+			assert.Equal(t, "TKN", doc.Symbol)
+			assert.Equal(t, tc.wantPrice, doc.SymbolPriceUsd)
+			assert.Equal(t, tc.wantTokenAmount, doc.TokenAmount)
+			assert.Equal(t, tc.wantUsdAmount, doc.UsdAmount)
+			assert.Equal(t, uint16(sdk.ChainIDEthereum), doc.TokenChain)
+			assert.Equal(t, tokenAddress.String(), doc.TokenAddress)
+			assert.Equal(t, "token", doc.CoinGeckoID)
+		})
+	}
+}
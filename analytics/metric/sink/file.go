@@ -0,0 +1,81 @@
+package sink
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+)
+
+// Record is the representation of a point written by the FileSink.
+type Record struct {
+	Bucket      Bucket                 `json:"bucket"`
+	Measurement string                 `json:"measurement"`
+	Time        time.Time              `json:"time"`
+	Tags        map[string]string      `json:"tags"`
+	Fields      map[string]interface{} `json:"fields"`
+}
+
+// NewRecord creates the record of a point.
+func NewRecord(bucket Bucket, point *write.Point) Record {
+	r := Record{
+		Bucket:      bucket,
+		Measurement: point.Name(),
+		Time:        point.Time().UTC(),
+		Tags:        make(map[string]string, len(point.TagList())),
+		Fields:      make(map[string]interface{}, len(point.FieldList())),
+	}
+	for _, tag := range point.TagList() {
+		r.Tags[tag.Key] = tag.Value
+	}
+	for _, field := range point.FieldList() {
+		r.Fields[field.Key] = field.Value
+	}
+	return r
+}
+
+// FileSink writes the points to a file as newline delimited JSON records.
+//
+// The keys of the tags and fields are sorted, so the files written by two versions of analytics
+// can be compared line by line and loaded into a data warehouse.
+type FileSink struct {
+	mu     sync.Mutex
+	file   *os.File
+	writer *bufio.Writer
+}
+
+// NewFileSink creates a new FileSink. The records are appended to the file if it exists.
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{file: file, writer: bufio.NewWriter(file)}, nil
+}
+
+// WritePoints appends a record per point to the file.
+func (s *FileSink) WritePoints(_ context.Context, bucket Bucket, points ...*write.Point) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	encoder := json.NewEncoder(s.writer)
+	for _, point := range points {
+		if err := encoder.Encode(NewRecord(bucket, point)); err != nil {
+			return err
+		}
+	}
+	return s.writer.Flush()
+}
+
+// Close flushes and closes the file.
+func (s *FileSink) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_ = s.writer.Flush()
+	_ = s.file.Close()
+}
@@ -0,0 +1,56 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api"
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+)
+
+// InfluxSink writes the points to InfluxDB.
+type InfluxSink struct {
+	client  influxdb2.Client
+	writers map[Bucket]api.WriteAPIBlocking
+}
+
+// NewInfluxSink creates a new InfluxSink.
+func NewInfluxSink(client influxdb2.Client, organization, bucketInfinite, bucket30Days, bucket24Hours string) *InfluxSink {
+	apiBucket24Hours := client.WriteAPIBlocking(organization, bucket24Hours)
+	apiBucket24Hours.EnableBatching()
+
+	return &InfluxSink{
+		client: client,
+		writers: map[Bucket]api.WriteAPIBlocking{
+			BucketInfinite: client.WriteAPIBlocking(organization, bucketInfinite),
+			Bucket30Days:   client.WriteAPIBlocking(organization, bucket30Days),
+			Bucket24Hours:  apiBucket24Hours,
+		},
+	}
+}
+
+// WritePoints writes the points into the InfluxDB bucket.
+func (s *InfluxSink) WritePoints(ctx context.Context, bucket Bucket, points ...*write.Point) error {
+	writer, ok := s.writers[bucket]
+	if !ok {
+		return fmt.Errorf("unknown bucket %s", bucket)
+	}
+	return writer.WritePoint(ctx, points...)
+}
+
+// Close flushes the buckets and closes the InfluxDB client.
+func (s *InfluxSink) Close() {
+
+	const flushTimeout = 5 * time.Second
+
+	// wait a bounded amount of time for all buckets to flush
+	ctx, cancelFunc := context.WithTimeout(context.Background(), flushTimeout)
+	for _, writer := range s.writers {
+		writer.Flush(ctx)
+	}
+	cancelFunc()
+
+	s.client.Close()
+}
@@ -0,0 +1,37 @@
+package sink
+
+import (
+	"context"
+	"sync"
+
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+)
+
+// MemorySink keeps the points in memory. It is meant to be used in tests and local runs.
+type MemorySink struct {
+	mu     sync.Mutex
+	points map[Bucket][]*write.Point
+}
+
+// NewMemorySink creates a new MemorySink.
+func NewMemorySink() *MemorySink {
+	return &MemorySink{points: make(map[Bucket][]*write.Point)}
+}
+
+// WritePoints keeps the points of a bucket.
+func (s *MemorySink) WritePoints(_ context.Context, bucket Bucket, points ...*write.Point) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.points[bucket] = append(s.points[bucket], points...)
+	return nil
+}
+
+// Points returns the points written into a bucket.
+func (s *MemorySink) Points(bucket Bucket) []*write.Point {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*write.Point(nil), s.points[bucket]...)
+}
+
+// Close does nothing.
+func (s *MemorySink) Close() {}
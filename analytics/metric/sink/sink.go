@@ -0,0 +1,25 @@
+// Package sink contains the destinations where the analytics metric points are written.
+package sink
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+)
+
+// Bucket identifies the retention of the written points.
+type Bucket string
+
+const (
+	BucketInfinite Bucket = "infinite"
+	Bucket30Days   Bucket = "30days"
+	Bucket24Hours  Bucket = "24hours"
+)
+
+// Sink is a destination of metric points.
+type Sink interface {
+	// WritePoints writes the points into a bucket.
+	WritePoints(ctx context.Context, bucket Bucket, points ...*write.Point) error
+	// Close flushes the pending points and releases the resources of the sink.
+	Close()
+}
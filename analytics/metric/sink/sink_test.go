@@ -0,0 +1,106 @@
+package sink
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+	"github.com/stretchr/testify/assert"
+)
+
+var pointTime = time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+func newPoint(measurement string, volume uint64) *write.Point {
+	return write.NewPoint(measurement,
+		map[string]string{"emitter_chain": "2", "destination_chain": "1"},
+		map[string]interface{}{"volume": volume},
+		pointTime)
+}
+
+func TestMemorySink(t *testing.T) {
+	s := NewMemorySink()
+	ctx := context.Background()
+
+	assert.NoError(t, s.WritePoints(ctx, BucketInfinite, newPoint("vaa_volume_v2", 1), newPoint("vaa_volume_v2", 2)))
+	assert.NoError(t, s.WritePoints(ctx, Bucket30Days, newPoint("vaa_count", 3)))
+	assert.NoError(t, s.WritePoints(ctx, BucketInfinite, newPoint("vaa_volume_v2", 4)))
+
+	points := s.Points(BucketInfinite)
+	assert.Len(t, points, 3)
+	for i, volume := range []uint64{1, 2, 4} {
+		assert.Equal(t, "vaa_volume_v2", points[i].Name())
+		assert.Equal(t, volume, points[i].FieldList()[0].Value)
+	}
+	assert.Len(t, s.Points(Bucket30Days), 1)
+	assert.Empty(t, s.Points(Bucket24Hours))
+
+	// the returned points are a copy.
+	points[0] = nil
+	assert.NotNil(t, s.Points(BucketInfinite)[0])
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "points.jsonl")
+	ctx := context.Background()
+
+	s, err := NewFileSink(path)
+	assert.NoError(t, err)
+	assert.NoError(t, s.WritePoints(ctx, BucketInfinite, newPoint("vaa_volume_v2", 1)))
+	assert.NoError(t, s.WritePoints(ctx, Bucket24Hours, newPoint("vaa_count", 2)))
+	s.Close()
+
+	// the records are appended when the file is opened again.
+	s, err = NewFileSink(path)
+	assert.NoError(t, err)
+	assert.NoError(t, s.WritePoints(ctx, Bucket30Days, newPoint("vaa_volume_v2", 3)))
+	s.Close()
+
+	records := readRecords(t, path)
+	assert.Len(t, records, 3)
+	assert.Equal(t, Record{
+		Bucket:      BucketInfinite,
+		Measurement: "vaa_volume_v2",
+		Time:        pointTime,
+		Tags:        map[string]string{"emitter_chain": "2", "destination_chain": "1"},
+		// the fields are decoded from JSON, so numbers are float64.
+		Fields: map[string]interface{}{"volume": float64(1)},
+	}, records[0])
+	assert.Equal(t, Bucket24Hours, records[1].Bucket)
+	assert.Equal(t, "vaa_count", records[1].Measurement)
+	assert.Equal(t, Bucket30Days, records[2].Bucket)
+	assert.Equal(t, float64(3), records[2].Fields["volume"])
+}
+
+func TestFileSink_SortedKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "points.jsonl")
+	s, err := NewFileSink(path)
+	assert.NoError(t, err)
+	assert.NoError(t, s.WritePoints(context.Background(), BucketInfinite, newPoint("vaa_volume_v2", 1)))
+	s.Close()
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"bucket":"infinite","measurement":"vaa_volume_v2","time":"2024-01-01T10:00:00Z",`+
+		`"tags":{"destination_chain":"1","emitter_chain":"2"},"fields":{"volume":1}}`+"\n", string(content))
+}
+
+func readRecords(t *testing.T, path string) []Record {
+	file, err := os.Open(path)
+	assert.NoError(t, err)
+	defer file.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var r Record
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &r))
+		records = append(records, r)
+	}
+	assert.NoError(t, scanner.Err())
+	return records
+}
@@ -2,6 +2,7 @@ package metric
 
 import (
 	"context"
+	"time"
 
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
@@ -10,6 +11,17 @@ type Params struct {
 	TrackID     string
 	Vaa         *vaa.VAA
 	VaaIsSigned bool
+	// Redeem is set instead of Vaa when the VAA was redeemed on the target chain.
+	Redeem *Redeem
+}
+
+// Redeem represents the redeem of a VAA on the target chain.
+type Redeem struct {
+	VaaID       string
+	SourceChain vaa.ChainID
+	TargetChain vaa.ChainID
+	TxHash      string
+	Timestamp   time.Time
 }
 
 // MetricPushFunc is a function to push metrics
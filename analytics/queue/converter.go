@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/wormhole-foundation/wormhole-explorer/common/events"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
 )
 
@@ -60,7 +61,8 @@ func NewNotificationEvent(log *zap.Logger) ConverterFunc {
 			return nil, err
 		}
 
-		if notification.Event != events.SignedVaaType && notification.Event != events.LogMessagePublishedType {
+		if notification.Event != events.SignedVaaType && notification.Event != events.LogMessagePublishedType &&
+			notification.Event != events.TransferRedeemedType {
 			log.Debug("Skip event type", zap.String("trackId", notification.TrackID), zap.String("type", notification.Event))
 			return nil, nil
 		}
@@ -112,6 +114,38 @@ func NewNotificationEvent(log *zap.Logger) ConverterFunc {
 				Vaa:            vaaBytes,
 				VaaIsSigned:    false,
 			}, nil
+		case events.TransferRedeemedType:
+			tr, err := events.GetEventData[events.TransferRedeemed](&notification)
+			if err != nil {
+				log.Error("Error decoding transferRedeemed from notification event", zap.String("trackId", notification.TrackID), zap.Error(err))
+				return nil, nil
+			}
+
+			address, err := sdk.StringToAddress(tr.Attributes.EmitterAddress)
+			if err != nil {
+				log.Debug("Error decoding emitter address of transferRedeemed event", zap.String("trackId", notification.TrackID), zap.Error(err))
+				return nil, nil
+			}
+			vaa := sdk.VAA{
+				EmitterChain:   sdk.ChainID(tr.Attributes.EmitterChain),
+				EmitterAddress: address,
+				Sequence:       tr.Attributes.Sequence,
+			}
+
+			return &Event{
+				Source:         "chain-event",
+				TrackID:        notification.TrackID,
+				ID:             vaa.MessageID(),
+				ChainID:        uint16(tr.Attributes.EmitterChain),
+				EmitterAddress: address.String(),
+				Sequence:       strconv.FormatUint(tr.Attributes.Sequence, 10),
+				Timestamp:      &tr.BlockTime,
+				Redeem: &Redeem{
+					TargetChainID: uint16(tr.ChainID),
+					TxHash:        tr.TxHash,
+					Timestamp:     tr.BlockTime,
+				},
+			}, nil
 		}
 		return nil, nil
 	}
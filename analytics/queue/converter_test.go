@@ -0,0 +1,94 @@
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestNotificationEvent_TransferRedeemed(t *testing.T) {
+	var tests = []struct {
+		name string
+		msg  string
+		want *Event
+	}{
+		{
+			name: "transfer redeemed",
+			msg: `{
+				"trackId": "chain-event-0xe210617eb9fdf2e970a3c9a1bc5e4ca2509066f40f0a7a663d3bcae0d60e72a9-8899811",
+				"source": "blockchain-watcher",
+				"event": "transfer-redeemed",
+				"version": "1",
+				"data": {
+					"chainId": 2,
+					"emitter": "0x4cb69fae7e7af841e44e1a1c30af640739378bb2",
+					"txHash": "0xe210617eb9fdf2e970a3c9a1bc5e4ca2509066f40f0a7a663d3bcae0d60e72a9",
+					"blockHeight": "8899811",
+					"blockTime": "2024-01-09T18:31:23Z",
+					"attributes": {
+						"emitterChain": 23,
+						"emitterAddress": "0000000000000000000000002703483B1A5A7C577E8680DE9DF8BE03C6F30E3C",
+						"sequence": 9487,
+						"status": "completed"
+					}
+				}
+			}`,
+			want: &Event{
+				Source:         "chain-event",
+				TrackID:        "chain-event-0xe210617eb9fdf2e970a3c9a1bc5e4ca2509066f40f0a7a663d3bcae0d60e72a9-8899811",
+				ID:             "23/0000000000000000000000002703483b1a5a7c577e8680de9df8be03c6f30e3c/9487",
+				ChainID:        23,
+				EmitterAddress: "0000000000000000000000002703483b1a5a7c577e8680de9df8be03c6f30e3c",
+				Sequence:       "9487",
+				Timestamp:      timePtr(time.Date(2024, 1, 9, 18, 31, 23, 0, time.UTC)),
+				Redeem: &Redeem{
+					TargetChainID: 2,
+					TxHash:        "0xe210617eb9fdf2e970a3c9a1bc5e4ca2509066f40f0a7a663d3bcae0d60e72a9",
+					Timestamp:     time.Date(2024, 1, 9, 18, 31, 23, 0, time.UTC),
+				},
+			},
+		},
+		{
+			name: "invalid emitter address",
+			msg: `{
+				"trackId": "chain-event-1",
+				"source": "blockchain-watcher",
+				"event": "transfer-redeemed",
+				"version": "1",
+				"data": {
+					"chainId": 2,
+					"txHash": "0x1",
+					"blockTime": "2024-01-09T18:31:23Z",
+					"attributes": {"emitterChain": 23, "emitterAddress": "not-an-address", "sequence": 1}
+				}
+			}`,
+			want: nil,
+		},
+		{
+			name: "invalid data",
+			msg: `{
+				"trackId": "chain-event-2",
+				"source": "blockchain-watcher",
+				"event": "transfer-redeemed",
+				"version": "1",
+				"data": {"chainId": "ethereum"}
+			}`,
+			want: nil,
+		},
+	}
+
+	converter := NewNotificationEvent(zap.NewNop())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := converter(tt.msg)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, event)
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
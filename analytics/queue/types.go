@@ -21,6 +21,14 @@ type Event struct {
 	Vaa            []byte
 	Timestamp      *time.Time
 	VaaIsSigned    bool
+	Redeem         *Redeem
+}
+
+// Redeem represents the redeem of a VAA on the target chain.
+type Redeem struct {
+	TargetChainID uint16
+	TxHash        string
+	Timestamp     time.Time
 }
 
 // ConsumerMessage defition.
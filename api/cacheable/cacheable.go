@@ -3,14 +3,43 @@ package cacheable
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
 	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 )
 
+const (
+	// loadTimeout is the max duration of a load shared by the concurrent calls of the same key.
+	loadTimeout = 1 * time.Minute
+	// refreshTimeout is the max duration of a background refresh of an expired result.
+	refreshTimeout = 1 * time.Minute
+)
+
+var (
+	// local is the in-process cache in front of the distributed cache.
+	local = newLRU(0)
+	// group coalesces the concurrent loads of the same key.
+	group singleflight.Group
+)
+
+// SetLocalCapacity sets the max number of results kept in the in-process cache.
+// A capacity lower or equal to zero disables the in-process cache, which is the default.
+func SetLocalCapacity(capacity int) {
+	local = newLRU(capacity)
+}
+
 // GetOrLoad is a function that tries to get the result from the cache, if it is not found or it is expired, then it loads the result.
+//
+// The result is looked up first in the in-process cache and then in the distributed cache. Concurrent calls
+// with the same key are coalesced, so load runs once per key at a time. An expired result younger than two
+// times the expiration is returned immediately while it is refreshed in the background (stale-while-revalidate).
+//
+// The load function may run in the background after the request has finished, so it must use the context
+// it receives instead of the request one.
 func GetOrLoad[T any](
 	ctx context.Context,
 	logger *zap.Logger,
@@ -18,10 +47,78 @@ func GetOrLoad[T any](
 	expirations time.Duration,
 	key string,
 	metrics metrics.Metrics,
-	load func() (T, error),
+	load func(ctx context.Context) (T, error),
 ) (T, error) {
 	log := logger.With(zap.String("key", key))
 
+	// Try to get the result from the in-process cache.
+	if cached, ok := getLocal[T](key); ok {
+		switch {
+		case isFresh(cached, expirations):
+			return cached.Result, nil
+		case isStale(cached, expirations):
+			refresh(log, cacheClient, expirations, key, metrics, load)
+			return cached.Result, nil
+		}
+	}
+
+	// Only one goroutine per key goes to the distributed cache and runs the load function.
+	// The shared load does not depend on the request of the first caller, so it is not cancelled
+	// when that client disconnects, and every caller stops waiting when its own request ends.
+	ch := group.DoChan(key, func() (result any, err error) {
+		defer recoverLoad(log, &err)
+		loadCtx, cancel := context.WithTimeout(detach(ctx), loadTimeout)
+		defer cancel()
+		return getOrLoadShared(loadCtx, log, cacheClient, expirations, key, metrics, load, true)
+	})
+	select {
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	case res := <-ch:
+		// the result of a failed load is returned too, because it may be partial.
+		cached, _ := res.Val.(CachedResult[T])
+		return cached.Result, res.Err
+	}
+}
+
+// recoverLoad turns a panic of a load function into an error. The load runs in its own goroutine,
+// so an unrecovered panic would crash the process instead of failing the request.
+func recoverLoad(log *zap.Logger, err *error) {
+	if r := recover(); r != nil {
+		log.Error("load function panicked", zap.Any("panic", r), zap.Stack("stack"))
+		*err = fmt.Errorf("load function panicked: %v", r)
+	}
+}
+
+// detachedContext keeps the values of a context without its cancellation and deadline.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+func (c detachedContext) Value(key any) any         { return c.parent.Value(key) }
+
+// detach returns a context that is not cancelled when ctx is cancelled.
+func detach(ctx context.Context) context.Context {
+	return detachedContext{parent: ctx}
+}
+
+// getOrLoadShared tries to get the result from the distributed cache, if it is not found or it is expired, then it loads the result.
+// When serveStale is true, an stale result is returned and refreshed in the background instead of loading it.
+func getOrLoadShared[T any](
+	ctx context.Context,
+	log *zap.Logger,
+	cacheClient cache.Cache,
+	expirations time.Duration,
+	key string,
+	metrics metrics.Metrics,
+	load func(ctx context.Context) (T, error),
+	serveStale bool,
+) (CachedResult[T], error) {
+
 	// Try to get the result from the cache.
 	value, err := cacheClient.Get(ctx, key)
 	foundCache := true
@@ -40,33 +137,83 @@ func GetOrLoad[T any](
 		err = json.Unmarshal([]byte(value), &cached)
 		if err != nil {
 			log.Warn("unmarshal cache", zap.Error(err))
-		} else if cached.Timestamp.Add(expirations).After(time.Now()) {
-			return cached.Result, nil
+			foundCache = false
+		} else if isFresh(cached, expirations) {
+			local.set(key, cached)
+			return cached, nil
+		} else if serveStale && isStale(cached, expirations) {
+			local.set(key, cached)
+			refresh(log, cacheClient, expirations, key, metrics, load)
+			return cached, nil
 		}
 	}
 
 	//If the result is not found in the cache or it is expired, then load the result.
-	result, err := load()
+	result, err := load(ctx)
 	if err != nil {
 		//If the load function fails and the cache was found and is expired, the cache value is returned anyway.
 		if foundCache {
 			metrics.IncExpiredCacheResponse(key)
 			log.Warn("load function fails but returns cached result",
 				zap.Error(err), zap.String("cacheTime", cached.Timestamp.String()))
-			return cached.Result, nil
+			return cached, nil
 		}
-		return result, err
+		return CachedResult[T]{Result: result}, err
 	}
 
 	//Saves the result of the execution of the load function in cache.
 	newValue := CachedResult[T]{Timestamp: time.Now(), Result: result}
+	local.set(key, newValue)
 	err = cacheClient.Set(ctx, key, newValue, 0)
 	if err != nil {
 		log.Warn("saving the result in the cache", zap.Error(err))
 	}
 
 	//Returns the result of the execution of the function load
-	return result, nil
+	return newValue, nil
+}
+
+// refresh reloads an expired result in the background.
+//
+// The distributed cache is checked again before loading, because another pod may have already refreshed it.
+func refresh[T any](
+	log *zap.Logger,
+	cacheClient cache.Cache,
+	expirations time.Duration,
+	key string,
+	metrics metrics.Metrics,
+	load func(ctx context.Context) (T, error),
+) {
+	// DoChan does not block and starts a single refresh per key. Its result is not needed.
+	group.DoChan("refresh:"+key, func() (result any, err error) {
+		defer recoverLoad(log, &err)
+		ctx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+		defer cancel()
+		cached, err := getOrLoadShared(ctx, log, cacheClient, expirations, key, metrics, load, false)
+		if err != nil {
+			log.Warn("refreshing expired result", zap.Error(err))
+		}
+		return cached, err
+	})
+}
+
+func getLocal[T any](key string) (CachedResult[T], bool) {
+	value, ok := local.get(key)
+	if !ok {
+		return CachedResult[T]{}, false
+	}
+	cached, ok := value.(CachedResult[T])
+	return cached, ok
+}
+
+// isFresh checks if the result is not expired.
+func isFresh[T any](cached CachedResult[T], expirations time.Duration) bool {
+	return cached.Timestamp.Add(expirations).After(time.Now())
+}
+
+// isStale checks if the result is expired but can still be returned while it is refreshed.
+func isStale[T any](cached CachedResult[T], expirations time.Duration) bool {
+	return cached.Timestamp.Add(2 * expirations).After(time.Now())
 }
 
 type CachedResult[T any] struct {
@@ -0,0 +1,208 @@
+package cacheable
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
+	"go.uber.org/zap"
+)
+
+// memoryCache is a cache.Cache backed by a map.
+type memoryCache struct {
+	mu     sync.Mutex
+	values map[string]string
+	gets   int32
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{values: make(map[string]string)}
+}
+
+func (c *memoryCache) Get(_ context.Context, key string) (string, error) {
+	atomic.AddInt32(&c.gets, 1)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.values[key]
+	if !ok {
+		return "", cache.ErrNotFound
+	}
+	return v, nil
+}
+
+func (c *memoryCache) Set(_ context.Context, key string, value interface{}, _ time.Duration) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] = string(b)
+	return nil
+}
+
+func (c *memoryCache) Close() error { return nil }
+
+func TestGetOrLoad_CoalescesConcurrentLoads(t *testing.T) {
+	SetLocalCapacity(10)
+	defer SetLocalCapacity(0)
+
+	c := newMemoryCache()
+	var loads int32
+	release := make(chan struct{})
+	load := func(_ context.Context) (int, error) {
+		atomic.AddInt32(&loads, 1)
+		<-release
+		return 42, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := GetOrLoad(context.Background(), zap.NewNop(), c, time.Minute, "coalesce", metrics.NewNoOpMetrics(), load)
+			assert.NoError(t, err)
+			assert.Equal(t, 42, v)
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&loads))
+}
+
+func TestGetOrLoad_LocalTier(t *testing.T) {
+	SetLocalCapacity(10)
+	defer SetLocalCapacity(0)
+
+	c := newMemoryCache()
+	load := func(_ context.Context) (string, error) { return "value", nil }
+
+	for i := 0; i < 3; i++ {
+		v, err := GetOrLoad(context.Background(), zap.NewNop(), c, time.Minute, "local", metrics.NewNoOpMetrics(), load)
+		assert.NoError(t, err)
+		assert.Equal(t, "value", v)
+	}
+
+	// only the first call reaches the distributed cache.
+	assert.Equal(t, int32(1), atomic.LoadInt32(&c.gets))
+}
+
+func TestGetOrLoad_StaleWhileRevalidate(t *testing.T) {
+	SetLocalCapacity(10)
+	defer SetLocalCapacity(0)
+
+	c := newMemoryCache()
+	expired := CachedResult[string]{Timestamp: time.Now().Add(-90 * time.Second), Result: "old"}
+	assert.NoError(t, c.Set(context.Background(), "stale", expired, 0))
+
+	refreshed := make(chan struct{})
+	load := func(_ context.Context) (string, error) {
+		defer close(refreshed)
+		return "new", nil
+	}
+
+	v, err := GetOrLoad(context.Background(), zap.NewNop(), c, time.Minute, "stale", metrics.NewNoOpMetrics(), load)
+	assert.NoError(t, err)
+	assert.Equal(t, "old", v)
+
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("expired result was not refreshed")
+	}
+
+	assert.Eventually(t, func() bool {
+		v, err := GetOrLoad(context.Background(), zap.NewNop(), c, time.Minute, "stale", metrics.NewNoOpMetrics(),
+			func(_ context.Context) (string, error) { return "unexpected", nil })
+		return err == nil && v == "new"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestLRU_Evicts(t *testing.T) {
+	c := newLRU(2)
+	c.set("a", 1)
+	c.set("b", 2)
+	c.get("a")
+	c.set("c", 3)
+
+	_, ok := c.get("b")
+	assert.False(t, ok)
+	_, ok = c.get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 2, c.len())
+}
+
+func TestGetOrLoad_FirstCallerCancelled(t *testing.T) {
+	c := newMemoryCache()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	load := func(ctx context.Context) (int, error) {
+		close(started)
+		select {
+		case <-release:
+			return 42, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+
+	// the first caller starts the load and disconnects.
+	firstCtx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error)
+	go func() {
+		_, err := GetOrLoad(firstCtx, zap.NewNop(), c, time.Minute, "cancelled", metrics.NewNoOpMetrics(), load)
+		firstErr <- err
+	}()
+	<-started
+
+	waiter := make(chan int)
+	go func() {
+		v, err := GetOrLoad(context.Background(), zap.NewNop(), c, time.Minute, "cancelled", metrics.NewNoOpMetrics(), load)
+		assert.NoError(t, err)
+		waiter <- v
+	}()
+
+	cancel()
+	assert.ErrorIs(t, <-firstErr, context.Canceled)
+
+	// the shared load goes on for the waiter.
+	close(release)
+	select {
+	case v := <-waiter:
+		assert.Equal(t, 42, v)
+	case <-time.After(time.Second):
+		t.Fatal("waiter did not receive the result")
+	}
+}
+
+func TestGetOrLoad_ReturnsPartialResultOnError(t *testing.T) {
+	c := newMemoryCache()
+	load := func(ctx context.Context) (string, error) {
+		return "partial", errors.New("load failed")
+	}
+
+	v, err := GetOrLoad(context.Background(), zap.NewNop(), c, time.Minute, "partial", metrics.NewNoOpMetrics(), load)
+	assert.EqualError(t, err, "load failed")
+	assert.Equal(t, "partial", v)
+}
+
+func TestGetOrLoad_RecoversLoadPanic(t *testing.T) {
+	c := newMemoryCache()
+	load := func(ctx context.Context) (int, error) {
+		panic("unexpected call")
+	}
+
+	v, err := GetOrLoad(context.Background(), zap.NewNop(), c, time.Minute, "panic", metrics.NewNoOpMetrics(), load)
+	assert.EqualError(t, err, "load function panicked: unexpected call")
+	assert.Equal(t, 0, v)
+}
@@ -28,6 +28,8 @@ require (
 require (
 	github.com/go-redis/redis/v8 v8.11.5
 	github.com/test-go/testify v1.1.4
+	golang.org/x/sync v0.4.0
+	golang.org/x/time v0.3.0
 )
 
 require (
@@ -139,7 +141,6 @@ require (
 	go.uber.org/multierr v1.11.0 // indirect
 	golang.org/x/crypto v0.19.0 // indirect
 	golang.org/x/net v0.21.0 // indirect
-	golang.org/x/sync v0.4.0 // indirect
 	golang.org/x/sys v0.17.0 // indirect
 	golang.org/x/term v0.17.0 // indirect
 	golang.org/x/text v0.14.0 // indirect
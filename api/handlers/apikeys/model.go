@@ -0,0 +1,52 @@
+// Package apikeys handle the api keys used to authenticate and rate limit the requests to the api.
+package apikeys
+
+import (
+	"time"
+)
+
+// ApiKeyDoc represent an api key stored in the apiKeys collection.
+type ApiKeyDoc struct {
+	ID      string `bson:"_id" json:"key"`
+	Name    string `bson:"name" json:"name"`
+	Owner   string `bson:"owner" json:"owner"`
+	Enabled bool   `bson:"enabled" json:"enabled"`
+	// RequestsPerMinute overrides the default rate for the key when it is greater than zero.
+	RequestsPerMinute int `bson:"requestsPerMinute" json:"requestsPerMinute"`
+	// Burst overrides the default burst for the key when it is greater than zero.
+	Burst     int        `bson:"burst" json:"burst"`
+	CreatedAt *time.Time `bson:"createdAt" json:"createdAt"`
+	UpdatedAt *time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+// UsageDoc represent the daily usage of an api key.
+type UsageDoc struct {
+	ID        string    `bson:"_id" json:"-"`
+	ApiKey    string    `bson:"apiKey" json:"apiKey"`
+	Date      string    `bson:"date" json:"date"`
+	Requests  int64     `bson:"requests" json:"requests"`
+	Throttled int64     `bson:"throttled" json:"throttled"`
+	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+// UsageQuery contains the filters used to search api key usage.
+type UsageQuery struct {
+	ApiKey string
+	From   time.Time
+	To     time.Time
+}
+
+// usageDateLayout is the layout used to store the date of the usage documents.
+const usageDateLayout = "2006-01-02"
+
+// usageCounter accumulates the usage of an api key between flushes.
+type usageCounter struct {
+	requests  int64
+	throttled int64
+}
+
+// usageID identifies the usage of an api key in a day.
+type usageID struct {
+	apiKey string
+	date   string
+}
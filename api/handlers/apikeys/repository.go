@@ -0,0 +1,105 @@
+package apikeys
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Repository definition.
+type Repository struct {
+	db          *mongo.Database
+	logger      *zap.Logger
+	collections struct {
+		apiKeys      *mongo.Collection
+		apiKeysUsage *mongo.Collection
+	}
+}
+
+// NewRepository create a new Repository.
+func NewRepository(db *mongo.Database, logger *zap.Logger) *Repository {
+	return &Repository{db: db,
+		logger: logger.With(zap.String("module", "ApiKeysRepository")),
+		collections: struct {
+			apiKeys      *mongo.Collection
+			apiKeysUsage *mongo.Collection
+		}{
+			apiKeys:      db.Collection("apiKeys"),
+			apiKeysUsage: db.Collection("apiKeysUsage"),
+		},
+	}
+}
+
+// FindAll get all the api keys.
+func (r *Repository) FindAll(ctx context.Context) ([]*ApiKeyDoc, error) {
+	cur, err := r.collections.apiKeys.Find(ctx, bson.D{})
+	if err != nil {
+		r.logger.Error("failed execute Find command to get api keys", zap.Error(err))
+		return nil, errors.WithStack(err)
+	}
+	var keys []*ApiKeyDoc
+	if err := cur.All(ctx, &keys); err != nil {
+		r.logger.Error("failed decoding cursor to []*ApiKeyDoc", zap.Error(err))
+		return nil, errors.WithStack(err)
+	}
+	return keys, nil
+}
+
+// FindUsage get the daily usage of the api keys that match the query.
+func (r *Repository) FindUsage(ctx context.Context, q *UsageQuery) ([]*UsageDoc, error) {
+	filter := bson.D{
+		{Key: "date", Value: bson.D{
+			{Key: "$gte", Value: q.From.UTC().Format(usageDateLayout)},
+			{Key: "$lte", Value: q.To.UTC().Format(usageDateLayout)},
+		}},
+	}
+	if q.ApiKey != "" {
+		filter = append(filter, bson.E{Key: "apiKey", Value: q.ApiKey})
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: -1}, {Key: "apiKey", Value: 1}})
+	cur, err := r.collections.apiKeysUsage.Find(ctx, filter, opts)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed execute Find command to get api keys usage",
+			zap.Error(err), zap.Any("q", q), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	var usage []*UsageDoc
+	if err := cur.All(ctx, &usage); err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed decoding cursor to []*UsageDoc",
+			zap.Error(err), zap.Any("q", q), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	return usage, nil
+}
+
+// IncUsage increments the usage counters of an api key for a given day.
+func (r *Repository) IncUsage(ctx context.Context, apiKey, date string, requests, throttled int64) error {
+	id := fmt.Sprintf("%s/%s", apiKey, date)
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "apiKey", Value: apiKey},
+			{Key: "date", Value: date},
+			{Key: "updatedAt", Value: time.Now()},
+		}},
+		{Key: "$inc", Value: bson.D{
+			{Key: "requests", Value: requests},
+			{Key: "throttled", Value: throttled},
+		}},
+	}
+	opts := options.Update().SetUpsert(true)
+	_, err := r.collections.apiKeysUsage.UpdateByID(ctx, id, update, opts)
+	if err != nil {
+		r.logger.Error("failed to increment api key usage",
+			zap.Error(err), zap.String("id", id))
+		return errors.WithStack(err)
+	}
+	return nil
+}
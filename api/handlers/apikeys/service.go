@@ -0,0 +1,156 @@
+package apikeys
+
+import (
+	"context"
+	"crypto/subtle"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Service definition.
+type Service struct {
+	repo            *Repository
+	adminToken      string
+	refreshInterval time.Duration
+	keysMu          sync.RWMutex
+	keys            map[string]*ApiKeyDoc
+	usageMu         sync.Mutex
+	usage           map[usageID]*usageCounter
+	logger          *zap.Logger
+}
+
+// NewService create a new Service.
+//
+// The api keys are kept in memory and reloaded from the database every refreshInterval,
+// the usage counters are accumulated in memory and flushed to the database with the same interval.
+func NewService(repo *Repository, adminToken string, refreshInterval time.Duration, logger *zap.Logger) *Service {
+	if refreshInterval <= 0 {
+		refreshInterval = time.Minute
+	}
+	return &Service{
+		repo:            repo,
+		adminToken:      adminToken,
+		refreshInterval: refreshInterval,
+		keys:            make(map[string]*ApiKeyDoc),
+		usage:           make(map[usageID]*usageCounter),
+		logger:          logger.With(zap.String("module", "ApiKeysService")),
+	}
+}
+
+// Start loads the api keys and starts the background refresh of keys and usage counters.
+func (s *Service) Start(ctx context.Context) error {
+	if err := s.loadKeys(ctx); err != nil {
+		return err
+	}
+	go s.run(ctx)
+	return nil
+}
+
+func (s *Service) run(ctx context.Context) {
+	ticker := time.NewTicker(s.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			// flush the pending usage with a fresh context, the root one is already cancelled.
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			s.flushUsage(flushCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			if err := s.loadKeys(ctx); err != nil {
+				s.logger.Error("failed to refresh api keys", zap.Error(err))
+			}
+			s.flushUsage(ctx)
+		}
+	}
+}
+
+func (s *Service) loadKeys(ctx context.Context) error {
+	docs, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+	keys := make(map[string]*ApiKeyDoc, len(docs))
+	for _, doc := range docs {
+		keys[doc.ID] = doc
+	}
+	s.keysMu.Lock()
+	s.keys = keys
+	s.keysMu.Unlock()
+	s.logger.Debug("api keys loaded", zap.Int("count", len(keys)))
+	return nil
+}
+
+// FindKey returns an enabled api key.
+func (s *Service) FindKey(key string) (*ApiKeyDoc, bool) {
+	s.keysMu.RLock()
+	defer s.keysMu.RUnlock()
+	doc, ok := s.keys[key]
+	if !ok || !doc.Enabled {
+		return nil, false
+	}
+	return doc, true
+}
+
+// FindApiKeys returns all the api keys stored in the database.
+func (s *Service) FindApiKeys(ctx context.Context) ([]*ApiKeyDoc, error) {
+	return s.repo.FindAll(ctx)
+}
+
+// RecordRequest accounts a request made with an api key.
+func (s *Service) RecordRequest(key string, throttled bool) {
+	id := usageID{apiKey: key, date: time.Now().UTC().Format(usageDateLayout)}
+	s.usageMu.Lock()
+	defer s.usageMu.Unlock()
+	counter, ok := s.usage[id]
+	if !ok {
+		counter = &usageCounter{}
+		s.usage[id] = counter
+	}
+	counter.requests++
+	if throttled {
+		counter.throttled++
+	}
+}
+
+// FindUsage returns the daily usage of the api keys.
+//
+// The usage accumulated in memory is flushed before querying the database.
+func (s *Service) FindUsage(ctx context.Context, q *UsageQuery) ([]*UsageDoc, error) {
+	s.flushUsage(ctx)
+	return s.repo.FindUsage(ctx, q)
+}
+
+func (s *Service) flushUsage(ctx context.Context) {
+	s.usageMu.Lock()
+	pending := s.usage
+	s.usage = make(map[usageID]*usageCounter)
+	s.usageMu.Unlock()
+
+	for id, counter := range pending {
+		if err := s.repo.IncUsage(ctx, id.apiKey, id.date, counter.requests, counter.throttled); err != nil {
+			// keep the counter to retry on the next flush.
+			s.usageMu.Lock()
+			current, ok := s.usage[id]
+			if !ok {
+				current = &usageCounter{}
+				s.usage[id] = current
+			}
+			current.requests += counter.requests
+			current.throttled += counter.throttled
+			s.usageMu.Unlock()
+		}
+	}
+}
+
+// IsAdmin check if the token grants access to the admin endpoints.
+// When no admin token is configured the admin endpoints are disabled.
+func (s *Service) IsAdmin(token string) bool {
+	if s.adminToken == "" || token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(s.adminToken), []byte(token)) == 1
+}
@@ -205,3 +205,51 @@ type EnqueuedVaaItem struct {
 	NotionalValue  mongo.Uint64 `bson:"notionalvalue" json:"notionalValue"`
 	TxHash         string       `bson:"txhash" json:"txHash"`
 }
+
+// governorStatusHistoryDoc definition.
+// Each document contains the remaining available notional reported by each guardian
+// for a chain during a time bucket.
+type governorStatusHistoryDoc struct {
+	ChainID              vaa.ChainID             `bson:"chainId"`
+	Bucket               time.Time               `bson:"bucket"`
+	Guardians            map[string]mongo.Uint64 `bson:"guardians"`
+	MinAvailableNotional mongo.Uint64            `bson:"minAvailableNotional"`
+}
+
+// AvailableNotionalPoint is a point of the available notional time series of a chain.
+type AvailableNotionalPoint struct {
+	Time              time.Time    `json:"time"`
+	AvailableNotional mongo.Uint64 `json:"availableNotional"`
+	MinNotional       mongo.Uint64 `json:"minAvailableNotional"`
+}
+
+// AvailableNotionalHistory is the available notional time series of a chain.
+type AvailableNotionalHistory struct {
+	ChainID vaa.ChainID              `json:"chainId"`
+	Points  []AvailableNotionalPoint `json:"points"`
+}
+
+// SimulationReason is the reason why a transfer would be delayed by the governor.
+type SimulationReason string
+
+const (
+	SimulationReasonNone                 SimulationReason = ""
+	SimulationReasonBigTransaction       SimulationReason = "big_transaction"
+	SimulationReasonInsufficientNotional SimulationReason = "insufficient_available_notional"
+)
+
+// TransferSimulation is the result of simulating a transfer against the governor.
+type TransferSimulation struct {
+	ChainID               vaa.ChainID      `json:"chainId"`
+	TokenChainID          vaa.ChainID      `json:"tokenChainId"`
+	TokenAddress          string           `json:"tokenAddress"`
+	Amount                float64          `json:"amount"`
+	Governed              bool             `json:"governed"`
+	NotionalValue         float64          `json:"notionalValue"`
+	AvailableNotional     uint64           `json:"availableNotional"`
+	MaxTransactionSize    uint64           `json:"maxTransactionSize"`
+	Delayed               bool             `json:"delayed"`
+	Reason                SimulationReason `json:"reason,omitempty"`
+	EstimatedDelaySeconds int64            `json:"estimatedDelaySeconds"`
+	EstimatedReleaseAt    *time.Time       `json:"estimatedReleaseAt,omitempty"`
+}
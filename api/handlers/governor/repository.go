@@ -11,6 +11,7 @@ import (
 	errs "github.com/wormhole-foundation/wormhole-explorer/api/internal/errors"
 	mongoTypes "github.com/wormhole-foundation/wormhole-explorer/api/internal/mongo"
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/pagination"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
 	"github.com/wormhole-foundation/wormhole-explorer/common/types"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.mongodb.org/mongo-driver/bson"
@@ -26,9 +27,10 @@ type Repository struct {
 	db          *mongo.Database
 	logger      *zap.Logger
 	collections struct {
-		governorConfig *mongo.Collection
-		governorStatus *mongo.Collection
-		governorVaas   *mongo.Collection
+		governorConfig        *mongo.Collection
+		governorStatus        *mongo.Collection
+		governorStatusHistory *mongo.Collection
+		governorVaas          *mongo.Collection
 	}
 }
 
@@ -37,13 +39,15 @@ func NewRepository(db *mongo.Database, logger *zap.Logger) *Repository {
 	return &Repository{db: db,
 		logger: logger.With(zap.String("module", "GovernorRepository")),
 		collections: struct {
-			governorConfig *mongo.Collection
-			governorStatus *mongo.Collection
-			governorVaas   *mongo.Collection
+			governorConfig        *mongo.Collection
+			governorStatus        *mongo.Collection
+			governorStatusHistory *mongo.Collection
+			governorVaas          *mongo.Collection
 		}{
-			governorConfig: db.Collection("governorConfig"),
-			governorStatus: db.Collection("governorStatus"),
-			governorVaas:   db.Collection("governorVaas"),
+			governorConfig:        db.Collection("governorConfig"),
+			governorStatus:        db.Collection("governorStatus"),
+			governorStatusHistory: db.Collection(repository.GovernorStatusHistory),
+			governorVaas:          db.Collection("governorVaas"),
 		},
 	}
 }
@@ -1772,3 +1776,49 @@ func (r *Repository) GetGovernorVaas(ctx context.Context) ([]GovernorVaaDoc, err
 	}
 	return result, nil
 }
+
+// FindGovernorVaaByID get a governor vaa by id.
+func (r *Repository) FindGovernorVaaByID(ctx context.Context, id string) (*GovernorVaaDoc, error) {
+	var doc GovernorVaaDoc
+	err := r.collections.governorVaas.FindOne(ctx, bson.D{{Key: "_id", Value: id}}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errs.ErrNotFound
+		}
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed execute FindOne command to get governor vaa",
+			zap.Error(err), zap.String("id", id), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	return &doc, nil
+}
+
+// FindGovernorStatusHistory get the governor status history of a chain in the range [from, to), sorted by time.
+func (r *Repository) FindGovernorStatusHistory(
+	ctx context.Context,
+	chainID vaa.ChainID,
+	from, to time.Time,
+) ([]*governorStatusHistoryDoc, error) {
+
+	filter := bson.D{
+		{Key: "chainId", Value: chainID},
+		{Key: "bucket", Value: bson.D{{Key: "$gte", Value: from}, {Key: "$lt", Value: to}}},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "bucket", Value: 1}})
+	cur, err := r.collections.governorStatusHistory.Find(ctx, filter, opts)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed execute Find command to get governor status history",
+			zap.Error(err), zap.Uint16("chainId", uint16(chainID)), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+
+	var docs []*governorStatusHistoryDoc
+	if err := cur.All(ctx, &docs); err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed decoding cursor to []*governorStatusHistoryDoc",
+			zap.Error(err), zap.Uint16("chainId", uint16(chainID)), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	return docs, nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/wormhole-foundation/wormhole-explorer/api/cacheable"
 	errs "github.com/wormhole-foundation/wormhole-explorer/api/internal/errors"
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/metrics"
@@ -27,8 +28,9 @@ type Service struct {
 }
 
 const (
-	availableNotionByChain = "wormscan:available-notion-by-chain"
-	tokenList              = "wormscan:token-list"
+	availableNotionByChain   = "wormscan:available-notion-by-chain"
+	tokenList                = "wormscan:token-list"
+	availableNotionalHistory = "wormscan:available-notional-history"
 )
 
 // NewService create a new governor.Service.
@@ -186,7 +188,7 @@ func (s *Service) GetGovernorLimit(ctx context.Context, p *pagination.Pagination
 func (s *Service) GetAvailNotionByChain(ctx context.Context) ([]*AvailableNotionalByChain, error) {
 	key := availableNotionByChain
 	return cacheable.GetOrLoad(ctx, s.logger, s.cache, 1*time.Minute, key, s.metrics,
-		func() ([]*AvailableNotionalByChain, error) {
+		func(ctx context.Context) ([]*AvailableNotionalByChain, error) {
 			return s.repo.GetAvailNotionByChain(ctx)
 		})
 }
@@ -196,7 +198,7 @@ func (s *Service) GetAvailNotionByChain(ctx context.Context) ([]*AvailableNotion
 func (s *Service) GetTokenList(ctx context.Context) ([]*TokenList, error) {
 	key := tokenList
 	return cacheable.GetOrLoad(ctx, s.logger, s.cache, 1*time.Minute, key, s.metrics,
-		func() ([]*TokenList, error) {
+		func(ctx context.Context) ([]*TokenList, error) {
 			return s.repo.GetTokenList(ctx)
 		})
 
@@ -238,3 +240,95 @@ func (s *Service) GetGovernorVaas(ctx context.Context) ([]GovernorVaaDoc, error)
 	}
 	return result, nil
 }
+
+// GetAvailableNotionalHistory get the available notional time series of a chain for the given time span.
+func (s *Service) GetAvailableNotionalHistory(ctx context.Context, chainID vaa.ChainID, timeSpan string) (*AvailableNotionalHistory, error) {
+	var window, resolution time.Duration
+	switch timeSpan {
+	case "1d":
+		window, resolution = 24*time.Hour, 15*time.Minute
+	case "1w":
+		window, resolution = 7*24*time.Hour, time.Hour
+	case "1mo":
+		window, resolution = 30*24*time.Hour, 6*time.Hour
+	default:
+		return nil, errs.ErrMalformedQuery
+	}
+
+	key := fmt.Sprintf("%s:%d:%s", availableNotionalHistory, chainID, timeSpan)
+	return cacheable.GetOrLoad(ctx, s.logger, s.cache, 5*time.Minute, key, s.metrics,
+		func(ctx context.Context) (*AvailableNotionalHistory, error) {
+			to := time.Now().UTC()
+			docs, err := s.repo.FindGovernorStatusHistory(ctx, chainID, to.Add(-window), to)
+			if err != nil {
+				return nil, err
+			}
+			return &AvailableNotionalHistory{
+				ChainID: chainID,
+				Points:  toAvailableNotionalPoints(docs, resolution),
+			}, nil
+		})
+}
+
+// SimulateTransfer predict whether a transfer of the given amount of a token from a chain would be delayed
+// by the governor, and for how long, based on the governor token list and the current usage of the chain.
+func (s *Service) SimulateTransfer(
+	ctx context.Context,
+	chainID vaa.ChainID,
+	tokenChainID vaa.ChainID,
+	tokenAddress *types.Address,
+	amount float64,
+) (*TransferSimulation, error) {
+
+	result := &TransferSimulation{
+		ChainID:      chainID,
+		TokenChainID: tokenChainID,
+		TokenAddress: tokenAddress.Hex(),
+		Amount:       amount,
+	}
+
+	tokens, err := s.GetTokenList(ctx)
+	if err != nil && !errors.Is(err, errs.ErrNotFound) {
+		return nil, err
+	}
+	token, ok := findToken(tokens, uint16(tokenChainID), tokenAddress.Hex())
+	if !ok {
+		return result, nil
+	}
+
+	limits, err := s.GetAvailNotionByChain(ctx)
+	if err != nil && !errors.Is(err, errs.ErrNotFound) {
+		return nil, err
+	}
+	var limit *AvailableNotionalByChain
+	for _, l := range limits {
+		if l.ChainID == chainID {
+			limit = l
+			break
+		}
+	}
+	if limit == nil {
+		return result, nil
+	}
+
+	now := time.Now().UTC()
+	docs, err := s.repo.FindGovernorStatusHistory(ctx, chainID, now.Add(-governorWindow), now)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Governed = true
+	result.NotionalValue = amount * float64(token.Price)
+	result.AvailableNotional = uint64(limit.AvailableNotional)
+	result.MaxTransactionSize = uint64(limit.MaxTransactionSize)
+	reason, delay := estimateDelay(result.NotionalValue, result.AvailableNotional, result.MaxTransactionSize,
+		toAvailableNotionalPoints(docs, historyBucket), now)
+	if reason != SimulationReasonNone {
+		releaseAt := now.Add(delay)
+		result.Delayed = true
+		result.Reason = reason
+		result.EstimatedDelaySeconds = int64(delay.Seconds())
+		result.EstimatedReleaseAt = &releaseAt
+	}
+	return result, nil
+}
@@ -0,0 +1,119 @@
+package governor
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/mongo"
+)
+
+// governorWindow is the sliding window used by the governor to compute the available notional.
+// It is also the delay applied to enqueued VAAs.
+const governorWindow = 24 * time.Hour
+
+// historyBucket is the time resolution of the governor status history stored by fly.
+const historyBucket = 15 * time.Minute
+
+// toAvailableNotionalPoints build the available notional time series from the governor status history.
+// The available notional of each bucket is the minimum value reported by the guardians, and the points
+// are downsampled to the given resolution keeping the last value of each interval.
+func toAvailableNotionalPoints(docs []*governorStatusHistoryDoc, resolution time.Duration) []AvailableNotionalPoint {
+	points := make([]AvailableNotionalPoint, 0, len(docs))
+	for _, doc := range docs {
+		available := doc.MinAvailableNotional
+		first := true
+		for _, v := range doc.Guardians {
+			if first || v < available {
+				available = v
+				first = false
+			}
+		}
+
+		t := doc.Bucket.UTC().Truncate(resolution)
+		if n := len(points); n > 0 && points[n-1].Time.Equal(t) {
+			points[n-1].AvailableNotional = available
+			points[n-1].MinNotional = minUint64(points[n-1].MinNotional, doc.MinAvailableNotional)
+			continue
+		}
+		points = append(points, AvailableNotionalPoint{
+			Time:              t,
+			AvailableNotional: available,
+			MinNotional:       doc.MinAvailableNotional,
+		})
+	}
+	return points
+}
+
+// estimateDelay estimate whether a transfer of the given notional value would be delayed by the governor,
+// and for how long.
+//
+// Big transactions are always delayed for the full governor window. Otherwise, the notional consumed by
+// previous transfers (the decreases of the available notional in the history of the last window) is
+// released when those transfers leave the sliding window, so the transfer is released once enough
+// notional has been freed.
+func estimateDelay(
+	notional float64,
+	available, maxTransactionSize uint64,
+	history []AvailableNotionalPoint,
+	now time.Time,
+) (SimulationReason, time.Duration) {
+
+	if maxTransactionSize > 0 && notional > float64(maxTransactionSize) {
+		return SimulationReasonBigTransaction, governorWindow
+	}
+	if notional <= float64(available) {
+		return SimulationReasonNone, 0
+	}
+
+	points := make([]AvailableNotionalPoint, 0, len(history))
+	for _, p := range history {
+		if !p.Time.Before(now.Add(-governorWindow)) && !p.Time.After(now) {
+			points = append(points, p)
+		}
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].Time.Before(points[j].Time) })
+
+	freed := float64(available)
+	for i := 1; i < len(points); i++ {
+		prev, curr := points[i-1].AvailableNotional, points[i].AvailableNotional
+		if curr >= prev {
+			continue
+		}
+		freed += float64(prev - curr)
+		if freed >= notional {
+			delay := points[i].Time.Add(governorWindow).Sub(now)
+			if delay < 0 {
+				delay = 0
+			}
+			return SimulationReasonInsufficientNotional, delay
+		}
+	}
+	return SimulationReasonInsufficientNotional, governorWindow
+}
+
+// findToken find a token in the governor token list.
+func findToken(tokens []*TokenList, chainID uint16, address string) (*TokenList, bool) {
+	for _, t := range tokens {
+		if uint16(t.OriginChainID) == chainID && normalizeTokenAddress(t.OriginAddress) == normalizeTokenAddress(address) {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+// normalizeTokenAddress normalize a token address to 64 lowercase hex digits without the 0x prefix.
+func normalizeTokenAddress(address string) string {
+	a := strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(address, "0x"), "0X"))
+	if len(a) < 64 {
+		a = strings.Repeat("0", 64-len(a)) + a
+	}
+	return a
+}
+
+func minUint64(a, b mongo.Uint64) mongo.Uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,69 @@
+package governor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/mongo"
+)
+
+func TestToAvailableNotionalPoints(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	docs := []*governorStatusHistoryDoc{
+		{Bucket: base, Guardians: map[string]mongo.Uint64{"a": 100, "b": 90}, MinAvailableNotional: 80},
+		{Bucket: base.Add(15 * time.Minute), Guardians: map[string]mongo.Uint64{"a": 70, "b": 75}, MinAvailableNotional: 60},
+		{Bucket: base.Add(time.Hour), MinAvailableNotional: 50},
+	}
+
+	points := toAvailableNotionalPoints(docs, time.Hour)
+
+	assert.Len(t, points, 2)
+	assert.Equal(t, base, points[0].Time)
+	assert.Equal(t, mongo.Uint64(70), points[0].AvailableNotional)
+	assert.Equal(t, mongo.Uint64(60), points[0].MinNotional)
+	assert.Equal(t, mongo.Uint64(50), points[1].AvailableNotional)
+}
+
+func TestEstimateDelay(t *testing.T) {
+	now := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	history := []AvailableNotionalPoint{
+		{Time: now.Add(-20 * time.Hour), AvailableNotional: 1000},
+		{Time: now.Add(-18 * time.Hour), AvailableNotional: 700},
+		{Time: now.Add(-10 * time.Hour), AvailableNotional: 200},
+		{Time: now.Add(-1 * time.Hour), AvailableNotional: 100},
+	}
+
+	testCases := []struct {
+		name     string
+		notional float64
+		reason   SimulationReason
+		delay    time.Duration
+	}{
+		{"fits in available notional", 100, SimulationReasonNone, 0},
+		{"big transaction", 6000, SimulationReasonBigTransaction, governorWindow},
+		{"released by first transfer", 400, SimulationReasonInsufficientNotional, 6 * time.Hour},
+		{"released by second transfer", 800, SimulationReasonInsufficientNotional, 14 * time.Hour},
+		{"never released by history", 2000, SimulationReasonInsufficientNotional, governorWindow},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reason, delay := estimateDelay(tc.notional, 100, 5000, history, now)
+			assert.Equal(t, tc.reason, reason)
+			assert.Equal(t, tc.delay, delay)
+		})
+	}
+}
+
+func TestFindToken(t *testing.T) {
+	tokens := []*TokenList{
+		{OriginChainID: 2, OriginAddress: "000000000000000000000000c02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", Price: 2000},
+	}
+
+	token, ok := findToken(tokens, 2, "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	assert.True(t, ok)
+	assert.Equal(t, float32(2000), token.Price)
+
+	_, ok = findToken(tokens, 4, "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	assert.False(t, ok)
+}
@@ -38,7 +38,7 @@ func NewService(repo *repository.GuardianSetRepository, p2pNetwork string, cache
 
 func (s *Service) GetGuardianSet(ctx context.Context) (*GuardianSet, error) {
 	return cacheable.GetOrLoad(ctx, s.logger, s.cache, 1*time.Minute, currentGuardianSetKey, s.metrics,
-		func() (*GuardianSet, error) {
+		func(ctx context.Context) (*GuardianSet, error) {
 			return s.getGuardianSet(ctx)
 		})
 }
@@ -47,3 +47,53 @@ type FindAllParams struct {
 	Pagination *pagination.Pagination
 	TxHash     *types.TxHash
 }
+
+// SigningTimeline is the signing timeline of a VAA by the guardians of its guardian set.
+type SigningTimeline struct {
+	VaaID              string              `json:"vaaId"`
+	GuardianSetIndex   uint32              `json:"guardianSetIndex"`
+	Quorum             int                 `json:"quorum"`
+	Signatures         int                 `json:"signatures"`
+	FirstObservationAt *time.Time          `json:"firstObservationAt"`
+	QuorumReachedAt    *time.Time          `json:"quorumReachedAt"`
+	QuorumLatencyMs    *int64              `json:"quorumLatencyMs"`
+	Guardians          []GuardianSignature `json:"guardians"`
+}
+
+// GuardianSignature is the signature of a guardian in a SigningTimeline.
+type GuardianSignature struct {
+	Index    int        `json:"index"`
+	Address  string     `json:"address"`
+	Signed   bool       `json:"signed"`
+	SignedAt *time.Time `json:"signedAt,omitempty"`
+	// LatencyMs is the time elapsed since the first observation of the VAA.
+	LatencyMs *int64 `json:"latencyMs,omitempty"`
+}
+
+// GuardianPerformance is the signing performance of a guardian over a time window.
+type GuardianPerformance struct {
+	Address         string  `json:"address"`
+	Signatures      int64   `json:"signatures"`
+	SigningRate     float64 `json:"signingRate"`
+	MedianLatencyMs int64   `json:"medianLatencyMs"`
+}
+
+// GuardiansPerformance is the signing performance of the current guardian set over a time window.
+type GuardiansPerformance struct {
+	From      time.Time             `json:"from"`
+	To        time.Time             `json:"to"`
+	TotalVaas int64                 `json:"totalVaas"`
+	Guardians []GuardianPerformance `json:"guardians"`
+}
+
+// vaaGuardianSetDoc contains the guardian set index of a VAA document.
+type vaaGuardianSetDoc struct {
+	GuardianSetIndex uint32 `bson:"guardianSetIndex"`
+}
+
+// guardianLatenciesDoc contains the signing latencies of a guardian.
+type guardianLatenciesDoc struct {
+	GuardianAddr  string  `bson:"_id"`
+	Signatures    int64   `bson:"signatures"`
+	MedianLatency float64 `bson:"medianLatency"`
+}
@@ -4,6 +4,7 @@ package observations
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	errs "github.com/wormhole-foundation/wormhole-explorer/api/internal/errors"
@@ -22,14 +23,21 @@ type Repository struct {
 	logger      *zap.Logger
 	collections struct {
 		observations *mongo.Collection
+		vaas         *mongo.Collection
 	}
 }
 
 // NewRepository create a new Repository.
 func NewRepository(db *mongo.Database, logger *zap.Logger) *Repository {
 	return &Repository{db: db,
-		logger:      logger.With(zap.String("module", "ObservationsRepository")),
-		collections: struct{ observations *mongo.Collection }{observations: db.Collection("observations")},
+		logger: logger.With(zap.String("module", "ObservationsRepository")),
+		collections: struct {
+			observations *mongo.Collection
+			vaas         *mongo.Collection
+		}{
+			observations: db.Collection("observations"),
+			vaas:         db.Collection("vaas"),
+		},
 	}
 }
 
@@ -166,3 +174,115 @@ func (q *ObservationQuery) toBSON() *bson.D {
 
 	return &r
 }
+
+// FindAllByVAA get all the observations of a VAA sorted by indexedAt in ascending order.
+func (r *Repository) FindAllByVAA(ctx context.Context, chainID vaa.ChainID, emitter, seq string) ([]*ObservationDoc, error) {
+	q := Query().SetChain(chainID).SetEmitter(emitter).SetSequence(seq)
+	cur, err := r.collections.observations.Find(ctx, q.toBSON(), options.Find().SetSort(bson.D{{Key: "indexedAt", Value: 1}}))
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed execute Find command to get observations by vaa",
+			zap.Error(err), zap.Any("q", q), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	var obs []*ObservationDoc
+	if err := cur.All(ctx, &obs); err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed decoding cursor to []*ObservationDoc", zap.Error(err), zap.Any("q", q),
+			zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	return obs, nil
+}
+
+// FindGuardianSetIndex get the guardian set index of a VAA.
+func (r *Repository) FindGuardianSetIndex(ctx context.Context, id string) (uint32, error) {
+	var doc vaaGuardianSetDoc
+	opts := options.FindOne().SetProjection(bson.D{{Key: "guardianSetIndex", Value: 1}})
+	err := r.collections.vaas.FindOne(ctx, bson.D{{Key: "_id", Value: id}}, opts).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return 0, errs.ErrNotFound
+		}
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed execute FindOne command to get vaa guardian set index",
+			zap.Error(err), zap.String("id", id), zap.String("requestID", requestID))
+		return 0, errors.WithStack(err)
+	}
+	return doc.GuardianSetIndex, nil
+}
+
+// vaaGroupStages groups the observations indexed in [from, to) by VAA, with the time of its first observation.
+func vaaGroupStages(from, to time.Time) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "indexedAt", Value: bson.D{{Key: "$gte", Value: from}, {Key: "$lt", Value: to}}}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{
+				{Key: "emitterChain", Value: "$emitterChain"},
+				{Key: "emitterAddr", Value: "$emitterAddr"},
+				{Key: "sequence", Value: "$sequence"},
+			}},
+			{Key: "firstObservationAt", Value: bson.D{{Key: "$min", Value: "$indexedAt"}}},
+			{Key: "observations", Value: bson.D{{Key: "$push", Value: bson.D{
+				{Key: "guardianAddr", Value: "$guardianAddr"},
+				{Key: "indexedAt", Value: "$indexedAt"},
+			}}}},
+		}}},
+	}
+}
+
+// CountVAAs count the VAAs with observations indexed in [from, to).
+func (r *Repository) CountVAAs(ctx context.Context, from, to time.Time) (int64, error) {
+	pipeline := append(vaaGroupStages(from, to), bson.D{{Key: "$count", Value: "total"}})
+	cur, err := r.collections.observations.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		r.logger.Error("failed execute aggregation to count vaas with observations", zap.Error(err))
+		return 0, errors.WithStack(err)
+	}
+	var result []struct {
+		Total int64 `bson:"total"`
+	}
+	if err := cur.All(ctx, &result); err != nil {
+		r.logger.Error("failed decoding cursor to count vaas with observations", zap.Error(err))
+		return 0, errors.WithStack(err)
+	}
+	if len(result) == 0 {
+		return 0, nil
+	}
+	return result[0].Total, nil
+}
+
+// FindGuardianLatencies get, for each guardian, the number of VAAs signed in [from, to) and the median latency
+// in milliseconds of the signatures since the first observation of the VAA.
+//
+// The median is computed by the server with the approximate $median accumulator (MongoDB 7.0 or later),
+// so the latencies of long time windows are not collected in a single document.
+func (r *Repository) FindGuardianLatencies(ctx context.Context, from, to time.Time) ([]*guardianLatenciesDoc, error) {
+	pipeline := append(vaaGroupStages(from, to),
+		bson.D{{Key: "$unwind", Value: "$observations"}},
+		// a guardian may sign more than one observation for a VAA, keep the first one.
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "vaa", Value: "$_id"}, {Key: "guardianAddr", Value: "$observations.guardianAddr"}}},
+			{Key: "latency", Value: bson.D{{Key: "$min", Value: bson.D{{Key: "$subtract", Value: bson.A{"$observations.indexedAt", "$firstObservationAt"}}}}}},
+		}}},
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$_id.guardianAddr"},
+			{Key: "signatures", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "medianLatency", Value: bson.D{{Key: "$median", Value: bson.D{
+				{Key: "input", Value: "$latency"},
+				{Key: "method", Value: "approximate"},
+			}}}},
+		}}},
+	)
+	cur, err := r.collections.observations.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		r.logger.Error("failed execute aggregation to get guardian latencies", zap.Error(err))
+		return nil, errors.WithStack(err)
+	}
+	var docs []*guardianLatenciesDoc
+	if err := cur.All(ctx, &docs); err != nil {
+		r.logger.Error("failed decoding cursor to []*guardianLatenciesDoc", zap.Error(err))
+		return nil, errors.WithStack(err)
+	}
+	return docs, nil
+}
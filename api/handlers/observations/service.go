@@ -3,8 +3,17 @@ package observations
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/pkg/errors"
+	"github.com/wormhole-foundation/wormhole-explorer/api/cacheable"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/guardian"
+	errs "github.com/wormhole-foundation/wormhole-explorer/api/internal/errors"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/pagination"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
 	"github.com/wormhole-foundation/wormhole-explorer/common/types"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
@@ -12,13 +21,24 @@ import (
 
 // Service definition.
 type Service struct {
-	repo   *Repository
-	logger *zap.Logger
+	repo            *Repository
+	guardianService *guardian.Service
+	cache           cache.Cache
+	metrics         metrics.Metrics
+	logger          *zap.Logger
 }
 
+const guardiansPerformanceKey = "wormscan:guardians-performance"
+
 // NewService create a new Service.
-func NewService(dao *Repository, logger *zap.Logger) *Service {
-	return &Service{repo: dao, logger: logger.With(zap.String("module", "ObservationsService"))}
+func NewService(dao *Repository, guardianService *guardian.Service, cache cache.Cache, metrics metrics.Metrics, logger *zap.Logger) *Service {
+	return &Service{
+		repo:            dao,
+		guardianService: guardianService,
+		cache:           cache,
+		metrics:         metrics,
+		logger:          logger.With(zap.String("module", "ObservationsService")),
+	}
 }
 
 // FindAll get all the observations.
@@ -85,3 +105,106 @@ func (s *Service) FindOne(
 
 	return s.repo.FindOne(ctx, query)
 }
+
+// FindSigningTimeline get the signing timeline of a VAA by the guardians of its guardian set.
+// If the VAA is not signed yet, the current guardian set is used.
+func (s *Service) FindSigningTimeline(ctx context.Context, chain vaa.ChainID, emitter *types.Address, seq string) (*SigningTimeline, error) {
+
+	obs, err := s.repo.FindAllByVAA(ctx, chain, emitter.Hex(), seq)
+	if err != nil {
+		return nil, err
+	}
+	if len(obs) == 0 {
+		return nil, errs.ErrNotFound
+	}
+
+	gs, err := s.guardianService.GetGuardianSet(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(gs.GstByIndex) == 0 {
+		return nil, errors.New("guardian set not found")
+	}
+
+	vaaID := fmt.Sprintf("%d/%s/%s", chain, emitter.Hex(), seq)
+	set := gs.GstByIndex[len(gs.GstByIndex)-1]
+	gsIndex, err := s.repo.FindGuardianSetIndex(ctx, vaaID)
+	switch {
+	case err == nil:
+		if gst, ok := findGuardianSet(gs, gsIndex); ok {
+			set = gst
+		}
+	case errors.Is(err, errs.ErrNotFound):
+	default:
+		return nil, err
+	}
+
+	return computeSigningTimeline(vaaID, set.Index, set.Keys, obs), nil
+}
+
+// GetGuardiansPerformance get the signing rate and the median signing latency of the guardians
+// of the current guardian set for the given time span.
+func (s *Service) GetGuardiansPerformance(ctx context.Context, timeSpan string) (*GuardiansPerformance, error) {
+	window, err := timeSpanDuration(timeSpan)
+	if err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("%s:%s", guardiansPerformanceKey, timeSpan)
+	return cacheable.GetOrLoad(ctx, s.logger, s.cache, 5*time.Minute, key, s.metrics,
+		func(ctx context.Context) (*GuardiansPerformance, error) {
+			return s.getGuardiansPerformance(ctx, window)
+		})
+}
+
+func (s *Service) getGuardiansPerformance(ctx context.Context, window time.Duration) (*GuardiansPerformance, error) {
+	gs, err := s.guardianService.GetGuardianSet(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(gs.GstByIndex) == 0 {
+		return nil, errors.New("guardian set not found")
+	}
+	set := gs.GstByIndex[len(gs.GstByIndex)-1]
+
+	to := time.Now().UTC()
+	from := to.Add(-window)
+	totalVaas, err := s.repo.CountVAAs(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	docs, err := s.repo.FindGuardianLatencies(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GuardiansPerformance{
+		From:      from,
+		To:        to,
+		TotalVaas: totalVaas,
+		Guardians: computeGuardiansPerformance(set.Keys, totalVaas, docs),
+	}, nil
+}
+
+// findGuardianSet find a guardian set by index.
+func findGuardianSet(gs *guardian.GuardianSet, index uint32) (common.GuardianSet, bool) {
+	for _, gst := range gs.GstByIndex {
+		if gst.Index == index {
+			return gst, true
+		}
+	}
+	return common.GuardianSet{}, false
+}
+
+// timeSpanDuration convert a time span (1d, 1w or 1mo) to a duration.
+func timeSpanDuration(timeSpan string) (time.Duration, error) {
+	switch timeSpan {
+	case "1d":
+		return 24 * time.Hour, nil
+	case "1w":
+		return 7 * 24 * time.Hour, nil
+	case "1mo":
+		return 30 * 24 * time.Hour, nil
+	default:
+		return 0, errs.ErrMalformedQuery
+	}
+}
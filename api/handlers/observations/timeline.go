@@ -0,0 +1,98 @@
+package observations
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	eth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// computeSigningTimeline build the signing timeline of a VAA from its observations.
+// Only the first observation of each guardian of the guardian set is taken into account.
+func computeSigningTimeline(vaaID string, gsIndex uint32, keys []eth_common.Address, obs []*ObservationDoc) *SigningTimeline {
+
+	// get the time of the first observation of each guardian.
+	signedAt := make(map[string]time.Time, len(keys))
+	for _, o := range obs {
+		if o.IndexedAt == nil {
+			continue
+		}
+		addr := strings.ToLower(o.GuardianAddr)
+		if t, ok := signedAt[addr]; !ok || o.IndexedAt.Before(t) {
+			signedAt[addr] = *o.IndexedAt
+		}
+	}
+
+	timeline := &SigningTimeline{
+		VaaID:            vaaID,
+		GuardianSetIndex: gsIndex,
+		Quorum:           vaa.CalculateQuorum(len(keys)),
+		Guardians:        make([]GuardianSignature, 0, len(keys)),
+	}
+
+	var signatures []time.Time
+	for i, key := range keys {
+		t, ok := signedAt[strings.ToLower(key.Hex())]
+		if ok {
+			signatures = append(signatures, t)
+		}
+		timeline.Guardians = append(timeline.Guardians, GuardianSignature{
+			Index:   i,
+			Address: key.Hex(),
+			Signed:  ok,
+		})
+	}
+	if len(signatures) == 0 {
+		return timeline
+	}
+
+	sort.Slice(signatures, func(i, j int) bool { return signatures[i].Before(signatures[j]) })
+	first := signatures[0]
+	timeline.Signatures = len(signatures)
+	timeline.FirstObservationAt = &first
+	if len(signatures) >= timeline.Quorum {
+		quorumAt := signatures[timeline.Quorum-1]
+		latency := quorumAt.Sub(first).Milliseconds()
+		timeline.QuorumReachedAt = &quorumAt
+		timeline.QuorumLatencyMs = &latency
+	}
+
+	for i := range timeline.Guardians {
+		g := &timeline.Guardians[i]
+		if !g.Signed {
+			continue
+		}
+		t := signedAt[strings.ToLower(g.Address)]
+		latency := t.Sub(first).Milliseconds()
+		g.SignedAt = &t
+		g.LatencyMs = &latency
+	}
+	return timeline
+}
+
+// computeGuardiansPerformance build the signing performance of the guardians of a guardian set.
+// Guardians without signatures in the time window are included with a signing rate of zero.
+func computeGuardiansPerformance(keys []eth_common.Address, totalVaas int64, docs []*guardianLatenciesDoc) []GuardianPerformance {
+
+	byAddr := make(map[string]*guardianLatenciesDoc, len(docs))
+	for _, d := range docs {
+		byAddr[strings.ToLower(d.GuardianAddr)] = d
+	}
+
+	result := make([]GuardianPerformance, 0, len(keys))
+	for _, key := range keys {
+		p := GuardianPerformance{Address: key.Hex()}
+		if d, ok := byAddr[strings.ToLower(key.Hex())]; ok {
+			p.Signatures = d.Signatures
+			p.MedianLatencyMs = int64(math.Round(d.MedianLatency))
+			if totalVaas > 0 {
+				p.SigningRate = float64(d.Signatures) / float64(totalVaas)
+			}
+		}
+		result = append(result, p)
+	}
+	return result
+}
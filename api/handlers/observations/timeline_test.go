@@ -0,0 +1,90 @@
+package observations
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	eth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func testKeys() []eth_common.Address {
+	return []eth_common.Address{
+		eth_common.HexToAddress("0x58CC3AE5C097b213cE3c81979e1B9f9570746AA5"),
+		eth_common.HexToAddress("0xfF6CB952589BDE862c25Ef4392132fb9D4A42157"),
+		eth_common.HexToAddress("0x114De8460193bdf3A2fCf81f86a09765F4762fD1"),
+		eth_common.HexToAddress("0x107A0086b32d7A0977926A205131d8731D39cbEB"),
+	}
+}
+
+func observation(addr eth_common.Address, t time.Time) *ObservationDoc {
+	return &ObservationDoc{GuardianAddr: addr.Hex(), IndexedAt: &t}
+}
+
+func TestComputeSigningTimeline(t *testing.T) {
+	keys := testKeys()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	obs := []*ObservationDoc{
+		observation(keys[1], base),
+		// duplicated observation of the same guardian, the first one is kept.
+		observation(keys[1], base.Add(5*time.Second)),
+		// guardian address in lowercase.
+		{GuardianAddr: strings.ToLower(keys[0].Hex()), IndexedAt: timePtr(base.Add(2 * time.Second))},
+		observation(keys[3], base.Add(3*time.Second)),
+		// observation of a guardian not in the guardian set.
+		observation(eth_common.HexToAddress("0x01"), base.Add(time.Second)),
+	}
+
+	timeline := computeSigningTimeline("2/abc/1", 3, keys, obs)
+
+	assert.Equal(t, 3, timeline.Quorum)
+	assert.Equal(t, 3, timeline.Signatures)
+	assert.Equal(t, base, *timeline.FirstObservationAt)
+	assert.Equal(t, base.Add(3*time.Second), *timeline.QuorumReachedAt)
+	assert.Equal(t, int64(3000), *timeline.QuorumLatencyMs)
+	assert.Len(t, timeline.Guardians, 4)
+
+	assert.True(t, timeline.Guardians[0].Signed)
+	assert.Equal(t, int64(2000), *timeline.Guardians[0].LatencyMs)
+	assert.True(t, timeline.Guardians[1].Signed)
+	assert.Equal(t, int64(0), *timeline.Guardians[1].LatencyMs)
+	assert.False(t, timeline.Guardians[2].Signed)
+	assert.Nil(t, timeline.Guardians[2].SignedAt)
+	assert.True(t, timeline.Guardians[3].Signed)
+}
+
+func TestComputeSigningTimelineWithoutQuorum(t *testing.T) {
+	keys := testKeys()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	timeline := computeSigningTimeline("2/abc/1", 3, keys, []*ObservationDoc{observation(keys[2], base)})
+
+	assert.Equal(t, 1, timeline.Signatures)
+	assert.Equal(t, base, *timeline.FirstObservationAt)
+	assert.Nil(t, timeline.QuorumReachedAt)
+	assert.Nil(t, timeline.QuorumLatencyMs)
+}
+
+func TestComputeGuardiansPerformance(t *testing.T) {
+	keys := testKeys()
+	docs := []*guardianLatenciesDoc{
+		{GuardianAddr: keys[0].Hex(), Signatures: 4, MedianLatency: 249.6},
+		{GuardianAddr: strings.ToLower(keys[1].Hex()), Signatures: 3, MedianLatency: 20},
+	}
+
+	result := computeGuardiansPerformance(keys, 4, docs)
+
+	assert.Len(t, result, 4)
+	assert.Equal(t, 1.0, result[0].SigningRate)
+	assert.Equal(t, int64(250), result[0].MedianLatencyMs)
+	assert.Equal(t, 0.75, result[1].SigningRate)
+	assert.Equal(t, int64(20), result[1].MedianLatencyMs)
+	assert.Equal(t, int64(0), result[2].Signatures)
+	assert.Equal(t, 0.0, result[2].SigningRate)
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -86,3 +86,11 @@ type DestinationTx struct {
 	Timestamp   *time.Time  `bson:"timestamp" json:"timestamp"`
 	UpdatedAt   *time.Time  `bson:"updatedAt" json:"updatedAt"`
 }
+
+// CompletionTimeDoc contains the timestamps of a completed operation.
+type CompletionTimeDoc struct {
+	ID                   string     `bson:"_id"`
+	VaaTimestamp         *time.Time `bson:"vaaTimestamp"`
+	OriginTimestamp      *time.Time `bson:"originTimestamp"`
+	DestinationTimestamp *time.Time `bson:"destinationTimestamp"`
+}
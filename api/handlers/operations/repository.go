@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"strings"
+	"time"
 
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/errors"
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/pagination"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	"github.com/wormhole-foundation/wormhole-explorer/common/utils"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -371,3 +373,44 @@ func (r *Repository) FindAll(ctx context.Context, query OperationQuery) ([]*Oper
 
 	return operations, nil
 }
+
+// FindCompletionTimes returns the timestamps of the last completed operations between two chains.
+func (r *Repository) FindCompletionTimes(ctx context.Context, fromChain, toChain vaa.ChainID, since time.Time, limit int64) ([]*CompletionTimeDoc, error) {
+
+	pipeline := mongo.Pipeline{
+		// filter the last vaas of the chain pair
+		{{Key: "$match", Value: bson.D{
+			{Key: "standardizedProperties.fromChain", Value: fromChain},
+			{Key: "standardizedProperties.toChain", Value: toChain},
+			{Key: "timestamp", Value: bson.D{{Key: "$gte", Value: since}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "timestamp", Value: -1}}}},
+		{{Key: "$limit", Value: limit}},
+
+		// lookup globalTransactions
+		{{Key: "$lookup", Value: bson.D{{Key: "from", Value: "globalTransactions"}, {Key: "localField", Value: "_id"}, {Key: "foreignField", Value: "_id"}, {Key: "as", Value: "globalTransactions"}}}},
+
+		// project the timestamps of the completed operations
+		{{Key: "$project", Value: bson.D{
+			{Key: "vaaTimestamp", Value: "$timestamp"},
+			{Key: "originTimestamp", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$globalTransactions.originTx.timestamp", 0}}}},
+			{Key: "destinationTimestamp", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$globalTransactions.destinationTx.timestamp", 0}}}},
+			{Key: "destinationStatus", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$globalTransactions.destinationTx.status", 0}}}},
+		}}},
+		{{Key: "$match", Value: bson.D{{Key: "destinationStatus", Value: domain.DstTxStatusConfirmed}}}},
+	}
+
+	cur, err := r.collections.parsedVaa.Aggregate(ctx, pipeline)
+	if err != nil {
+		r.logger.Error("failed execute aggregation pipeline to get completion times",
+			zap.Error(err), zap.Uint16("fromChain", uint16(fromChain)), zap.Uint16("toChain", uint16(toChain)))
+		return nil, err
+	}
+
+	var docs []*CompletionTimeDoc
+	if err := cur.All(ctx, &docs); err != nil {
+		r.logger.Error("failed to decode cursor", zap.Error(err))
+		return nil, err
+	}
+	return docs, nil
+}
@@ -3,21 +3,47 @@ package operations
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/pkg/errors"
+	"github.com/wormhole-foundation/wormhole-explorer/api/cacheable"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/governor"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/relays"
+	errs "github.com/wormhole-foundation/wormhole-explorer/api/internal/errors"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/pagination"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
 	"github.com/wormhole-foundation/wormhole-explorer/common/types"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
 )
 
+const (
+	completionTimesKey        = "wormscan:operations:completion-times"
+	completionTimesExpiration = 1 * time.Hour
+	completionTimesWindow     = 7 * 24 * time.Hour
+	completionTimesLimit      = 1000
+)
+
 type Service struct {
-	repo   *Repository
-	logger *zap.Logger
+	repo         *Repository
+	governorRepo *governor.Repository
+	relaysRepo   *relays.Repository
+	cache        cache.Cache
+	metrics      metrics.Metrics
+	logger       *zap.Logger
 }
 
 // NewService create a new Service.
-func NewService(repo *Repository, logger *zap.Logger) *Service {
-	return &Service{repo: repo, logger: logger.With(zap.String("module", "OperationService"))}
+func NewService(repo *Repository, governorRepo *governor.Repository, relaysRepo *relays.Repository, cache cache.Cache, metrics metrics.Metrics, logger *zap.Logger) *Service {
+	return &Service{
+		repo:         repo,
+		governorRepo: governorRepo,
+		relaysRepo:   relaysRepo,
+		cache:        cache,
+		metrics:      metrics,
+		logger:       logger.With(zap.String("module", "OperationService")),
+	}
 }
 
 // FindById returns the operations for the given chainID/emitter/seq.
@@ -68,3 +94,77 @@ func (s *Service) FindAll(ctx context.Context, filter OperationFilter) ([]*Opera
 	}
 	return operations, nil
 }
+
+// FindStatusById returns the lifecycle status and the estimated completion of the operation for the given chainID/emitter/seq.
+func (s *Service) FindStatusById(ctx context.Context, chainID vaa.ChainID,
+	emitter *types.Address, seq string) (*OperationStatus, error) {
+
+	id := fmt.Sprintf("%d/%s/%s", chainID, emitter.Hex(), seq)
+	operation, err := s.repo.FindById(ctx, id)
+	if err != nil && !errors.Is(err, errs.ErrNotFound) {
+		return nil, err
+	}
+
+	in := lifecycleInput{operation: operation}
+
+	// while the vaa is not signed, check if it is enqueued by the governor.
+	if operation == nil || operation.Vaa == nil {
+		in.isEnqueued, err = s.governorRepo.IsVaaEnqueued(ctx, chainID, emitter, seq)
+		if err != nil {
+			return nil, err
+		}
+		if operation == nil && !in.isEnqueued {
+			return nil, errs.ErrNotFound
+		}
+	}
+	if operation == nil {
+		operation = &OperationDto{ID: id}
+		in.operation = operation
+	}
+
+	governorVaa, err := s.governorRepo.FindGovernorVaaByID(ctx, id)
+	if err != nil && !errors.Is(err, errs.ErrNotFound) {
+		return nil, err
+	}
+	if governorVaa != nil {
+		in.releaseTime = &governorVaa.ReleaseTime
+	}
+
+	// the relay only exists for operations delivered by a relayer.
+	if operation.Vaa != nil {
+		query := relays.Query().SetChain(chainID).SetEmitter(emitter.Hex()).SetSequence(seq)
+		in.relay, err = s.relaysRepo.FindOne(ctx, query)
+		if err != nil && !errors.Is(err, errs.ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	status, steps := computeLifecycle(in)
+	result := &OperationStatus{ID: id, Status: status, Steps: steps}
+
+	// estimate the completion with the completion times of the chain pair.
+	props := operation.StandardizedProperties
+	if status != LifecycleRedeemed && status != LifecycleFailed && props != nil && props.FromChain != 0 && props.ToChain != 0 {
+		times, err := s.getCompletionTimes(ctx, props.FromChain, props.ToChain)
+		if err != nil {
+			s.logger.Warn("failed to get completion times", zap.Error(err), zap.String("id", id))
+		} else {
+			result.Eta = computeEta(status, in, times, time.Now())
+		}
+	}
+
+	return result, nil
+}
+
+func (s *Service) getCompletionTimes(ctx context.Context, fromChain, toChain vaa.ChainID) (completionTimes, error) {
+	key := fmt.Sprintf("%s:%d:%d", completionTimesKey, fromChain, toChain)
+	docs, err := cacheable.GetOrLoad(ctx, s.logger, s.cache, completionTimesExpiration, key, s.metrics,
+		func(ctx context.Context) ([]*CompletionTimeDoc, error) {
+			since := time.Now().Add(-completionTimesWindow)
+			return s.repo.FindCompletionTimes(ctx, fromChain, toChain, since, completionTimesLimit)
+		})
+	if err != nil {
+		return completionTimes{}, err
+	}
+	return newCompletionTimes(docs), nil
+}
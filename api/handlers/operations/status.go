@@ -0,0 +1,212 @@
+package operations
+
+import (
+	"sort"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/relays"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+)
+
+// LifecycleStatus is a stage of the lifecycle of an operation.
+type LifecycleStatus string
+
+const (
+	LifecycleSourceConfirmed   LifecycleStatus = "source_confirmed"
+	LifecycleAwaitingGuardians LifecycleStatus = "awaiting_guardians"
+	LifecycleGovernorEnqueued  LifecycleStatus = "governor_enqueued"
+	LifecycleVaaSigned         LifecycleStatus = "vaa_signed"
+	LifecycleRelayed           LifecycleStatus = "relayed"
+	LifecycleRedeemed          LifecycleStatus = "redeemed"
+	LifecycleFailed            LifecycleStatus = "failed"
+)
+
+// relay statuses reported by the relayer.
+const (
+	relayStatusCompleted = "completed"
+	relayStatusFailed    = "failed"
+)
+
+// OperationStatus is the computed lifecycle of an operation.
+type OperationStatus struct {
+	ID     string          `json:"id"`
+	Status LifecycleStatus `json:"status"`
+	Steps  []LifecycleStep `json:"steps"`
+	Eta    *Eta            `json:"eta,omitempty"`
+}
+
+// LifecycleStep is a milestone of the lifecycle of an operation.
+type LifecycleStep struct {
+	Status    LifecycleStatus `json:"status"`
+	Completed bool            `json:"completed"`
+	Timestamp *time.Time      `json:"timestamp,omitempty"`
+}
+
+// Eta is the estimated completion of an operation based on the historical completion times of its chain pair.
+type Eta struct {
+	EstimatedCompletionAt time.Time `json:"estimatedCompletionAt"`
+	RemainingSeconds      int64     `json:"remainingSeconds"`
+	SampleSize            int       `json:"sampleSize"`
+}
+
+// lifecycleInput contains the data used to compute the lifecycle of an operation.
+type lifecycleInput struct {
+	operation   *OperationDto
+	isEnqueued  bool
+	releaseTime *time.Time
+	relay       *relays.RelayDoc
+}
+
+// computeLifecycle returns the current status and the milestones of an operation.
+//
+// The status is the last milestone reached. While the VAA is not signed, the operation is either
+// awaiting guardians or enqueued by the governor.
+func computeLifecycle(in lifecycleInput) (LifecycleStatus, []LifecycleStep) {
+	op := in.operation
+	var steps []LifecycleStep
+
+	// source transaction
+	sourceStep := LifecycleStep{Status: LifecycleSourceConfirmed}
+	if op.SourceTx != nil && op.SourceTx.Status == string(domain.SourceTxStatusConfirmed) {
+		sourceStep.Completed = true
+		sourceStep.Timestamp = op.SourceTx.Timestamp
+	}
+	steps = append(steps, sourceStep)
+
+	// governor, only when the vaa was or is enqueued.
+	if in.isEnqueued || in.releaseTime != nil {
+		steps = append(steps, LifecycleStep{
+			Status:    LifecycleGovernorEnqueued,
+			Completed: op.Vaa != nil,
+			Timestamp: in.releaseTime,
+		})
+	}
+
+	// signed vaa
+	vaaStep := LifecycleStep{Status: LifecycleVaaSigned}
+	if op.Vaa != nil {
+		vaaStep.Completed = true
+		vaaStep.Timestamp = op.Vaa.Timestamp
+	}
+	steps = append(steps, vaaStep)
+
+	// relay, only for relayed operations.
+	relayFailed := false
+	if in.relay != nil {
+		relayStep := LifecycleStep{Status: LifecycleRelayed}
+		switch in.relay.Data.Status {
+		case relayStatusCompleted:
+			relayStep.Completed = true
+			relayStep.Timestamp = in.relay.Data.CompletedAt
+		case relayStatusFailed:
+			relayFailed = true
+		}
+		steps = append(steps, relayStep)
+	}
+
+	// redeem
+	redeemStep := LifecycleStep{Status: LifecycleRedeemed}
+	redeemFailed := false
+	if op.DestinationTx != nil {
+		switch op.DestinationTx.Status {
+		case domain.DstTxStatusConfirmed:
+			redeemStep.Completed = true
+			redeemStep.Timestamp = op.DestinationTx.Timestamp
+		case domain.DstTxStatusFailedToProcess:
+			redeemFailed = true
+		}
+	}
+	steps = append(steps, redeemStep)
+
+	switch {
+	case redeemStep.Completed:
+		return LifecycleRedeemed, steps
+	case redeemFailed || relayFailed:
+		return LifecycleFailed, steps
+	case in.relay != nil && in.relay.Data.Status == relayStatusCompleted:
+		return LifecycleRelayed, steps
+	case op.Vaa != nil:
+		return LifecycleVaaSigned, steps
+	case in.isEnqueued:
+		return LifecycleGovernorEnqueued, steps
+	default:
+		return LifecycleAwaitingGuardians, steps
+	}
+}
+
+// completionTimes are the historical completion times of a chain pair.
+type completionTimes struct {
+	// sourceToRedeem are the durations from the source transaction to the redeem.
+	sourceToRedeem []time.Duration
+	// vaaToRedeem are the durations from the signed vaa to the redeem.
+	vaaToRedeem []time.Duration
+}
+
+func newCompletionTimes(docs []*CompletionTimeDoc) completionTimes {
+	var c completionTimes
+	for _, doc := range docs {
+		if doc.DestinationTimestamp == nil {
+			continue
+		}
+		if doc.OriginTimestamp != nil {
+			if d := doc.DestinationTimestamp.Sub(*doc.OriginTimestamp); d >= 0 {
+				c.sourceToRedeem = append(c.sourceToRedeem, d)
+			}
+		}
+		if doc.VaaTimestamp != nil {
+			if d := doc.DestinationTimestamp.Sub(*doc.VaaTimestamp); d >= 0 {
+				c.vaaToRedeem = append(c.vaaToRedeem, d)
+			}
+		}
+	}
+	sort.Slice(c.sourceToRedeem, func(i, j int) bool { return c.sourceToRedeem[i] < c.sourceToRedeem[j] })
+	sort.Slice(c.vaaToRedeem, func(i, j int) bool { return c.vaaToRedeem[i] < c.vaaToRedeem[j] })
+	return c
+}
+
+// computeEta estimates the completion of a pending operation with the median completion time of its chain pair.
+//
+// The estimation starts from the release time when the vaa is enqueued by the governor, from the vaa
+// timestamp once it is signed, or from the source transaction otherwise.
+func computeEta(status LifecycleStatus, in lifecycleInput, times completionTimes, now time.Time) *Eta {
+	var start *time.Time
+	var durations []time.Duration
+
+	switch status {
+	case LifecycleRedeemed, LifecycleFailed:
+		return nil
+	case LifecycleVaaSigned, LifecycleRelayed:
+		if in.operation.Vaa != nil {
+			start = in.operation.Vaa.Timestamp
+		}
+		durations = times.vaaToRedeem
+	case LifecycleGovernorEnqueued:
+		start, durations = in.releaseTime, times.vaaToRedeem
+	default:
+		if in.operation.SourceTx != nil {
+			start = in.operation.SourceTx.Timestamp
+		}
+		durations = times.sourceToRedeem
+	}
+	if start == nil || len(durations) == 0 {
+		return nil
+	}
+
+	estimated := start.Add(percentile(durations, 0.5))
+	if estimated.Before(now) {
+		estimated = now
+	}
+	return &Eta{
+		EstimatedCompletionAt: estimated,
+		RemainingSeconds:      int64(estimated.Sub(now).Seconds()),
+		SampleSize:            len(durations),
+	}
+}
+
+// percentile returns the p percentile of sorted durations.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	return sorted[int(p*float64(len(sorted)-1))]
+}
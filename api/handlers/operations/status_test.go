@@ -0,0 +1,114 @@
+package operations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/relays"
+)
+
+func TestComputeLifecycle(t *testing.T) {
+	sourceTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	vaaTime := sourceTime.Add(15 * time.Minute)
+	redeemTime := vaaTime.Add(5 * time.Minute)
+
+	confirmedSource := &OriginTx{Status: "confirmed", Timestamp: &sourceTime}
+	signedVaa := &VaaDto{Timestamp: &vaaTime}
+
+	completedRelay := &relays.RelayDoc{}
+	completedRelay.Data.Status = "completed"
+
+	tests := []struct {
+		name     string
+		in       lifecycleInput
+		expected LifecycleStatus
+		steps    int
+	}{
+		{
+			name:     "awaiting guardians",
+			in:       lifecycleInput{operation: &OperationDto{SourceTx: confirmedSource}},
+			expected: LifecycleAwaitingGuardians,
+			steps:    3,
+		},
+		{
+			name:     "governor enqueued",
+			in:       lifecycleInput{operation: &OperationDto{SourceTx: confirmedSource}, isEnqueued: true},
+			expected: LifecycleGovernorEnqueued,
+			steps:    4,
+		},
+		{
+			name:     "vaa signed",
+			in:       lifecycleInput{operation: &OperationDto{SourceTx: confirmedSource, Vaa: signedVaa}},
+			expected: LifecycleVaaSigned,
+			steps:    3,
+		},
+		{
+			name:     "relayed",
+			in:       lifecycleInput{operation: &OperationDto{SourceTx: confirmedSource, Vaa: signedVaa}, relay: completedRelay},
+			expected: LifecycleRelayed,
+			steps:    4,
+		},
+		{
+			name: "redeemed",
+			in: lifecycleInput{operation: &OperationDto{SourceTx: confirmedSource, Vaa: signedVaa,
+				DestinationTx: &DestinationTx{Status: "completed", Timestamp: &redeemTime}}},
+			expected: LifecycleRedeemed,
+			steps:    3,
+		},
+		{
+			name: "failed",
+			in: lifecycleInput{operation: &OperationDto{SourceTx: confirmedSource, Vaa: signedVaa,
+				DestinationTx: &DestinationTx{Status: "failed"}}},
+			expected: LifecycleFailed,
+			steps:    3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, steps := computeLifecycle(tt.in)
+			assert.Equal(t, tt.expected, status)
+			assert.Len(t, steps, tt.steps)
+			assert.Equal(t, LifecycleSourceConfirmed, steps[0].Status)
+			assert.True(t, steps[0].Completed)
+		})
+	}
+}
+
+func TestComputeEta(t *testing.T) {
+	now := time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)
+	sourceTime := now.Add(-10 * time.Minute)
+	vaaTime := now.Add(-2 * time.Minute)
+
+	docs := make([]*CompletionTimeDoc, 0)
+	for i := 1; i <= 5; i++ {
+		origin := now.Add(-24 * time.Hour)
+		vaa := origin.Add(15 * time.Minute)
+		destination := vaa.Add(time.Duration(i) * time.Minute)
+		docs = append(docs, &CompletionTimeDoc{OriginTimestamp: &origin, VaaTimestamp: &vaa, DestinationTimestamp: &destination})
+	}
+	times := newCompletionTimes(docs)
+
+	// pending signature: median source to redeem is 18 minutes.
+	in := lifecycleInput{operation: &OperationDto{SourceTx: &OriginTx{Status: "confirmed", Timestamp: &sourceTime}}}
+	eta := computeEta(LifecycleAwaitingGuardians, in, times, now)
+	assert.NotNil(t, eta)
+	assert.Equal(t, int64(8*60), eta.RemainingSeconds)
+	assert.Equal(t, 5, eta.SampleSize)
+
+	// signed vaa: median vaa to redeem is 3 minutes.
+	in.operation.Vaa = &VaaDto{Timestamp: &vaaTime}
+	eta = computeEta(LifecycleVaaSigned, in, times, now)
+	assert.NotNil(t, eta)
+	assert.Equal(t, int64(60), eta.RemainingSeconds)
+
+	// overdue operations are expected to complete now.
+	late := now.Add(-time.Hour)
+	in.operation.Vaa = &VaaDto{Timestamp: &late}
+	eta = computeEta(LifecycleVaaSigned, in, times, now)
+	assert.Equal(t, int64(0), eta.RemainingSeconds)
+
+	// completed operations have no eta.
+	assert.Nil(t, computeEta(LifecycleRedeemed, in, times, now))
+}
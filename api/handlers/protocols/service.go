@@ -5,6 +5,7 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/api/cacheable"
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	"go.uber.org/zap"
 	"strconv"
 	"strings"
@@ -20,6 +21,7 @@ type Service struct {
 	repo           *Repository
 	logger         *zap.Logger
 	coreProtocols  []string
+	registry       *domain.ProtocolRegistry
 	cache          cache.Cache
 	cacheKeyPrefix string
 	cacheTTL       int
@@ -46,12 +48,13 @@ type tvlProvider interface {
 	Get(ctx context.Context) (string, error)
 }
 
-func NewService(extProtocols, coreProtocols []string, repo *Repository, logger *zap.Logger, cache cache.Cache, cacheKeyPrefix string, cacheTTL int, metrics metrics.Metrics, tvlProvider tvlProvider) *Service {
+func NewService(extProtocols, coreProtocols []string, registry *domain.ProtocolRegistry, repo *Repository, logger *zap.Logger, cache cache.Cache, cacheKeyPrefix string, cacheTTL int, metrics metrics.Metrics, tvlProvider tvlProvider) *Service {
 	return &Service{
 		Protocols:      extProtocols,
 		repo:           repo,
 		logger:         logger,
 		coreProtocols:  coreProtocols,
+		registry:       registry,
 		cache:          cache,
 		cacheKeyPrefix: cacheKeyPrefix,
 		cacheTTL:       cacheTTL,
@@ -60,6 +63,11 @@ func NewService(extProtocols, coreProtocols []string, repo *Repository, logger *
 	}
 }
 
+// GetProtocols returns the registry of the protocols and the emitters they own.
+func (s *Service) GetProtocols() *domain.ProtocolRegistry {
+	return s.registry
+}
+
 func (s *Service) GetProtocolsTotalValues(ctx context.Context) []ProtocolTotalValuesDTO {
 
 	wg := &sync.WaitGroup{}
@@ -104,7 +112,7 @@ func (s *Service) fetchProtocolValues(ctx context.Context, wg *sync.WaitGroup, p
 		time.Duration(s.cacheTTL)*time.Minute,
 		s.cacheKeyPrefix+":"+strings.ToUpper(protocol),
 		s.metrics,
-		func() (ProtocolStats, error) {
+		func(ctx context.Context) (ProtocolStats, error) {
 			return fetch(ctx, protocol)
 		},
 	)
@@ -2,7 +2,10 @@ package stats
 
 import (
 	"fmt"
+	"strings"
 	"time"
+
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
 
 const queryTemplateSymbolWithAssets = `
@@ -30,3 +33,70 @@ func buildTopCorridors(bucket string, t time.Time, measurement string) string {
 	start := t.Truncate(time.Hour * 24).Format(time.RFC3339Nano)
 	return fmt.Sprintf(queryTemplateTopCorridors, bucket, start, measurement)
 }
+
+const queryTemplateCorridorLatencies = `
+data = from(bucket: "%s")
+    |> range(start: %s)
+    |> filter(fn: (r) => r._measurement == "vaa_latency" and r._field == "%s")%s
+    |> group(columns: ["source_chain", "target_chain"])
+
+p50 = data |> quantile(q: 0.5, method: "estimate_tdigest") |> set(key: "percentile", value: "p50")
+p90 = data |> quantile(q: 0.9, method: "estimate_tdigest") |> set(key: "percentile", value: "p90")
+p99 = data |> quantile(q: 0.99, method: "estimate_tdigest") |> set(key: "percentile", value: "p99")
+total = data |> count() |> toFloat() |> set(key: "percentile", value: "count")
+
+union(tables: [p50, p90, p99, total])
+    |> group()
+`
+
+func buildCorridorLatencies(bucket string, t time.Time, q *CorridorLatencyQuery) string {
+	start := t.Add(-q.TimeSpan.Duration()).Truncate(time.Hour).Format(time.RFC3339Nano)
+
+	var filters string
+	if f := chainsFilter("source_chain", q.SourceChains); f != "" {
+		filters += "\n    |> filter(fn: (r) => " + f + ")"
+	}
+	if f := chainsFilter("target_chain", q.TargetChains); f != "" {
+		filters += "\n    |> filter(fn: (r) => " + f + ")"
+	}
+	if q.AppID != "" {
+		filters += fmt.Sprintf("\n    |> filter(fn: (r) => r.app_id == %q)", q.AppID)
+	}
+	return fmt.Sprintf(queryTemplateCorridorLatencies, bucket, start, q.Stage.field(), filters)
+}
+
+func chainsFilter(tag string, chains []sdk.ChainID) string {
+	conditions := make([]string, 0, len(chains))
+	for _, c := range chains {
+		conditions = append(conditions, fmt.Sprintf("r.%s == \"%d\"", tag, c))
+	}
+	return strings.Join(conditions, " or ")
+}
+
+const queryTemplateFees = `
+from(bucket: "%s")
+    |> range(start: %s)
+    |> filter(fn: (r) => r._measurement == "vaa_fee" and r._field == "fee_usd")%s
+    |> group(columns: ["app_id", "fee_chain", "relayer"])
+    |> aggregateWindow(every: %s, fn: sum, createEmpty: false)
+    |> group()
+    |> sort(columns: ["_time"], desc: true)
+`
+
+func buildFeesQuery(bucket string, t time.Time, q *FeesQuery) string {
+	var start time.Time
+	switch q.TimeSpan {
+	case "1w":
+		start = t.Truncate(24 * time.Hour).Add(-7 * 24 * time.Hour)
+	case "1mo":
+		start = t.Truncate(24 * time.Hour).Add(-30 * 24 * time.Hour)
+	default:
+		start = t.Truncate(time.Hour).Add(-24 * time.Hour)
+	}
+
+	var filters string
+	if q.AppID != "" {
+		filters = fmt.Sprintf("\n    |> filter(fn: (r) => r.app_id == %q)", q.AppID)
+	}
+	return fmt.Sprintf(queryTemplateFees, bucket, start.Format(time.RFC3339Nano), filters, q.SampleRate)
+}
@@ -0,0 +1,122 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestQueries_buildCorridorLatencies(t *testing.T) {
+	//2024-01-04T12:25:48.112233445Z
+	now := time.Date(2024, 1, 4, 12, 25, 48, 112233445, time.UTC)
+
+	var tests = []struct {
+		name    string
+		q       CorridorLatencyQuery
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "default stage without filters",
+			q:    CorridorLatencyQuery{TimeSpan: TimeSpan1DayLatency, Stage: LatencyStageTotal},
+			want: []string{
+				`range(start: 2024-01-03T12:00:00Z)`,
+				`r._field == "total_ms")
+    |> group(columns: ["source_chain", "target_chain"])`,
+			},
+			notWant: []string{"r.source_chain ==", "r.target_chain ==", "r.app_id =="},
+		},
+		{
+			name: "finality stage of 7 days",
+			q:    CorridorLatencyQuery{TimeSpan: TimeSpan7DaysLatency, Stage: LatencyStageFinality},
+			want: []string{`range(start: 2023-12-28T12:00:00Z)`, `r._field == "source_to_vaa_ms"`},
+		},
+		{
+			name: "indexing stage of 30 days",
+			q:    CorridorLatencyQuery{TimeSpan: TimeSpan30DaysLatency, Stage: LatencyStageIndexing},
+			want: []string{`range(start: 2023-12-05T12:00:00Z)`, `r._field == "vaa_to_indexed_ms"`},
+		},
+		{
+			name: "redeem stage with filters",
+			q: CorridorLatencyQuery{
+				TimeSpan:     TimeSpan1DayLatency,
+				Stage:        LatencyStageRedeem,
+				SourceChains: []sdk.ChainID{sdk.ChainIDEthereum, sdk.ChainIDSolana},
+				TargetChains: []sdk.ChainID{sdk.ChainIDArbitrum},
+				AppID:        "PORTAL_TOKEN_BRIDGE",
+			},
+			want: []string{
+				`r._field == "vaa_to_redeem_ms")
+    |> filter(fn: (r) => r.source_chain == "2" or r.source_chain == "1")
+    |> filter(fn: (r) => r.target_chain == "23")
+    |> filter(fn: (r) => r.app_id == "PORTAL_TOKEN_BRIDGE")
+    |> group(columns: ["source_chain", "target_chain"])`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := buildCorridorLatencies("wormscan", now, &tt.q)
+			assert.Contains(t, query, `from(bucket: "wormscan")`)
+			assert.Contains(t, query, `union(tables: [p50, p90, p99, total])`)
+			for _, want := range tt.want {
+				assert.Contains(t, query, want)
+			}
+			for _, notWant := range tt.notWant {
+				assert.NotContains(t, query, notWant)
+			}
+		})
+	}
+}
+
+func TestQueries_buildFeesQuery(t *testing.T) {
+	//2024-01-04T12:25:48.112233445Z
+	now := time.Date(2024, 1, 4, 12, 25, 48, 112233445, time.UTC)
+
+	var tests = []struct {
+		name    string
+		q       FeesQuery
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "1 day without app id",
+			q:       FeesQuery{TimeSpan: "1d", SampleRate: "1h"},
+			want:    []string{`range(start: 2024-01-03T12:00:00Z)`, `aggregateWindow(every: 1h, fn: sum, createEmpty: false)`},
+			notWant: []string{"r.app_id =="},
+		},
+		{
+			name: "1 week with app id",
+			q:    FeesQuery{TimeSpan: "1w", SampleRate: "1d", AppID: "PORTAL_TOKEN_BRIDGE"},
+			want: []string{
+				`range(start: 2023-12-28T00:00:00Z)`,
+				`r._field == "fee_usd")
+    |> filter(fn: (r) => r.app_id == "PORTAL_TOKEN_BRIDGE")
+    |> group(columns: ["app_id", "fee_chain", "relayer"])`,
+				`aggregateWindow(every: 1d, fn: sum, createEmpty: false)`,
+			},
+		},
+		{
+			name: "1 month",
+			q:    FeesQuery{TimeSpan: "1mo", SampleRate: "1d"},
+			want: []string{`range(start: 2023-12-05T00:00:00Z)`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := buildFeesQuery("wormscan", now, &tt.q)
+			assert.Contains(t, query, `from(bucket: "wormscan")`)
+			assert.Contains(t, query, `r._measurement == "vaa_fee"`)
+			for _, want := range tt.want {
+				assert.Contains(t, query, want)
+			}
+			for _, notWant := range tt.notWant {
+				assert.NotContains(t, query, notWant)
+			}
+		})
+	}
+}
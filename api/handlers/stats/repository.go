@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"strconv"
 	"time"
 
@@ -20,6 +21,7 @@ type Repository struct {
 	influxCli              influxdb2.Client
 	queryAPI               api.QueryAPI
 	bucket24HoursRetention string
+	bucketInfinite         string
 	logger                 *zap.Logger
 }
 
@@ -27,6 +29,7 @@ func NewRepository(
 	client influxdb2.Client,
 	org string,
 	bucket24HoursRetention string,
+	bucketInfinite string,
 	logger *zap.Logger,
 ) *Repository {
 
@@ -34,6 +37,7 @@ func NewRepository(
 		influxCli:              client,
 		queryAPI:               client.QueryAPI(org),
 		bucket24HoursRetention: bucket24HoursRetention,
+		bucketInfinite:         bucketInfinite,
 		logger:                 logger,
 	}
 	return &r
@@ -215,3 +219,127 @@ func (r *Repository) GetTopCorridores(ctx context.Context, timeSpan TopCorridors
 
 	return values, nil
 }
+
+func (r *Repository) GetCorridorLatencies(ctx context.Context, q *CorridorLatencyQuery) ([]CorridorLatencyDTO, error) {
+
+	query := buildCorridorLatencies(r.bucketInfinite, time.Now(), q)
+	result, err := r.queryAPI.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if result.Err() != nil {
+		return nil, result.Err()
+	}
+
+	// Scan query results
+	var rows []corridorLatencyRow
+	for result.Next() {
+		var row corridorLatencyRow
+		if err := mapstructure.Decode(result.Record().Values(), &row); err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return toCorridorLatencies(rows)
+}
+
+// corridorLatencyRow is a percentile of the latency of a corridor returned by the corridor latencies query.
+type corridorLatencyRow struct {
+	SourceChain string  `mapstructure:"source_chain"`
+	TargetChain string  `mapstructure:"target_chain"`
+	Percentile  string  `mapstructure:"percentile"`
+	Value       float64 `mapstructure:"_value"`
+}
+
+// toCorridorLatencies groups the percentiles by corridor, in the order the corridors are first found.
+func toCorridorLatencies(rows []corridorLatencyRow) ([]CorridorLatencyDTO, error) {
+	type corridor struct{ source, target string }
+	var keys []corridor
+	latencies := make(map[corridor]*CorridorLatencyDTO)
+	for _, row := range rows {
+		key := corridor{source: row.SourceChain, target: row.TargetChain}
+		dto, ok := latencies[key]
+		if !ok {
+			sourceChainID, err := strconv.ParseUint(row.SourceChain, 10, 16)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse source chain %s: %w", row.SourceChain, err)
+			}
+			targetChainID, err := strconv.ParseUint(row.TargetChain, 10, 16)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse target chain %s: %w", row.TargetChain, err)
+			}
+			dto = &CorridorLatencyDTO{
+				SourceChainID: sdk.ChainID(sourceChainID),
+				TargetChainID: sdk.ChainID(targetChainID),
+			}
+			latencies[key] = dto
+			keys = append(keys, key)
+		}
+		switch row.Percentile {
+		case "p50":
+			dto.P50 = row.Value
+		case "p90":
+			dto.P90 = row.Value
+		case "p99":
+			dto.P99 = row.Value
+		case "count":
+			dto.Count = uint64(row.Value)
+		}
+	}
+
+	values := make([]CorridorLatencyDTO, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, *latencies[key])
+	}
+	return values, nil
+}
+
+func (r *Repository) GetFees(ctx context.Context, q *FeesQuery) ([]FeeDTO, error) {
+
+	query := buildFeesQuery(r.bucketInfinite, time.Now(), q)
+	result, err := r.queryAPI.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if result.Err() != nil {
+		return nil, result.Err()
+	}
+
+	var rows []feeRow
+	for result.Next() {
+		var row feeRow
+		if err := mapstructure.Decode(result.Record().Values(), &row); err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return toFees(rows)
+}
+
+// feeRow is the fee in USD of an application, chain and relayer in a time window returned by the fees query.
+type feeRow struct {
+	Time     time.Time `mapstructure:"_time"`
+	AppID    string    `mapstructure:"app_id"`
+	FeeChain string    `mapstructure:"fee_chain"`
+	Relayer  string    `mapstructure:"relayer"`
+	Value    uint64    `mapstructure:"_value"`
+}
+
+// toFees converts the rows of the fees query, whose values are stored with 8 decimals of precision.
+func toFees(rows []feeRow) ([]FeeDTO, error) {
+	values := make([]FeeDTO, 0, len(rows))
+	for _, row := range rows {
+		feeChain, err := strconv.ParseUint(row.FeeChain, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse fee chain %s: %w", row.FeeChain, err)
+		}
+		values = append(values, FeeDTO{
+			Time:     row.Time,
+			AppID:    row.AppID,
+			FeeChain: sdk.ChainID(feeChain),
+			Relayer:  row.Relayer,
+			FeeUsd:   decimal.NewFromBigInt(new(big.Int).SetUint64(row.Value), -8),
+		})
+	}
+	return values, nil
+}
@@ -0,0 +1,113 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestToCorridorLatencies(t *testing.T) {
+	var tests = []struct {
+		name    string
+		rows    []corridorLatencyRow
+		want    []CorridorLatencyDTO
+		wantErr bool
+	}{
+		{
+			name: "no rows",
+			rows: nil,
+			want: []CorridorLatencyDTO{},
+		},
+		{
+			name: "percentiles grouped by corridor",
+			rows: []corridorLatencyRow{
+				{SourceChain: "2", TargetChain: "1", Percentile: "p50", Value: 1000},
+				{SourceChain: "1", TargetChain: "2", Percentile: "p50", Value: 500},
+				{SourceChain: "2", TargetChain: "1", Percentile: "p90", Value: 2000},
+				{SourceChain: "2", TargetChain: "1", Percentile: "p99", Value: 3000},
+				{SourceChain: "2", TargetChain: "1", Percentile: "count", Value: 42},
+				{SourceChain: "1", TargetChain: "2", Percentile: "count", Value: 7},
+				{SourceChain: "1", TargetChain: "2", Percentile: "unknown", Value: 1},
+			},
+			want: []CorridorLatencyDTO{
+				{SourceChainID: sdk.ChainIDEthereum, TargetChainID: sdk.ChainIDSolana, Count: 42, P50: 1000, P90: 2000, P99: 3000},
+				{SourceChainID: sdk.ChainIDSolana, TargetChainID: sdk.ChainIDEthereum, Count: 7, P50: 500},
+			},
+		},
+		{
+			name:    "invalid source chain",
+			rows:    []corridorLatencyRow{{SourceChain: "ethereum", TargetChain: "1", Percentile: "p50"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid target chain",
+			rows:    []corridorLatencyRow{{SourceChain: "2", TargetChain: "70000", Percentile: "p50"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toCorridorLatencies(tt.rows)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestToFees(t *testing.T) {
+	window := time.Date(2024, 1, 4, 12, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		name    string
+		rows    []feeRow
+		want    []FeeDTO
+		wantErr bool
+	}{
+		{
+			name: "no rows",
+			rows: nil,
+			want: []FeeDTO{},
+		},
+		{
+			name: "fees with 8 decimals",
+			rows: []feeRow{
+				{Time: window, AppID: "PORTAL_TOKEN_BRIDGE", FeeChain: "2", Relayer: "0xrelayer", Value: 123456789},
+				{Time: window, AppID: "CCTP_WORMHOLE_INTEGRATION", FeeChain: "1", Relayer: "unknown", Value: 5},
+			},
+			want: []FeeDTO{
+				{Time: window, AppID: "PORTAL_TOKEN_BRIDGE", FeeChain: sdk.ChainIDEthereum, Relayer: "0xrelayer", FeeUsd: decimal.RequireFromString("1.23456789")},
+				{Time: window, AppID: "CCTP_WORMHOLE_INTEGRATION", FeeChain: sdk.ChainIDSolana, Relayer: "unknown", FeeUsd: decimal.RequireFromString("0.00000005")},
+			},
+		},
+		{
+			name:    "invalid fee chain",
+			rows:    []feeRow{{Time: window, FeeChain: "ethereum", Value: 1}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toFees(tt.rows)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, len(tt.want), len(got))
+			for i := range tt.want {
+				assert.True(t, tt.want[i].FeeUsd.Equal(got[i].FeeUsd), "fee %s, got %s", tt.want[i].FeeUsd, got[i].FeeUsd)
+				tt.want[i].FeeUsd, got[i].FeeUsd = decimal.Zero, decimal.Zero
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -22,6 +22,8 @@ type Service struct {
 const (
 	topSymbolsByVolumeKey  = "wormscan:top-assets-symbol-by-volume"
 	topCorridorsByCountKey = "wormscan:top-corridors-by-count"
+	corridorLatenciesKey   = "wormscan:corridor-latencies"
+	feesKey                = "wormscan:fees"
 )
 
 // NewService create a new Service.
@@ -33,7 +35,7 @@ func (s *Service) GetSymbolWithAssets(ctx context.Context, ts SymbolWithAssetsTi
 	key := topSymbolsByVolumeKey
 	key = fmt.Sprintf("%s:%s", key, ts)
 	return cacheable.GetOrLoad(ctx, s.logger, s.cache, s.expiration, key, s.metrics,
-		func() ([]SymbolWithAssetDTO, error) {
+		func(ctx context.Context) ([]SymbolWithAssetDTO, error) {
 			return s.repo.GetSymbolWithAssets(ctx, ts)
 		})
 }
@@ -42,7 +44,23 @@ func (s *Service) GetTopCorridors(ctx context.Context, ts TopCorridorsTimeSpan)
 	key := topCorridorsByCountKey
 	key = fmt.Sprintf("%s:%s", key, ts)
 	return cacheable.GetOrLoad(ctx, s.logger, s.cache, s.expiration, key, s.metrics,
-		func() ([]TopCorridorsDTO, error) {
+		func(ctx context.Context) ([]TopCorridorsDTO, error) {
 			return s.repo.GetTopCorridores(ctx, ts)
 		})
 }
+
+func (s *Service) GetCorridorLatencies(ctx context.Context, q *CorridorLatencyQuery) ([]CorridorLatencyDTO, error) {
+	key := fmt.Sprintf("%s:%s:%s:%v:%v:%s", corridorLatenciesKey, q.TimeSpan, q.Stage, q.SourceChains, q.TargetChains, q.AppID)
+	return cacheable.GetOrLoad(ctx, s.logger, s.cache, s.expiration, key, s.metrics,
+		func(ctx context.Context) ([]CorridorLatencyDTO, error) {
+			return s.repo.GetCorridorLatencies(ctx, q)
+		})
+}
+
+func (s *Service) GetFees(ctx context.Context, q *FeesQuery) ([]FeeDTO, error) {
+	key := fmt.Sprintf("%s:%s:%s:%s", feesKey, q.TimeSpan, q.SampleRate, q.AppID)
+	return cacheable.GetOrLoad(ctx, s.logger, s.cache, s.expiration, key, s.metrics,
+		func(ctx context.Context) ([]FeeDTO, error) {
+			return s.repo.GetFees(ctx, q)
+		})
+}
@@ -2,6 +2,7 @@ package stats
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/shopspring/decimal"
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
@@ -61,3 +62,114 @@ type TopCorridorsDTO struct {
 	TokenAddress       string
 	Txs                uint64
 }
+
+// LatencyTimeSpan is used as an input parameter for the function `GetCorridorLatencies`.
+type LatencyTimeSpan string
+
+const (
+	TimeSpan1DayLatency   LatencyTimeSpan = "1d"
+	TimeSpan7DaysLatency  LatencyTimeSpan = "7d"
+	TimeSpan30DaysLatency LatencyTimeSpan = "30d"
+)
+
+// ParseLatencyTimeSpan parses a string and returns a `LatencyTimeSpan`.
+func ParseLatencyTimeSpan(s string) (*LatencyTimeSpan, error) {
+	if s == string(TimeSpan1DayLatency) ||
+		s == string(TimeSpan7DaysLatency) ||
+		s == string(TimeSpan30DaysLatency) {
+
+		tmp := LatencyTimeSpan(s)
+		return &tmp, nil
+	}
+
+	return nil, fmt.Errorf("invalid time span: %s", s)
+}
+
+// Duration returns the duration of the time span.
+func (ts LatencyTimeSpan) Duration() time.Duration {
+	switch ts {
+	case TimeSpan7DaysLatency:
+		return 7 * 24 * time.Hour
+	case TimeSpan30DaysLatency:
+		return 30 * 24 * time.Hour
+	default:
+		return 24 * time.Hour
+	}
+}
+
+// LatencyStage is a stage of a cross-chain message whose latency is measured.
+type LatencyStage string
+
+const (
+	// LatencyStageTotal is the time from the source transaction to the redeem on the target chain.
+	LatencyStageTotal LatencyStage = "total"
+	// LatencyStageFinality is the time from the source transaction to the VAA.
+	LatencyStageFinality LatencyStage = "finality"
+	// LatencyStageIndexing is the time from the VAA to the moment it was indexed.
+	LatencyStageIndexing LatencyStage = "indexing"
+	// LatencyStageRedeem is the time from the VAA to the redeem on the target chain.
+	LatencyStageRedeem LatencyStage = "redeem"
+)
+
+// ParseLatencyStage parses a string and returns a `LatencyStage`.
+func ParseLatencyStage(s string) (*LatencyStage, error) {
+	if s == string(LatencyStageTotal) ||
+		s == string(LatencyStageFinality) ||
+		s == string(LatencyStageIndexing) ||
+		s == string(LatencyStageRedeem) {
+
+		tmp := LatencyStage(s)
+		return &tmp, nil
+	}
+
+	return nil, fmt.Errorf("invalid latency stage: %s", s)
+}
+
+// field returns the field of the `vaa_latency` measurement of the stage.
+func (s LatencyStage) field() string {
+	switch s {
+	case LatencyStageFinality:
+		return "source_to_vaa_ms"
+	case LatencyStageIndexing:
+		return "vaa_to_indexed_ms"
+	case LatencyStageRedeem:
+		return "vaa_to_redeem_ms"
+	default:
+		return "total_ms"
+	}
+}
+
+// CorridorLatencyQuery contains the filters of the corridor latencies.
+type CorridorLatencyQuery struct {
+	TimeSpan     LatencyTimeSpan
+	Stage        LatencyStage
+	SourceChains []sdk.ChainID
+	TargetChains []sdk.ChainID
+	AppID        string
+}
+
+// CorridorLatencyDTO contains the percentile latencies of a corridor in milliseconds.
+type CorridorLatencyDTO struct {
+	SourceChainID sdk.ChainID
+	TargetChainID sdk.ChainID
+	Count         uint64
+	P50           float64
+	P90           float64
+	P99           float64
+}
+
+// FeesQuery contains the filters of the fees.
+type FeesQuery struct {
+	TimeSpan   string
+	SampleRate string
+	AppID      string
+}
+
+// FeeDTO contains the fees in USD paid to a relayer of an application and chain in a time window.
+type FeeDTO struct {
+	Time     time.Time
+	AppID    string
+	FeeChain sdk.ChainID
+	Relayer  string
+	FeeUsd   decimal.Decimal
+}
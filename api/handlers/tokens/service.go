@@ -0,0 +1,68 @@
+package tokens
+
+import (
+	"context"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/pagination"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// Service definition.
+type Service struct {
+	repo            *repository.TokenRepository
+	tokenProvider   *domain.TokenProvider
+	refreshInterval time.Duration
+	logger          *zap.Logger
+}
+
+// NewService create a new Service.
+//
+// The token mappings are loaded into the token provider and reloaded from the database every refreshInterval,
+// so that the mappings created by other instances are also used.
+func NewService(repo *repository.TokenRepository, tokenProvider *domain.TokenProvider, refreshInterval time.Duration, logger *zap.Logger) *Service {
+	if refreshInterval <= 0 {
+		refreshInterval = 5 * time.Minute
+	}
+	return &Service{
+		repo:            repo,
+		tokenProvider:   tokenProvider,
+		refreshInterval: refreshInterval,
+		logger:          logger.With(zap.String("module", "TokensService")),
+	}
+}
+
+// Start loads the token mappings and starts their background refresh.
+func (s *Service) Start(ctx context.Context) error {
+	if err := s.repo.LoadTokenMappings(ctx, s.tokenProvider); err != nil {
+		return err
+	}
+	go s.repo.RefreshTokenMappings(ctx, s.tokenProvider, s.refreshInterval)
+	return nil
+}
+
+// FindUnknownTokens returns the tokens found in transfers that are not in the token list.
+func (s *Service) FindUnknownTokens(ctx context.Context, status repository.UnknownTokenStatus, p *pagination.Pagination) ([]repository.UnknownTokenDoc, error) {
+	return s.repo.FindUnknownTokens(ctx, status, p.Skip, p.Limit)
+}
+
+// FindTokenMappings returns the coingecko mappings of the unknown tokens.
+func (s *Service) FindTokenMappings(ctx context.Context) ([]repository.TokenMappingDoc, error) {
+	return s.repo.FindTokenMappings(ctx)
+}
+
+// IsListed returns true when the token is in the static token list.
+func (s *Service) IsListed(tokenChain sdk.ChainID, tokenAddress string) bool {
+	return s.tokenProvider.IsListed(tokenChain, tokenAddress)
+}
+
+// MapToken maps an unknown token to a coingecko id and reloads the token mappings.
+func (s *Service) MapToken(ctx context.Context, mapping *repository.TokenMappingDoc) error {
+	if err := s.repo.UpsertTokenMapping(ctx, mapping); err != nil {
+		return err
+	}
+	return s.repo.LoadTokenMappings(ctx, s.tokenProvider)
+}
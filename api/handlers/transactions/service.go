@@ -50,14 +50,14 @@ func NewService(repo *Repository, cache cache.Cache, expiration time.Duration, t
 func (s *Service) GetTransactionCount(ctx context.Context, q *TransactionCountQuery) ([]TransactionCountResult, error) {
 	key := fmt.Sprintf("%s:%s:%s:%v", lastTxsKey, q.TimeSpan, q.SampleRate, q.CumulativeSum)
 	return cacheable.GetOrLoad(ctx, s.logger, s.cache, s.expiration, key, s.metrics,
-		func() ([]TransactionCountResult, error) {
+		func(ctx context.Context) ([]TransactionCountResult, error) {
 			return s.repo.GetTransactionCount(ctx, q)
 		})
 }
 
 func (s *Service) GetScorecards(ctx context.Context) (*Scorecards, error) {
 	return cacheable.GetOrLoad(ctx, s.logger, s.cache, s.expiration, scorecardsKey, s.metrics,
-		func() (*Scorecards, error) {
+		func(ctx context.Context) (*Scorecards, error) {
 			return s.repo.GetScorecards(ctx)
 		})
 }
@@ -68,7 +68,7 @@ func (s *Service) GetTopAssets(ctx context.Context, timeSpan *TopStatisticsTimeS
 		key = fmt.Sprintf("%s:%s", key, *timeSpan)
 	}
 	return cacheable.GetOrLoad(ctx, s.logger, s.cache, s.expiration, key, s.metrics,
-		func() ([]AssetDTO, error) {
+		func(ctx context.Context) ([]AssetDTO, error) {
 			return s.repo.GetTopAssets(ctx, timeSpan)
 		})
 }
@@ -79,7 +79,7 @@ func (s *Service) GetTopChainPairs(ctx context.Context, timeSpan *TopStatisticsT
 		key = fmt.Sprintf("%s:%s", key, *timeSpan)
 	}
 	return cacheable.GetOrLoad(ctx, s.logger, s.cache, s.expiration, key, s.metrics,
-		func() ([]ChainPairDTO, error) {
+		func(ctx context.Context) ([]ChainPairDTO, error) {
 			return s.repo.GetTopChainPairs(ctx, timeSpan)
 		})
 }
@@ -88,7 +88,7 @@ func (s *Service) GetTopChainPairs(ctx context.Context, timeSpan *TopStatisticsT
 func (s *Service) GetChainActivity(ctx context.Context, q *ChainActivityQuery) ([]ChainActivityResult, error) {
 	key := fmt.Sprintf("%s:%s:%v:%s", chainActivityKey, q.TimeSpan, q.IsNotional, strings.Join(q.GetAppIDs(), ","))
 	return cacheable.GetOrLoad(ctx, s.logger, s.cache, s.expiration, key, s.metrics,
-		func() ([]ChainActivityResult, error) {
+		func(ctx context.Context) ([]ChainActivityResult, error) {
 			return s.repo.FindChainActivity(ctx, q)
 		})
 }
@@ -63,3 +63,33 @@ type VaaStats struct {
 	ChainID vaa.ChainID `bson:"_id" json:"chainId"`
 	Count   int64       `bson:"count" json:"count"`
 }
+
+// DuplicateVaaResolution is the audit record of a duplicate VAA resolution made by the fly-event-processor.
+type DuplicateVaaResolution struct {
+	ID              string                  `bson:"_id" json:"id"`
+	VaaID           string                  `bson:"vaaId" json:"vaaId"`
+	Candidates      []DuplicateVaaCandidate `bson:"candidates" json:"candidates"`
+	GuardianAnswers []GuardianDigestAnswer  `bson:"guardianAnswers" json:"guardianAnswers"`
+	Threshold       int                     `bson:"threshold" json:"threshold"`
+	ChosenDigest    string                  `bson:"chosenDigest" json:"chosenDigest,omitempty"`
+	PreviousDigest  string                  `bson:"previousDigest" json:"previousDigest"`
+	Decision        string                  `bson:"decision" json:"decision"`
+	Reason          string                  `bson:"reason" json:"reason,omitempty"`
+	CreatedAt       time.Time               `bson:"createdAt" json:"createdAt"`
+}
+
+// DuplicateVaaCandidate is one of the VAAs with the same ID considered in a duplicate VAA resolution.
+type DuplicateVaaCandidate struct {
+	ID        string     `bson:"id" json:"id"`
+	Digest    string     `bson:"digest" json:"digest"`
+	TxHash    string     `bson:"txHash" json:"txHash,omitempty"`
+	Timestamp *time.Time `bson:"timestamp" json:"timestamp"`
+	Stored    bool       `bson:"stored" json:"stored"`
+}
+
+// GuardianDigestAnswer is the digest of the signed VAA returned by a guardian API, or the error returned instead.
+type GuardianDigestAnswer struct {
+	Guardian string `bson:"guardian" json:"guardian"`
+	Digest   string `bson:"digest" json:"digest,omitempty"`
+	Error    string `bson:"error" json:"error,omitempty"`
+}
@@ -23,13 +23,14 @@ type Repository struct {
 	db          *mongo.Database
 	logger      *zap.Logger
 	collections struct {
-		vaas               *mongo.Collection
-		parsedVaa          *mongo.Collection
-		vaasPythnet        *mongo.Collection
-		invalidVaas        *mongo.Collection
-		vaaCount           *mongo.Collection
-		globalTransactions *mongo.Collection
-		duplicateVaas      *mongo.Collection
+		vaas                    *mongo.Collection
+		parsedVaa               *mongo.Collection
+		vaasPythnet             *mongo.Collection
+		invalidVaas             *mongo.Collection
+		vaaCount                *mongo.Collection
+		globalTransactions      *mongo.Collection
+		duplicateVaas           *mongo.Collection
+		duplicateVaaResolutions *mongo.Collection
 	}
 }
 
@@ -38,21 +39,23 @@ func NewRepository(db *mongo.Database, logger *zap.Logger) *Repository {
 	return &Repository{db: db,
 		logger: logger.With(zap.String("module", "VaaRepository")),
 		collections: struct {
-			vaas               *mongo.Collection
-			parsedVaa          *mongo.Collection
-			vaasPythnet        *mongo.Collection
-			invalidVaas        *mongo.Collection
-			vaaCount           *mongo.Collection
-			globalTransactions *mongo.Collection
-			duplicateVaas      *mongo.Collection
+			vaas                    *mongo.Collection
+			parsedVaa               *mongo.Collection
+			vaasPythnet             *mongo.Collection
+			invalidVaas             *mongo.Collection
+			vaaCount                *mongo.Collection
+			globalTransactions      *mongo.Collection
+			duplicateVaas           *mongo.Collection
+			duplicateVaaResolutions *mongo.Collection
 		}{
-			vaas:               db.Collection(repository.Vaas),
-			parsedVaa:          db.Collection("parsedVaa"),
-			vaasPythnet:        db.Collection("vaasPythnet"),
-			invalidVaas:        db.Collection("invalid_vaas"),
-			vaaCount:           db.Collection("vaaCounts"),
-			globalTransactions: db.Collection("globalTransactions"),
-			duplicateVaas:      db.Collection(repository.DuplicateVaas),
+			vaas:                    db.Collection(repository.Vaas),
+			parsedVaa:               db.Collection("parsedVaa"),
+			vaasPythnet:             db.Collection("vaasPythnet"),
+			invalidVaas:             db.Collection("invalid_vaas"),
+			vaaCount:                db.Collection("vaaCounts"),
+			globalTransactions:      db.Collection("globalTransactions"),
+			duplicateVaas:           db.Collection(repository.DuplicateVaas),
+			duplicateVaaResolutions: db.Collection(repository.DuplicateVaaResolutions),
 		},
 	}
 }
@@ -452,6 +455,32 @@ func (r *Repository) FindDuplicatedByID(ctx context.Context, chain sdk.ChainID,
 	return append(duplicateVaas, &vaa), nil
 }
 
+// FindDuplicateVaaResolutions returns the most recent resolutions of a duplicated VAA.
+func (r *Repository) FindDuplicateVaaResolutions(ctx context.Context, chain sdk.ChainID, emitter *types.Address, seq string, limit int64) ([]*DuplicateVaaResolution, error) {
+
+	vaaID := fmt.Sprintf("%d/%s/%s", chain, emitter.Hex(), seq)
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}}).
+		SetLimit(limit)
+	cur, err := r.collections.duplicateVaaResolutions.Find(ctx, bson.D{{Key: "vaaId", Value: vaaID}}, opts)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed execute Find command to get duplicate vaa resolutions",
+			zap.Error(err), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+
+	resolutions := []*DuplicateVaaResolution{}
+	err = cur.All(ctx, &resolutions)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed decoding cursor to []*DuplicateVaaResolution", zap.Error(err), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	return resolutions, nil
+}
+
 // VaaQuery respresent a query for the vaa mongodb document.
 type VaaQuery struct {
 	pagination.Pagination
@@ -16,6 +16,9 @@ import (
 	"go.uber.org/zap"
 )
 
+// maxDuplicateVaaResolutions is the maximum number of resolutions returned for a duplicated VAA.
+const maxDuplicateVaaResolutions = 100
+
 // Service definition.
 type Service struct {
 	repo         *Repository
@@ -300,3 +303,13 @@ func (s *Service) FindDuplicatedById(
 	resp := response.Response[[]*VaaDoc]{Data: vaas}
 	return &resp, err
 }
+
+// FindDuplicateVaaResolutions returns the history of the resolutions of a duplicated VAA, most recent first.
+func (s *Service) FindDuplicateVaaResolutions(
+	ctx context.Context,
+	chain sdk.ChainID,
+	emitter *types.Address,
+	seq string,
+) ([]*DuplicateVaaResolution, error) {
+	return s.repo.FindDuplicateVaaResolutions(ctx, chain, emitter, seq, maxDuplicateVaaResolutions)
+}
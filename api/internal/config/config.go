@@ -40,6 +40,8 @@ type AppConfig struct {
 		Prefix                   string
 		ProtocolsStatsKey        string
 		ProtocolsStatsExpiration int
+		// Max number of results kept in the in-process cache, zero disables it
+		LocalCapacity int
 	}
 	PORT         int
 	LogLevel     string
@@ -69,6 +71,25 @@ type AppConfig struct {
 		//Api Tokens
 		Tokens string
 	}
+	ApiKeys struct {
+		Enabled bool
+		// Reject requests without a valid api key
+		Required bool
+		// Default max number of requests per minute and burst for an api key
+		RequestsPerMinute int
+		Burst             int
+		// Max number of requests per minute and burst by ip for requests without api key
+		IpRequestsPerMinute int
+		IpBurst             int
+		// Interval in seconds to reload the api keys and flush the usage counters
+		RefreshInterval int
+		// Token required to call the admin endpoints
+		AdminToken string
+	}
+	Tokens struct {
+		// Interval in seconds to reload the token mappings of the unknown tokens
+		MappingsRefreshInterval int
+	}
 	Protocols []string
 }
 
@@ -88,8 +109,10 @@ func defaulConfig() *AppConfig {
 			Prefix                   string
 			ProtocolsStatsKey        string
 			ProtocolsStatsExpiration int
+			LocalCapacity            int
 		}{
 			MetricExpiration: 10,
+			LocalCapacity:    1000,
 		},
 	}
 }
@@ -0,0 +1,119 @@
+// Package ratelimit implements an in-memory token bucket rate limiter keyed by an arbitrary string.
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Result is the outcome of a call to Limiter.Allow.
+type Result struct {
+	// Allowed indicates whether the request can proceed.
+	Allowed bool
+	// Limit is the capacity of the bucket.
+	Limit int
+	// Remaining is the number of requests that can be made right now.
+	Remaining int
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is the time until the next request is allowed. It is zero when the request is allowed.
+	RetryAfter time.Duration
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// Limiter keeps a token bucket for each key.
+//
+// Buckets that are not used for idleTimeout are removed to bound the memory used
+// by keys with high cardinality, like client IPs.
+type Limiter struct {
+	mu          sync.Mutex
+	buckets     map[string]*bucket
+	idleTimeout time.Duration
+	lastCleanup time.Time
+	now         func() time.Time
+}
+
+// NewLimiter creates a new Limiter.
+func NewLimiter(idleTimeout time.Duration) *Limiter {
+	return &Limiter{
+		buckets:     make(map[string]*bucket),
+		idleTimeout: idleTimeout,
+		now:         time.Now,
+	}
+}
+
+// Allow consumes a token from the bucket of the key.
+//
+// The bucket refills at requestsPerMinute and holds at most burst tokens. If the limits of an
+// existing bucket changed they are updated in place, so the tokens already consumed are kept.
+func (l *Limiter) Allow(key string, requestsPerMinute, burst int) Result {
+	if burst <= 0 {
+		burst = 1
+	}
+	limit := rate.Limit(float64(requestsPerMinute) / 60)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.cleanup(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(limit, burst)}
+		l.buckets[key] = b
+	} else {
+		if b.limiter.Limit() != limit {
+			b.limiter.SetLimitAt(now, limit)
+		}
+		if b.limiter.Burst() != burst {
+			b.limiter.SetBurstAt(now, burst)
+		}
+	}
+	b.lastSeen = now
+
+	allowed := b.limiter.AllowN(now, 1)
+	tokens := math.Max(b.limiter.TokensAt(now), 0)
+
+	result := Result{
+		Allowed:   allowed,
+		Limit:     burst,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     timeToTokens(float64(burst)-tokens, limit),
+	}
+	if !allowed {
+		result.RetryAfter = timeToTokens(1-tokens, limit)
+	}
+	return result
+}
+
+// cleanup removes the buckets that have been idle for more than idleTimeout.
+func (l *Limiter) cleanup(now time.Time) {
+	if l.idleTimeout <= 0 || now.Sub(l.lastCleanup) < l.idleTimeout {
+		return
+	}
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) > l.idleTimeout {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastCleanup = now
+}
+
+// timeToTokens returns the time needed to refill the given amount of tokens.
+func timeToTokens(tokens float64, limit rate.Limit) time.Duration {
+	if tokens <= 0 {
+		return 0
+	}
+	if limit <= 0 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(tokens / float64(limit) * float64(time.Second))
+}
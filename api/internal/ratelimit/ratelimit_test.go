@@ -0,0 +1,52 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewLimiter(time.Hour)
+	l.now = func() time.Time { return now }
+
+	// 60 requests per minute with a burst of 2.
+	r := l.Allow("a", 60, 2)
+	assert.True(t, r.Allowed)
+	assert.Equal(t, 2, r.Limit)
+	assert.Equal(t, 1, r.Remaining)
+
+	r = l.Allow("a", 60, 2)
+	assert.True(t, r.Allowed)
+	assert.Equal(t, 0, r.Remaining)
+	assert.Equal(t, 2*time.Second, r.Reset)
+
+	r = l.Allow("a", 60, 2)
+	assert.False(t, r.Allowed)
+	assert.Equal(t, time.Second, r.RetryAfter)
+
+	// other keys have their own bucket.
+	r = l.Allow("b", 60, 2)
+	assert.True(t, r.Allowed)
+
+	// the bucket is refilled with time.
+	now = now.Add(time.Second)
+	r = l.Allow("a", 60, 2)
+	assert.True(t, r.Allowed)
+}
+
+func TestLimiter_Cleanup(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewLimiter(time.Minute)
+	l.now = func() time.Time { return now }
+
+	l.Allow("a", 60, 1)
+	now = now.Add(2 * time.Minute)
+	l.Allow("b", 60, 1)
+
+	assert.Len(t, l.buckets, 1)
+	_, ok := l.buckets["b"]
+	assert.True(t, ok)
+}
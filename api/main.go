@@ -26,7 +26,9 @@ import (
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
 
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/api/cacheable"
 	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/address"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/apikeys"
 	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/governor"
 	guardianHandlers "github.com/wormhole-foundation/wormhole-explorer/api/handlers/guardian"
 	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/heartbeats"
@@ -35,10 +37,12 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/operations"
 	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/relays"
 	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/stats"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/tokens"
 	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/transactions"
 	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/vaa"
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/config"
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/ratelimit"
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/tvl"
 	"github.com/wormhole-foundation/wormhole-explorer/api/middleware"
 	"github.com/wormhole-foundation/wormhole-explorer/api/response"
@@ -126,6 +130,11 @@ func main() {
 		rootLogger.Fatal("failed to initialize cache", zap.Error(err))
 	}
 
+	// in-process cache in front of the distributed cache
+	if cfg.Cache.Enabled {
+		cacheable.SetLocalCapacity(cfg.Cache.LocalCapacity)
+	}
+
 	// cfg.Cache.Expiration
 	rootLogger.Info("initializing TVL cache")
 	tvl := tvl.NewTVL(cfg.P2pNetwork, cache, cfg.Cache.TvlKey, cfg.Cache.TvlExpiration, rootLogger)
@@ -161,11 +170,14 @@ func main() {
 	)
 	relaysRepo := relays.NewRepository(db.Database, rootLogger)
 	operationsRepo := operations.NewRepository(db.Database, rootLogger)
-	statsRepo := stats.NewRepository(influxCli, cfg.Influx.Organization, cfg.Influx.Bucket24Hours, rootLogger)
+	statsRepo := stats.NewRepository(influxCli, cfg.Influx.Organization, cfg.Influx.Bucket24Hours, cfg.Influx.BucketInfinite, rootLogger)
 	protocolsRepo := protocols.NewRepository(protocols.WrapQueryAPI(influxCli.QueryAPI(cfg.Influx.Organization)), cfg.Influx.BucketInfinite, cfg.Influx.Bucket30Days, rootLogger)
 	guardianSetRepository := repository.NewGuardianSetRepository(db.Database, rootLogger)
+	apiKeysRepo := apikeys.NewRepository(db.Database, rootLogger)
+	tokenRepo := repository.NewTokenRepository(db.Database, rootLogger)
 	// create token provider
 	tokenProvider := domain.NewTokenProvider(cfg.P2pNetwork)
+	protocolRegistry := domain.NewProtocolRegistry(cfg.P2pNetwork)
 
 	metrics := metrics.NewPrometheusMetrics(cfg.Environment)
 
@@ -174,16 +186,21 @@ func main() {
 	expirationTime := time.Duration(cfg.Cache.MetricExpiration) * time.Minute
 	addressService := address.NewService(addressRepo, rootLogger)
 	vaaService := vaa.NewService(vaaRepo, cache.Get, vaaParserFunc, rootLogger)
-	obsService := observations.NewService(obsRepo, rootLogger)
+	guardianService := guardianHandlers.NewService(guardianSetRepository, cfg.P2pNetwork, cache, metrics, rootLogger)
+	obsService := observations.NewService(obsRepo, guardianService, cache, metrics, rootLogger)
 	governorService := governor.NewService(governorRepo, cache, metrics, rootLogger)
 	infrastructureService := infrastructure.NewService(infrastructureRepo, rootLogger)
 	heartbeatsService := heartbeats.NewService(heartbeatsRepo, rootLogger)
 	transactionsService := transactions.NewService(transactionsRepo, cache, expirationTime, tokenProvider, metrics, rootLogger)
 	relaysService := relays.NewService(relaysRepo, rootLogger)
-	operationsService := operations.NewService(operationsRepo, rootLogger)
+	operationsService := operations.NewService(operationsRepo, governorRepo, relaysRepo, cache, metrics, rootLogger)
 	statsService := stats.NewService(statsRepo, cache, expirationTime, metrics, rootLogger)
-	protocolsService := protocols.NewService(cfg.Protocols, []string{protocols.CCTP, protocols.PortalTokenBridge}, protocolsRepo, rootLogger, cache, cfg.Cache.ProtocolsStatsKey, cfg.Cache.ProtocolsStatsExpiration, metrics, tvl)
-	guardianService := guardianHandlers.NewService(guardianSetRepository, cfg.P2pNetwork, cache, metrics, rootLogger)
+	protocolsService := protocols.NewService(cfg.Protocols, protocolRegistry.GetCoreAppIDs(), protocolRegistry, protocolsRepo, rootLogger, cache, cfg.Cache.ProtocolsStatsKey, cfg.Cache.ProtocolsStatsExpiration, metrics, tvl)
+	apiKeysService := apikeys.NewService(apiKeysRepo, cfg.ApiKeys.AdminToken, time.Duration(cfg.ApiKeys.RefreshInterval)*time.Second, rootLogger)
+	tokensService := tokens.NewService(tokenRepo, tokenProvider, time.Duration(cfg.Tokens.MappingsRefreshInterval)*time.Second, rootLogger)
+	if err := tokensService.Start(appCtx); err != nil {
+		rootLogger.Fatal("failed to load token mappings", zap.Error(err))
+	}
 
 	// Set up a custom error handler
 	response.SetEnableStackTrace(*cfg)
@@ -212,6 +229,15 @@ func main() {
 	}
 	app.Use(cors.New())
 
+	// Configure api keys
+	if cfg.ApiKeys.Enabled {
+		apiKeysMiddleware, err := NewApiKeysMiddleware(appCtx, cfg, apiKeysService, rootLogger)
+		if err != nil {
+			rootLogger.Fatal("failed to initialize api keys", zap.Error(err))
+		}
+		app.Use(apiKeysMiddleware)
+	}
+
 	// Configure rate limiter
 	if cfg.RateLimit.Enabled {
 		rl, err := NewRateLimiter(appCtx, cfg, rootLogger)
@@ -223,7 +249,7 @@ func main() {
 
 	// Set up route handlers
 	app.Get("/swagger.json", GetSwagger)
-	wormscan.RegisterRoutes(app, rootLogger, addressService, vaaService, obsService, governorService, infrastructureService, transactionsService, relaysService, operationsService, statsService, protocolsService)
+	wormscan.RegisterRoutes(app, rootLogger, addressService, vaaService, obsService, governorService, infrastructureService, transactionsService, relaysService, operationsService, statsService, protocolsService, apiKeysService, tokensService)
 	guardian.RegisterRoutes(cfg, app, rootLogger, vaaService, governorService, heartbeatsService, guardianService)
 
 	// Set up gRPC handlers
@@ -334,6 +360,11 @@ func NewRateLimiter(ctx context.Context, cfg *config.AppConfig, logger *zap.Logg
 
 	router := limiter.New(limiter.Config{
 		Next: func(c *fiber.Ctx) bool {
+			// requests with a stored api key, and anonymous ones when api keys are enabled,
+			// are already limited by the api keys middleware.
+			if middleware.IsRateLimited(c) {
+				return true
+			}
 			if enableApiTokens {
 				apiKey := c.Get("X-API-KEY")
 				if apiKey != "" {
@@ -359,6 +390,44 @@ func NewRateLimiter(ctx context.Context, cfg *config.AppConfig, logger *zap.Logg
 
 }
 
+// NewApiKeysMiddleware loads the api keys and returns the middleware that authenticates and rate limits requests by api key and ip.
+func NewApiKeysMiddleware(ctx context.Context, cfg *config.AppConfig, srv *apikeys.Service, logger *zap.Logger) (func(*fiber.Ctx) error, error) {
+
+	if err := srv.Start(ctx); err != nil {
+		return nil, err
+	}
+
+	mwCfg := middleware.ApiKeysConfig{
+		Required:            cfg.ApiKeys.Required,
+		RequestsPerMinute:   cfg.ApiKeys.RequestsPerMinute,
+		Burst:               cfg.ApiKeys.Burst,
+		IpRequestsPerMinute: cfg.ApiKeys.IpRequestsPerMinute,
+		IpBurst:             cfg.ApiKeys.IpBurst,
+	}
+
+	// default to 600 requests per minute for api keys and 60 requests per minute by ip
+	if mwCfg.RequestsPerMinute == 0 {
+		mwCfg.RequestsPerMinute = 600
+	}
+	if mwCfg.Burst == 0 {
+		mwCfg.Burst = 100
+	}
+	if mwCfg.IpRequestsPerMinute == 0 {
+		mwCfg.IpRequestsPerMinute = 60
+	}
+	if mwCfg.IpBurst == 0 {
+		mwCfg.IpBurst = 20
+	}
+
+	logger.Info("api keys enabled",
+		zap.Bool("required", mwCfg.Required),
+		zap.Int("requests per minute", mwCfg.RequestsPerMinute),
+		zap.Int("ip requests per minute", mwCfg.IpRequestsPerMinute))
+
+	limiter := ratelimit.NewLimiter(10 * time.Minute)
+	return middleware.ApiKeys(srv, limiter, mwCfg), nil
+}
+
 // NewVaaParserFunc returns a function to parse VAA payload.
 func NewVaaParserFunc(cfg *config.AppConfig, logger *zap.Logger) (vaaPayloadParser.ParseVaaFunc, error) {
 	if cfg.RunMode == config.RunModeDevelopmernt && !cfg.VaaPayloadParser.Enabled {
@@ -0,0 +1,113 @@
+// package middleare contains all the middleware function to use in the API.
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/apikeys"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/ratelimit"
+	"github.com/wormhole-foundation/wormhole-explorer/api/response"
+	"github.com/wormhole-foundation/wormhole-explorer/common/utils"
+)
+
+const (
+	// HeaderApiKey is the header used by clients to send their api key.
+	HeaderApiKey = "X-API-KEY"
+	// LocalsApiKey is the fiber local where the api key of an authenticated request is stored.
+	LocalsApiKey = "apiKey"
+	// LocalsRateLimited is the fiber local set on the requests already rate limited by the ApiKeys middleware.
+	LocalsRateLimited = "rateLimited"
+
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+)
+
+// ApiKeysConfig defines the limits applied by the ApiKeys middleware.
+type ApiKeysConfig struct {
+	// Required rejects the requests without a valid api key.
+	Required bool
+	// RequestsPerMinute and Burst are the default limits for requests with an api key.
+	RequestsPerMinute int
+	Burst             int
+	// IpRequestsPerMinute and IpBurst are the limits for requests without an api key, by client ip.
+	IpRequestsPerMinute int
+	IpBurst             int
+}
+
+// ApiKeysService finds the api keys and accounts their usage.
+type ApiKeysService interface {
+	FindKey(key string) (*apikeys.ApiKeyDoc, bool)
+	RecordRequest(key string, throttled bool)
+}
+
+// ApiKeys authenticates the requests with the X-API-KEY header and applies a token bucket
+// rate limit per api key, or per client ip for anonymous requests.
+//
+// Every response includes the X-RateLimit-* headers, and the usage of each api key is
+// accounted in the api keys service.
+func ApiKeys(srv ApiKeysService, limiter *ratelimit.Limiter, cfg ApiKeysConfig) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if IsK8sPath(c.Path()) {
+			return c.Next()
+		}
+
+		key := c.Get(HeaderApiKey)
+		if key != "" {
+			doc, ok := srv.FindKey(key)
+			if !ok {
+				return response.NewUnauthorizedError(c, "INVALID API KEY")
+			}
+			c.Locals(LocalsApiKey, key)
+
+			requestsPerMinute, burst := cfg.RequestsPerMinute, cfg.Burst
+			if doc.RequestsPerMinute > 0 {
+				requestsPerMinute = doc.RequestsPerMinute
+			}
+			if doc.Burst > 0 {
+				burst = doc.Burst
+			}
+			result := limiter.Allow("key:"+key, requestsPerMinute, burst)
+			srv.RecordRequest(key, !result.Allowed)
+			return applyRateLimit(c, result)
+		}
+
+		if cfg.Required {
+			return response.NewUnauthorizedError(c, "MISSING API KEY")
+		}
+
+		ip := utils.GetRealIp(c)
+		if utils.IsPrivateIPAsString(ip) {
+			return c.Next()
+		}
+		result := limiter.Allow("ip:"+ip, cfg.IpRequestsPerMinute, cfg.IpBurst)
+		return applyRateLimit(c, result)
+	}
+}
+
+// IsRateLimited checks if a request was already rate limited by the ApiKeys middleware.
+func IsRateLimited(c *fiber.Ctx) bool {
+	limited, _ := c.Locals(LocalsRateLimited).(bool)
+	return limited
+}
+
+func applyRateLimit(c *fiber.Ctx, result ratelimit.Result) error {
+	c.Locals(LocalsRateLimited, true)
+	c.Set(headerRateLimitLimit, strconv.Itoa(result.Limit))
+	c.Set(headerRateLimitRemaining, strconv.Itoa(result.Remaining))
+	c.Set(headerRateLimitReset, formatSeconds(result.Reset))
+	if !result.Allowed {
+		c.Set(fiber.HeaderRetryAfter, formatSeconds(result.RetryAfter))
+		return response.NewTooManyRequestsError(c)
+	}
+	return c.Next()
+}
+
+// formatSeconds rounds up a duration to seconds.
+func formatSeconds(d time.Duration) string {
+	return fmt.Sprintf("%d", int64(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,169 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/apikeys"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/ratelimit"
+)
+
+const publicIp = "8.8.8.8"
+
+type apiKeysServiceMock struct {
+	mu        sync.Mutex
+	keys      map[string]*apikeys.ApiKeyDoc
+	requests  map[string]int
+	throttled map[string]int
+}
+
+func newApiKeysServiceMock(docs ...*apikeys.ApiKeyDoc) *apiKeysServiceMock {
+	m := &apiKeysServiceMock{
+		keys:      make(map[string]*apikeys.ApiKeyDoc),
+		requests:  make(map[string]int),
+		throttled: make(map[string]int),
+	}
+	for _, doc := range docs {
+		m.keys[doc.ID] = doc
+	}
+	return m
+}
+
+func (m *apiKeysServiceMock) FindKey(key string) (*apikeys.ApiKeyDoc, bool) {
+	doc, ok := m.keys[key]
+	if !ok || !doc.Enabled {
+		return nil, false
+	}
+	return doc, true
+}
+
+func (m *apiKeysServiceMock) RecordRequest(key string, throttled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[key]++
+	if throttled {
+		m.throttled[key]++
+	}
+}
+
+// newApiKeysTestApp creates an app with the ApiKeys middleware whose handler reports if the request was rate limited.
+func newApiKeysTestApp(srv ApiKeysService, cfg ApiKeysConfig) *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Use(ApiKeys(srv, ratelimit.NewLimiter(time.Hour), cfg))
+	app.Get("/*", func(c *fiber.Ctx) error {
+		if IsRateLimited(c) {
+			return c.SendString("limited")
+		}
+		return c.SendString("not limited")
+	})
+	return app
+}
+
+func doRequest(t *testing.T, app *fiber.App, path, apiKey, ip string) (int, string, map[string]string) {
+	req := httptest.NewRequest(fiber.MethodGet, path, nil)
+	if apiKey != "" {
+		req.Header.Set(HeaderApiKey, apiKey)
+	}
+	req.Header.Set("X-Forwarded-For", ip)
+	resp, err := app.Test(req)
+	assert.Nil(t, err)
+	body := make([]byte, 256)
+	n, _ := resp.Body.Read(body)
+	headers := map[string]string{
+		headerRateLimitLimit:     resp.Header.Get(headerRateLimitLimit),
+		headerRateLimitRemaining: resp.Header.Get(headerRateLimitRemaining),
+		fiber.HeaderRetryAfter:   resp.Header.Get(fiber.HeaderRetryAfter),
+	}
+	return resp.StatusCode, string(body[:n]), headers
+}
+
+func TestApiKeys(t *testing.T) {
+	cfg := ApiKeysConfig{RequestsPerMinute: 60, Burst: 2, IpRequestsPerMinute: 60, IpBurst: 1}
+
+	t.Run("valid key", func(t *testing.T) {
+		srv := newApiKeysServiceMock(&apikeys.ApiKeyDoc{ID: "key1", Enabled: true, Burst: 5})
+		app := newApiKeysTestApp(srv, cfg)
+
+		status, body, headers := doRequest(t, app, "/api/v1/vaas", "key1", publicIp)
+		assert.Equal(t, fiber.StatusOK, status)
+		assert.Equal(t, "limited", body)
+		// the limits of the key override the default ones.
+		assert.Equal(t, "5", headers[headerRateLimitLimit])
+		assert.Equal(t, "4", headers[headerRateLimitRemaining])
+		assert.Equal(t, 1, srv.requests["key1"])
+		assert.Equal(t, 0, srv.throttled["key1"])
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		srv := newApiKeysServiceMock(&apikeys.ApiKeyDoc{ID: "disabled", Enabled: false})
+		app := newApiKeysTestApp(srv, cfg)
+
+		status, _, _ := doRequest(t, app, "/api/v1/vaas", "unknown", publicIp)
+		assert.Equal(t, fiber.StatusUnauthorized, status)
+		status, _, _ = doRequest(t, app, "/api/v1/vaas", "disabled", publicIp)
+		assert.Equal(t, fiber.StatusUnauthorized, status)
+		assert.Empty(t, srv.requests)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		srv := newApiKeysServiceMock()
+		app := newApiKeysTestApp(srv, cfg)
+
+		// anonymous requests are limited by ip.
+		status, body, headers := doRequest(t, app, "/api/v1/vaas", "", publicIp)
+		assert.Equal(t, fiber.StatusOK, status)
+		assert.Equal(t, "limited", body)
+		assert.Equal(t, "1", headers[headerRateLimitLimit])
+
+		// private ips are not limited.
+		status, body, _ = doRequest(t, app, "/api/v1/vaas", "", "10.0.0.1")
+		assert.Equal(t, fiber.StatusOK, status)
+		assert.Equal(t, "not limited", body)
+
+		// k8s probes are not limited.
+		status, body, _ = doRequest(t, app, "/api/v1/health", "", publicIp)
+		assert.Equal(t, fiber.StatusOK, status)
+		assert.Equal(t, "not limited", body)
+	})
+
+	t.Run("missing key required", func(t *testing.T) {
+		required := cfg
+		required.Required = true
+		app := newApiKeysTestApp(newApiKeysServiceMock(), required)
+
+		status, _, _ := doRequest(t, app, "/api/v1/vaas", "", publicIp)
+		assert.Equal(t, fiber.StatusUnauthorized, status)
+	})
+
+	t.Run("over limit key", func(t *testing.T) {
+		srv := newApiKeysServiceMock(&apikeys.ApiKeyDoc{ID: "key1", Enabled: true})
+		app := newApiKeysTestApp(srv, cfg)
+
+		for i := 0; i < cfg.Burst; i++ {
+			status, _, _ := doRequest(t, app, "/api/v1/vaas", "key1", publicIp)
+			assert.Equal(t, fiber.StatusOK, status)
+		}
+		status, _, headers := doRequest(t, app, "/api/v1/vaas", "key1", publicIp)
+		assert.Equal(t, fiber.StatusTooManyRequests, status)
+		assert.Equal(t, "0", headers[headerRateLimitRemaining])
+		assert.Equal(t, "1", headers[fiber.HeaderRetryAfter])
+		assert.Equal(t, 3, srv.requests["key1"])
+		assert.Equal(t, 1, srv.throttled["key1"])
+	})
+
+	t.Run("over limit ip", func(t *testing.T) {
+		app := newApiKeysTestApp(newApiKeysServiceMock(), cfg)
+
+		status, _, _ := doRequest(t, app, "/api/v1/vaas", "", publicIp)
+		assert.Equal(t, fiber.StatusOK, status)
+		status, _, _ = doRequest(t, app, "/api/v1/vaas", "", publicIp)
+		assert.Equal(t, fiber.StatusTooManyRequests, status)
+		// other ips have their own limit.
+		status, _, _ = doRequest(t, app, "/api/v1/vaas", "", "8.8.4.4")
+		assert.Equal(t, fiber.StatusOK, status)
+	})
+}
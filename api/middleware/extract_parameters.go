@@ -496,3 +496,31 @@ func ExtractTopCorridorsTimeSpan(ctx *fiber.Ctx) (*stats.TopCorridorsTimeSpan, e
 
 	return timeSpan, nil
 }
+
+func ExtractLatencyTimeSpan(ctx *fiber.Ctx) (*stats.LatencyTimeSpan, error) {
+	defaultTimeSpan := stats.TimeSpan7DaysLatency
+	s := ctx.Query("timeSpan")
+	if s == "" {
+		return &defaultTimeSpan, nil
+	}
+	timeSpan, err := stats.ParseLatencyTimeSpan(s)
+	if err != nil {
+		return nil, response.NewInvalidQueryParamError(ctx, "INVALID <timeSpan> QUERY PARAMETER", nil)
+	}
+
+	return timeSpan, nil
+}
+
+func ExtractLatencyStage(ctx *fiber.Ctx) (*stats.LatencyStage, error) {
+	defaultStage := stats.LatencyStageTotal
+	s := ctx.Query("stage")
+	if s == "" {
+		return &defaultStage, nil
+	}
+	stage, err := stats.ParseLatencyStage(s)
+	if err != nil {
+		return nil, response.NewInvalidQueryParamError(ctx, "INVALID <stage> QUERY PARAMETER", nil)
+	}
+
+	return stage, nil
+}
@@ -163,3 +163,30 @@ func NewRequestBodyError(ctx *fiber.Ctx, message string, err error) APIError {
 		Details:    []ErrorDetail{detail},
 	}
 }
+
+// NewUnauthorizedError create a new APIError for requests without valid credentials.
+func NewUnauthorizedError(ctx *fiber.Ctx, message string) APIError {
+	if message == "" {
+		message = "UNAUTHENTICATED"
+	}
+	return APIError{
+		StatusCode: fiber.StatusUnauthorized,
+		Code:       Unauthenticated,
+		Message:    message,
+		Details: []ErrorDetail{{
+			RequestID: fmt.Sprintf("%v", ctx.Locals("requestid")),
+		}},
+	}
+}
+
+// NewTooManyRequestsError create a new APIError for requests that exceeded the rate limit.
+func NewTooManyRequestsError(ctx *fiber.Ctx) APIError {
+	return APIError{
+		StatusCode: fiber.StatusTooManyRequests,
+		Code:       ResourceExhausted,
+		Message:    "TOO MANY REQUESTS",
+		Details: []ErrorDetail{{
+			RequestID: fmt.Sprintf("%v", ctx.Locals("requestid")),
+		}},
+	}
+}
@@ -0,0 +1,100 @@
+// Package apikeys handle the request of the api keys admin endpoints defined in the api.
+package apikeys
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/apikeys"
+	"github.com/wormhole-foundation/wormhole-explorer/api/middleware"
+	"github.com/wormhole-foundation/wormhole-explorer/api/response"
+	"go.uber.org/zap"
+)
+
+// headerAdminToken is the header used to send the token of the admin endpoints.
+const headerAdminToken = "X-ADMIN-TOKEN"
+
+// usageDateLayout is the layout of the from and to query parameters.
+const usageDateLayout = "2006-01-02"
+
+// Controller definition.
+type Controller struct {
+	srv    *apikeys.Service
+	logger *zap.Logger
+}
+
+// NewController create a new controler.
+func NewController(srv *apikeys.Service, logger *zap.Logger) *Controller {
+	return &Controller{
+		srv:    srv,
+		logger: logger.With(zap.String("module", "ApiKeysController")),
+	}
+}
+
+// Authorize checks the admin token of the request.
+func (c *Controller) Authorize(ctx *fiber.Ctx) error {
+	if !c.srv.IsAdmin(ctx.Get(headerAdminToken)) {
+		return response.NewUnauthorizedError(ctx, "INVALID ADMIN TOKEN")
+	}
+	return ctx.Next()
+}
+
+// FindApiKeys godoc
+// @Description Returns the api keys and their rate limits. Requires the X-ADMIN-TOKEN header.
+// @Tags wormholescan
+// @ID find-api-keys
+// @Success 200 {object} []apikeys.ApiKeyDoc
+// @Failure 401
+// @Failure 500
+// @Router /api/v1/admin/api-keys [get]
+func (c *Controller) FindApiKeys(ctx *fiber.Ctx) error {
+	keys, err := c.srv.FindApiKeys(ctx.Context())
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(keys)
+}
+
+// FindUsage godoc
+// @Description Returns the daily usage counters of the api keys. Requires the X-ADMIN-TOKEN header.
+// @Tags wormholescan
+// @ID find-api-keys-usage
+// @Param apiKey query string false "api key to filter the usage."
+// @Param from query string false "first day of the usage (format 2006-01-02). Default 30 days ago."
+// @Param to query string false "last day of the usage (format 2006-01-02). Default today."
+// @Success 200 {object} []apikeys.UsageDoc
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /api/v1/admin/api-keys/usage [get]
+func (c *Controller) FindUsage(ctx *fiber.Ctx) error {
+	from, err := middleware.ExtractTime(ctx, usageDateLayout, "from")
+	if err != nil {
+		return err
+	}
+	to, err := middleware.ExtractTime(ctx, usageDateLayout, "to")
+	if err != nil {
+		return err
+	}
+
+	q := &apikeys.UsageQuery{
+		ApiKey: ctx.Query("apiKey"),
+		To:     time.Now(),
+	}
+	if to != nil {
+		q.To = *to
+	}
+	q.From = q.To.AddDate(0, 0, -30)
+	if from != nil {
+		q.From = *from
+	}
+	if q.From.After(q.To) {
+		return response.NewInvalidQueryParamError(ctx, "INVALID <from> QUERY PARAMETER, MUST BE BEFORE <to>", nil)
+	}
+
+	usage, err := c.srv.FindUsage(ctx.Context(), q)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(usage)
+}
@@ -3,12 +3,16 @@ package governor
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
 	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/governor"
 	"github.com/wormhole-foundation/wormhole-explorer/api/middleware"
 	"github.com/wormhole-foundation/wormhole-explorer/api/response"
 	_ "github.com/wormhole-foundation/wormhole-explorer/api/response" // needed by swaggo docs
+	"github.com/wormhole-foundation/wormhole-explorer/common/types"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
 )
 
@@ -434,3 +438,76 @@ func (c *Controller) GetGovernorVaas(ctx *fiber.Ctx) error {
 
 	return ctx.JSON(result)
 }
+
+// GetAvailableNotionalHistoryByChainID godoc
+// @Description Returns the available notional time series for a given blockchain.
+// @Tags wormholescan
+// @ID governor-notional-available-history-by-chain
+// @Param chain path integer true "id of the blockchain"
+// @Param timeSpan query string false "Time span, supported values: 1d, 1w and 1mo (default is 1d)."
+// @Success 200 {object} governor.AvailableNotionalHistory
+// @Failure 400
+// @Failure 500
+// @Router /api/v1/governor/notional/available/{chain}/history [get]
+func (c *Controller) GetAvailableNotionalHistoryByChainID(ctx *fiber.Ctx) error {
+	chainID, err := middleware.ExtractChainID(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+	timeSpan, err := middleware.ExtractTimeSpan(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+	history, err := c.srv.GetAvailableNotionalHistory(ctx.Context(), chainID, timeSpan)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(history)
+}
+
+// SimulateTransfer godoc
+// @Description Predicts whether a token transfer would be delayed by the governor and for roughly how long,
+// @Description based on the governor token list and the current usage of the source chain.
+// @Tags wormholescan
+// @ID governor-simulate-transfer
+// @Param chain query integer true "id of the source blockchain"
+// @Param tokenChain query integer true "id of the origin blockchain of the token"
+// @Param tokenAddress query string true "origin address of the token"
+// @Param amount query number true "amount of tokens to transfer"
+// @Success 200 {object} governor.TransferSimulation
+// @Failure 400
+// @Failure 500
+// @Router /api/v1/governor/simulate [get]
+func (c *Controller) SimulateTransfer(ctx *fiber.Ctx) error {
+	chainID, err := c.parseChainQueryParam(ctx, "chain")
+	if err != nil {
+		return err
+	}
+	tokenChainID, err := c.parseChainQueryParam(ctx, "tokenChain")
+	if err != nil {
+		return err
+	}
+	tokenAddress, err := types.StringToAddress(ctx.Query("tokenAddress"), true)
+	if err != nil {
+		return response.NewInvalidQueryParamError(ctx, "INVALID <tokenAddress> QUERY PARAMETER", errors.WithStack(err))
+	}
+	amount, err := strconv.ParseFloat(ctx.Query("amount"), 64)
+	if err != nil || amount <= 0 {
+		return response.NewInvalidQueryParamError(ctx, "INVALID <amount> QUERY PARAMETER", errors.WithStack(err))
+	}
+
+	simulation, err := c.srv.SimulateTransfer(ctx.Context(), chainID, tokenChainID, tokenAddress, amount)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(simulation)
+}
+
+func (c *Controller) parseChainQueryParam(ctx *fiber.Ctx, queryParam string) (sdk.ChainID, error) {
+	chain, err := strconv.ParseUint(ctx.Query(queryParam), 10, 16)
+	if err != nil {
+		return sdk.ChainIDUnset, response.NewInvalidQueryParamError(ctx,
+			fmt.Sprintf("INVALID <%s> QUERY PARAMETER", queryParam), errors.WithStack(err))
+	}
+	return sdk.ChainID(chain), nil
+}
@@ -205,3 +205,49 @@ func (c *Controller) FindOne(ctx *fiber.Ctx) error {
 	}
 	return ctx.JSON(obs)
 }
+
+// FindSigningTimeline godoc
+// @Description Returns the signing timeline of a VAA: the time at which each guardian of the guardian set signed it,
+// @Description the signing latency of each guardian since the first observation and the time at which quorum was reached.
+// @Tags wormholescan
+// @ID find-observations-signing-timeline
+// @Param chain_id path integer true "id of the blockchain"
+// @Param emitter path string true "address of the emitter"
+// @Param seq path integer true "sequence of the VAA"
+// @Success 200 {object} observations.SigningTimeline
+// @Failure 400
+// @Failure 404
+// @Failure 500
+// @Router /api/v1/observations/{chain_id}/{emitter}/{seq}/timeline [get]
+func (c *Controller) FindSigningTimeline(ctx *fiber.Ctx) error {
+	chainID, addr, seq, err := middleware.ExtractVAAParams(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+	timeline, err := c.srv.FindSigningTimeline(ctx.Context(), chainID, addr, strconv.FormatUint(seq, 10))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(timeline)
+}
+
+// GetGuardiansPerformance godoc
+// @Description Returns the signing rate and the median signing latency of each guardian of the current guardian set.
+// @Tags wormholescan
+// @ID get-guardians-performance
+// @Param timeSpan query string false "Time span, supported values: 1d, 1w and 1mo (default is 1d)."
+// @Success 200 {object} observations.GuardiansPerformance
+// @Failure 400
+// @Failure 500
+// @Router /api/v1/guardians/performance [get]
+func (c *Controller) GetGuardiansPerformance(ctx *fiber.Ctx) error {
+	timeSpan, err := middleware.ExtractTimeSpan(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+	performance, err := c.srv.GetGuardiansPerformance(ctx.Context(), timeSpan)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(performance)
+}
@@ -145,3 +145,29 @@ func (c *Controller) FindById(ctx *fiber.Ctx) error {
 	}
 	return ctx.JSON(response)
 }
+
+// FindStatusById godoc
+// @Description Find the lifecycle status of an operation by ID (chainID/emitter/sequence), with an estimated completion time based on the historical completion times of its chain pair.
+// @Tags wormholescan
+// @ID get-operation-status-by-id
+// @Param chain_id path integer true "id of the blockchain"
+// @Param emitter path string true "address of the emitter"
+// @Param seq path integer true "sequence of the VAA"
+// @Success 200 {object} operations.OperationStatus
+// @Failure 400
+// @Failure 404
+// @Failure 500
+// @Router /api/v1/operations/{chain_id}/{emitter}/{seq}/status [get]
+func (c *Controller) FindStatusById(ctx *fiber.Ctx) error {
+	// Extract query params
+	chainID, emitter, seq, err := middleware.ExtractVAAParams(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+
+	status, err := c.srv.FindStatusById(ctx.Context(), chainID, emitter, strconv.FormatUint(seq, 10))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(status)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/gofiber/fiber/v2"
 	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/protocols"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	"go.uber.org/zap"
 )
 
@@ -13,6 +14,7 @@ type Controller struct {
 }
 
 type service interface {
+	GetProtocols() *domain.ProtocolRegistry
 	GetProtocolsTotalValues(ctx context.Context) []protocols.ProtocolTotalValuesDTO
 }
 
@@ -43,3 +45,14 @@ func (c *Controller) GetProtocolsTotalValues(ctx *fiber.Ctx) error {
 	}
 	return err
 }
+
+// GetProtocols godoc
+// @Description Returns the registry of the protocols built on top of wormhole, with the emitters of each protocol.
+// @Tags wormholescan
+// @ID get-protocols
+// @Success 200 {object} domain.ProtocolRegistry
+// @Failure 500
+// @Router /api/v1/protocols [get]
+func (c *Controller) GetProtocols(ctx *fiber.Ctx) error {
+	return ctx.JSON(c.srv.GetProtocols())
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/valyala/fasthttp"
 	contributorsHandlerPkg "github.com/wormhole-foundation/wormhole-explorer/api/handlers/protocols"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/protocols"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	"go.uber.org/zap"
 	"net/http"
 	"testing"
@@ -68,3 +69,20 @@ type mockService func(ctx context.Context) []contributorsHandlerPkg.ProtocolTota
 func (m mockService) GetProtocolsTotalValues(ctx context.Context) []contributorsHandlerPkg.ProtocolTotalValuesDTO {
 	return m(ctx)
 }
+
+func (m mockService) GetProtocols() *domain.ProtocolRegistry {
+	return domain.NewProtocolRegistry(domain.P2pMainNet)
+}
+
+func TestGetProtocols(t *testing.T) {
+
+	app := fiber.New()
+	defer app.Shutdown()
+	c := app.AcquireCtx(&fasthttp.RequestCtx{})
+
+	controller := protocols.NewController(zap.NewNop(), mockService(nil))
+	err := controller.GetProtocols(c)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, c.Response().StatusCode())
+	assert.Contains(t, string(c.Response().Body()), "\"appId\":\"CCTP_WORMHOLE_INTEGRATION\"")
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/cache"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	addrsvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/address"
+	apikeyssvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/apikeys"
 	govsvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/governor"
 	infrasvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/infrastructure"
 	obssvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/observations"
@@ -14,9 +15,11 @@ import (
 	protocolssvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/protocols"
 	relayssvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/relays"
 	statssvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/stats"
+	tokenssvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/tokens"
 	trxsvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/transactions"
 	vaasvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/vaa"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/address"
+	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/apikeys"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/governor"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/infrastructure"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/observations"
@@ -24,6 +27,7 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/protocols"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/relays"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/stats"
+	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/tokens"
 
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/transactions"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/vaa"
@@ -54,6 +58,8 @@ func RegisterRoutes(
 	operationsService *opsvc.Service,
 	statsService *statssvc.Service,
 	protocolsService *protocolssvc.Service,
+	apiKeysService *apikeyssvc.Service,
+	tokensService *tokenssvc.Service,
 ) {
 
 	// Set up controllers
@@ -67,6 +73,8 @@ func RegisterRoutes(
 	opsCtrl := operations.NewController(operationsService, rootLogger)
 	statsCtrl := stats.NewController(statsService, rootLogger)
 	contributorsCtrl := protocols.NewController(rootLogger, protocolsService)
+	apiKeysCtrl := apikeys.NewController(apiKeysService, rootLogger)
+	tokensCtrl := tokens.NewController(tokensService, rootLogger)
 
 	// Set up route handlers
 	api := app.Group("/api/v1")
@@ -96,12 +104,16 @@ func RegisterRoutes(
 	// stats custom endpoints
 	api.Get("/top-symbols-by-volume", statsCtrl.GetTopSymbolsByVolume)
 	api.Get("/top-100-corridors", statsCtrl.GetTopCorridors)
+	api.Get("/corridors/latency", statsCtrl.GetCorridorLatencies)
+	api.Get("/fees", statsCtrl.GetFees)
+	api.Get("/protocols", contributorsCtrl.GetProtocols)
 	api.Get("/protocols/stats", contributorsCtrl.GetProtocolsTotalValues)
 
 	// operations resource
 	operations := api.Group("/operations")
 	operations.Get("/", opsCtrl.FindAll)
 	operations.Get("/:chain/:emitter/:sequence", opsCtrl.FindById)
+	operations.Get("/:chain/:emitter/:sequence/status", opsCtrl.FindStatusById)
 
 	// vaas resource
 	vaas := api.Group("/vaas")
@@ -120,6 +132,7 @@ func RegisterRoutes(
 	observations.Get("/:chain", observationsCtrl.FindAllByChain)
 	observations.Get("/:chain/:emitter", observationsCtrl.FindAllByEmitter)
 	observations.Get("/:chain/:emitter/:sequence", observationsCtrl.FindAllByVAA)
+	observations.Get("/:chain/:emitter/:sequence/timeline", observationsCtrl.FindSigningTimeline)
 	observations.Get("/:chain/:emitter/:sequence/:signer/:hash", observationsCtrl.FindOne)
 
 	// governor resources
@@ -140,13 +153,26 @@ func RegisterRoutes(
 	governorNotional.Get("/limit/:chain", governorCtrl.GetNotionalLimitByChainID)
 	governorNotional.Get("/available/", governorCtrl.GetAvailableNotional)
 	governorNotional.Get("/available/:chain", governorCtrl.GetAvailableNotionalByChainID)
+	governorNotional.Get("/available/:chain/history", governorCtrl.GetAvailableNotionalHistoryByChainID)
 	governorNotional.Get("/max_available/:chain", governorCtrl.GetMaxNotionalAvailableByChainID)
 
 	enqueueVaas := governor.Group("/enqueued_vaas")
 	enqueueVaas.Get("/", governorCtrl.GetEnqueuedVaas)
 	enqueueVaas.Get("/:chain", governorCtrl.GetEnqueuedVaasByChainID)
 	governor.Get("/vaas", governorCtrl.GetGovernorVaas)
+	governor.Get("/simulate", governorCtrl.SimulateTransfer)
+
+	// guardians resources
+	api.Get("/guardians/performance", observationsCtrl.GetGuardiansPerformance)
 
 	relays := api.Group("/relays")
 	relays.Get("/:chain/:emitter/:sequence", relaysCtrl.FindOne)
+
+	// admin resources
+	admin := api.Group("/admin", apiKeysCtrl.Authorize)
+	admin.Get("/api-keys", apiKeysCtrl.FindApiKeys)
+	admin.Get("/api-keys/usage", apiKeysCtrl.FindUsage)
+	admin.Get("/unknown-tokens", tokensCtrl.FindUnknownTokens)
+	admin.Put("/unknown-tokens/:chain/:token_address/mapping", tokensCtrl.MapToken)
+	admin.Get("/token-mappings", tokensCtrl.FindTokenMappings)
 }
@@ -142,3 +142,115 @@ func createTop100CorridorsResult(corridors []stats.TopCorridorsDTO) []*TopCorrid
 	}
 	return result
 }
+
+// GetCorridorLatencies godoc
+// @Description Returns the latency percentiles (p50, p90 and p99) in milliseconds of the cross-chain corridors.
+// @Description The latency is measured from the redeemed VAAs.
+// @Tags wormholescan
+// @ID /api/v1/corridors/latency
+// @Param timeSpan query string false "Time span, supported values: 1d, 7d and 30d (default is 7d)."
+// @Param stage query string false "Measured stage, supported values: total (source tx to redeem), finality (source tx to VAA), indexing (VAA to indexed) and redeem (VAA to redeem). Default is total."
+// @Param sourceChain query string false "Source chain id, several values separated by comma."
+// @Param targetChain query string false "Target chain id, several values separated by comma."
+// @Param appId query string false "Application id."
+// @Success 200 {object} stats.CorridorLatenciesResult
+// @Failure 400
+// @Failure 500
+// @Router /api/v1/corridors/latency [get]
+func (c *Controller) GetCorridorLatencies(ctx *fiber.Ctx) error {
+	timeSpan, err := middleware.ExtractLatencyTimeSpan(ctx)
+	if err != nil {
+		return err
+	}
+	stage, err := middleware.ExtractLatencyStage(ctx)
+	if err != nil {
+		return err
+	}
+	sourceChains, err := middleware.ExtractSourceChain(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+	targetChains, err := middleware.ExtractTargetChain(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+
+	q := &stats.CorridorLatencyQuery{
+		TimeSpan:     *timeSpan,
+		Stage:        *stage,
+		SourceChains: sourceChains,
+		TargetChains: targetChains,
+		AppID:        middleware.ExtractAppId(ctx, c.logger),
+	}
+
+	latencies, err := c.srv.GetCorridorLatencies(ctx.Context(), q)
+	if err != nil {
+		c.logger.Error("Error getting corridor latencies", zap.Error(err))
+		return err
+	}
+
+	result := make([]*CorridorLatency, 0, len(latencies))
+	for _, l := range latencies {
+		result = append(result, &CorridorLatency{
+			SourceChainID: l.SourceChainID,
+			TargetChainID: l.TargetChainID,
+			Count:         l.Count,
+			P50:           l.P50,
+			P90:           l.P90,
+			P99:           l.P99,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Count > result[j].Count
+	})
+
+	return ctx.JSON(CorridorLatenciesResult{Corridors: result})
+}
+
+// GetFees godoc
+// @Description Returns the fees in USD paid to the relayers by application and chain, for a defined time span and sample rate.
+// @Description The fees are valued with the notional price of the transferred token at the time the VAA was emitted.
+// @Tags wormholescan
+// @ID get-fees
+// @Param timeSpan query string false "Time Span, default: 1d, supported values: [1d, 1w, 1mo]. 1mo is 30 days."
+// @Param sampleRate query string false "Sample Rate, default: 1h, supported values: [1h, 1d]. Valid configurations with timeSpan: 1d/1h, 1w/1d, 1mo/1d"
+// @Param appId query string false "Application id."
+// @Success 200 {object} []stats.FeeResult
+// @Failure 400
+// @Failure 500
+// @Router /api/v1/fees [get]
+func (c *Controller) GetFees(ctx *fiber.Ctx) error {
+	timeSpan, sampleRate, err := middleware.ExtractTimeSpanAndSampleRate(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+
+	q := &stats.FeesQuery{
+		TimeSpan:   timeSpan,
+		SampleRate: sampleRate,
+		AppID:      middleware.ExtractAppId(ctx, c.logger),
+	}
+
+	fees, err := c.srv.GetFees(ctx.Context(), q)
+	if err != nil {
+		c.logger.Error("Error getting fees", zap.Error(err))
+		return err
+	}
+
+	return ctx.JSON(toFeeResults(fees))
+}
+
+// toFeeResults builds the response of the fees endpoint.
+func toFeeResults(fees []stats.FeeDTO) []FeeResult {
+	result := make([]FeeResult, 0, len(fees))
+	for _, f := range fees {
+		result = append(result, FeeResult{
+			Time:     f.Time,
+			AppID:    f.AppID,
+			FeeChain: f.FeeChain,
+			Relayer:  f.Relayer,
+			FeeUsd:   f.FeeUsd,
+		})
+	}
+	return result
+}
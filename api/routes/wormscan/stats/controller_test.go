@@ -0,0 +1,43 @@
+package stats
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/stats"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestToFeeResults(t *testing.T) {
+	window := time.Date(2024, 1, 4, 12, 0, 0, 0, time.UTC)
+
+	t.Run("no fees", func(t *testing.T) {
+		body, err := json.Marshal(toFeeResults(nil))
+		assert.NoError(t, err)
+		assert.Equal(t, "[]", string(body))
+	})
+
+	t.Run("fees", func(t *testing.T) {
+		fees := []stats.FeeDTO{
+			{
+				Time:     window,
+				AppID:    "PORTAL_TOKEN_BRIDGE",
+				FeeChain: sdk.ChainIDEthereum,
+				Relayer:  "0xrelayer",
+				FeeUsd:   decimal.New(123456789, -8),
+			},
+		}
+		body, err := json.Marshal(toFeeResults(fees))
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[{
+			"time": "2024-01-04T12:00:00Z",
+			"app_id": "PORTAL_TOKEN_BRIDGE",
+			"fee_chain": 2,
+			"relayer": "0xrelayer",
+			"fee_usd": "1.23456789"
+		}]`, string(body))
+	})
+}
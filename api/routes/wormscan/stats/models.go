@@ -1,6 +1,8 @@
 package stats
 
 import (
+	"time"
+
 	"github.com/shopspring/decimal"
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
@@ -35,3 +37,26 @@ type TopCorridor struct {
 	TokenAddress   string      `json:"token_address"`
 	Txs            uint64      `json:"txs"`
 }
+
+type CorridorLatenciesResult struct {
+	Corridors []*CorridorLatency `json:"corridors"`
+}
+
+// CorridorLatency contains the latency percentiles of a corridor in milliseconds.
+type CorridorLatency struct {
+	SourceChainID sdk.ChainID `json:"source_chain"`
+	TargetChainID sdk.ChainID `json:"target_chain"`
+	Count         uint64      `json:"count"`
+	P50           float64     `json:"p50"`
+	P90           float64     `json:"p90"`
+	P99           float64     `json:"p99"`
+}
+
+// FeeResult contains the fees in USD paid to a relayer of an application and chain in a time window.
+type FeeResult struct {
+	Time     time.Time       `json:"time"`
+	AppID    string          `json:"app_id"`
+	FeeChain sdk.ChainID     `json:"fee_chain"`
+	Relayer  string          `json:"relayer"`
+	FeeUsd   decimal.Decimal `json:"fee_usd"`
+}
@@ -0,0 +1,139 @@
+// Package tokens handle the request of the unknown tokens admin endpoints defined in the api.
+package tokens
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/tokens"
+	"github.com/wormhole-foundation/wormhole-explorer/api/middleware"
+	"github.com/wormhole-foundation/wormhole-explorer/api/response"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	"go.uber.org/zap"
+)
+
+// Controller definition.
+type Controller struct {
+	srv    *tokens.Service
+	logger *zap.Logger
+}
+
+// NewController create a new controler.
+func NewController(srv *tokens.Service, logger *zap.Logger) *Controller {
+	return &Controller{
+		srv:    srv,
+		logger: logger.With(zap.String("module", "TokensController")),
+	}
+}
+
+// MapTokenRequest is the body of the request to map an unknown token to a coingecko id.
+type MapTokenRequest struct {
+	CoingeckoID string `json:"coingeckoId"`
+	Symbol      string `json:"symbol"`
+	Decimals    int64  `json:"decimals"`
+}
+
+// FindUnknownTokens godoc
+// @Description Returns the tokens found in transfers that are not in the token list, sorted by number of transfers.
+// @Description Requires the X-ADMIN-TOKEN header.
+// @Tags wormholescan
+// @ID find-unknown-tokens
+// @Param status query string false "onboarding status of the tokens: pending, mapped or backfilled."
+// @Param page query integer false "Page number."
+// @Param pageSize query integer false "Number of elements per page."
+// @Success 200 {object} []repository.UnknownTokenDoc
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /api/v1/admin/unknown-tokens [get]
+func (c *Controller) FindUnknownTokens(ctx *fiber.Ctx) error {
+	p, err := middleware.ExtractPagination(ctx)
+	if err != nil {
+		return err
+	}
+
+	status := repository.UnknownTokenStatus(ctx.Query("status"))
+	switch status {
+	case "", repository.UnknownTokenStatusPending, repository.UnknownTokenStatusMapped, repository.UnknownTokenStatusBackfilled:
+	default:
+		return response.NewInvalidQueryParamError(ctx, "INVALID <status> QUERY PARAMETER", nil)
+	}
+
+	unknownTokens, err := c.srv.FindUnknownTokens(ctx.Context(), status, p)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(unknownTokens)
+}
+
+// FindTokenMappings godoc
+// @Description Returns the coingecko mappings of the unknown tokens. Requires the X-ADMIN-TOKEN header.
+// @Tags wormholescan
+// @ID find-token-mappings
+// @Success 200 {object} []repository.TokenMappingDoc
+// @Failure 401
+// @Failure 500
+// @Router /api/v1/admin/token-mappings [get]
+func (c *Controller) FindTokenMappings(ctx *fiber.Ctx) error {
+	mappings, err := c.srv.FindTokenMappings(ctx.Context())
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(mappings)
+}
+
+// MapToken godoc
+// @Description Maps an unknown token to a coingecko id. Requires the X-ADMIN-TOKEN header.
+// @Description The mapping is used to compute the volume of the new transfers of the token,
+// @Description the volume of the previous transfers is computed by the analytics unknown-tokens backfill.
+// @Tags wormholescan
+// @ID map-token
+// @Param chain path integer true "token chain id"
+// @Param token_address path string true "token address"
+// @Param request body MapTokenRequest true "coingecko id, symbol and decimals of the token"
+// @Success 200 {object} repository.TokenMappingDoc
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /api/v1/admin/unknown-tokens/{chain}/{token_address}/mapping [put]
+func (c *Controller) MapToken(ctx *fiber.Ctx) error {
+	chainID, err := middleware.ExtractChainID(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+	tokenAddress, err := middleware.ExtractTokenAddress(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+
+	var req MapTokenRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return response.NewRequestBodyError(ctx, "invalid token mapping request, unable to parse", errors.WithStack(err))
+	}
+	req.CoingeckoID = strings.TrimSpace(req.CoingeckoID)
+	req.Symbol = strings.TrimSpace(req.Symbol)
+	if req.CoingeckoID == "" || req.Symbol == "" {
+		return response.NewRequestBodyError(ctx, "invalid token mapping request, coingeckoId and symbol are required", nil)
+	}
+	if req.Decimals < 0 {
+		return response.NewRequestBodyError(ctx, "invalid token mapping request, decimals must be a non-negative integer", nil)
+	}
+	if c.srv.IsListed(chainID, tokenAddress.Hex()) {
+		return response.NewRequestBodyError(ctx, "invalid token mapping request, the token is already listed", nil)
+	}
+
+	mapping := repository.TokenMappingDoc{
+		ID:           repository.TokenID(chainID, tokenAddress.Hex()),
+		TokenChain:   chainID,
+		TokenAddress: tokenAddress.Hex(),
+		Symbol:       req.Symbol,
+		CoingeckoID:  req.CoingeckoID,
+		Decimals:     req.Decimals,
+	}
+	if err := c.srv.MapToken(ctx.Context(), &mapping); err != nil {
+		c.logger.Error("Error mapping token", zap.String("id", mapping.ID), zap.Error(err))
+		return err
+	}
+	return ctx.JSON(mapping)
+}
@@ -272,13 +272,13 @@ func (c *Controller) ParseVaa(ctx *fiber.Ctx) error {
 }
 
 // FindDuplicatedById godoc
-// @Description Find duplicated VAA by ID.
+// @Description Find duplicated VAA by ID, along with the history of the resolutions of the canonical VAA.
 // @Tags wormholescan
 // @ID find-duplicated-vaa-by-id
 // @Param chain_id path integer true "id of the blockchain"
 // @Param emitter path string true "address of the emitter"
 // @Param seq path integer true "sequence of the VAA"
-// @Success 200 {object} response.Response[[]vaa.VaaDoc]
+// @Success 200 {object} DuplicatedVaasResponse
 // @Failure 400
 // @Failure 500
 // @Router /api/v1/vaas/:chain_id/:emitter/:seq/duplicated [get]
@@ -299,6 +299,16 @@ func (c *Controller) FindDuplicatedById(ctx *fiber.Ctx) error {
 		return err
 	}
 
+	resolutions, err := c.srv.FindDuplicateVaaResolutions(
+		ctx.Context(),
+		chainID,
+		emitter,
+		strconv.FormatUint(seq, 10),
+	)
+	if err != nil {
+		return err
+	}
+
 	var duplicateVaas []DuplicateVaaResponse
 	for _, v := range vaas.Data {
 		duplicateVaas = append(duplicateVaas, DuplicateVaaResponse{
@@ -316,5 +326,5 @@ func (c *Controller) FindDuplicatedById(ctx *fiber.Ctx) error {
 			Digest:            v.Digest,
 		})
 	}
-	return ctx.JSON(response.Response[[]DuplicateVaaResponse]{Data: duplicateVaas})
+	return ctx.JSON(DuplicatedVaasResponse{Data: duplicateVaas, Resolutions: resolutions})
 }
@@ -3,6 +3,8 @@ package vaa
 import (
 	"time"
 
+	vaaHandler "github.com/wormhole-foundation/wormhole-explorer/api/handlers/vaa"
+	"github.com/wormhole-foundation/wormhole-explorer/api/response"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
 
@@ -20,3 +22,11 @@ type DuplicateVaaResponse struct {
 	IndexedAt         *time.Time  `json:"indexedAt"`
 	Digest            string      `json:"digest"`
 }
+
+// DuplicatedVaasResponse is the response of the duplicated VAA endpoint, the VAAs with the same ID
+// and the history of the resolutions that chose the VAA stored as the canonical one.
+type DuplicatedVaasResponse struct {
+	Data        []DuplicateVaaResponse               `json:"data"`
+	Resolutions []*vaaHandler.DuplicateVaaResolution `json:"resolutions"`
+	Pagination  response.ResponsePagination          `json:"pagination"`
+}
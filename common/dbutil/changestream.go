@@ -0,0 +1,262 @@
+package dbutil
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// server error codes returned when a change stream cannot be resumed from a resume token or an operation time.
+const (
+	errCodeInvalidResumeToken      = 260
+	errCodeChangeStreamFatalError  = 280
+	errCodeChangeStreamHistoryLost = 286
+)
+
+const (
+	defaultCheckpointInterval = 10 * time.Second
+	changeStreamRetryDelay    = 5 * time.Second
+	// gapFillMargin is subtracted from the last event time to cover the clock skew between
+	// the cluster time of the events and the indexedAt of the documents.
+	gapFillMargin = time.Minute
+)
+
+// ChangeStreamCheckpoint is the last position of a change stream consumer.
+type ChangeStreamCheckpoint struct {
+	ID          string   `bson:"_id"`
+	ResumeToken bson.Raw `bson:"resumeToken"`
+	// EventTime is the cluster time of the last event, it is used when the resume token is no longer valid.
+	EventTime primitive.Timestamp `bson:"eventTime"`
+	UpdatedAt time.Time           `bson:"updatedAt"`
+}
+
+// ChangeStreamHandler processes the full document of a change event.
+type ChangeStreamHandler func(ctx context.Context, doc bson.Raw)
+
+// ChangeStreamParams are the params of a resumable change stream.
+type ChangeStreamParams struct {
+	// ID identifies the consumer of the stream in the checkpoints collection.
+	ID       string
+	Pipeline []bson.D
+	// CheckpointInterval is the minimum interval between checkpoints of the resume token.
+	CheckpointInterval time.Duration
+	// GapFillCollection is scanned by indexedAt to get the documents inserted while the stream
+	// was stopped when it cannot be resumed, because the resume point is no longer in the oplog.
+	GapFillCollection string
+}
+
+// ResumableChangeStream is a database change stream that checkpoints its resume token, so that
+// it is resumed from the last processed event after a restart or a stream error.
+// The events are delivered at least once.
+type ResumableChangeStream struct {
+	db          *mongo.Database
+	checkpoints *mongo.Collection
+	params      ChangeStreamParams
+	handler     ChangeStreamHandler
+	logger      *zap.Logger
+	// position of the last processed event.
+	resumeToken    bson.Raw
+	eventTime      primitive.Timestamp
+	checkpointedAt time.Time
+}
+
+type changeEvent struct {
+	ClusterTime  primitive.Timestamp `bson:"clusterTime"`
+	FullDocument bson.Raw            `bson:"fullDocument"`
+}
+
+// NewResumableChangeStream creates a new resumable change stream.
+func NewResumableChangeStream(db *mongo.Database, params ChangeStreamParams, handler ChangeStreamHandler, logger *zap.Logger) *ResumableChangeStream {
+	if params.CheckpointInterval <= 0 {
+		params.CheckpointInterval = defaultCheckpointInterval
+	}
+	return &ResumableChangeStream{
+		db:          db,
+		checkpoints: db.Collection(repository.ChangeStreamCheckpoints),
+		params:      params,
+		handler:     handler,
+		logger:      logger.With(zap.String("changeStream", params.ID)),
+	}
+}
+
+// Start opens the change stream from the last checkpoint and consumes it in background until the context is done.
+func (s *ResumableChangeStream) Start(ctx context.Context) error {
+	if err := s.loadCheckpoint(ctx); err != nil {
+		return err
+	}
+	stream, err := s.open(ctx)
+	if err != nil {
+		return err
+	}
+	go s.run(ctx, stream)
+	return nil
+}
+
+func (s *ResumableChangeStream) run(ctx context.Context, stream *mongo.ChangeStream) {
+	for {
+		err := s.consume(ctx, stream)
+		if ctx.Err() != nil {
+			return
+		}
+		s.logger.Error("change stream stopped, restarting", zap.Error(err))
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(changeStreamRetryDelay):
+			}
+			stream, err = s.open(ctx)
+			if err == nil {
+				break
+			}
+			s.logger.Error("cannot restart change stream", zap.Error(err))
+		}
+	}
+}
+
+// consume processes the events of the stream until it fails or the context is done.
+func (s *ResumableChangeStream) consume(ctx context.Context, stream *mongo.ChangeStream) error {
+	defer stream.Close(context.Background())
+	// the last position is saved when the stream stops to resume it from there.
+	defer s.saveCheckpoint(context.Background())
+
+	for stream.Next(ctx) {
+		var e changeEvent
+		if err := stream.Decode(&e); err != nil {
+			s.logger.Error("Error unmarshalling change event", zap.Error(err))
+		} else {
+			s.handler(ctx, e.FullDocument)
+		}
+		s.resumeToken = stream.ResumeToken()
+		if !e.ClusterTime.IsZero() {
+			s.eventTime = e.ClusterTime
+		}
+		if time.Since(s.checkpointedAt) >= s.params.CheckpointInterval {
+			s.saveCheckpoint(ctx)
+		}
+	}
+	return stream.Err()
+}
+
+// open opens the change stream from the resume token. If the token is no longer valid, the stream
+// is opened from the time of the last event and, if that time is no longer in the oplog either,
+// it is opened from now and the documents inserted since the last event are scanned to fill the gap.
+func (s *ResumableChangeStream) open(ctx context.Context) (*mongo.ChangeStream, error) {
+	if s.resumeToken != nil {
+		stream, err := s.db.Watch(ctx, s.params.Pipeline, options.ChangeStream().SetResumeAfter(s.resumeToken))
+		if !isResumeError(err) {
+			return stream, err
+		}
+		s.logger.Warn("cannot resume change stream from resume token, resuming from the last event time",
+			zap.Time("eventTime", toTime(s.eventTime)), zap.Error(err))
+		s.resumeToken = nil
+	}
+
+	if s.eventTime.IsZero() {
+		// there is no previous position, the stream starts now.
+		return s.db.Watch(ctx, s.params.Pipeline)
+	}
+
+	eventTime := s.eventTime
+	stream, err := s.db.Watch(ctx, s.params.Pipeline, options.ChangeStream().SetStartAtOperationTime(&eventTime))
+	if !isResumeError(err) {
+		return stream, err
+	}
+
+	// the stream is opened before filling the gap, so the documents inserted meanwhile are not lost.
+	s.logger.Warn("cannot resume change stream from the last event time, filling the gap",
+		zap.Time("eventTime", toTime(eventTime)), zap.Error(err))
+	stream, err = s.db.Watch(ctx, s.params.Pipeline)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.fillGap(ctx, toTime(eventTime).Add(-gapFillMargin)); err != nil {
+		stream.Close(context.Background())
+		return nil, err
+	}
+	return stream, nil
+}
+
+// fillGap delivers the documents of the gap fill collection indexed since the given time.
+func (s *ResumableChangeStream) fillGap(ctx context.Context, from time.Time) error {
+	if s.params.GapFillCollection == "" {
+		s.logger.Warn("change stream gap not filled, events may be lost", zap.Time("from", from))
+		return nil
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "indexedAt", Value: 1}})
+	cur, err := s.db.Collection(s.params.GapFillCollection).Find(ctx, bson.M{"indexedAt": bson.M{"$gte": from}}, opts)
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	var count int
+	for cur.Next(ctx) {
+		s.handler(ctx, cur.Current)
+		count++
+	}
+	if err := cur.Err(); err != nil {
+		return err
+	}
+	s.logger.Info("change stream gap filled", zap.Time("from", from), zap.Int("documents", count))
+	return nil
+}
+
+func (s *ResumableChangeStream) loadCheckpoint(ctx context.Context) error {
+	var checkpoint ChangeStreamCheckpoint
+	err := s.checkpoints.FindOne(ctx, bson.M{"_id": s.params.ID}).Decode(&checkpoint)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		s.logger.Info("change stream checkpoint not found, starting from now")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	s.resumeToken = checkpoint.ResumeToken
+	s.eventTime = checkpoint.EventTime
+	s.logger.Info("resuming change stream from checkpoint", zap.Time("eventTime", toTime(s.eventTime)))
+	return nil
+}
+
+func (s *ResumableChangeStream) saveCheckpoint(ctx context.Context) {
+	if s.resumeToken == nil {
+		return
+	}
+	checkpoint := ChangeStreamCheckpoint{
+		ID:          s.params.ID,
+		ResumeToken: s.resumeToken,
+		EventTime:   s.eventTime,
+		UpdatedAt:   time.Now(),
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	_, err := s.checkpoints.ReplaceOne(ctx, bson.M{"_id": s.params.ID}, checkpoint, options.Replace().SetUpsert(true))
+	if err != nil {
+		s.logger.Error("cannot save change stream checkpoint", zap.Error(err))
+		return
+	}
+	s.checkpointedAt = checkpoint.UpdatedAt
+}
+
+// isResumeError checks if the error is returned because the stream cannot be resumed from the requested position.
+func isResumeError(err error) bool {
+	var serverErr mongo.ServerError
+	if !errors.As(err, &serverErr) {
+		return false
+	}
+	return serverErr.HasErrorCode(errCodeInvalidResumeToken) ||
+		serverErr.HasErrorCode(errCodeChangeStreamFatalError) ||
+		serverErr.HasErrorCode(errCodeChangeStreamHistoryLost)
+}
+
+func toTime(ts primitive.Timestamp) time.Time {
+	return time.Unix(int64(ts.T), 0)
+}
@@ -0,0 +1,31 @@
+package dbutil
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/test-go/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestIsResumeError(t *testing.T) {
+	var tests = []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "nil", err: nil, expected: false},
+		{name: "not a server error", err: errors.New("connection reset"), expected: false},
+		{name: "history lost", err: mongo.CommandError{Code: 286, Name: "ChangeStreamHistoryLost"}, expected: true},
+		{name: "invalid resume token", err: mongo.CommandError{Code: 260, Name: "InvalidResumeToken"}, expected: true},
+		{name: "change stream fatal error", err: mongo.CommandError{Code: 280, Name: "ChangeStreamFatalError"}, expected: true},
+		{name: "wrapped", err: fmt.Errorf("watch: %w", mongo.CommandError{Code: 286}), expected: true},
+		{name: "other server error", err: mongo.CommandError{Code: 11600, Name: "InterruptedAtShutdown"}, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isResumeError(tt.err))
+		})
+	}
+}
@@ -8,8 +8,12 @@ const (
 )
 
 const (
-	AppIdUnkonwn           = "UNKONWN"
-	AppIdPortalTokenBridge = "PORTAL_TOKEN_BRIDGE"
+	AppIdUnkonwn             = "UNKONWN"
+	AppIdPortalTokenBridge   = "PORTAL_TOKEN_BRIDGE"
+	AppIdPortalNftBridge     = "PORTAL_NFT_BRIDGE"
+	AppIdGenericRelayer      = "GENERIC_RELAYER"
+	AppIdCCTP                = "CCTP_WORMHOLE_INTEGRATION"
+	AppIdNativeTokenTransfer = "NATIVE_TOKEN_TRANSFER"
 )
 
 // SourceTxStatus is meant to be a user-facing enum that describes the status of the source transaction.
@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// payload type constants.
+const (
+	PayloadTypeTokenBridge    = "token_bridge"
+	PayloadTypeNftBridge      = "nft_bridge"
+	PayloadTypeGenericRelayer = "generic_relayer"
+	PayloadTypeCCTP           = "cctp"
+	PayloadTypeNTT            = "ntt"
+)
+
+//go:embed protocols/*.json
+var protocolFiles embed.FS
+
+// ProtocolEmitter is an emitter or contract of a protocol.
+type ProtocolEmitter struct {
+	ChainID sdk.ChainID `json:"chainId"`
+	// Address is the emitter address in wormhole format (32 bytes hex encoded without 0x prefix).
+	Address string `json:"address"`
+}
+
+// Protocol contains the information of a protocol built on top of wormhole.
+type Protocol struct {
+	Name        string `json:"name"`
+	AppID       string `json:"appId"`
+	PayloadType string `json:"payloadType"`
+	// Core is true for the protocols whose stats are computed by wormholescan.
+	Core     bool              `json:"core"`
+	Emitters []ProtocolEmitter `json:"emitters"`
+}
+
+// ProtocolRegistry maps the emitters of a p2p network to the protocols that own them.
+type ProtocolRegistry struct {
+	Version            int        `json:"version"`
+	Network            string     `json:"network"`
+	Protocols          []Protocol `json:"protocols"`
+	protocolsByEmitter map[string]*Protocol
+}
+
+// NewProtocolRegistry creates the protocol registry of a p2p network from the embedded registry files.
+func NewProtocolRegistry(p2pNetwork string) *ProtocolRegistry {
+	switch p2pNetwork {
+	case P2pMainNet, P2pTestNet, P2pDevNet:
+	default:
+		panic(fmt.Sprintf("unknown p2p network: %s", p2pNetwork))
+	}
+
+	data, err := protocolFiles.ReadFile(fmt.Sprintf("protocols/%s.json", p2pNetwork))
+	if err != nil {
+		panic(fmt.Sprintf("protocol registry not found for p2p network %s: %v", p2pNetwork, err))
+	}
+	registry, err := ParseProtocolRegistry(data)
+	if err != nil {
+		panic(fmt.Sprintf("invalid protocol registry for p2p network %s: %v", p2pNetwork, err))
+	}
+	return registry
+}
+
+// ParseProtocolRegistry creates a protocol registry from its JSON representation.
+func ParseProtocolRegistry(data []byte) (*ProtocolRegistry, error) {
+	var registry ProtocolRegistry
+	if err := json.Unmarshal(data, &registry); err != nil {
+		return nil, err
+	}
+
+	registry.protocolsByEmitter = make(map[string]*Protocol)
+	for i := range registry.Protocols {
+		p := &registry.Protocols[i]
+		for _, e := range p.Emitters {
+			key, err := makeEmitterID(e.ChainID, e.Address)
+			if err != nil {
+				return nil, fmt.Errorf("protocol %s has an invalid emitter address %s: %w", p.AppID, e.Address, err)
+			}
+			if other, ok := registry.protocolsByEmitter[key]; ok {
+				return nil, fmt.Errorf("emitter %s is registered by protocols %s and %s", key, other.AppID, p.AppID)
+			}
+			registry.protocolsByEmitter[key] = p
+		}
+	}
+	return &registry, nil
+}
+
+func makeEmitterID(chainID sdk.ChainID, address string) (string, error) {
+	addr, err := sdk.StringToAddress(address)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d/%s", chainID, addr.String()), nil
+}
+
+// GetProtocolByEmitter returns the protocol that owns an emitter.
+//
+// The address can be in wormhole format, with or without 0x prefix.
+func (r *ProtocolRegistry) GetProtocolByEmitter(chainID sdk.ChainID, address string) (*Protocol, bool) {
+	key, err := makeEmitterID(chainID, address)
+	if err != nil {
+		return nil, false
+	}
+	p, ok := r.protocolsByEmitter[key]
+	return p, ok
+}
+
+// GetProtocolByAppID returns the protocol with the given appId.
+func (r *ProtocolRegistry) GetProtocolByAppID(appID string) (*Protocol, bool) {
+	for i := range r.Protocols {
+		if r.Protocols[i].AppID == appID {
+			return &r.Protocols[i], true
+		}
+	}
+	return nil, false
+}
+
+// GetCoreAppIDs returns the appIds of the core protocols.
+func (r *ProtocolRegistry) GetCoreAppIDs() []string {
+	var appIDs []string
+	for _, p := range r.Protocols {
+		if p.Core {
+			appIDs = append(appIDs, p.AppID)
+		}
+	}
+	return appIDs
+}
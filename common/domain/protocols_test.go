@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/test-go/testify/assert"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestNewProtocolRegistry(t *testing.T) {
+	for _, network := range []string{P2pMainNet, P2pTestNet, P2pDevNet} {
+		registry := NewProtocolRegistry(network)
+		assert.Equal(t, network, registry.Network)
+		assert.Equal(t, 1, registry.Version)
+		_, ok := registry.GetProtocolByAppID(AppIdPortalTokenBridge)
+		assert.True(t, ok)
+	}
+}
+
+func TestGetProtocolByEmitter(t *testing.T) {
+	registry := NewProtocolRegistry(P2pMainNet)
+
+	p, ok := registry.GetProtocolByEmitter(sdk.ChainIDEthereum, "0x0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585")
+	assert.True(t, ok)
+	assert.Equal(t, AppIdPortalTokenBridge, p.AppID)
+	assert.Equal(t, PayloadTypeTokenBridge, p.PayloadType)
+
+	p, ok = registry.GetProtocolByEmitter(sdk.ChainIDBase, "00000000000000000000000003fabb06fa052557143dc28efcfc63fc12843f1d")
+	assert.True(t, ok)
+	assert.Equal(t, AppIdCCTP, p.AppID)
+
+	_, ok = registry.GetProtocolByEmitter(sdk.ChainIDSolana, "0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{AppIdPortalTokenBridge, AppIdCCTP}, registry.GetCoreAppIDs())
+}
+
+func TestParseProtocolRegistryDuplicatedEmitter(t *testing.T) {
+	data := []byte(`{"version": 1, "network": "mainnet", "protocols": [
+		{"appId": "A", "emitters": [{"chainId": 2, "address": "0x01"}]},
+		{"appId": "B", "emitters": [{"chainId": 2, "address": "0000000000000000000000000000000000000000000000000000000000000001"}]}
+	]}`)
+	_, err := ParseProtocolRegistry(data)
+	assert.Error(t, err)
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"fmt"
+	"sync"
 
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
@@ -29,6 +30,13 @@ type TokenProvider struct {
 	tokenMetadata              []TokenMetadata
 	tokenMetadataByContractID  map[string]*TokenMetadata
 	tokenMetadataByCoingeckoID map[string]*TokenMetadata
+
+	// mappedTokens contains the tokens that were mapped at runtime (e.g.: from the database).
+	// They never override the tokens of the static list.
+	mappedTokensLock          sync.RWMutex
+	mappedTokens              []TokenMetadata
+	mappedTokensByContractID  map[string]*TokenMetadata
+	mappedTokensByCoingeckoID map[string]*TokenMetadata
 }
 
 func (t *TokenMetadata) GetTokenID() string {
@@ -75,20 +83,61 @@ func NewTokenProvider(p2pNetwork string) *TokenProvider {
 	}
 }
 
+// SetMappedTokens replaces the tokens mapped at runtime.
+//
+// The tokens that already exist in the static list are ignored.
+func (t *TokenProvider) SetMappedTokens(tokens []TokenMetadata) {
+
+	mappedTokens := make([]TokenMetadata, 0, len(tokens))
+	for _, token := range tokens {
+		if _, ok := t.tokenMetadataByContractID[makeContractID(token.TokenChain, token.TokenAddress)]; ok {
+			continue
+		}
+		mappedTokens = append(mappedTokens, token)
+	}
+
+	byContractID := make(map[string]*TokenMetadata, len(mappedTokens))
+	byCoingeckoID := make(map[string]*TokenMetadata, len(mappedTokens))
+	for i := range mappedTokens {
+		byContractID[makeContractID(mappedTokens[i].TokenChain, mappedTokens[i].TokenAddress)] = &mappedTokens[i]
+		if mappedTokens[i].CoingeckoID != "" {
+			byCoingeckoID[mappedTokens[i].CoingeckoID] = &mappedTokens[i]
+		}
+	}
+
+	t.mappedTokensLock.Lock()
+	defer t.mappedTokensLock.Unlock()
+	t.mappedTokens = mappedTokens
+	t.mappedTokensByContractID = byContractID
+	t.mappedTokensByCoingeckoID = byCoingeckoID
+}
+
 // GetAllTokens returns a list of all tokens that exist in the database.
 //
 // The caller must not modify the `[]TokenMetadata` returned.
 func (t *TokenProvider) GetAllTokens() []TokenMetadata {
-	return t.tokenMetadata
+
+	t.mappedTokensLock.RLock()
+	defer t.mappedTokensLock.RUnlock()
+
+	if len(t.mappedTokens) == 0 {
+		return t.tokenMetadata
+	}
+
+	tokens := make([]TokenMetadata, 0, len(t.tokenMetadata)+len(t.mappedTokens))
+	tokens = append(tokens, t.tokenMetadata...)
+	return append(tokens, t.mappedTokens...)
 }
 
 // GetAllCoingeckoIDs returns a list of all coingecko IDs that exist in the database.
 func (t *TokenProvider) GetAllCoingeckoIDs() []string {
 
+	tokens := t.GetAllTokens()
+
 	// use a map to remove duplicates
-	uniqueIDs := make(map[string]bool, len(t.tokenMetadata))
-	for i := range t.tokenMetadata {
-		uniqueIDs[t.tokenMetadata[i].CoingeckoID] = true
+	uniqueIDs := make(map[string]bool, len(tokens))
+	for i := range tokens {
+		uniqueIDs[tokens[i].CoingeckoID] = true
 	}
 
 	// collect keys into a slice
@@ -107,7 +156,10 @@ func (t *TokenProvider) GetTokenByCoingeckoID(coingeckoID string) (*TokenMetadat
 
 	result, ok := t.tokenMetadataByCoingeckoID[coingeckoID]
 	if !ok {
-		return nil, false
+		t.mappedTokensLock.RLock()
+		defer t.mappedTokensLock.RUnlock()
+		result, ok = t.mappedTokensByCoingeckoID[coingeckoID]
+		return result, ok
 	}
 
 	return result, true
@@ -122,12 +174,21 @@ func (t *TokenProvider) GetTokenByAddress(tokenChain sdk.ChainID, tokenAddress s
 
 	result, ok := t.tokenMetadataByContractID[key]
 	if !ok {
-		return nil, false
+		t.mappedTokensLock.RLock()
+		defer t.mappedTokensLock.RUnlock()
+		result, ok = t.mappedTokensByContractID[key]
+		return result, ok
 	}
 
 	return result, true
 }
 
+// IsListed returns true when the token is in the static token list, the tokens mapped at runtime are not considered.
+func (t *TokenProvider) IsListed(tokenChain sdk.ChainID, tokenAddress string) bool {
+	_, ok := t.tokenMetadataByContractID[makeContractID(tokenChain, tokenAddress)]
+	return ok
+}
+
 func (t *TokenProvider) GetP2pNewtork() string {
 	return t.p2pNetwork
 }
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/test-go/testify/assert"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestSetMappedTokens(t *testing.T) {
+	provider := NewTokenProvider(P2pMainNet)
+	staticTokens := len(provider.GetAllTokens())
+	static := provider.GetAllTokens()[0]
+
+	mapped := TokenMetadata{
+		TokenChain:   sdk.ChainIDEthereum,
+		TokenAddress: "000000000000000000000000000000000000000000000000000000000000beef",
+		Symbol:       "BEEF",
+		CoingeckoID:  "beef-token",
+		Decimals:     18,
+	}
+	overridden := static
+	overridden.CoingeckoID = "overridden"
+
+	_, ok := provider.GetTokenByAddress(mapped.TokenChain, mapped.TokenAddress)
+	assert.False(t, ok)
+
+	provider.SetMappedTokens([]TokenMetadata{mapped, overridden})
+
+	token, ok := provider.GetTokenByAddress(mapped.TokenChain, mapped.TokenAddress)
+	assert.True(t, ok)
+	assert.Equal(t, mapped, *token)
+
+	token, ok = provider.GetTokenByCoingeckoID("beef-token")
+	assert.True(t, ok)
+	assert.Equal(t, mapped, *token)
+
+	// the static token list takes precedence over the mapped tokens
+	token, ok = provider.GetTokenByAddress(static.TokenChain, static.TokenAddress)
+	assert.True(t, ok)
+	assert.Equal(t, static.CoingeckoID, token.CoingeckoID)
+	_, ok = provider.GetTokenByCoingeckoID("overridden")
+	assert.False(t, ok)
+
+	assert.Equal(t, staticTokens+1, len(provider.GetAllTokens()))
+	assert.True(t, provider.IsListed(static.TokenChain, static.TokenAddress))
+	assert.False(t, provider.IsListed(mapped.TokenChain, mapped.TokenAddress))
+
+	provider.SetMappedTokens(nil)
+	_, ok = provider.GetTokenByAddress(mapped.TokenChain, mapped.TokenAddress)
+	assert.False(t, ok)
+	assert.Equal(t, staticTokens, len(provider.GetAllTokens()))
+}
@@ -11,6 +11,7 @@ const (
 	EvmTransactionFoundType = "evm-transaction-found"
 	TransferRedeemedType    = "transfer-redeemed"
 	EvmTransferRedeemedName = "transfer-redeemed"
+	GovernorVaaReleasedType = "governor-vaa-released"
 )
 
 type NotificationEvent struct {
@@ -38,7 +39,7 @@ func NewNotificationEvent[T EventData](trackID, source, _type string, data T) (*
 }
 
 type EventData interface {
-	SignedVaa | LogMessagePublished | EvmTransactionFound | TransferRedeemed
+	SignedVaa | LogMessagePublished | EvmTransactionFound | TransferRedeemed | GovernorVaaReleased
 }
 
 func GetEventData[T EventData](e *NotificationEvent) (T, error) {
@@ -114,3 +115,33 @@ type TransferRedeemedAttributes struct {
 	To             string `json:"to"`
 	Status         string `json:"status"`
 }
+
+// GovernorVaaReleased is the event of a VAA released by the governor after being enqueued.
+type GovernorVaaReleased struct {
+	ID             string `json:"id"`
+	EmitterChain   uint16 `json:"emitterChain"`
+	EmitterAddress string `json:"emitterAddress"`
+	Sequence       string `json:"sequence"`
+	TxHash         string `json:"txHash"`
+	// Amount is the notional value of the transfer in USD.
+	Amount uint64 `json:"amount"`
+	// ScheduledReleaseTime is the time the governor releases the VAA if it is not released before.
+	ScheduledReleaseTime time.Time  `json:"scheduledReleaseTime"`
+	EnqueuedAt           *time.Time `json:"enqueuedAt,omitempty"`
+	ReleasedAt           time.Time  `json:"releasedAt"`
+	// WaitSeconds is the time between the enqueue and the release.
+	WaitSeconds int64 `json:"waitSeconds,omitempty"`
+	// ReleaseSource is how the release was detected: a quorum of guardians released the VAA or the signed VAA was found.
+	ReleaseSource string                     `json:"releaseSource"`
+	Guardians     []GovernorVaaGuardianState `json:"guardians"`
+}
+
+// GovernorVaaGuardianState is the enqueue and release time of a VAA reported by a guardian.
+type GovernorVaaGuardianState struct {
+	Name       string     `json:"name"`
+	Address    string     `json:"address"`
+	EnqueuedAt *time.Time `json:"enqueuedAt,omitempty"`
+	ReleasedAt *time.Time `json:"releasedAt,omitempty"`
+	// ReleaseSkewMs is the delay of the guardian release from the first guardian release.
+	ReleaseSkewMs *int64 `json:"releaseSkewMs,omitempty"`
+}
@@ -175,3 +175,43 @@ func Test_GetTransferRedeemedPayload(t *testing.T) {
 	assert.Equal(t, "MethodRedeemTokensCCTP", etf.Attributes.Method)
 	assert.Equal(t, "completed", etf.Attributes.Status)
 }
+
+func Test_GetGovernorVaaReleasedPayload(t *testing.T) {
+
+	body := `{
+		"trackId": "governor-vaa-released-2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/123",
+		"source": "fly-event-processor",
+		"event": "governor-vaa-released",
+		"version": "1",
+		"data": {
+			"id": "2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/123",
+			"emitterChain": 2,
+			"emitterAddress": "0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585",
+			"sequence": "123",
+			"txHash": "0x3ad91ec530187bb2ce3b394d587878cd1e9e037a97e51fbc34af89b2e0719367",
+			"amount": 5000000,
+			"scheduledReleaseTime": "2024-05-02T10:00:00Z",
+			"enqueuedAt": "2024-05-01T10:00:00Z",
+			"releasedAt": "2024-05-02T10:00:05Z",
+			"waitSeconds": 86405,
+			"releaseSource": "quorum",
+			"guardians": [
+				{"name": "guardian-1", "address": "0x58CC3AE5C097b213cE3c81979e1B9f9570746AA5", "releasedAt": "2024-05-02T10:00:05Z", "releaseSkewMs": 0},
+				{"name": "guardian-2", "address": "0xfF6CB952589BDE862c25Ef4392132fb9D4A42157", "releasedAt": "2024-05-02T10:00:07Z", "releaseSkewMs": 2000}
+			]
+		}
+	}`
+
+	event := NotificationEvent{}
+	err := json.Unmarshal([]byte(body), &event)
+	assert.NoError(t, err)
+	assert.Equal(t, GovernorVaaReleasedType, event.Event)
+	released, err := GetEventData[GovernorVaaReleased](&event)
+	assert.NoError(t, err)
+	assert.Equal(t, "2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/123", released.ID)
+	assert.Equal(t, uint64(5000000), released.Amount)
+	assert.Equal(t, int64(86405), released.WaitSeconds)
+	assert.Equal(t, "quorum", released.ReleaseSource)
+	assert.Len(t, released.Guardians, 2)
+	assert.Equal(t, int64(2000), *released.Guardians[1].ReleaseSkewMs)
+}
@@ -1,12 +1,24 @@
 package repository
 
 const (
-	VaaIdTxHash      = "vaaIdTxHash"
-	TransferPrices   = "transferPrices"
-	Vaas             = "vaas"
-	DuplicateVaas    = "duplicateVaas"
-	GuardianSets     = "guardianSets"
-	NodeGovernorVaas = "nodeGovernorVaas"
-	GovernorVaas     = "governorVaas"
-	Observations     = "observations"
+	VaaIdTxHash             = "vaaIdTxHash"
+	TransferPrices          = "transferPrices"
+	Vaas                    = "vaas"
+	DuplicateVaas           = "duplicateVaas"
+	DuplicateVaaResolutions = "duplicateVaaResolutions"
+	GuardianSets            = "guardianSets"
+	NodeGovernorVaas        = "nodeGovernorVaas"
+	GovernorVaas            = "governorVaas"
+	GovernorVaaReleases     = "governorVaaReleases"
+	Observations            = "observations"
+	Heartbeats              = "heartbeats"
+	GovernorStatus          = "governorStatus"
+	GovernorStatusHistory   = "governorStatusHistory"
+	GlobalTransactions      = "globalTransactions"
+	ParsedVaa               = "parsedVaa"
+	UnknownTokens           = "unknownTokens"
+	UnknownTokenTransfers   = "unknownTokenTransfers"
+	TokenMappings           = "tokenMappings"
+	TxHashFixQueue          = "txHashFixQueue"
+	ChangeStreamCheckpoints = "changeStreamCheckpoints"
 )
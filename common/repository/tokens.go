@@ -0,0 +1,272 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// maxUnknownTokenSamples is the max number of sample VAA ids stored for an unknown token.
+const maxUnknownTokenSamples = 10
+
+// UnknownTokenStatus is the onboarding status of an unknown token.
+type UnknownTokenStatus string
+
+const (
+	// UnknownTokenStatusPending is the status of the tokens that are not mapped yet.
+	UnknownTokenStatusPending UnknownTokenStatus = "pending"
+	// UnknownTokenStatusMapped is the status of the tokens mapped to a coingecko id whose volume is not backfilled yet.
+	UnknownTokenStatusMapped UnknownTokenStatus = "mapped"
+	// UnknownTokenStatusBackfilled is the status of the tokens mapped to a coingecko id whose volume was backfilled.
+	UnknownTokenStatusBackfilled UnknownTokenStatus = "backfilled"
+)
+
+// UnknownTokenDoc is a token found in a transfer that is not in the token list.
+type UnknownTokenDoc struct {
+	ID           string             `bson:"_id" json:"id"`
+	TokenChain   sdk.ChainID        `bson:"tokenChain" json:"tokenChain"`
+	TokenAddress string             `bson:"tokenAddress" json:"tokenAddress"`
+	Status       UnknownTokenStatus `bson:"status" json:"status"`
+	Count        int64              `bson:"count" json:"count"`
+	SampleVaaIDs []string           `bson:"sampleVaaIds" json:"sampleVaaIds"`
+	FirstSeen    time.Time          `bson:"firstSeen" json:"firstSeen"`
+	LastSeen     time.Time          `bson:"lastSeen" json:"lastSeen"`
+	UpdatedAt    time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// UnknownTokenTransferDoc is a transfer of an unknown token whose volume was not computed.
+type UnknownTokenTransferDoc struct {
+	ID           string      `bson:"_id"`
+	TokenChain   sdk.ChainID `bson:"tokenChain"`
+	TokenAddress string      `bson:"tokenAddress"`
+	Timestamp    time.Time   `bson:"timestamp"`
+}
+
+// TokenMappingDoc maps a token that is not in the token list to a coingecko id.
+type TokenMappingDoc struct {
+	ID           string      `bson:"_id" json:"id"`
+	TokenChain   sdk.ChainID `bson:"tokenChain" json:"tokenChain"`
+	TokenAddress string      `bson:"tokenAddress" json:"tokenAddress"`
+	Symbol       string      `bson:"symbol" json:"symbol"`
+	CoingeckoID  string      `bson:"coingeckoId" json:"coingeckoId"`
+	Decimals     int64       `bson:"decimals" json:"decimals"`
+	CreatedAt    time.Time   `bson:"createdAt" json:"createdAt"`
+	UpdatedAt    time.Time   `bson:"updatedAt" json:"updatedAt"`
+}
+
+// ToTokenMetadata converts the mapping to the token metadata used by the token provider.
+func (d *TokenMappingDoc) ToTokenMetadata() domain.TokenMetadata {
+	return domain.TokenMetadata{
+		TokenChain:   d.TokenChain,
+		TokenAddress: d.TokenAddress,
+		Symbol:       domain.Symbol(d.Symbol),
+		CoingeckoID:  d.CoingeckoID,
+		Decimals:     d.Decimals,
+	}
+}
+
+// TokenID returns the id of the unknown token and token mapping documents.
+func TokenID(tokenChain sdk.ChainID, tokenAddress string) string {
+	return fmt.Sprintf("%d/%s", tokenChain, tokenAddress)
+}
+
+// TokenRepository is a repository for the unknown tokens and their mappings.
+type TokenRepository struct {
+	db          *mongo.Database
+	logger      *zap.Logger
+	collections struct {
+		unknownTokens         *mongo.Collection
+		unknownTokenTransfers *mongo.Collection
+		tokenMappings         *mongo.Collection
+	}
+}
+
+// NewTokenRepository create a new token repository.
+func NewTokenRepository(db *mongo.Database, logger *zap.Logger) *TokenRepository {
+	r := TokenRepository{db: db, logger: logger.With(zap.String("module", "TokenRepository"))}
+	r.collections.unknownTokens = db.Collection(UnknownTokens)
+	r.collections.unknownTokenTransfers = db.Collection(UnknownTokenTransfers)
+	r.collections.tokenMappings = db.Collection(TokenMappings)
+	return &r
+}
+
+// RecordUnknownToken registers a transfer of a token that is not in the token list.
+//
+// The token counter is only incremented the first time a transfer is recorded, so it is safe to
+// record the same VAA several times (e.g.: when a VAA is reprocessed).
+func (r *TokenRepository) RecordUnknownToken(ctx context.Context, tokenChain sdk.ChainID, tokenAddress, vaaID string, timestamp time.Time) error {
+
+	id := TokenID(tokenChain, tokenAddress)
+
+	transfer := bson.D{{Key: "$setOnInsert", Value: bson.D{
+		{Key: "tokenChain", Value: tokenChain},
+		{Key: "tokenAddress", Value: tokenAddress},
+		{Key: "timestamp", Value: timestamp},
+	}}}
+	result, err := r.collections.unknownTokenTransfers.UpdateByID(ctx, vaaID, transfer, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to record transfer %s of unknown token %s: %w", vaaID, id, err)
+	}
+	if result.UpsertedCount == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	update := bson.D{
+		{Key: "$setOnInsert", Value: bson.D{
+			{Key: "tokenChain", Value: tokenChain},
+			{Key: "tokenAddress", Value: tokenAddress},
+			{Key: "status", Value: UnknownTokenStatusPending},
+			{Key: "firstSeen", Value: now},
+		}},
+		{Key: "$set", Value: bson.D{
+			{Key: "lastSeen", Value: now},
+			{Key: "updatedAt", Value: now},
+		}},
+		{Key: "$inc", Value: bson.D{{Key: "count", Value: 1}}},
+		{Key: "$push", Value: bson.D{{Key: "sampleVaaIds", Value: bson.D{
+			{Key: "$each", Value: []string{vaaID}},
+			{Key: "$slice", Value: -maxUnknownTokenSamples},
+		}}}},
+	}
+	_, err = r.collections.unknownTokens.UpdateByID(ctx, id, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to record unknown token %s: %w", id, err)
+	}
+	return nil
+}
+
+// FindUnknownTokens returns the unknown tokens sorted by number of transfers.
+// When status is empty the tokens of every status are returned.
+func (r *TokenRepository) FindUnknownTokens(ctx context.Context, status UnknownTokenStatus, skip, limit int64) ([]UnknownTokenDoc, error) {
+	filter := bson.D{}
+	if status != "" {
+		filter = append(filter, bson.E{Key: "status", Value: status})
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}).
+		SetSkip(skip).
+		SetLimit(limit)
+	cur, err := r.collections.unknownTokens.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	tokens := []UnknownTokenDoc{}
+	if err := cur.All(ctx, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// UpdateUnknownTokenStatus updates the onboarding status of an unknown token.
+func (r *TokenRepository) UpdateUnknownTokenStatus(ctx context.Context, tokenChain sdk.ChainID, tokenAddress string, status UnknownTokenStatus) error {
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "status", Value: status},
+		{Key: "updatedAt", Value: time.Now()},
+	}}}
+	_, err := r.collections.unknownTokens.UpdateByID(ctx, TokenID(tokenChain, tokenAddress), update)
+	return err
+}
+
+// FindUnknownTokenTransfers returns a page of the transfers of an unknown token sorted by VAA id.
+func (r *TokenRepository) FindUnknownTokenTransfers(ctx context.Context, tokenChain sdk.ChainID, tokenAddress, lastID string, limit int64) ([]UnknownTokenTransferDoc, error) {
+	filter := bson.D{
+		{Key: "tokenChain", Value: tokenChain},
+		{Key: "tokenAddress", Value: tokenAddress},
+	}
+	if lastID != "" {
+		filter = append(filter, bson.E{Key: "_id", Value: bson.D{{Key: "$gt", Value: lastID}}})
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(limit)
+	cur, err := r.collections.unknownTokenTransfers.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var transfers []UnknownTokenTransferDoc
+	if err := cur.All(ctx, &transfers); err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
+
+// DeleteUnknownTokenTransfers deletes the transfers of an unknown token.
+func (r *TokenRepository) DeleteUnknownTokenTransfers(ctx context.Context, tokenChain sdk.ChainID, tokenAddress string) error {
+	filter := bson.D{
+		{Key: "tokenChain", Value: tokenChain},
+		{Key: "tokenAddress", Value: tokenAddress},
+	}
+	_, err := r.collections.unknownTokenTransfers.DeleteMany(ctx, filter)
+	return err
+}
+
+// FindTokenMappings returns all the token mappings.
+func (r *TokenRepository) FindTokenMappings(ctx context.Context) ([]TokenMappingDoc, error) {
+	cur, err := r.collections.tokenMappings.Find(ctx, bson.D{})
+	if err != nil {
+		return nil, err
+	}
+	mappings := []TokenMappingDoc{}
+	if err := cur.All(ctx, &mappings); err != nil {
+		return nil, err
+	}
+	return mappings, nil
+}
+
+// UpsertTokenMapping creates or updates a token mapping and marks the unknown token as mapped.
+func (r *TokenRepository) UpsertTokenMapping(ctx context.Context, mapping *TokenMappingDoc) error {
+	now := time.Now()
+	id := TokenID(mapping.TokenChain, mapping.TokenAddress)
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "tokenChain", Value: mapping.TokenChain},
+			{Key: "tokenAddress", Value: mapping.TokenAddress},
+			{Key: "symbol", Value: mapping.Symbol},
+			{Key: "coingeckoId", Value: mapping.CoingeckoID},
+			{Key: "decimals", Value: mapping.Decimals},
+			{Key: "updatedAt", Value: now},
+		}},
+		{Key: "$setOnInsert", Value: bson.D{{Key: "createdAt", Value: now}}},
+	}
+	_, err := r.collections.tokenMappings.UpdateByID(ctx, id, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to upsert token mapping %s: %w", id, err)
+	}
+	return r.UpdateUnknownTokenStatus(ctx, mapping.TokenChain, mapping.TokenAddress, UnknownTokenStatusMapped)
+}
+
+// LoadTokenMappings reads the token mappings and sets them in the token provider.
+func (r *TokenRepository) LoadTokenMappings(ctx context.Context, tokenProvider *domain.TokenProvider) error {
+	mappings, err := r.FindTokenMappings(ctx)
+	if err != nil {
+		return err
+	}
+	tokens := make([]domain.TokenMetadata, 0, len(mappings))
+	for i := range mappings {
+		tokens = append(tokens, mappings[i].ToTokenMetadata())
+	}
+	tokenProvider.SetMappedTokens(tokens)
+	return nil
+}
+
+// RefreshTokenMappings loads the token mappings into the token provider every interval until the context is done.
+func (r *TokenRepository) RefreshTokenMappings(ctx context.Context, tokenProvider *domain.TokenProvider, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := r.LoadTokenMappings(ctx, tokenProvider); err != nil {
+			r.logger.Error("Failed to load token mappings", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
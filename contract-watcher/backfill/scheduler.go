@@ -0,0 +1,316 @@
+package backfill
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/storage"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/watcher"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxChunks is the maximum number of chunks of a job, the chunk size is increased for larger block ranges.
+	maxChunks = 10_000
+	// defaultChunkSize is the chunk size used when the scheduler is created without one.
+	defaultChunkSize = 100
+)
+
+// backfill errors
+var (
+	ErrBlockchainNotFound = errors.New("blockchain not found")
+	ErrInvalidBlockRange  = errors.New("invalid block range")
+	ErrJobFinished        = errors.New("backfill job already finished")
+)
+
+// Repository is the storage used to persist the backfill jobs.
+type Repository interface {
+	InsertBackfillJob(ctx context.Context, job *storage.BackfillJob) error
+	GetBackfillJob(ctx context.Context, id string) (*storage.BackfillJob, error)
+	FindBackfillJobsByStatus(ctx context.Context, status string) ([]storage.BackfillJob, error)
+	CompleteBackfillChunk(ctx context.Context, id string, chunk int) error
+	FailBackfillChunk(ctx context.Context, id string, chunk int, cause string) error
+	UpdateBackfillJobStatus(ctx context.Context, id string, status string) error
+}
+
+// Request is a request to backfill a block range of a blockchain.
+type Request struct {
+	Blockchain string
+	FromBlock  uint64
+	ToBlock    uint64
+	// ChunkSize and Concurrency are optional, the scheduler defaults are used when they are zero.
+	ChunkSize   uint64
+	Concurrency int
+}
+
+// Scheduler runs backfill jobs. The block range of a job is split in chunks that are processed
+// concurrently, bounded by the concurrency of the blockchain, and the completed chunks are persisted
+// so that a job interrupted by a restart is resumed from the pending chunks. The chunks that cannot be
+// processed are recorded with their error and the job finishes with the failed status.
+type Scheduler struct {
+	ctx                     context.Context
+	watcherByBlockchain     map[string]watcher.ContractWatcher
+	concurrencyByBlockchain map[string]int
+	chunkSize               uint64
+	repository              Repository
+	logger                  *zap.Logger
+	mu                      sync.Mutex
+	cancelByJob             map[string]context.CancelFunc
+	wg                      sync.WaitGroup
+}
+
+// NewScheduler creates a backfill scheduler. The jobs run until they finish, are cancelled or the context is done.
+func NewScheduler(
+	ctx context.Context,
+	watchers []watcher.ContractWatcher,
+	concurrencyByBlockchain map[string]int,
+	chunkSize uint64,
+	repository Repository,
+	logger *zap.Logger,
+) *Scheduler {
+	watcherByBlockchain := make(map[string]watcher.ContractWatcher)
+	for _, w := range watchers {
+		watcherByBlockchain[w.GetBlockchain()] = w
+	}
+	if chunkSize == 0 {
+		chunkSize = defaultChunkSize
+	}
+	return &Scheduler{
+		ctx:                     ctx,
+		watcherByBlockchain:     watcherByBlockchain,
+		concurrencyByBlockchain: concurrencyByBlockchain,
+		chunkSize:               chunkSize,
+		repository:              repository,
+		logger:                  logger,
+		cancelByJob:             make(map[string]context.CancelFunc),
+	}
+}
+
+// Submit creates a backfill job and starts it.
+func (s *Scheduler) Submit(ctx context.Context, req Request) (*storage.BackfillJob, error) {
+	w, ok := s.watcherByBlockchain[req.Blockchain]
+	if !ok {
+		return nil, ErrBlockchainNotFound
+	}
+	if req.FromBlock > req.ToBlock {
+		return nil, ErrInvalidBlockRange
+	}
+
+	chunkSize := req.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = s.chunkSize
+	}
+	if blocks := req.ToBlock - req.FromBlock + 1; blocks > 0 && blocks/chunkSize >= maxChunks {
+		chunkSize = blocks/maxChunks + 1
+	}
+	concurrency := s.getConcurrency(req.Blockchain)
+	if req.Concurrency > 0 && req.Concurrency < concurrency {
+		concurrency = req.Concurrency
+	}
+
+	now := time.Now()
+	job := &storage.BackfillJob{
+		ID:          primitive.NewObjectID().Hex(),
+		Blockchain:  req.Blockchain,
+		FromBlock:   req.FromBlock,
+		ToBlock:     req.ToBlock,
+		ChunkSize:   chunkSize,
+		Concurrency: concurrency,
+		Status:      storage.BackfillJobStatusRunning,
+		Chunks:      newChunks(req.FromBlock, req.ToBlock, chunkSize),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.repository.InsertBackfillJob(ctx, job); err != nil {
+		return nil, err
+	}
+
+	s.start(w, job)
+	return job, nil
+}
+
+// Get returns a backfill job.
+func (s *Scheduler) Get(ctx context.Context, id string) (*storage.BackfillJob, error) {
+	return s.repository.GetBackfillJob(ctx, id)
+}
+
+// Cancel stops a running backfill job. The completed chunks are kept.
+func (s *Scheduler) Cancel(ctx context.Context, id string) (*storage.BackfillJob, error) {
+	job, err := s.repository.GetBackfillJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != storage.BackfillJobStatusRunning {
+		return job, ErrJobFinished
+	}
+
+	// the status is persisted before stopping the job so that it is not resumed.
+	if err := s.repository.UpdateBackfillJobStatus(ctx, id, storage.BackfillJobStatusCancelled); err != nil {
+		if errors.Is(err, storage.ErrDocNotFound) {
+			return job, ErrJobFinished
+		}
+		return nil, err
+	}
+	s.mu.Lock()
+	if cancel, ok := s.cancelByJob[id]; ok {
+		cancel()
+	}
+	s.mu.Unlock()
+
+	s.logger.Info("backfill job cancelled", zap.String("id", id))
+	return s.repository.GetBackfillJob(ctx, id)
+}
+
+// Resume restarts the running jobs interrupted by a previous shutdown.
+func (s *Scheduler) Resume(ctx context.Context) error {
+	jobs, err := s.repository.FindBackfillJobsByStatus(ctx, storage.BackfillJobStatusRunning)
+	if err != nil {
+		return err
+	}
+	for i := range jobs {
+		job := &jobs[i]
+		w, ok := s.watcherByBlockchain[job.Blockchain]
+		if !ok {
+			s.logger.Warn("cannot resume backfill job, blockchain not found",
+				zap.String("id", job.ID), zap.String("blockchain", job.Blockchain))
+			continue
+		}
+		s.logger.Info("resuming backfill job", zap.String("id", job.ID),
+			zap.Int("completedChunks", job.CompletedChunks), zap.Int("chunks", len(job.Chunks)))
+		s.start(w, job)
+	}
+	return nil
+}
+
+// Close stops the running jobs and waits for them. The jobs keep the running status to be resumed.
+func (s *Scheduler) Close() {
+	s.mu.Lock()
+	for _, cancel := range s.cancelByJob {
+		cancel()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+func (s *Scheduler) start(w watcher.ContractWatcher, job *storage.BackfillJob) {
+	ctx, cancel := context.WithCancel(s.ctx)
+	s.mu.Lock()
+	s.cancelByJob[job.ID] = cancel
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() {
+			s.mu.Lock()
+			delete(s.cancelByJob, job.ID)
+			s.mu.Unlock()
+			cancel()
+		}()
+		s.run(ctx, w, job)
+	}()
+}
+
+// run processes the pending chunks of a job with up to job.Concurrency workers.
+func (s *Scheduler) run(ctx context.Context, w watcher.ContractWatcher, job *storage.BackfillJob) {
+	logger := s.logger.With(zap.String("id", job.ID), zap.String("blockchain", job.Blockchain))
+	logger.Info("running backfill job", zap.Uint64("from", job.FromBlock), zap.Uint64("to", job.ToBlock),
+		zap.Int("chunks", len(job.Chunks)), zap.Int("concurrency", job.Concurrency))
+
+	var failed atomic.Int32
+	pending := make(chan int)
+	go func() {
+		defer close(pending)
+		for i, chunk := range job.Chunks {
+			if chunk.Completed {
+				continue
+			}
+			select {
+			case pending <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	workers := job.Concurrency
+	if workers < 1 {
+		workers = 1
+	}
+	var wg sync.WaitGroup
+	for n := 0; n < workers; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range pending {
+				chunk := job.Chunks[i]
+				err := w.Backfill(ctx, chunk.FromBlock, chunk.ToBlock, job.ChunkSize, false)
+				// a chunk interrupted by a cancellation is processed again when the job is resumed.
+				if ctx.Err() != nil {
+					return
+				}
+				if err != nil {
+					failed.Add(1)
+					logger.Error("cannot backfill chunk", zap.Int("chunk", i),
+						zap.Uint64("from", chunk.FromBlock), zap.Uint64("to", chunk.ToBlock), zap.Error(err))
+					if err := s.repository.FailBackfillChunk(ctx, job.ID, i, err.Error()); err != nil {
+						logger.Error("cannot save backfill chunk error", zap.Int("chunk", i), zap.Error(err))
+					}
+					continue
+				}
+				if err := s.repository.CompleteBackfillChunk(ctx, job.ID, i); err != nil {
+					logger.Error("cannot save backfill chunk progress", zap.Int("chunk", i), zap.Error(err))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		logger.Info("backfill job stopped")
+		return
+	}
+	status := storage.BackfillJobStatusCompleted
+	if failed.Load() > 0 {
+		status = storage.BackfillJobStatusFailed
+	}
+	if err := s.repository.UpdateBackfillJobStatus(s.ctx, job.ID, status); err != nil {
+		if errors.Is(err, storage.ErrDocNotFound) {
+			logger.Info("backfill job cancelled before completion")
+			return
+		}
+		logger.Error("cannot complete backfill job", zap.Error(err))
+		return
+	}
+	logger.Info("backfill job finished", zap.String("status", status), zap.Int32("failedChunks", failed.Load()))
+}
+
+func (s *Scheduler) getConcurrency(blockchain string) int {
+	if concurrency := s.concurrencyByBlockchain[blockchain]; concurrency > 0 {
+		return concurrency
+	}
+	return 1
+}
+
+// newChunks splits the block range [fromBlock, toBlock] in chunks of chunkSize blocks.
+func newChunks(fromBlock, toBlock, chunkSize uint64) []storage.BackfillChunk {
+	if chunkSize == 0 {
+		chunkSize = 1
+	}
+	chunks := make([]storage.BackfillChunk, 0, (toBlock-fromBlock)/chunkSize+1)
+	for from := fromBlock; from <= toBlock; from += chunkSize {
+		to := from + chunkSize - 1
+		if to > toBlock || to < from {
+			to = toBlock
+		}
+		chunks = append(chunks, storage.BackfillChunk{FromBlock: from, ToBlock: to})
+		if to == toBlock {
+			break
+		}
+	}
+	return chunks
+}
@@ -0,0 +1,278 @@
+package backfill
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/storage"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/watcher"
+	"go.uber.org/zap"
+)
+
+type fakeRepository struct {
+	mu   sync.Mutex
+	jobs map[string]*storage.BackfillJob
+}
+
+func newFakeRepository(jobs ...storage.BackfillJob) *fakeRepository {
+	r := &fakeRepository{jobs: make(map[string]*storage.BackfillJob)}
+	for i := range jobs {
+		r.jobs[jobs[i].ID] = &jobs[i]
+	}
+	return r
+}
+
+func (r *fakeRepository) InsertBackfillJob(_ context.Context, job *storage.BackfillJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	j := *job
+	j.Chunks = append([]storage.BackfillChunk{}, job.Chunks...)
+	r.jobs[job.ID] = &j
+	return nil
+}
+
+func (r *fakeRepository) GetBackfillJob(_ context.Context, id string) (*storage.BackfillJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, storage.ErrDocNotFound
+	}
+	j := *job
+	j.Chunks = append([]storage.BackfillChunk{}, job.Chunks...)
+	return &j, nil
+}
+
+func (r *fakeRepository) FindBackfillJobsByStatus(_ context.Context, status string) ([]storage.BackfillJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var result []storage.BackfillJob
+	for _, job := range r.jobs {
+		if job.Status == status {
+			j := *job
+			j.Chunks = append([]storage.BackfillChunk{}, job.Chunks...)
+			result = append(result, j)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeRepository) CompleteBackfillChunk(_ context.Context, id string, chunk int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.jobs[id]
+	if !job.Chunks[chunk].Completed {
+		job.Chunks[chunk].Completed = true
+		job.CompletedChunks++
+	}
+	return nil
+}
+
+func (r *fakeRepository) FailBackfillChunk(_ context.Context, id string, chunk int, cause string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.jobs[id]
+	if !job.Chunks[chunk].Completed {
+		job.Chunks[chunk].Error = cause
+	}
+	return nil
+}
+
+func (r *fakeRepository) UpdateBackfillJobStatus(_ context.Context, id string, status string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id]
+	if !ok || job.Status != storage.BackfillJobStatusRunning {
+		return storage.ErrDocNotFound
+	}
+	job.Status = status
+	return nil
+}
+
+type fakeWatcher struct {
+	mu      sync.Mutex
+	ranges  [][2]uint64
+	block   chan struct{}
+	running int
+	maxRun  int
+	// failFrom are the first blocks of the ranges that fail.
+	failFrom map[uint64]bool
+}
+
+func (w *fakeWatcher) GetBlockchain() string         { return "base" }
+func (w *fakeWatcher) Start(_ context.Context) error { return nil }
+func (w *fakeWatcher) Close()                        {}
+
+func (w *fakeWatcher) processed() [][2]uint64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.ranges
+}
+
+func (w *fakeWatcher) maxConcurrency() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.maxRun
+}
+
+func (w *fakeWatcher) Backfill(ctx context.Context, from, to, _ uint64, _ bool) error {
+	w.mu.Lock()
+	w.running++
+	if w.running > w.maxRun {
+		w.maxRun = w.running
+	}
+	w.mu.Unlock()
+
+	if w.block != nil {
+		select {
+		case <-w.block:
+		case <-ctx.Done():
+		}
+	} else {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.running--
+	if w.failFrom[from] {
+		return errors.New("cannot get logs")
+	}
+	if ctx.Err() == nil {
+		w.ranges = append(w.ranges, [2]uint64{from, to})
+	}
+	return nil
+}
+
+func waitForStatus(t *testing.T, s *Scheduler, id string, status string) *storage.BackfillJob {
+	var job *storage.BackfillJob
+	assert.Eventually(t, func() bool {
+		job, _ = s.Get(context.Background(), id)
+		return job != nil && job.Status == status
+	}, 2*time.Second, 10*time.Millisecond)
+	return job
+}
+
+func Test_newChunks(t *testing.T) {
+	chunks := newChunks(10, 35, 10)
+	assert.Equal(t, []storage.BackfillChunk{
+		{FromBlock: 10, ToBlock: 19},
+		{FromBlock: 20, ToBlock: 29},
+		{FromBlock: 30, ToBlock: 35},
+	}, chunks)
+
+	assert.Equal(t, []storage.BackfillChunk{{FromBlock: 5, ToBlock: 5}}, newChunks(5, 5, 100))
+}
+
+func TestScheduler_Submit(t *testing.T) {
+	w := &fakeWatcher{}
+	repo := newFakeRepository()
+	s := NewScheduler(context.Background(), []watcher.ContractWatcher{w}, map[string]int{"base": 3}, 10, repo, zap.NewNop())
+
+	job, err := s.Submit(context.Background(), Request{Blockchain: "base", FromBlock: 1, ToBlock: 100})
+	assert.Nil(t, err)
+	assert.Equal(t, 10, len(job.Chunks))
+	assert.Equal(t, 3, job.Concurrency)
+
+	job = waitForStatus(t, s, job.ID, storage.BackfillJobStatusCompleted)
+	assert.Equal(t, 10, job.CompletedChunks)
+	assert.Equal(t, 10, len(w.processed()))
+	assert.LessOrEqual(t, w.maxConcurrency(), 3)
+	s.Close()
+}
+
+func TestScheduler_SubmitFailedChunks(t *testing.T) {
+	w := &fakeWatcher{failFrom: map[uint64]bool{11: true, 31: true}}
+	repo := newFakeRepository()
+	s := NewScheduler(context.Background(), []watcher.ContractWatcher{w}, map[string]int{"base": 2}, 10, repo, zap.NewNop())
+
+	job, err := s.Submit(context.Background(), Request{Blockchain: "base", FromBlock: 1, ToBlock: 50})
+	assert.Nil(t, err)
+
+	job = waitForStatus(t, s, job.ID, storage.BackfillJobStatusFailed)
+	assert.Equal(t, 3, job.CompletedChunks)
+	for i, chunk := range job.Chunks {
+		failed := chunk.FromBlock == 11 || chunk.FromBlock == 31
+		assert.Equal(t, !failed, chunk.Completed, "chunk %d", i)
+		if failed {
+			assert.Equal(t, "cannot get logs", chunk.Error, "chunk %d", i)
+		} else {
+			assert.Empty(t, chunk.Error, "chunk %d", i)
+		}
+	}
+	s.Close()
+}
+
+func TestScheduler_SubmitDefaultChunkSize(t *testing.T) {
+	w := &fakeWatcher{}
+	s := NewScheduler(context.Background(), []watcher.ContractWatcher{w}, nil, 0, newFakeRepository(), zap.NewNop())
+
+	job, err := s.Submit(context.Background(), Request{Blockchain: "base", FromBlock: 1, ToBlock: 250})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(defaultChunkSize), job.ChunkSize)
+	assert.Equal(t, 3, len(job.Chunks))
+
+	waitForStatus(t, s, job.ID, storage.BackfillJobStatusCompleted)
+	s.Close()
+}
+
+func TestScheduler_SubmitErrors(t *testing.T) {
+	s := NewScheduler(context.Background(), []watcher.ContractWatcher{&fakeWatcher{}}, nil, 10, newFakeRepository(), zap.NewNop())
+
+	_, err := s.Submit(context.Background(), Request{Blockchain: "solana", FromBlock: 1, ToBlock: 10})
+	assert.ErrorIs(t, err, ErrBlockchainNotFound)
+
+	_, err = s.Submit(context.Background(), Request{Blockchain: "base", FromBlock: 10, ToBlock: 1})
+	assert.ErrorIs(t, err, ErrInvalidBlockRange)
+}
+
+func TestScheduler_Cancel(t *testing.T) {
+	w := &fakeWatcher{block: make(chan struct{})}
+	repo := newFakeRepository()
+	s := NewScheduler(context.Background(), []watcher.ContractWatcher{w}, map[string]int{"base": 1}, 10, repo, zap.NewNop())
+
+	job, err := s.Submit(context.Background(), Request{Blockchain: "base", FromBlock: 1, ToBlock: 100})
+	assert.Nil(t, err)
+
+	job, err = s.Cancel(context.Background(), job.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, storage.BackfillJobStatusCancelled, job.Status)
+	s.Close()
+
+	job, _ = s.Get(context.Background(), job.ID)
+	assert.Equal(t, storage.BackfillJobStatusCancelled, job.Status)
+	assert.Equal(t, 0, job.CompletedChunks)
+
+	_, err = s.Cancel(context.Background(), job.ID)
+	assert.ErrorIs(t, err, ErrJobFinished)
+}
+
+func TestScheduler_Resume(t *testing.T) {
+	w := &fakeWatcher{}
+	repo := newFakeRepository(storage.BackfillJob{
+		ID:          "job",
+		Blockchain:  "base",
+		FromBlock:   1,
+		ToBlock:     30,
+		ChunkSize:   10,
+		Concurrency: 2,
+		Status:      storage.BackfillJobStatusRunning,
+		Chunks: []storage.BackfillChunk{
+			{FromBlock: 1, ToBlock: 10, Completed: true},
+			{FromBlock: 11, ToBlock: 20},
+			{FromBlock: 21, ToBlock: 30, Completed: true},
+		},
+		CompletedChunks: 2,
+	})
+	s := NewScheduler(context.Background(), []watcher.ContractWatcher{w}, map[string]int{"base": 2}, 10, repo, zap.NewNop())
+
+	assert.Nil(t, s.Resume(context.Background()))
+	job := waitForStatus(t, s, "job", storage.BackfillJobStatusCompleted)
+	assert.Equal(t, 3, job.CompletedChunks)
+	assert.Equal(t, [][2]uint64{{11, 20}}, w.processed())
+	s.Close()
+}
@@ -1,14 +1,18 @@
 package builder
 
 import (
+	"strconv"
 	"time"
 
 	solana_go "github.com/gagliardetto/solana-go"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/config"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/algorand"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/ankr"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/aptos"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/evm"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/solana"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/sui"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/terra"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/storage"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/watcher"
@@ -25,14 +29,24 @@ func CreateAnkrEvmWatcher(rateLimit int, chainURL string, wb config.WatcherBlock
 	return watcher.NewEVMWatcher(ankrClient, repo, params, metrics, logger)
 }
 
-func CreateSolanaWatcher(rateLimit int, chainURL string, wb config.WatcherBlockchain, logger *zap.Logger, repo *storage.Repository, metrics metrics.Metrics) watcher.ContractWatcher {
+func CreateSolanaWatcher(rateLimit int, chainURL string, wb config.WatcherBlockchain, nttPrograms []string, logger *zap.Logger, repo *storage.Repository, metrics metrics.Metrics) watcher.ContractWatcher {
 	contractAddress, err := solana_go.PublicKeyFromBase58(wb.Address)
 	if err != nil {
 		logger.Fatal("failed to parse solana contract address", zap.Error(err))
 	}
+	nttProgramIDs := make([]solana_go.PublicKey, 0, len(nttPrograms))
+	for _, program := range nttPrograms {
+		programID, err := solana_go.PublicKeyFromBase58(program)
+		if err != nil {
+			logger.Fatal("failed to parse solana ntt program", zap.String("program", program), zap.Error(err))
+		}
+		nttProgramIDs = append(nttProgramIDs, programID)
+	}
+	cctpMessageTransmitter := solana_go.MustPublicKeyFromBase58(config.SolanaCctpMessageTransmitter)
 	solanaLimiter := ratelimit.New(rateLimit, ratelimit.Per(time.Second))
 	solanaClient := solana.NewSolanaSDK(chainURL, solanaLimiter, metrics, solana.WithRetries(3, 10*time.Second))
 	params := watcher.SolanaParams{Blockchain: wb.Name, ContractAddress: contractAddress,
+		NttPrograms: nttProgramIDs, CctpMessageTransmitter: cctpMessageTransmitter,
 		SizeBlocks: wb.SizeBlocks, WaitSeconds: wb.WaitSeconds, InitialBlock: wb.InitialBlock}
 	return watcher.NewSolanaWatcher(solanaClient, repo, params, metrics, logger)
 }
@@ -45,7 +59,43 @@ func CreateTerraWatcher(rateLimit int, chainURL string, wb config.WatcherBlockch
 	return watcher.NewTerraWatcher(terraClient, params, repo, metrics, logger)
 }
 
+// CreateAptosWatcher creates an aptos watcher. The complete transfers are queried from the indexer API and
+// their transactions are read from the node API.
+func CreateAptosWatcher(rateLimit int, chainURL string, indexerURL string, wb config.WatcherBlockchain, logger *zap.Logger, repo *storage.Repository, metrics metrics.Metrics) watcher.ContractWatcher {
+	if indexerURL == "" {
+		logger.Fatal("aptos indexer url is required")
+	}
+	aptosLimiter := ratelimit.New(rateLimit, ratelimit.Per(time.Second))
+	aptosClient := aptos.NewAptosSDK(chainURL, indexerURL, aptosLimiter, metrics)
+	params := watcher.AptosParams{Blockchain: wb.Name, ContractAddress: wb.Address,
+		WaitSeconds: wb.WaitSeconds, InitialBlock: wb.InitialBlock}
+	return watcher.NewAptosWatcher(aptosClient, params, repo, metrics, logger)
+}
+
+func CreateSuiWatcher(rateLimit int, chainURL string, wb config.WatcherBlockchain, logger *zap.Logger, repo *storage.Repository, metrics metrics.Metrics) watcher.ContractWatcher {
+	suiLimiter := ratelimit.New(rateLimit, ratelimit.Per(time.Second))
+	suiClient := sui.NewSuiSDK(chainURL, suiLimiter, metrics)
+	params := watcher.SuiParams{Blockchain: wb.Name, ContractAddress: wb.Address, UpgradedAddresses: wb.UpgradedAddresses,
+		WaitSeconds: wb.WaitSeconds, InitialBlock: wb.InitialBlock}
+	return watcher.NewSuiWatcher(suiClient, params, repo, metrics, logger)
+}
+
+func CreateAlgorandWatcher(rateLimit int, chainURL string, wb config.WatcherBlockchain, logger *zap.Logger, repo *storage.Repository, metrics metrics.Metrics) watcher.ContractWatcher {
+	applicationID, err := strconv.ParseUint(wb.Address, 10, 64)
+	if err != nil {
+		logger.Fatal("failed to parse algorand application id", zap.Error(err))
+	}
+	algorandLimiter := ratelimit.New(rateLimit, ratelimit.Per(time.Second))
+	algorandClient := algorand.NewAlgorandSDK(chainURL, algorandLimiter, metrics)
+	params := watcher.AlgorandParams{Blockchain: wb.Name, ApplicationID: applicationID,
+		SizeBlocks: wb.SizeBlocks, WaitSeconds: wb.WaitSeconds, InitialBlock: wb.InitialBlock}
+	return watcher.NewAlgorandWatcher(algorandClient, params, repo, metrics, logger)
+}
+
+// CreateEvmWatcher creates an evm watcher. With config.EvmWatcherModeLogs the redeems are detected
+// from the token bridge logs, otherwise from the method id of the transactions sent to the contracts.
 func CreateEvmWatcher(
+	mode string,
 	rateLimit int,
 	chainURL string,
 	wb config.WatcherBlockchainAddresses,
@@ -57,13 +107,20 @@ func CreateEvmWatcher(
 	limiter := ratelimit.New(rateLimit, ratelimit.Per(time.Second))
 	client := evm.NewEvmSDK(chainURL, limiter, metrics)
 	params := watcher.EVMParams{
-		ChainID:          wb.ChainID,
-		Blockchain:       wb.Name,
-		SizeBlocks:       wb.SizeBlocks,
-		WaitSeconds:      wb.WaitSeconds,
-		InitialBlock:     wb.InitialBlock,
-		MethodsByAddress: wb.MethodsByAddress,
+		ChainID:            wb.ChainID,
+		Blockchain:         wb.Name,
+		SizeBlocks:         wb.SizeBlocks,
+		WaitSeconds:        wb.WaitSeconds,
+		InitialBlock:       wb.InitialBlock,
+		MethodsByAddress:   wb.MethodsByAddress,
+		ConfirmationBlocks: wb.ConfirmationBlocks,
+		// used by the log based watcher.
+		TokenBridgeAddress: wb.TokenBridgeAddress,
+		CoreAddress:        wb.CoreAddress,
 	}
 
+	if mode == config.EvmWatcherModeLogs {
+		return watcher.NewEvmLogWatcher(client, params, repo, metrics, logger)
+	}
 	return watcher.NewEvmStandardWatcher(client, params, repo, metrics, logger)
 }
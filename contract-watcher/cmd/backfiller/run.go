@@ -54,10 +54,13 @@ func Run(config *config.BackfillerConfiguration) {
 		zap.String("network", config.Network),
 		zap.String("chain", config.ChainName),
 		zap.Bool("persistBlock", config.PersistBlock),
+		zap.String("evmWatcherMode", config.EvmWatcherMode),
 		zap.Uint64("from", config.FromBlock),
 		zap.Uint64("to", config.ToBlock))
 
-	watcher.Backfill(rootCtx, config.FromBlock, config.ToBlock, config.PageSize, config.PersistBlock)
+	if err := watcher.Backfill(rootCtx, config.FromBlock, config.ToBlock, config.PageSize, config.PersistBlock); err != nil {
+		logger.Error("backfill failed", zap.Error(err))
+	}
 
 	logger.Info("closing MongoDB connection...")
 	db.DisconnectWithTimeout(10 * time.Second)
@@ -72,7 +75,13 @@ func newWatcherForMainnet(cfg *config.BackfillerConfiguration, repo *storage.Rep
 	case config.TERRA_MAINNET.ChainID.String():
 		watcher = builder.CreateTerraWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.TERRA_MAINNET, logger, repo, metrics)
 	case config.BASE_MAINNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.BASE_MAINNET, logger, repo, metrics)
+		watcher = builder.CreateEvmWatcher(cfg.EvmWatcherMode, cfg.RateLimitPerSecond, cfg.ChainUrl, config.BASE_MAINNET.WithNttTransceivers(cfg.NttTransceiverAddresses), logger, repo, metrics)
+	case config.APTOS_MAINNET.ChainID.String():
+		watcher = builder.CreateAptosWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, cfg.AptosIndexerUrl, config.APTOS_MAINNET, logger, repo, metrics)
+	case config.SUI_MAINNET.ChainID.String():
+		watcher = builder.CreateSuiWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.SUI_MAINNET.WithUpgradedAddresses(cfg.SuiUpgradedPackageIDs), logger, repo, metrics)
+	case config.ALGORAND_MAINNET.ChainID.String():
+		watcher = builder.CreateAlgorandWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.ALGORAND_MAINNET, logger, repo, metrics)
 	default:
 		logger.Fatal("chain not supported")
 	}
@@ -83,7 +92,13 @@ func newWatcherForTestnet(cfg *config.BackfillerConfiguration, repo *storage.Rep
 	var watcher watcher.ContractWatcher
 	switch cfg.ChainName {
 	case config.BASE_TESTNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.BASE_TESTNET, logger, repo, metrics)
+		watcher = builder.CreateEvmWatcher(cfg.EvmWatcherMode, cfg.RateLimitPerSecond, cfg.ChainUrl, config.BASE_TESTNET.WithNttTransceivers(cfg.NttTransceiverAddresses), logger, repo, metrics)
+	case config.APTOS_TESTNET.ChainID.String():
+		watcher = builder.CreateAptosWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, cfg.AptosIndexerUrl, config.APTOS_TESTNET, logger, repo, metrics)
+	case config.SUI_TESTNET.ChainID.String():
+		watcher = builder.CreateSuiWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.SUI_TESTNET.WithUpgradedAddresses(cfg.SuiUpgradedPackageIDs), logger, repo, metrics)
+	case config.ALGORAND_TESTNET.ChainID.String():
+		watcher = builder.CreateAlgorandWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.ALGORAND_TESTNET, logger, repo, metrics)
 	default:
 		logger.Fatal("chain not supported")
 	}
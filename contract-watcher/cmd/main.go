@@ -39,26 +39,32 @@ func addServiceCommand(root *cobra.Command) {
 }
 
 func addBackfillerCommand(parent *cobra.Command) {
-	var network, mongoUri, mongoDb, chainName, chainURL, logLevel string
+	var network, mongoUri, mongoDb, chainName, chainURL, logLevel, evmWatcherMode string
 	var fromBlock, toBlock, pageSize uint64
 	var rateLimit int
 	var persistBlock bool
+	var nttTransceivers, suiUpgradedPackages []string
+	var aptosIndexerURL string
 	backfillerCommand := &cobra.Command{
 		Use:   "backfiller",
 		Short: "Run backfiller to backfill data",
 		Run: func(c *cobra.Command, _ []string) {
 			cfg := &config.BackfillerConfiguration{
-				LogLevel:           logLevel,
-				Network:            network,
-				MongoURI:           mongoUri,
-				MongoDatabase:      mongoDb,
-				ChainName:          chainName,
-				ChainUrl:           chainURL,
-				FromBlock:          fromBlock,
-				ToBlock:            toBlock,
-				RateLimitPerSecond: rateLimit,
-				PageSize:           pageSize,
-				PersistBlock:       persistBlock,
+				LogLevel:                logLevel,
+				Network:                 network,
+				MongoURI:                mongoUri,
+				MongoDatabase:           mongoDb,
+				ChainName:               chainName,
+				ChainUrl:                chainURL,
+				FromBlock:               fromBlock,
+				ToBlock:                 toBlock,
+				RateLimitPerSecond:      rateLimit,
+				PageSize:                pageSize,
+				PersistBlock:            persistBlock,
+				EvmWatcherMode:          evmWatcherMode,
+				NttTransceiverAddresses: nttTransceivers,
+				AptosIndexerUrl:         aptosIndexerURL,
+				SuiUpgradedPackageIDs:   suiUpgradedPackages,
 			}
 
 			backfiller.Run(cfg)
@@ -75,6 +81,10 @@ func addBackfillerCommand(parent *cobra.Command) {
 	backfillerCommand.Flags().IntVar(&rateLimit, "rate-limit", 3, "rate limit per second")
 	backfillerCommand.Flags().Uint64Var(&pageSize, "page-size", 100, "maximum number to process at one time")
 	backfillerCommand.Flags().BoolVar(&persistBlock, "persist-blocks", false, "persist processed blocks in storage")
+	backfillerCommand.Flags().StringVar(&evmWatcherMode, "evm-watcher-mode", config.EvmWatcherModeTransactions, "evm watcher mode (transactions or logs)")
+	backfillerCommand.Flags().StringSliceVar(&nttTransceivers, "ntt-transceivers", nil, "NTT wormhole transceiver addresses tracked in evm chains")
+	backfillerCommand.Flags().StringVar(&aptosIndexerURL, "aptos-indexer-url", "", "aptos indexer graphql URL (required for aptos)")
+	backfillerCommand.Flags().StringSliceVar(&suiUpgradedPackages, "sui-upgraded-packages", nil, "package ids of the upgraded versions of the sui token bridge")
 
 	backfillerCommand.MarkFlagRequired("network")
 	backfillerCommand.MarkFlagRequired("mongo-uri")
@@ -14,6 +14,7 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	"github.com/wormhole-foundation/wormhole-explorer/common/health"
 	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/backfill"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/builder"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/config"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/http/infrastructure"
@@ -42,6 +43,9 @@ type watchersConfig struct {
 	base        *config.WatcherBlockchainAddresses
 	baseSepolia *config.WatcherBlockchainAddresses
 	terra       *config.WatcherBlockchain
+	aptos       *config.WatcherBlockchain
+	sui         *config.WatcherBlockchain
+	algorand    *config.WatcherBlockchain
 	rateLimit   rateLimitConfig
 }
 
@@ -49,6 +53,9 @@ type rateLimitConfig struct {
 	base        int
 	baseSepolia int
 	terra       int
+	aptos       int
+	sui         int
+	algorand    int
 }
 
 func Run() {
@@ -95,14 +102,21 @@ func Run() {
 	repo := storage.NewRepository(db.Database, metrics, alerts, logger)
 
 	// create watchers
-	watchers := newWatchers(cfg, testnetConfig, repo, metrics, logger)
+	watchers, rateLimits := newWatchers(cfg, testnetConfig, repo, metrics, logger)
 
 	//create processor
 	processor := processor.NewProcessor(watchers, logger)
 	processor.Start(rootCtx)
 
+	// create backfill scheduler and resume the jobs interrupted by a previous shutdown.
+	backfillConcurrency := newBackfillConcurrency(rateLimits, cfg.BackfillMaxConcurrency)
+	scheduler := backfill.NewScheduler(rootCtx, watchers, backfillConcurrency, cfg.BackfillChunkSize, repo, logger)
+	if err := scheduler.Resume(rootCtx); err != nil {
+		logger.Error("failed to resume backfill jobs", zap.Error(err))
+	}
+
 	// create and start server.
-	redeemController := redeem.NewController(watchers, logger)
+	redeemController := redeem.NewController(scheduler, logger)
 	server := infrastructure.NewServer(logger, cfg.Port, cfg.PprofEnabled, redeemController, healthChecks...)
 	server.Start()
 
@@ -121,6 +135,9 @@ func Run() {
 	logger.Info("root context cancelled, exiting...")
 	rootCtxCancel()
 
+	logger.Info("Closing backfill scheduler ...")
+	scheduler.Close()
+
 	logger.Info("Closing processor ...")
 	processor.Close()
 
@@ -137,7 +154,7 @@ func newHealthChecks(_ context.Context, db *mongo.Database) ([]health.Check, err
 	return []health.Check{health.Mongo(db)}, nil
 }
 
-func newWatchers(config *config.ServiceConfiguration, testnetConfig *config.TestnetConfiguration, repo *storage.Repository, metrics metrics.Metrics, logger *zap.Logger) []watcher.ContractWatcher {
+func newWatchers(config *config.ServiceConfiguration, testnetConfig *config.TestnetConfiguration, repo *storage.Repository, metrics metrics.Metrics, logger *zap.Logger) ([]watcher.ContractWatcher, map[string]int) {
 	var watchers *watchersConfig
 	switch config.P2pNetwork {
 	case domain.P2pMainNet:
@@ -149,36 +166,83 @@ func newWatchers(config *config.ServiceConfiguration, testnetConfig *config.Test
 	}
 
 	result := make([]watcher.ContractWatcher, 0)
+	rateLimits := make(map[string]int)
 
 	// add terra watcher
 	if watchers.terra != nil {
 		terraWatcher := builder.CreateTerraWatcher(watchers.rateLimit.terra, config.TerraUrl, *watchers.terra, logger, repo, metrics)
 		result = append(result, terraWatcher)
+		rateLimits[watchers.terra.Name] = watchers.rateLimit.terra
+	}
+
+	// add aptos watcher
+	if watchers.aptos != nil {
+		aptosWatcher := builder.CreateAptosWatcher(watchers.rateLimit.aptos, config.AptosUrl, config.AptosIndexerUrl, *watchers.aptos, logger, repo, metrics)
+		result = append(result, aptosWatcher)
+		rateLimits[watchers.aptos.Name] = watchers.rateLimit.aptos
+	}
+
+	// add sui watcher
+	if watchers.sui != nil {
+		suiWatcher := builder.CreateSuiWatcher(watchers.rateLimit.sui, config.SuiUrl, watchers.sui.WithUpgradedAddresses(config.SuiUpgradedPackageIDs), logger, repo, metrics)
+		result = append(result, suiWatcher)
+		rateLimits[watchers.sui.Name] = watchers.rateLimit.sui
+	}
+
+	// add algorand watcher
+	if watchers.algorand != nil {
+		algorandWatcher := builder.CreateAlgorandWatcher(watchers.rateLimit.algorand, config.AlgorandUrl, *watchers.algorand, logger, repo, metrics)
+		result = append(result, algorandWatcher)
+		rateLimits[watchers.algorand.Name] = watchers.rateLimit.algorand
 	}
 
 	// add base watcher
 	if watchers.base != nil {
-		baseWatcher := builder.CreateEvmWatcher(watchers.rateLimit.base, config.BaseUrl, *watchers.base, logger, repo, metrics)
+		baseWatcher := builder.CreateEvmWatcher(config.EvmWatcherMode, watchers.rateLimit.base, config.BaseUrl, watchers.base.WithNttTransceivers(config.NttTransceiverAddresses), logger, repo, metrics)
 		result = append(result, baseWatcher)
+		rateLimits[watchers.base.Name] = watchers.rateLimit.base
 	}
 
 	// add base sepolia watcher
 	if watchers.baseSepolia != nil {
-		baseSepoliaWatcher := builder.CreateEvmWatcher(watchers.rateLimit.baseSepolia, testnetConfig.BaseSepoliaBaseUrl, *watchers.baseSepolia, logger, repo, metrics)
+		baseSepoliaWatcher := builder.CreateEvmWatcher(config.EvmWatcherMode, watchers.rateLimit.baseSepolia, testnetConfig.BaseSepoliaBaseUrl, watchers.baseSepolia.WithNttTransceivers(config.NttTransceiverAddresses), logger, repo, metrics)
 		result = append(result, baseSepoliaWatcher)
+		rateLimits[watchers.baseSepolia.Name] = watchers.rateLimit.baseSepolia
 	}
 
+	return result, rateLimits
+}
+
+// newBackfillConcurrency bounds the concurrency of the backfill jobs of each blockchain by its rate limit.
+func newBackfillConcurrency(rateLimits map[string]int, maxConcurrency int) map[string]int {
+	result := make(map[string]int, len(rateLimits))
+	for blockchain, rateLimit := range rateLimits {
+		concurrency := maxConcurrency
+		if rateLimit > 0 && rateLimit < concurrency {
+			concurrency = rateLimit
+		}
+		if concurrency < 1 {
+			concurrency = 1
+		}
+		result[blockchain] = concurrency
+	}
 	return result
 }
 
 func newWatchersForMainnet(cfg *config.ServiceConfiguration) *watchersConfig {
 	return &watchersConfig{
-		base:  &config.BASE_MAINNET,
-		terra: &config.TERRA_MAINNET,
+		base:     &config.BASE_MAINNET,
+		terra:    &config.TERRA_MAINNET,
+		aptos:    &config.APTOS_MAINNET,
+		sui:      &config.SUI_MAINNET,
+		algorand: &config.ALGORAND_MAINNET,
 
 		rateLimit: rateLimitConfig{
-			base:  cfg.BaseRequestsPerSecond,
-			terra: cfg.TerraRequestsPerSecond,
+			base:     cfg.BaseRequestsPerSecond,
+			terra:    cfg.TerraRequestsPerSecond,
+			aptos:    cfg.AptosRequestsPerSecond,
+			sui:      cfg.SuiRequestsPerSecond,
+			algorand: cfg.AlgorandRequestsPerSecond,
 		},
 	}
 }
@@ -187,10 +251,16 @@ func newWatchersForTestnet(cfg *config.ServiceConfiguration, testnetCfg *config.
 	return &watchersConfig{
 		base:        &config.BASE_TESTNET,
 		baseSepolia: &config.BASE_SEPOLIA_TESTNET,
+		aptos:       &config.APTOS_TESTNET,
+		sui:         &config.SUI_TESTNET,
+		algorand:    &config.ALGORAND_TESTNET,
 		rateLimit: rateLimitConfig{
 			base:        cfg.BaseRequestsPerSecond,
 			baseSepolia: testnetCfg.BaseSepoliaRequestsPerMinute,
 			terra:       cfg.TerraRequestsPerSecond,
+			aptos:       cfg.AptosRequestsPerSecond,
+			sui:         cfg.SuiRequestsPerSecond,
+			algorand:    cfg.AlgorandRequestsPerSecond,
 		},
 	}
 }
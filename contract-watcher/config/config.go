@@ -11,9 +11,25 @@ type ServiceConfiguration struct {
 	P2pNetwork    string `env:"P2P_NETWORK,required"`
 	AlertEnabled  bool   `env:"ALERT_ENABLED,required"`
 	AlertApiKey   string `env:"ALERT_API_KEY"`
+	// EvmWatcherMode is the strategy used to detect redeems in evm chains (transactions or logs).
+	EvmWatcherMode string `env:"EVM_WATCHER_MODE,default=transactions"`
+	// NttTransceiverAddresses are the NTT wormhole transceivers whose redeems are tracked in evm chains.
+	NttTransceiverAddresses []string `env:"NTT_TRANSCEIVER_ADDRESSES"`
+	// SuiUpgradedPackageIDs are the package ids of the upgraded versions of the sui token bridge.
+	SuiUpgradedPackageIDs []string `env:"SUI_UPGRADED_PACKAGE_IDS"`
+	// BackfillChunkSize is the default number of blocks of the chunks of a backfill job.
+	BackfillChunkSize uint64 `env:"BACKFILL_CHUNK_SIZE,default=100"`
+	// BackfillMaxConcurrency is the maximum number of chunks processed concurrently by a backfill job,
+	// it is also bounded by the requests per second of the chain.
+	BackfillMaxConcurrency int `env:"BACKFILL_MAX_CONCURRENCY,default=5"`
 
+	AlgorandUrl                string `env:"ALGORAND_URL,required"`
+	AlgorandRequestsPerSecond  int    `env:"ALGORAND_REQUESTS_PER_SECOND,required"`
 	AnkrUrl                    string `env:"ANKR_URL,required"`
 	AnkrRequestsPerSecond      int    `env:"ANKR_REQUESTS_PER_SECOND,required"`
+	AptosUrl                   string `env:"APTOS_URL,required"`
+	AptosIndexerUrl            string `env:"APTOS_INDEXER_URL,required"`
+	AptosRequestsPerSecond     int    `env:"APTOS_REQUESTS_PER_SECOND,required"`
 	ArbitrumUrl                string `env:"ARBITRUM_URL,required"`
 	ArbitrumRequestsPerSecond  int    `env:"ARBITRUM_REQUESTS_PER_SECOND,required"`
 	AvalancheUrl               string `env:"AVALANCHE_URL,required"`
@@ -30,6 +46,8 @@ type ServiceConfiguration struct {
 	OasisRequestsPerSecond     int    `env:"OASIS_REQUESTS_PER_SECOND,required"`
 	PolygonUrl                 string `env:"POLYGON_URL,required"`
 	PolygonRequestsPerSecond   int    `env:"POLYGON_REQUESTS_PER_SECOND,required"`
+	SuiUrl                     string `env:"SUI_URL,required"`
+	SuiRequestsPerSecond       int    `env:"SUI_REQUESTS_PER_SECOND,required"`
 	TerraUrl                   string `env:"TERRA_URL,required"`
 	TerraRequestsPerSecond     int    `env:"TERRA_REQUESTS_PER_SECOND,required"`
 }
@@ -54,4 +72,11 @@ type BackfillerConfiguration struct {
 	RateLimitPerSecond int    `env:"RATE_LIMIT_PER_SECOND,default=10"`
 	PageSize           uint64 `env:"PAGE_SIZE,default=100"`
 	PersistBlock       bool   `env:"PERSIST_BLOCK,default=false"`
+	EvmWatcherMode     string `env:"EVM_WATCHER_MODE,default=transactions"`
+	// NttTransceiverAddresses are the NTT wormhole transceivers whose redeems are tracked in evm chains.
+	NttTransceiverAddresses []string `env:"NTT_TRANSCEIVER_ADDRESSES"`
+	// AptosIndexerUrl is the aptos indexer graphql API used to query the complete transfers.
+	AptosIndexerUrl string `env:"APTOS_INDEXER_URL"`
+	// SuiUpgradedPackageIDs are the package ids of the upgraded versions of the sui token bridge.
+	SuiUpgradedPackageIDs []string `env:"SUI_UPGRADED_PACKAGE_IDS"`
 }
@@ -15,12 +15,42 @@ var TERRA_MAINNET = WatcherBlockchain{
 	InitialBlock: 3911168,
 }
 
-var BASE_MAINNET = WatcherBlockchainAddresses{
-	ChainID:      vaa.ChainIDBase,
-	Name:         "base",
+var APTOS_MAINNET = WatcherBlockchain{
+	ChainID:      vaa.ChainIDAptos,
+	Name:         "aptos",
+	Address:      "0x576410486a2da45eee6c949c995670112ddf2fbeedab20350d506328eefc9d4f",
+	SizeBlocks:   100,
+	WaitSeconds:  10,
+	InitialBlock: 1_500_000_000,
+}
+
+var SUI_MAINNET = WatcherBlockchain{
+	ChainID:      vaa.ChainIDSui,
+	Name:         "sui",
+	Address:      "0x26efee2b51c911237888e5dc6702868abca3c7ac12c53f76ef8eba0697695e3d",
+	SizeBlocks:   10,
+	WaitSeconds:  5,
+	InitialBlock: 30_000_000,
+}
+
+var ALGORAND_MAINNET = WatcherBlockchain{
+	ChainID:      vaa.ChainIDAlgorand,
+	Name:         "algorand",
+	Address:      "842126029",
 	SizeBlocks:   100,
 	WaitSeconds:  10,
-	InitialBlock: 1_422_314,
+	InitialBlock: 37_000_000,
+}
+
+var BASE_MAINNET = WatcherBlockchainAddresses{
+	ChainID:            vaa.ChainIDBase,
+	Name:               "base",
+	SizeBlocks:         100,
+	WaitSeconds:        10,
+	InitialBlock:       1_422_314,
+	ConfirmationBlocks: 10,
+	TokenBridgeAddress: strings.ToLower("0x8d2de8d2f73F1F4cAB472AC9A881C9b123C79627"),
+	CoreAddress:        strings.ToLower("0xbebdb6C8ddC678FfA9f8748f85C815C556Dd8ac6"),
 	MethodsByAddress: map[string][]BlockchainMethod{
 		strings.ToLower("0x8d2de8d2f73F1F4cAB472AC9A881C9b123C79627"): {
 			{
@@ -28,5 +58,21 @@ var BASE_MAINNET = WatcherBlockchainAddresses{
 				Name: MetehodCompleteTransferWithRelay,
 			},
 		},
+		// wormhole CCTP integration.
+		strings.ToLower("0x03faBB06Fa052557143dC28eFCFc63FC12843f1D"): {
+			{
+				ID:       MethodIDRedeemTokensWithPayload,
+				Name:     MethodRedeemTokensWithPayload,
+				Protocol: ProtocolCCTP,
+			},
+		},
+		// circle message transmitter.
+		strings.ToLower("0xAD09780d193884d503182aD4588450C416D6F9D4"): {
+			{
+				ID:       MethodIDCctpReceiveMessage,
+				Name:     MethodReceiveMessage,
+				Protocol: ProtocolCCTP,
+			},
+		},
 	},
 }
@@ -6,12 +6,42 @@ import (
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
 
-var BASE_TESTNET = WatcherBlockchainAddresses{
-	ChainID:      vaa.ChainIDBase,
-	Name:         "base_goerli",
+var APTOS_TESTNET = WatcherBlockchain{
+	ChainID:      vaa.ChainIDAptos,
+	Name:         "aptos",
+	Address:      "0x576410486a2da45eee6c949c995670112ddf2fbeedab20350d506328eefc9d4f",
+	SizeBlocks:   100,
+	WaitSeconds:  10,
+	InitialBlock: 950_000_000,
+}
+
+var SUI_TESTNET = WatcherBlockchain{
+	ChainID:      vaa.ChainIDSui,
+	Name:         "sui",
+	Address:      "0x562760fc51d90d4ae1835bac3e91e0e6987d3497b06f066941d3e51f6e8d76d0",
+	SizeBlocks:   10,
+	WaitSeconds:  5,
+	InitialBlock: 25_000_000,
+}
+
+var ALGORAND_TESTNET = WatcherBlockchain{
+	ChainID:      vaa.ChainIDAlgorand,
+	Name:         "algorand",
+	Address:      "86525641",
 	SizeBlocks:   100,
 	WaitSeconds:  10,
-	InitialBlock: 902_385,
+	InitialBlock: 39_000_000,
+}
+
+var BASE_TESTNET = WatcherBlockchainAddresses{
+	ChainID:            vaa.ChainIDBase,
+	Name:               "base_goerli",
+	SizeBlocks:         100,
+	WaitSeconds:        10,
+	InitialBlock:       902_385,
+	ConfirmationBlocks: 5,
+	TokenBridgeAddress: strings.ToLower("0xA31aa3FDb7aF7Db93d18DDA4e19F811342EDF780"),
+	CoreAddress:        strings.ToLower("0x23908A62110e21C04F3A4e011d24F901F911744A"),
 	MethodsByAddress: map[string][]BlockchainMethod{
 		strings.ToLower("0xA31aa3FDb7aF7Db93d18DDA4e19F811342EDF780"): {
 			{
@@ -23,11 +53,14 @@ var BASE_TESTNET = WatcherBlockchainAddresses{
 }
 
 var BASE_SEPOLIA_TESTNET = WatcherBlockchainAddresses{
-	ChainID:      vaa.ChainIDBaseSepolia,
-	Name:         "base_sepolia",
-	SizeBlocks:   100,
-	WaitSeconds:  10,
-	InitialBlock: 3_415_420,
+	ChainID:            vaa.ChainIDBaseSepolia,
+	Name:               "base_sepolia",
+	SizeBlocks:         100,
+	WaitSeconds:        10,
+	InitialBlock:       3_415_420,
+	ConfirmationBlocks: 5,
+	TokenBridgeAddress: strings.ToLower("0x86F55A04690fd7815A3D802bD587e83eA888B239"),
+	CoreAddress:        strings.ToLower("0x79A1027a6A159502049F10906D333EC57E95F083"),
 	MethodsByAddress: map[string][]BlockchainMethod{
 		strings.ToLower("0x86F55A04690fd7815A3D802bD587e83eA888B239"): {
 			{
@@ -35,5 +68,13 @@ var BASE_SEPOLIA_TESTNET = WatcherBlockchainAddresses{
 				Name: MetehodCompleteTransferWithRelay,
 			},
 		},
+		// circle message transmitter.
+		strings.ToLower("0x7865fAfC2db2093669d92c0F33AeEF291086BEFD"): {
+			{
+				ID:       MethodIDCctpReceiveMessage,
+				Name:     MethodReceiveMessage,
+				Protocol: ProtocolCCTP,
+			},
+		},
 	},
 }
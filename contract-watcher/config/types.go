@@ -1,6 +1,11 @@
 package config
 
-import "github.com/wormhole-foundation/wormhole/sdk/vaa"
+import (
+	"strings"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
 
 const (
 	//Method names for wormhole token bridge contract.
@@ -37,8 +42,46 @@ const (
 
 	//Method id for Portico contract
 	MethodIDReceiveMessageAndSwap = "0x3d528f35"
+
+	//Method names for NTT wormhole transceiver and CCTP contracts.
+	MethodReceiveMessage          = "receiveMessage"
+	MethodRedeemTokensWithPayload = "redeemTokensWithPayload"
+
+	//Method id for NTT wormhole transceiver receiveMessage(bytes encodedMessage)
+	MethodIDNttReceiveMessage = "0xf953cec7"
+
+	//Method id for circle message transmitter receiveMessage(bytes message, bytes attestation)
+	MethodIDCctpReceiveMessage = "0x57ecfd28"
+
+	//Method id for wormhole CCTP integration redeemTokensWithPayload((bytes,bytes,bytes))
+	MethodIDRedeemTokensWithPayload = "0x57bf927b"
+
+	//Method name used for redeems detected from the token bridge TransferRedeemed event.
+	MethodTransferRedeemed = "transferRedeemed"
+
+	//Event topics for wormhole token bridge and core contracts.
+	//TransferRedeemed(uint16 indexed emitterChainId, bytes32 indexed emitterAddress, uint64 indexed sequence)
+	EventTopicTransferRedeemed = "0xcaf280c8cfeba144da67230d9b009c8f868a75bac9a528fa0474be1ba317c169"
+	//LogMessagePublished(address indexed sender, uint64 sequence, uint32 nonce, bytes payload, uint8 consistencyLevel)
+	EventTopicLogMessagePublished = "0x6eb224fb001ed210e379b335e35efe88672a8ce935d981a6896b27ffdf52a3b2"
 )
 
+const (
+	// EvmWatcherModeTransactions detects redeems matching the method id of the transactions sent to the contracts.
+	EvmWatcherModeTransactions = "transactions"
+	// EvmWatcherModeLogs detects redeems from the token bridge TransferRedeemed logs, independent of the calling method.
+	EvmWatcherModeLogs = "logs"
+)
+
+// Protocol tags of the destination txs of the protocols built on top of wormhole.
+const (
+	ProtocolNTT  = domain.AppIdNativeTokenTransfer
+	ProtocolCCTP = domain.AppIdCCTP
+)
+
+// SolanaCctpMessageTransmitter is the circle message transmitter program on solana.
+const SolanaCctpMessageTransmitter = "CCTPmbSD7gX1bxKPAmg77w8oFzNFpaQiQUWD43TKaecd"
+
 type WatcherBlockchain struct {
 	ChainID      vaa.ChainID
 	Name         string
@@ -46,6 +89,15 @@ type WatcherBlockchain struct {
 	SizeBlocks   uint8
 	WaitSeconds  uint16
 	InitialBlock int64
+	// UpgradedAddresses are the addresses of the upgraded versions of the contract, for chains where an
+	// upgrade is deployed at a new address (sui packages).
+	UpgradedAddresses []string
+}
+
+// WithUpgradedAddresses returns a copy of the blockchain that also watches the given upgraded addresses.
+func (w WatcherBlockchain) WithUpgradedAddresses(addresses []string) WatcherBlockchain {
+	w.UpgradedAddresses = append(append([]string{}, w.UpgradedAddresses...), addresses...)
+	return w
 }
 
 type WatcherBlockchainAddresses struct {
@@ -56,9 +108,42 @@ type WatcherBlockchainAddresses struct {
 	// Initial block indicates for the supported contracts, the oldest block from which to start processing.
 	InitialBlock     int64
 	MethodsByAddress map[string][]BlockchainMethod
+	// ConfirmationBlocks is the confirmation depth, blocks newer than the latest block minus this depth are not processed yet.
+	ConfirmationBlocks uint64
+	// Token bridge and core contract addresses, used by the log based watcher.
+	TokenBridgeAddress string
+	CoreAddress        string
+}
+
+// WithNttTransceivers returns a copy of the blockchain addresses that also detects the
+// receiveMessage calls of the given NTT wormhole transceivers.
+func (w WatcherBlockchainAddresses) WithNttTransceivers(addresses []string) WatcherBlockchainAddresses {
+	if len(addresses) == 0 {
+		return w
+	}
+	methodsByAddress := make(map[string][]BlockchainMethod, len(w.MethodsByAddress)+len(addresses))
+	for address, methods := range w.MethodsByAddress {
+		methodsByAddress[address] = methods
+	}
+	for _, address := range addresses {
+		address = strings.ToLower(strings.TrimSpace(address))
+		if address == "" {
+			continue
+		}
+		methods := append([]BlockchainMethod{}, methodsByAddress[address]...)
+		methodsByAddress[address] = append(methods, BlockchainMethod{
+			ID:       MethodIDNttReceiveMessage,
+			Name:     MethodReceiveMessage,
+			Protocol: ProtocolNTT,
+		})
+	}
+	w.MethodsByAddress = methodsByAddress
+	return w
 }
 
 type BlockchainMethod struct {
 	ID   string
 	Name string
+	// Protocol is the tag of the protocol built on top of wormhole that owns the method, empty for the token bridge.
+	Protocol string
 }
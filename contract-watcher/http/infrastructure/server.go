@@ -34,6 +34,8 @@ func NewServer(logger *zap.Logger, port string, pprofEnabled bool, redeemControl
 	api.Get("/ready", ctrl.ReadyCheck)
 
 	api.Post("/backfill", redeemController.Backfill)
+	api.Get("/backfill/:id", redeemController.GetBackfill)
+	api.Delete("/backfill/:id", redeemController.CancelBackfill)
 
 	return &Server{
 		app:    app,
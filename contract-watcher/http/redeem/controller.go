@@ -1,31 +1,33 @@
 package redeem
 
 import (
+	"errors"
+
 	"github.com/gofiber/fiber/v2"
-	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/watcher"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/backfill"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/storage"
 	"go.uber.org/zap"
 )
 
 // Controller definition.
 type Controller struct {
-	watcherByBlockchain map[string]watcher.ContractWatcher
-	logger              *zap.Logger
+	scheduler *backfill.Scheduler
+	logger    *zap.Logger
 }
 
 // NewController creates a Controller instance.
-func NewController(watckers []watcher.ContractWatcher, logger *zap.Logger) *Controller {
-	watcherByBlockchain := make(map[string]watcher.ContractWatcher)
-	for _, w := range watckers {
-		watcherByBlockchain[w.GetBlockchain()] = w
-	}
-	return &Controller{watcherByBlockchain: watcherByBlockchain, logger: logger}
+func NewController(scheduler *backfill.Scheduler, logger *zap.Logger) *Controller {
+	return &Controller{scheduler: scheduler, logger: logger}
 }
 
+// Backfill submits a backfill job. The job runs in background and its progress is returned by GetBackfill.
 func (c *Controller) Backfill(ctx *fiber.Ctx) error {
 	payload := struct {
-		Blockchain string `json:"blockchain"`
-		FromBlock  uint64 `json:"fromBlock"`
-		ToBlock    uint64 `json:"toBlock"`
+		Blockchain  string `json:"blockchain"`
+		FromBlock   uint64 `json:"fromBlock"`
+		ToBlock     uint64 `json:"toBlock"`
+		ChunkSize   uint64 `json:"chunkSize"`
+		Concurrency int    `json:"concurrency"`
 	}{}
 
 	if err := ctx.BodyParser(&payload); err != nil {
@@ -34,12 +36,49 @@ func (c *Controller) Backfill(ctx *fiber.Ctx) error {
 
 	c.logger.Info("Executing contract-watcher", zap.Any("payload", payload))
 
-	watcher, ok := c.watcherByBlockchain[payload.Blockchain]
-	if !ok {
-		return fiber.NewError(fiber.StatusNotFound, "Blockchain not found")
+	job, err := c.scheduler.Submit(ctx.Context(), backfill.Request{
+		Blockchain:  payload.Blockchain,
+		FromBlock:   payload.FromBlock,
+		ToBlock:     payload.ToBlock,
+		ChunkSize:   payload.ChunkSize,
+		Concurrency: payload.Concurrency,
+	})
+	if err != nil {
+		return toHttpError(err)
 	}
 
-	watcher.Backfill(ctx.Context(), payload.FromBlock, payload.ToBlock, 100, false)
+	return ctx.Status(fiber.StatusAccepted).JSON(job)
+}
+
+// GetBackfill returns a backfill job with the progress of its chunks.
+func (c *Controller) GetBackfill(ctx *fiber.Ctx) error {
+	job, err := c.scheduler.Get(ctx.Context(), ctx.Params("id"))
+	if err != nil {
+		return toHttpError(err)
+	}
+	return ctx.JSON(job)
+}
 
-	return nil
+// CancelBackfill cancels a running backfill job.
+func (c *Controller) CancelBackfill(ctx *fiber.Ctx) error {
+	job, err := c.scheduler.Cancel(ctx.Context(), ctx.Params("id"))
+	if err != nil {
+		return toHttpError(err)
+	}
+	return ctx.JSON(job)
+}
+
+func toHttpError(err error) error {
+	switch {
+	case errors.Is(err, backfill.ErrBlockchainNotFound):
+		return fiber.NewError(fiber.StatusNotFound, "Blockchain not found")
+	case errors.Is(err, storage.ErrDocNotFound):
+		return fiber.NewError(fiber.StatusNotFound, "Backfill not found")
+	case errors.Is(err, backfill.ErrInvalidBlockRange):
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	case errors.Is(err, backfill.ErrJobFinished):
+		return fiber.NewError(fiber.StatusConflict, err.Error())
+	default:
+		return err
+	}
 }
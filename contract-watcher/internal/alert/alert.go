@@ -9,6 +9,7 @@ import (
 // alert key constants definition.
 const (
 	ErrorSaveDestinationTx = "ERROR_SAVE_DESTINATION_TX"
+	ErrorProcessBlocks     = "ERROR_PROCESS_BLOCKS"
 )
 
 func LoadAlerts(cfg alert.AlertConfig) map[string]alert.Alert {
@@ -25,5 +26,16 @@ func LoadAlerts(cfg alert.AlertConfig) map[string]alert.Alert {
 		Priority:    alert.CRITICAL,
 	}
 
+	// Alert error processing a range of blocks.
+	alerts[ErrorProcessBlocks] = alert.Alert{
+		Alias:       ErrorProcessBlocks,
+		Message:     fmt.Sprintf("[%s] %s", cfg.Environment, "Error processing blocks"),
+		Description: "A range of blocks could not be processed after retrying, the watcher does not advance until it is processed.",
+		Actions:     []string{"check the rpc of the chain", "check watcherBlock collection"},
+		Tags:        []string{cfg.Environment, "contract-watcher", "blocks", "rpc"},
+		Entity:      "contract-watcher",
+		Priority:    alert.CRITICAL,
+	}
+
 	return alerts
 }
@@ -0,0 +1,96 @@
+package algorand
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"go.uber.org/ratelimit"
+)
+
+var ErrTooManyRequests = fmt.Errorf("too many requests")
+
+const clientName = "algorand"
+
+// AlgorandSDK is a client for the Algorand indexer REST API.
+type AlgorandSDK struct {
+	client  *resty.Client
+	rl      ratelimit.Limiter
+	metrics metrics.Metrics
+}
+
+// NewAlgorandSDK creates a new AlgorandSDK.
+func NewAlgorandSDK(url string, rl ratelimit.Limiter, metrics metrics.Metrics) *AlgorandSDK {
+	return &AlgorandSDK{
+		rl:      rl,
+		client:  resty.New().SetBaseURL(url),
+		metrics: metrics,
+	}
+}
+
+// GetLatestRound returns the latest round indexed.
+func (s *AlgorandSDK) GetLatestRound(ctx context.Context) (uint64, error) {
+	s.rl.Take()
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetResult(&HealthResponse{}).
+		Get("/health")
+
+	if err != nil {
+		return 0, err
+	}
+
+	s.metrics.IncRpcRequest(clientName, "get-latest-round", resp.StatusCode())
+
+	if resp.IsError() {
+		if resp.StatusCode() == http.StatusTooManyRequests {
+			return 0, ErrTooManyRequests
+		}
+		return 0, fmt.Errorf("status code: %s. %s", resp.Status(), string(resp.Body()))
+	}
+
+	result := resp.Result().(*HealthResponse)
+	if result == nil {
+		return 0, fmt.Errorf("empty response")
+	}
+	return result.Round, nil
+}
+
+// GetApplicationTransactions returns the application call transactions of an application
+// between minRound and maxRound (both inclusive). next is the token of the page to return.
+func (s *AlgorandSDK) GetApplicationTransactions(ctx context.Context, applicationID uint64, minRound, maxRound uint64, next string) (*TransactionsResponse, error) {
+	s.rl.Take()
+	req := s.client.R().
+		SetContext(ctx).
+		SetQueryParam("application-id", strconv.FormatUint(applicationID, 10)).
+		SetQueryParam("tx-type", "appl").
+		SetQueryParam("min-round", strconv.FormatUint(minRound, 10)).
+		SetQueryParam("max-round", strconv.FormatUint(maxRound, 10)).
+		SetResult(&TransactionsResponse{})
+	if next != "" {
+		req.SetQueryParam("next", next)
+	}
+	resp, err := req.Get("/v2/transactions")
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.metrics.IncRpcRequest(clientName, "get-application-transactions", resp.StatusCode())
+
+	if resp.IsError() {
+		if resp.StatusCode() == http.StatusTooManyRequests {
+			return nil, ErrTooManyRequests
+		}
+		return nil, fmt.Errorf("status code: %s. %s", resp.Status(), string(resp.Body()))
+	}
+
+	result := resp.Result().(*TransactionsResponse)
+	if result == nil {
+		return nil, fmt.Errorf("empty response")
+	}
+	return result, nil
+}
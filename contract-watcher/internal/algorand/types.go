@@ -0,0 +1,31 @@
+package algorand
+
+// HealthResponse is the response of the indexer health endpoint.
+type HealthResponse struct {
+	Round uint64 `json:"round"`
+}
+
+// TransactionsResponse is the response of the indexer transactions endpoint.
+type TransactionsResponse struct {
+	CurrentRound uint64        `json:"current-round"`
+	NextToken    string        `json:"next-token"`
+	Transactions []Transaction `json:"transactions"`
+}
+
+// Transaction is an algorand transaction.
+type Transaction struct {
+	ID                     string                 `json:"id"`
+	Sender                 string                 `json:"sender"`
+	ConfirmedRound         uint64                 `json:"confirmed-round"`
+	RoundTime              int64                  `json:"round-time"`
+	TxType                 string                 `json:"tx-type"`
+	Group                  string                 `json:"group"`
+	ApplicationTransaction ApplicationTransaction `json:"application-transaction"`
+}
+
+// ApplicationTransaction are the fields of an application call transaction.
+type ApplicationTransaction struct {
+	ApplicationID uint64 `json:"application-id"`
+	// ApplicationArgs are base64 encoded.
+	ApplicationArgs []string `json:"application-args"`
+}
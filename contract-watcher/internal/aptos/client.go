@@ -0,0 +1,144 @@
+package aptos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"go.uber.org/ratelimit"
+)
+
+var ErrTooManyRequests = fmt.Errorf("too many requests")
+
+const clientName = "aptos"
+
+const latestUserTransactionQuery = `query LatestUserTransaction {
+  user_transactions(order_by: {version: desc}, limit: 1) {
+    version
+  }
+}`
+
+const userTransactionsQuery = `query UserTransactions($functions: [String!], $from: bigint, $to: bigint, $limit: Int) {
+  user_transactions(
+    where: {entry_function_id_str: {_in: $functions}, version: {_gte: $from, _lte: $to}}
+    order_by: {version: asc}
+    limit: $limit
+  ) {
+    version
+  }
+}`
+
+// AptosSDK is a client for the Aptos node REST API and the Aptos indexer GraphQL API.
+type AptosSDK struct {
+	client  *resty.Client
+	indexer *resty.Client
+	rl      ratelimit.Limiter
+	metrics metrics.Metrics
+}
+
+// NewAptosSDK creates a new AptosSDK.
+func NewAptosSDK(url string, indexerURL string, rl ratelimit.Limiter, metrics metrics.Metrics) *AptosSDK {
+	return &AptosSDK{
+		rl:      rl,
+		client:  resty.New().SetBaseURL(url),
+		indexer: resty.New().SetBaseURL(indexerURL),
+		metrics: metrics,
+	}
+}
+
+// GetLatestIndexedVersion returns the version of the latest user transaction processed by the indexer.
+func (s *AptosSDK) GetLatestIndexedVersion(ctx context.Context) (uint64, error) {
+	var result userTransactionsResult
+	err := s.query(ctx, "get-latest-indexed-version", &result, latestUserTransactionQuery, nil)
+	if err != nil {
+		return 0, err
+	}
+	if len(result.UserTransactions) == 0 {
+		return 0, fmt.Errorf("empty response")
+	}
+	return strconv.ParseUint(result.UserTransactions[0].Version.String(), 10, 64)
+}
+
+// GetUserTransactionVersions returns, in ascending order, the versions of the user transactions between
+// fromVersion and toVersion that call one of the entry functions (<address>::<module>::<function>).
+func (s *AptosSDK) GetUserTransactionVersions(ctx context.Context, functions []string, fromVersion uint64, toVersion uint64, limit uint64) ([]uint64, error) {
+	variables := map[string]any{
+		"functions": functions,
+		"from":      fromVersion,
+		"to":        toVersion,
+		"limit":     limit,
+	}
+	var result userTransactionsResult
+	err := s.query(ctx, "get-user-transactions", &result, userTransactionsQuery, variables)
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]uint64, 0, len(result.UserTransactions))
+	for _, tx := range result.UserTransactions {
+		version, err := strconv.ParseUint(tx.Version.String(), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
+
+// GetTransactionByVersion returns the transaction of the given version.
+func (s *AptosSDK) GetTransactionByVersion(ctx context.Context, version uint64) (*Transaction, error) {
+	s.rl.Take()
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetResult(&Transaction{}).
+		Get("/v1/transactions/by_version/" + strconv.FormatUint(version, 10))
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.metrics.IncRpcRequest(clientName, "get-transaction-by-version", resp.StatusCode())
+
+	if resp.IsError() {
+		if resp.StatusCode() == http.StatusTooManyRequests {
+			return nil, ErrTooManyRequests
+		}
+		return nil, fmt.Errorf("status code: %s. %s", resp.Status(), string(resp.Body()))
+	}
+
+	result := resp.Result().(*Transaction)
+	if result == nil {
+		return nil, fmt.Errorf("empty response")
+	}
+	return result, nil
+}
+
+func (s *AptosSDK) query(ctx context.Context, operation string, result any, query string, variables map[string]any) error {
+	s.rl.Take()
+	response := &graphqlResponse{Data: result}
+	resp, err := s.indexer.R().
+		SetContext(ctx).
+		SetBody(graphqlRequest{Query: query, Variables: variables}).
+		SetResult(response).
+		Post("")
+
+	if err != nil {
+		return err
+	}
+
+	s.metrics.IncRpcRequest(clientName, operation, resp.StatusCode())
+
+	if resp.IsError() {
+		if resp.StatusCode() == http.StatusTooManyRequests {
+			return ErrTooManyRequests
+		}
+		return fmt.Errorf("status code: %s. %s", resp.Status(), string(resp.Body()))
+	}
+
+	if len(response.Errors) > 0 {
+		return fmt.Errorf("graphql error: %s", response.Errors[0].Message)
+	}
+	return nil
+}
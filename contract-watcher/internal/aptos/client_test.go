@@ -0,0 +1,65 @@
+package aptos
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"go.uber.org/ratelimit"
+)
+
+func TestAptosSDK_GetUserTransactionVersions(t *testing.T) {
+	functions := []string{"0x1::complete_transfer::submit_vaa_entry"}
+	var tests = []struct {
+		name         string
+		response     string
+		wantVersions []uint64
+		wantErr      bool
+	}{
+		{
+			name:         "versions encoded as numbers and strings",
+			response:     `{"data":{"user_transactions":[{"version":1574291021},{"version":"1574291030"}]}}`,
+			wantVersions: []uint64{1574291021, 1574291030},
+		},
+		{
+			name:         "no transactions",
+			response:     `{"data":{"user_transactions":[]}}`,
+			wantVersions: []uint64{},
+		},
+		{
+			name:     "graphql error",
+			response: `{"errors":[{"message":"field 'user_transactions' not found"}]}`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				var request graphqlRequest
+				assert.Nil(t, json.NewDecoder(r.Body).Decode(&request))
+				assert.Equal(t, userTransactionsQuery, request.Query)
+				assert.Equal(t, []any{functions[0]}, request.Variables["functions"])
+				assert.Equal(t, float64(10), request.Variables["from"])
+				assert.Equal(t, float64(20), request.Variables["to"])
+				assert.Equal(t, float64(100), request.Variables["limit"])
+				rw.Header().Set("Content-Type", "application/json")
+				rw.Write([]byte(tc.response))
+			}))
+			defer server.Close()
+
+			client := NewAptosSDK("", server.URL, ratelimit.NewUnlimited(), metrics.NewNoopMetrics())
+			versions, err := client.GetUserTransactionVersions(context.Background(), functions, 10, 20, 100)
+			if tc.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.wantVersions, versions)
+		})
+	}
+}
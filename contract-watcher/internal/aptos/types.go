@@ -0,0 +1,45 @@
+package aptos
+
+import "encoding/json"
+
+type graphqlRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+type graphqlError struct {
+	Message string `json:"message"`
+}
+
+type graphqlResponse struct {
+	Data   any            `json:"data"`
+	Errors []graphqlError `json:"errors"`
+}
+
+// userTransactionsResult is the result of the user_transactions indexer queries.
+type userTransactionsResult struct {
+	UserTransactions []struct {
+		// Version is a bigint, it can be encoded as a number or a string.
+		Version json.Number `json:"version"`
+	} `json:"user_transactions"`
+}
+
+// Transaction is a transaction returned by the transactions endpoint.
+type Transaction struct {
+	Type      string   `json:"type"`
+	Version   string   `json:"version"`
+	Hash      string   `json:"hash"`
+	Sender    string   `json:"sender"`
+	Success   bool     `json:"success"`
+	VmStatus  string   `json:"vm_status"`
+	Timestamp string   `json:"timestamp"`
+	Payload   *Payload `json:"payload"`
+}
+
+// Payload is the payload of a user transaction.
+type Payload struct {
+	Type          string   `json:"type"`
+	Function      string   `json:"function"`
+	TypeArguments []string `json:"type_arguments"`
+	Arguments     []any    `json:"arguments"`
+}
@@ -116,6 +116,46 @@ func (s *EvmSDK) GetTransactionReceipt(ctx context.Context, txHash string) (*Tra
 	return &result.Result, nil
 }
 
+// GetLogs returns the logs emitted by the given addresses between fromBlock and toBlock (both inclusive)
+// whose first topic matches any of the given topics.
+func (s *EvmSDK) GetLogs(ctx context.Context, fromBlock, toBlock uint64, addresses []string, topics []string) ([]Log, error) {
+	s.rl.Take()
+	filter := LogFilter{
+		FromBlock: utils.EncodeHex(fromBlock),
+		ToBlock:   utils.EncodeHex(toBlock),
+		Address:   addresses,
+		Topics:    [][]string{topics},
+	}
+	req := newEvmRequest("eth_getLogs", filter)
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetBody(req).
+		SetResult(&getLogsResponse{}).
+		Post("")
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.metrics.IncRpcRequest(clientName, "get-logs", resp.StatusCode())
+
+	if resp.IsError() {
+		if resp.StatusCode() == http.StatusTooManyRequests {
+			return nil, ErrTooManyRequests
+		}
+		return nil, fmt.Errorf("status code: %s. %s", resp.Status(), string(resp.Body()))
+	}
+
+	result := resp.Result().(*getLogsResponse)
+	if result == nil {
+		return nil, fmt.Errorf("empty response")
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("rpc error: %d. %s", result.Error.Code, result.Error.Message)
+	}
+	return result.Result, nil
+}
+
 func newEvmRequest(method string, params ...any) EvmRequest {
 	return EvmRequest{
 		Jsonrpc: "2.0",
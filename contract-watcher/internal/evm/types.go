@@ -28,6 +28,7 @@ type Transaction struct {
 
 type GetBlockResult struct {
 	Hash         string        `json:"hash"`
+	ParentHash   string        `json:"parentHash"`
 	Number       string        `json:"number"`
 	Timestamp    string        `json:"timestamp"`
 	Transactions []Transaction `json:"transactions"`
@@ -58,6 +59,36 @@ type TransactionReceiptResult struct {
 	Type              string  `json:"type"`
 }
 
+// LogFilter is the filter object of an eth_getLogs request.
+type LogFilter struct {
+	FromBlock string     `json:"fromBlock"`
+	ToBlock   string     `json:"toBlock"`
+	Address   []string   `json:"address,omitempty"`
+	Topics    [][]string `json:"topics,omitempty"`
+}
+
+type Log struct {
+	Address          string   `json:"address"`
+	Topics           []string `json:"topics"`
+	Data             string   `json:"data"`
+	BlockNumber      string   `json:"blockNumber"`
+	BlockHash        string   `json:"blockHash"`
+	TransactionHash  string   `json:"transactionHash"`
+	TransactionIndex string   `json:"transactionIndex"`
+	LogIndex         string   `json:"logIndex"`
+	Removed          bool     `json:"removed"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type getLogsResponse struct {
+	Result []Log     `json:"result"`
+	Error  *rpcError `json:"error"`
+}
+
 type EvmRequest struct {
 	Jsonrpc string `json:"jsonrpc"`
 	Method  string `json:"method"`
@@ -11,4 +11,6 @@ type Metrics interface {
 	SetCurrentBlock(chain sdk.ChainID, block uint64)
 	IncDestinationTrxSaved(chain sdk.ChainID)
 	IncRpcRequest(client string, method string, statusCode int)
+	ObserveReorgDepth(chain sdk.ChainID, depth uint64)
+	IncRollbackDestinationTrx(chain sdk.ChainID, count int64)
 }
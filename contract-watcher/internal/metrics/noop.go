@@ -22,3 +22,9 @@ func (m *NoopMetrics) IncDestinationTrxSaved(chain sdk.ChainID) {
 
 func (m *NoopMetrics) IncRpcRequest(client string, method string, statusCode int) {
 }
+
+func (m *NoopMetrics) ObserveReorgDepth(chain sdk.ChainID, depth uint64) {
+}
+
+func (m *NoopMetrics) IncRollbackDestinationTrx(chain sdk.ChainID, count int64) {
+}
@@ -13,6 +13,8 @@ type PrometheusMetrics struct {
 	lastBlock           *prometheus.GaugeVec
 	currentBlock        *prometheus.GaugeVec
 	requestsTotal       *prometheus.CounterVec
+	reorgDepth          *prometheus.HistogramVec
+	rollbackTrxCount    *prometheus.CounterVec
 }
 
 // NewPrometheusMetrics returns a new instance of PrometheusMetrics.
@@ -53,11 +55,28 @@ func NewPrometheusMetrics(environment string) *PrometheusMetrics {
 		[]string{"client", "operation", "status_code"},
 	)
 
+	reorgDepth := promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:        "reorg_depth_by_chain",
+			Help:        "Depth of the reorgs detected by chain",
+			ConstLabels: constLabels,
+			Buckets:     []float64{1, 2, 3, 5, 10, 20, 50, 100},
+		}, []string{"chain"})
+
+	rollbackTrxCount := promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "rollback_destination_tx_count_by_chain",
+			Help:        "Total number of destination trx rolled back by reorgs by chain",
+			ConstLabels: constLabels,
+		}, []string{"chain"})
+
 	return &PrometheusMetrics{
 		lastBlock:           lastBlock,
 		currentBlock:        currentBlock,
 		destinationTrxCount: destinationTrxCount,
 		requestsTotal:       requestsTotal,
+		reorgDepth:          reorgDepth,
+		rollbackTrxCount:    rollbackTrxCount,
 	}
 }
 
@@ -76,3 +95,11 @@ func (m *PrometheusMetrics) IncDestinationTrxSaved(chain sdk.ChainID) {
 func (m *PrometheusMetrics) IncRpcRequest(client string, operation string, statusCode int) {
 	m.requestsTotal.WithLabelValues(client, operation, strconv.Itoa(statusCode)).Inc()
 }
+
+func (m *PrometheusMetrics) ObserveReorgDepth(chain sdk.ChainID, depth uint64) {
+	m.reorgDepth.WithLabelValues(chain.String()).Observe(float64(depth))
+}
+
+func (m *PrometheusMetrics) IncRollbackDestinationTrx(chain sdk.ChainID, count int64) {
+	m.rollbackTrxCount.WithLabelValues(chain.String()).Add(float64(count))
+}
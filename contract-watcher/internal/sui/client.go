@@ -0,0 +1,114 @@
+package sui
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"go.uber.org/ratelimit"
+)
+
+var ErrTooManyRequests = fmt.Errorf("too many requests")
+
+const (
+	clientName = "sui"
+	// maxQueryTransactionBlocks is the maximum page size allowed by suix_queryTransactionBlocks.
+	maxQueryTransactionBlocks = 50
+)
+
+// SuiSDK is a client for the Sui JSON-RPC API.
+type SuiSDK struct {
+	client  *resty.Client
+	rl      ratelimit.Limiter
+	metrics metrics.Metrics
+}
+
+// NewSuiSDK creates a new SuiSDK.
+func NewSuiSDK(url string, rl ratelimit.Limiter, metrics metrics.Metrics) *SuiSDK {
+	return &SuiSDK{
+		rl:      rl,
+		client:  resty.New().SetBaseURL(url),
+		metrics: metrics,
+	}
+}
+
+// GetLatestCheckpoint returns the sequence number of the latest executed checkpoint.
+func (s *SuiSDK) GetLatestCheckpoint(ctx context.Context) (uint64, error) {
+	var result string
+	err := s.call(ctx, "get-latest-checkpoint", &result, "sui_getLatestCheckpointSequenceNumber")
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(result, 10, 64)
+}
+
+// GetCheckpoint returns a checkpoint by its sequence number.
+func (s *SuiSDK) GetCheckpoint(ctx context.Context, sequence uint64) (*Checkpoint, error) {
+	var result Checkpoint
+	err := s.call(ctx, "get-checkpoint", &result, "sui_getCheckpoint", strconv.FormatUint(sequence, 10))
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// QueryTransactionBlocks returns a page of the transaction blocks matching the query. The first page is
+// requested with an empty cursor and the next ones with the cursor of the previous page.
+func (s *SuiSDK) QueryTransactionBlocks(ctx context.Context, query TransactionBlockQuery, cursor string, limit uint64, descending bool) (*TransactionBlocksPage, error) {
+	var pageCursor any
+	if cursor != "" {
+		pageCursor = cursor
+	}
+	if limit > maxQueryTransactionBlocks {
+		limit = maxQueryTransactionBlocks
+	}
+	var result TransactionBlocksPage
+	err := s.call(ctx, "query-transaction-blocks", &result, "suix_queryTransactionBlocks", query, pageCursor, limit, descending)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (s *SuiSDK) call(ctx context.Context, operation string, result any, method string, params ...any) error {
+	s.rl.Take()
+	response := &suiResponse{Result: result}
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetBody(newSuiRequest(method, params...)).
+		SetResult(response).
+		Post("")
+
+	if err != nil {
+		return err
+	}
+
+	s.metrics.IncRpcRequest(clientName, operation, resp.StatusCode())
+
+	if resp.IsError() {
+		if resp.StatusCode() == http.StatusTooManyRequests {
+			return ErrTooManyRequests
+		}
+		return fmt.Errorf("status code: %s. %s", resp.Status(), string(resp.Body()))
+	}
+
+	if response.Error != nil {
+		return fmt.Errorf("rpc error: %d. %s", response.Error.Code, response.Error.Message)
+	}
+	return nil
+}
+
+func newSuiRequest(method string, params ...any) suiRequest {
+	if params == nil {
+		params = []any{}
+	}
+	return suiRequest{
+		Jsonrpc: "2.0",
+		Method:  method,
+		Params:  params,
+		ID:      1,
+	}
+}
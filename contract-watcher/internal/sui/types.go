@@ -0,0 +1,102 @@
+package sui
+
+import "encoding/json"
+
+type suiRequest struct {
+	Jsonrpc string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+	ID      int    `json:"id"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type suiResponse struct {
+	Result any       `json:"result"`
+	Error  *rpcError `json:"error"`
+}
+
+// TransactionBlockOptions are the options of the returned transaction blocks.
+type TransactionBlockOptions struct {
+	ShowInput   bool `json:"showInput"`
+	ShowEffects bool `json:"showEffects"`
+}
+
+// TransactionBlockQuery is the query of suix_queryTransactionBlocks.
+type TransactionBlockQuery struct {
+	Filter  TransactionFilter       `json:"filter"`
+	Options TransactionBlockOptions `json:"options"`
+}
+
+// TransactionFilter is the filter of a transaction block query. Only move function filters are supported.
+type TransactionFilter struct {
+	MoveFunction *MoveFunctionFilter `json:"MoveFunction,omitempty"`
+}
+
+// MoveFunctionFilter matches the transactions calling a move function of a package.
+type MoveFunctionFilter struct {
+	Package  string `json:"package"`
+	Module   string `json:"module,omitempty"`
+	Function string `json:"function,omitempty"`
+}
+
+// Checkpoint is a sui checkpoint with the digests of its transactions.
+type Checkpoint struct {
+	SequenceNumber string   `json:"sequenceNumber"`
+	Transactions   []string `json:"transactions"`
+}
+
+// TransactionBlocksPage is a page of transaction blocks returned by suix_queryTransactionBlocks.
+type TransactionBlocksPage struct {
+	Data        []TransactionBlock `json:"data"`
+	NextCursor  *string            `json:"nextCursor"`
+	HasNextPage bool               `json:"hasNextPage"`
+}
+
+// TransactionBlock is a sui transaction block.
+type TransactionBlock struct {
+	Digest      string `json:"digest"`
+	TimestampMs string `json:"timestampMs"`
+	Checkpoint  string `json:"checkpoint"`
+	Transaction struct {
+		Data struct {
+			Sender      string               `json:"sender"`
+			Transaction TransactionBlockKind `json:"transaction"`
+		} `json:"data"`
+	} `json:"transaction"`
+	Effects struct {
+		Status struct {
+			Status string `json:"status"`
+			Error  string `json:"error"`
+		} `json:"status"`
+	} `json:"effects"`
+}
+
+// TransactionBlockKind is the kind of a transaction block. Only programmable transactions have inputs and commands.
+type TransactionBlockKind struct {
+	Kind         string    `json:"kind"`
+	Inputs       []Input   `json:"inputs"`
+	Transactions []Command `json:"transactions"`
+}
+
+// Input is an input of a programmable transaction.
+type Input struct {
+	Type      string          `json:"type"`
+	ValueType string          `json:"valueType"`
+	Value     json.RawMessage `json:"value"`
+}
+
+// Command is a command of a programmable transaction. Only move calls are decoded.
+type Command struct {
+	MoveCall *MoveCall `json:"MoveCall"`
+}
+
+// MoveCall is a move call command.
+type MoveCall struct {
+	Package  string `json:"package"`
+	Module   string `json:"module"`
+	Function string `json:"function"`
+}
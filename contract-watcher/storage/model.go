@@ -17,6 +17,8 @@ type DestinationTx struct {
 	BlockNumber string      `bson:"blockNumber"`
 	Timestamp   *time.Time  `bson:"timestamp"`
 	UpdatedAt   *time.Time  `bson:"updatedAt"`
+	// Protocol is the tag of the protocol built on top of wormhole that redeemed the VAA (e.g. NTT or CCTP).
+	Protocol string `bson:"protocol,omitempty"`
 }
 
 // TransactionUpdate represents a transaction document.
@@ -35,6 +37,7 @@ func (t *TransactionUpdate) ToMap() map[string]string {
 		"destination.from":        t.Destination.From,
 		"destination.to":          t.Destination.To,
 		"destination.blockNumber": t.Destination.BlockNumber,
+		"destination.protocol":    t.Destination.Protocol,
 	}
 }
 
@@ -42,4 +45,48 @@ type WatcherBlock struct {
 	ID          string    `bson:"_id"`
 	BlockNumber int64     `bson:"blockNumber"`
 	UpdatedAt   time.Time `bson:"updatedAt"`
+	// RecentBlocks are the hashes of the last processed blocks, used to detect reorgs.
+	RecentBlocks []ProcessedBlock `bson:"recentBlocks,omitempty"`
+}
+
+// ProcessedBlock represents the hash of a processed block.
+type ProcessedBlock struct {
+	Number uint64 `bson:"number"`
+	Hash   string `bson:"hash"`
+}
+
+// Backfill job statuses.
+const (
+	BackfillJobStatusRunning   = "running"
+	BackfillJobStatusCompleted = "completed"
+	BackfillJobStatusCancelled = "cancelled"
+	// BackfillJobStatusFailed is the status of a job that finished with failed chunks.
+	BackfillJobStatusFailed = "failed"
+)
+
+// BackfillJob represents a backfill of a block range of a blockchain split in chunks.
+type BackfillJob struct {
+	ID              string          `bson:"_id" json:"id"`
+	Blockchain      string          `bson:"blockchain" json:"blockchain"`
+	FromBlock       uint64          `bson:"fromBlock" json:"fromBlock"`
+	ToBlock         uint64          `bson:"toBlock" json:"toBlock"`
+	ChunkSize       uint64          `bson:"chunkSize" json:"chunkSize"`
+	Concurrency     int             `bson:"concurrency" json:"concurrency"`
+	Status          string          `bson:"status" json:"status"`
+	Chunks          []BackfillChunk `bson:"chunks" json:"chunks"`
+	CompletedChunks int             `bson:"completedChunks" json:"completedChunks"`
+	CreatedAt       time.Time       `bson:"createdAt" json:"createdAt"`
+	UpdatedAt       time.Time       `bson:"updatedAt" json:"updatedAt"`
+	FinishedAt      *time.Time      `bson:"finishedAt,omitempty" json:"finishedAt,omitempty"`
+}
+
+// BackfillChunk represents a block range of a backfill job.
+type BackfillChunk struct {
+	FromBlock   uint64     `bson:"fromBlock" json:"fromBlock"`
+	ToBlock     uint64     `bson:"toBlock" json:"toBlock"`
+	Completed   bool       `bson:"completed" json:"completed"`
+	CompletedAt *time.Time `bson:"completedAt,omitempty" json:"completedAt,omitempty"`
+	// Error is the error of the last attempt to process a failed chunk.
+	Error    string     `bson:"error,omitempty" json:"error,omitempty"`
+	FailedAt *time.Time `bson:"failedAt,omitempty" json:"failedAt,omitempty"`
 }
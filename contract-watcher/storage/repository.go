@@ -3,9 +3,12 @@ package storage
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/alert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
 	cwAlert "github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/alert"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
@@ -28,6 +31,8 @@ type Repository struct {
 	collections struct {
 		watcherBlock       *mongo.Collection
 		globalTransactions *mongo.Collection
+		parsedVaa          *mongo.Collection
+		backfillJobs       *mongo.Collection
 	}
 }
 
@@ -36,9 +41,13 @@ func NewRepository(db *mongo.Database, metrics metrics.Metrics, alerts alert.Ale
 	return &Repository{db, log, metrics, alerts, struct {
 		watcherBlock       *mongo.Collection
 		globalTransactions *mongo.Collection
+		parsedVaa          *mongo.Collection
+		backfillJobs       *mongo.Collection
 	}{
 		watcherBlock:       db.Collection("watcherBlock"),
 		globalTransactions: db.Collection("globalTransactions"),
+		parsedVaa:          db.Collection("parsedVaa"),
+		backfillJobs:       db.Collection("backfillJobs"),
 	}}
 }
 
@@ -66,6 +75,19 @@ func (s *Repository) UpsertGlobalTransaction(ctx context.Context, chainID sdk.Ch
 
 }
 
+// SendProcessBlocksAlert sends an alert when a range of blocks of a chain cannot be processed.
+func (s *Repository) SendProcessBlocksAlert(ctx context.Context, chainID sdk.ChainID, fromBlock, toBlock uint64, err error) {
+	alertContext := alert.AlertContext{
+		Details: map[string]string{
+			"chainId":   chainID.String(),
+			"fromBlock": strconv.FormatUint(fromBlock, 10),
+			"toBlock":   strconv.FormatUint(toBlock, 10),
+		},
+		Error: err,
+	}
+	s.alerts.CreateAndSend(ctx, cwAlert.ErrorProcessBlocks, alertContext)
+}
+
 func (s *Repository) GetGlobalTransactionByID(ctx context.Context, id string) (TransactionUpdate, error) {
 	var tx TransactionUpdate
 	err := s.collections.globalTransactions.FindOne(ctx, bson.M{"_id": id}).Decode(&tx)
@@ -78,6 +100,28 @@ func (s *Repository) GetGlobalTransactionByID(ctx context.Context, id string) (T
 	return tx, nil
 }
 
+// GetVaaIDByCctpNonce returns the id of the wormhole CCTP integration VAA of a circle message,
+// identified by its source domain and nonce.
+func (s *Repository) GetVaaIDByCctpNonce(ctx context.Context, sourceDomain uint32, nonce uint64) (string, error) {
+	filter := bson.M{
+		"appIds":                     domain.AppIdCCTP,
+		"parsedPayload.sourceDomain": sourceDomain,
+		"parsedPayload.nonce":        strconv.FormatUint(nonce, 10),
+	}
+	var result struct {
+		ID string `bson:"_id"`
+	}
+	opts := options.FindOne().SetProjection(bson.M{"_id": 1})
+	err := s.collections.parsedVaa.FindOne(ctx, filter, opts).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return "", ErrDocNotFound
+		}
+		return "", err
+	}
+	return result.ID, nil
+}
+
 func (s *Repository) UpdateWatcherBlock(ctx context.Context, chainID sdk.ChainID, watcherBlock WatcherBlock) error {
 	update := bson.M{
 		"$set":         watcherBlock,
@@ -92,6 +136,38 @@ func (s *Repository) UpdateWatcherBlock(ctx context.Context, chainID sdk.ChainID
 	return err
 }
 
+// GetWatcherBlock returns the last block processed by the watcher of the blockchain.
+func (s *Repository) GetWatcherBlock(ctx context.Context, blockchain string) (*WatcherBlock, error) {
+	var block WatcherBlock
+	err := s.collections.watcherBlock.FindOne(ctx, bson.M{"_id": blockchain}).Decode(&block)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrDocNotFound
+		}
+		return nil, err
+	}
+	return &block, nil
+}
+
+// RollbackDestinationTxs removes the destination txs of the chain included in the given blocks.
+// It is used when the blocks were orphaned by a reorg.
+func (s *Repository) RollbackDestinationTxs(ctx context.Context, chainID sdk.ChainID, blockNumbers []string) (int64, error) {
+	filter := bson.M{
+		"destinationTx.chainId":     chainID,
+		"destinationTx.blockNumber": bson.M{"$in": blockNumbers},
+	}
+	update := bson.M{
+		"$unset": bson.M{"destinationTx": ""},
+		"$inc":   bson.D{{Key: "revision", Value: 1}},
+	}
+	result, err := s.collections.globalTransactions.UpdateMany(ctx, filter, update)
+	if err != nil {
+		s.log.Error("Error rolling back destination txs", zap.Error(err))
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
 func (s *Repository) GetCurrentBlock(ctx context.Context, blockchain string, defaultBlock int64) (int64, error) {
 	var block WatcherBlock
 	err := s.collections.watcherBlock.FindOne(ctx, bson.M{"_id": blockchain}).Decode(&block)
@@ -103,3 +179,105 @@ func (s *Repository) GetCurrentBlock(ctx context.Context, blockchain string, def
 	}
 	return block.BlockNumber, nil
 }
+
+// InsertBackfillJob saves a new backfill job.
+func (s *Repository) InsertBackfillJob(ctx context.Context, job *BackfillJob) error {
+	_, err := s.collections.backfillJobs.InsertOne(ctx, job)
+	if err != nil {
+		s.log.Error("Error inserting backfill job", zap.String("id", job.ID), zap.Error(err))
+	}
+	return err
+}
+
+// GetBackfillJob returns a backfill job by id.
+func (s *Repository) GetBackfillJob(ctx context.Context, id string) (*BackfillJob, error) {
+	var job BackfillJob
+	err := s.collections.backfillJobs.FindOne(ctx, bson.M{"_id": id}).Decode(&job)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrDocNotFound
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+// FindBackfillJobsByStatus returns the backfill jobs with the given status.
+func (s *Repository) FindBackfillJobsByStatus(ctx context.Context, status string) ([]BackfillJob, error) {
+	cur, err := s.collections.backfillJobs.Find(ctx, bson.M{"status": status})
+	if err != nil {
+		return nil, err
+	}
+	var jobs []BackfillJob
+	if err := cur.All(ctx, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// CompleteBackfillChunk marks a chunk of a backfill job as completed.
+func (s *Repository) CompleteBackfillChunk(ctx context.Context, id string, chunk int) error {
+	now := time.Now()
+	filter := bson.M{
+		"_id": id,
+		fmt.Sprintf("chunks.%d.completed", chunk): false,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			fmt.Sprintf("chunks.%d.completed", chunk):   true,
+			fmt.Sprintf("chunks.%d.completedAt", chunk): now,
+			"updatedAt": now,
+		},
+		"$unset": bson.M{
+			fmt.Sprintf("chunks.%d.error", chunk):    "",
+			fmt.Sprintf("chunks.%d.failedAt", chunk): "",
+		},
+		"$inc": bson.M{"completedChunks": 1},
+	}
+	_, err := s.collections.backfillJobs.UpdateOne(ctx, filter, update)
+	if err != nil {
+		s.log.Error("Error completing backfill chunk", zap.String("id", id), zap.Int("chunk", chunk), zap.Error(err))
+	}
+	return err
+}
+
+// FailBackfillChunk records the error of a chunk of a backfill job that cannot be processed.
+func (s *Repository) FailBackfillChunk(ctx context.Context, id string, chunk int, cause string) error {
+	now := time.Now()
+	filter := bson.M{
+		"_id": id,
+		fmt.Sprintf("chunks.%d.completed", chunk): false,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			fmt.Sprintf("chunks.%d.error", chunk):    cause,
+			fmt.Sprintf("chunks.%d.failedAt", chunk): now,
+			"updatedAt":                              now,
+		},
+	}
+	_, err := s.collections.backfillJobs.UpdateOne(ctx, filter, update)
+	if err != nil {
+		s.log.Error("Error failing backfill chunk", zap.String("id", id), zap.Int("chunk", chunk), zap.Error(err))
+	}
+	return err
+}
+
+// UpdateBackfillJobStatus updates the status of a running backfill job. It returns ErrDocNotFound
+// if the job does not exist or it is already finished.
+func (s *Repository) UpdateBackfillJobStatus(ctx context.Context, id string, status string) error {
+	now := time.Now()
+	set := bson.M{"status": status, "updatedAt": now}
+	if status != BackfillJobStatusRunning {
+		set["finishedAt"] = now
+	}
+	filter := bson.M{"_id": id, "status": BackfillJobStatusRunning}
+	result, err := s.collections.backfillJobs.UpdateOne(ctx, filter, bson.M{"$set": set})
+	if err != nil {
+		s.log.Error("Error updating backfill job status", zap.String("id", id), zap.Error(err))
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrDocNotFound
+	}
+	return nil
+}
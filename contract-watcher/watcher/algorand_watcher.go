@@ -0,0 +1,233 @@
+package watcher
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/algorand"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/storage"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// Algorand token bridge application call to complete a transfer.
+const AlgorandMethodCompleteTransfer = "completeTransfer"
+
+// AlgorandWatcher is a watcher for the algorand chain. The transactions are processed by round.
+type AlgorandWatcher struct {
+	client        *algorand.AlgorandSDK
+	chainID       vaa.ChainID
+	blockchain    string
+	applicationID uint64
+	sizeBlocks    uint64
+	waitSeconds   uint16
+	initialBlock  int64
+	repository    *storage.Repository
+	logger        *zap.Logger
+	close         chan bool
+	wg            sync.WaitGroup
+	metrics       metrics.Metrics
+}
+
+// AlgorandParams are the params for the algorand watcher.
+type AlgorandParams struct {
+	Blockchain string
+	// ApplicationID is the token bridge application id.
+	ApplicationID uint64
+	SizeBlocks    uint8
+	WaitSeconds   uint16
+	InitialBlock  int64
+}
+
+// NewAlgorandWatcher creates a new algorand watcher.
+func NewAlgorandWatcher(client *algorand.AlgorandSDK, params AlgorandParams, repo *storage.Repository, metrics metrics.Metrics, logger *zap.Logger) *AlgorandWatcher {
+	return &AlgorandWatcher{
+		client:        client,
+		chainID:       vaa.ChainIDAlgorand,
+		blockchain:    params.Blockchain,
+		applicationID: params.ApplicationID,
+		sizeBlocks:    uint64(params.SizeBlocks),
+		waitSeconds:   params.WaitSeconds,
+		initialBlock:  params.InitialBlock,
+		repository:    repo,
+		metrics:       metrics,
+		close:         make(chan bool),
+		logger:        logger.With(zap.String("blockchain", params.Blockchain), zap.Uint16("chainId", uint16(vaa.ChainIDAlgorand))),
+	}
+}
+
+// GetBlockchain returns the blockchain name.
+func (w *AlgorandWatcher) GetBlockchain() string {
+	return w.blockchain
+}
+
+// Start starts the algorand watcher.
+func (w *AlgorandWatcher) Start(ctx context.Context) error {
+	// get the current round for the chain.
+	cRound, err := w.repository.GetCurrentBlock(ctx, w.blockchain, w.initialBlock)
+	if err != nil {
+		w.logger.Error("cannot get current round", zap.Error(err))
+		return err
+	}
+	currentRound := uint64(cRound)
+	w.wg.Add(1)
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("clossing algorand watcher by context")
+			w.wg.Done()
+			return nil
+		case <-w.close:
+			w.logger.Info("clossing algorand watcher")
+			w.wg.Done()
+			return nil
+		default:
+			// get the latest round for the chain.
+			lastRound, err := w.client.GetLatestRound(ctx)
+			if err != nil {
+				w.logger.Error("cannot get algorand latest round", zap.Error(err))
+			}
+
+			if currentRound < lastRound {
+				w.metrics.SetLastBlock(w.chainID, lastRound)
+				totalPages := getTotalBlocks(lastRound, currentRound+1, w.sizeBlocks)
+				for i := uint64(0); i < totalPages; i++ {
+					fromRound, toRound := getPage(currentRound+1, i, w.sizeBlocks, lastRound)
+					w.logger.Debug("processing rounds", zap.Uint64("from", fromRound), zap.Uint64("to", toRound))
+					if err := w.processRounds(ctx, fromRound, toRound, true); err != nil {
+						w.logger.Error("cannot process rounds", zap.Uint64("from", fromRound), zap.Uint64("to", toRound), zap.Error(err))
+					}
+				}
+				currentRound = lastRound
+			} else {
+				w.logger.Debug("waiting for new algorand rounds")
+				select {
+				case <-ctx.Done():
+					w.wg.Done()
+					return nil
+				case <-time.After(time.Duration(w.waitSeconds) * time.Second):
+				}
+			}
+		}
+	}
+}
+
+// Backfill processes the rounds between fromRound and toRound. It stops at the first page of rounds that cannot be processed.
+func (w *AlgorandWatcher) Backfill(ctx context.Context, fromRound uint64, toRound uint64, pageSize uint64, persistBlock bool) error {
+	totalPages := getTotalBlocks(toRound, fromRound, pageSize)
+	for i := uint64(0); i < totalPages; i++ {
+		fromRound, toRound := getPage(fromRound, i, pageSize, toRound)
+		w.logger.Info("processing rounds", zap.Uint64("from", fromRound), zap.Uint64("to", toRound))
+		if err := w.processRounds(ctx, fromRound, toRound, persistBlock); err != nil {
+			w.logger.Error("cannot process rounds", zap.Uint64("from", fromRound), zap.Uint64("to", toRound), zap.Error(err))
+			return err
+		}
+		w.logger.Info("rounds processed", zap.Uint64("from", fromRound), zap.Uint64("to", toRound))
+	}
+	return nil
+}
+
+func (w *AlgorandWatcher) processRounds(ctx context.Context, fromRound uint64, toRound uint64, updateWatcherBlock bool) error {
+	return retry.Do(
+		func() error {
+			next := ""
+			for {
+				response, err := w.client.GetApplicationTransactions(ctx, w.applicationID, fromRound, toRound, next)
+				if err != nil {
+					w.logger.Error("cannot get application transactions", zap.Uint64("from", fromRound), zap.Uint64("to", toRound), zap.Error(err))
+					return err
+				}
+
+				for _, tx := range response.Transactions {
+					globalTx, err := getAlgorandGlobalTransaction(w.chainID, w.applicationID, tx)
+					if err != nil {
+						w.logger.Error("cannot get transaction data", zap.String("txHash", tx.ID), zap.Uint64("round", tx.ConfirmedRound), zap.Error(err))
+						continue
+					}
+					if globalTx == nil {
+						continue
+					}
+					// update global transaction and check if it should be updated.
+					updateGlobalTransaction(ctx, w.chainID, *globalTx, w.repository, w.logger)
+				}
+
+				if response.NextToken == "" || len(response.Transactions) == 0 {
+					break
+				}
+				next = response.NextToken
+			}
+
+			if updateWatcherBlock {
+				// update the last round processed in the database.
+				watcherBlock := storage.WatcherBlock{
+					ID:          w.blockchain,
+					BlockNumber: int64(toRound),
+					UpdatedAt:   time.Now(),
+				}
+				return w.repository.UpdateWatcherBlock(ctx, w.chainID, watcherBlock)
+			}
+			return nil
+		},
+		retry.Attempts(evmMaxRetries),
+		retry.Delay(evmRetryDelay),
+		retry.Context(ctx),
+	)
+}
+
+// getAlgorandGlobalTransaction returns the destination tx of a token bridge complete transfer.
+// If the transaction does not complete a transfer it returns nil.
+func getAlgorandGlobalTransaction(chainID vaa.ChainID, applicationID uint64, tx algorand.Transaction) (*storage.TransactionUpdate, error) {
+	appTx := tx.ApplicationTransaction
+	if appTx.ApplicationID != applicationID || len(appTx.ApplicationArgs) == 0 {
+		return nil, nil
+	}
+
+	method, err := base64.StdEncoding.DecodeString(appTx.ApplicationArgs[0])
+	if err != nil || string(method) != AlgorandMethodCompleteTransfer {
+		return nil, nil
+	}
+
+	// the second argument of the application call is the VAA.
+	if len(appTx.ApplicationArgs) < 2 {
+		return nil, errors.New("missing VAA argument")
+	}
+	vaaBytes, err := base64.StdEncoding.DecodeString(appTx.ApplicationArgs[1])
+	if err != nil {
+		return nil, err
+	}
+	v, err := vaa.Unmarshal(vaaBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// the indexer only returns transactions committed to the ledger.
+	timestamp := time.Unix(tx.RoundTime, 0)
+	updatedAt := time.Now()
+	return &storage.TransactionUpdate{
+		ID: v.MessageID(),
+		Destination: storage.DestinationTx{
+			ChainID:     chainID,
+			Status:      domain.DstTxStatusConfirmed,
+			Method:      AlgorandMethodCompleteTransfer,
+			TxHash:      tx.ID,
+			From:        tx.Sender,
+			To:          strconv.FormatUint(applicationID, 10),
+			BlockNumber: strconv.FormatUint(tx.ConfirmedRound, 10),
+			Timestamp:   &timestamp,
+			UpdatedAt:   &updatedAt,
+		},
+	}, nil
+}
+
+// Close closes the algorand watcher.
+func (w *AlgorandWatcher) Close() {
+	close(w.close)
+	w.wg.Wait()
+}
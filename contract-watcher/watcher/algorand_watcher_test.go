@@ -0,0 +1,43 @@
+package watcher
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/algorand"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+const testAlgorandTokenBridge uint64 = 842126029
+
+func Test_getAlgorandGlobalTransaction(t *testing.T) {
+	data, err := os.ReadFile("testdata/algorand_transactions.json")
+	assert.Nil(t, err)
+	var response algorand.TransactionsResponse
+	assert.Nil(t, json.Unmarshal(data, &response))
+	txs := response.Transactions
+	assert.Equal(t, 3, len(txs))
+
+	// completed transfer.
+	globalTx, err := getAlgorandGlobalTransaction(vaa.ChainIDAlgorand, testAlgorandTokenBridge, txs[0])
+	assert.Nil(t, err)
+	assert.NotNil(t, globalTx)
+	assert.Equal(t, testRedeemedVaaID, globalTx.ID)
+	assert.Equal(t, vaa.ChainIDAlgorand, globalTx.Destination.ChainID)
+	assert.Equal(t, domain.DstTxStatusConfirmed, globalTx.Destination.Status)
+	assert.Equal(t, AlgorandMethodCompleteTransfer, globalTx.Destination.Method)
+	assert.Equal(t, "Q5G3Z6XHBQ7KMLX4CPM2QHOZ6PRDEFJ4WJ2G7V6ZK3B5WJ2X6L5A", globalTx.Destination.TxHash)
+	assert.Equal(t, "842126029", globalTx.Destination.To)
+	assert.Equal(t, "37400100", globalTx.Destination.BlockNumber)
+	assert.Equal(t, int64(1711999990), globalTx.Destination.Timestamp.Unix())
+
+	// other transactions of the token bridge and the core bridge.
+	for _, tx := range txs[1:] {
+		globalTx, err = getAlgorandGlobalTransaction(vaa.ChainIDAlgorand, testAlgorandTokenBridge, tx)
+		assert.Nil(t, err)
+		assert.Nil(t, globalTx)
+	}
+}
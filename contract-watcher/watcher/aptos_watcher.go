@@ -0,0 +1,303 @@
+package watcher
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/utils"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/aptos"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/storage"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// Aptos token bridge entry functions to complete a transfer.
+const (
+	aptosCompleteTransferModule                 = "complete_transfer"
+	AptosMethodSubmitVaaEntry                   = "submit_vaa_entry"
+	AptosMethodSubmitVaaAndRegisterEntry        = "submit_vaa_and_register_entry"
+	aptosUserTransactionType                    = "user_transaction"
+	aptosMaxTransactionsPerRequest       uint64 = 100
+)
+
+// AptosWatcher is a watcher for the aptos chain. The complete transfers are queried from the indexer by
+// entry function and the last version processed is persisted.
+type AptosWatcher struct {
+	client          *aptos.AptosSDK
+	chainID         vaa.ChainID
+	blockchain      string
+	contractAddress string
+	functions       []string
+	waitSeconds     uint16
+	initialBlock    int64
+	repository      *storage.Repository
+	logger          *zap.Logger
+	close           chan bool
+	wg              sync.WaitGroup
+	metrics         metrics.Metrics
+}
+
+// AptosParams are the params for the aptos watcher.
+type AptosParams struct {
+	Blockchain      string
+	ContractAddress string
+	WaitSeconds     uint16
+	InitialBlock    int64
+}
+
+// NewAptosWatcher creates a new aptos watcher.
+func NewAptosWatcher(client *aptos.AptosSDK, params AptosParams, repo *storage.Repository, metrics metrics.Metrics, logger *zap.Logger) *AptosWatcher {
+	contractAddress := strings.ToLower(params.ContractAddress)
+	functions := []string{
+		fmt.Sprintf("%s::%s::%s", contractAddress, aptosCompleteTransferModule, AptosMethodSubmitVaaEntry),
+		fmt.Sprintf("%s::%s::%s", contractAddress, aptosCompleteTransferModule, AptosMethodSubmitVaaAndRegisterEntry),
+	}
+	return &AptosWatcher{
+		client:          client,
+		chainID:         vaa.ChainIDAptos,
+		blockchain:      params.Blockchain,
+		contractAddress: contractAddress,
+		functions:       functions,
+		waitSeconds:     params.WaitSeconds,
+		initialBlock:    params.InitialBlock,
+		repository:      repo,
+		metrics:         metrics,
+		close:           make(chan bool),
+		logger:          logger.With(zap.String("blockchain", params.Blockchain), zap.Uint16("chainId", uint16(vaa.ChainIDAptos))),
+	}
+}
+
+// GetBlockchain returns the blockchain name.
+func (w *AptosWatcher) GetBlockchain() string {
+	return w.blockchain
+}
+
+// Start starts the aptos watcher.
+func (w *AptosWatcher) Start(ctx context.Context) error {
+	// get the current version for the chain.
+	cVersion, err := w.repository.GetCurrentBlock(ctx, w.blockchain, w.initialBlock)
+	if err != nil {
+		w.logger.Error("cannot get current version", zap.Error(err))
+		return err
+	}
+	currentVersion := uint64(cVersion)
+	w.wg.Add(1)
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("clossing aptos watcher by context")
+			w.wg.Done()
+			return nil
+		case <-w.close:
+			w.logger.Info("clossing aptos watcher")
+			w.wg.Done()
+			return nil
+		default:
+			// get the latest version processed by the indexer, newer versions cannot be queried yet.
+			lastVersion, err := w.client.GetLatestIndexedVersion(ctx)
+			if err != nil {
+				w.logger.Error("cannot get aptos latest indexed version", zap.Error(err))
+			}
+
+			if currentVersion < lastVersion {
+				w.metrics.SetLastBlock(w.chainID, lastVersion)
+				fromVersion := currentVersion + 1
+				w.logger.Debug("processing versions", zap.Uint64("from", fromVersion), zap.Uint64("to", lastVersion))
+				err := w.processVersions(ctx, fromVersion, lastVersion, true)
+				if err == nil {
+					currentVersion = lastVersion
+					continue
+				}
+				// the current version is not advanced, so the versions are processed again.
+				w.logger.Error("cannot process versions", zap.Uint64("from", fromVersion), zap.Uint64("to", lastVersion), zap.Error(err))
+				w.repository.SendProcessBlocksAlert(ctx, w.chainID, fromVersion, lastVersion, err)
+			} else {
+				w.logger.Debug("waiting for new aptos versions")
+			}
+			select {
+			case <-ctx.Done():
+				w.wg.Done()
+				return nil
+			case <-time.After(time.Duration(w.waitSeconds) * time.Second):
+			}
+		}
+	}
+}
+
+// Backfill processes the transactions between fromVersion and toVersion.
+func (w *AptosWatcher) Backfill(ctx context.Context, fromVersion uint64, toVersion uint64, pageSize uint64, persistBlock bool) error {
+	totalPages := getTotalBlocks(toVersion, fromVersion, pageSize)
+	for i := uint64(0); i < totalPages; i++ {
+		fromVersion, toVersion := getPage(fromVersion, i, pageSize, toVersion)
+		w.logger.Info("processing versions", zap.Uint64("from", fromVersion), zap.Uint64("to", toVersion))
+		if err := w.processVersions(ctx, fromVersion, toVersion, persistBlock); err != nil {
+			// stop at the failed page, the next pages are not processed so the persisted version does not skip it.
+			w.logger.Error("cannot process versions", zap.Uint64("from", fromVersion), zap.Uint64("to", toVersion), zap.Error(err))
+			w.repository.SendProcessBlocksAlert(ctx, w.chainID, fromVersion, toVersion, err)
+			return err
+		}
+		w.logger.Info("versions processed", zap.Uint64("from", fromVersion), zap.Uint64("to", toVersion))
+	}
+	return nil
+}
+
+// processVersions processes the complete transfers between fromVersion and toVersion. The watcher block is
+// updated after each page of transfers, and it is not updated past a page that cannot be processed.
+func (w *AptosWatcher) processVersions(ctx context.Context, fromVersion uint64, toVersion uint64, updateWatcherBlock bool) error {
+	for version := fromVersion; version <= toVersion; {
+		var versions []uint64
+		err := w.retry(ctx, func() error {
+			var err error
+			versions, err = w.client.GetUserTransactionVersions(ctx, w.functions, version, toVersion, aptosMaxTransactionsPerRequest)
+			if err != nil {
+				w.logger.Error("cannot get complete transfers", zap.Uint64("from", version), zap.Uint64("to", toVersion), zap.Error(err))
+			}
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, txVersion := range versions {
+			var tx *aptos.Transaction
+			err := w.retry(ctx, func() error {
+				var err error
+				tx, err = w.client.GetTransactionByVersion(ctx, txVersion)
+				if err != nil {
+					w.logger.Error("cannot get transaction", zap.Uint64("version", txVersion), zap.Error(err))
+				}
+				return err
+			})
+			if err != nil {
+				return err
+			}
+
+			globalTx, err := getAptosGlobalTransaction(w.chainID, w.contractAddress, *tx)
+			if err != nil {
+				w.logger.Error("cannot get transaction data", zap.String("txHash", tx.Hash), zap.String("version", tx.Version), zap.Error(err))
+				continue
+			}
+			if globalTx == nil {
+				continue
+			}
+			// update global transaction and check if it should be updated.
+			updateGlobalTransaction(ctx, w.chainID, *globalTx, w.repository, w.logger)
+		}
+
+		// a full page may be followed by more transfers, otherwise the range is complete.
+		lastVersion := toVersion
+		if uint64(len(versions)) == aptosMaxTransactionsPerRequest {
+			lastVersion = versions[len(versions)-1]
+		}
+
+		if updateWatcherBlock {
+			// update the last version processed in the database.
+			watcherBlock := storage.WatcherBlock{
+				ID:          w.blockchain,
+				BlockNumber: int64(lastVersion),
+				UpdatedAt:   time.Now(),
+			}
+			if err := w.repository.UpdateWatcherBlock(ctx, w.chainID, watcherBlock); err != nil {
+				return err
+			}
+		}
+		version = lastVersion + 1
+	}
+	return nil
+}
+
+func (w *AptosWatcher) retry(ctx context.Context, fn func() error) error {
+	return retry.Do(
+		fn,
+		retry.Attempts(evmMaxRetries),
+		retry.Delay(evmRetryDelay),
+		retry.Context(ctx),
+	)
+}
+
+// getAptosGlobalTransaction returns the destination tx of a token bridge complete transfer.
+// If the transaction does not complete a transfer it returns nil.
+func getAptosGlobalTransaction(chainID vaa.ChainID, contractAddress string, tx aptos.Transaction) (*storage.TransactionUpdate, error) {
+	if tx.Type != aptosUserTransactionType || tx.Payload == nil {
+		return nil, nil
+	}
+
+	method, ok := getAptosCompleteTransferMethod(contractAddress, tx.Payload.Function)
+	if !ok {
+		return nil, nil
+	}
+
+	// the first argument of the entry functions is the VAA.
+	if len(tx.Payload.Arguments) == 0 {
+		return nil, errors.New("missing VAA argument")
+	}
+	vaaHex, ok := tx.Payload.Arguments[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid VAA argument type %T", tx.Payload.Arguments[0])
+	}
+	vaaBytes, err := hex.DecodeString(utils.Remove0x(vaaHex))
+	if err != nil {
+		return nil, err
+	}
+	v, err := vaa.Unmarshal(vaaBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	status := domain.DstTxStatusFailedToProcess
+	if tx.Success {
+		status = domain.DstTxStatusConfirmed
+	}
+
+	var timestamp *time.Time
+	if usecs, err := strconv.ParseInt(tx.Timestamp, 10, 64); err == nil {
+		tm := time.UnixMicro(usecs)
+		timestamp = &tm
+	}
+
+	updatedAt := time.Now()
+	return &storage.TransactionUpdate{
+		ID: v.MessageID(),
+		Destination: storage.DestinationTx{
+			ChainID:     chainID,
+			Status:      status,
+			Method:      method,
+			TxHash:      utils.Remove0x(tx.Hash),
+			From:        tx.Sender,
+			To:          contractAddress,
+			BlockNumber: tx.Version,
+			Timestamp:   timestamp,
+			UpdatedAt:   &updatedAt,
+		},
+	}, nil
+}
+
+// getAptosCompleteTransferMethod checks if the function is a token bridge complete transfer entry function
+// (<address>::complete_transfer::<method>) and returns the method.
+func getAptosCompleteTransferMethod(contractAddress string, function string) (string, bool) {
+	parts := strings.Split(function, "::")
+	if len(parts) != 3 || strings.ToLower(parts[0]) != contractAddress || parts[1] != aptosCompleteTransferModule {
+		return "", false
+	}
+	switch parts[2] {
+	case AptosMethodSubmitVaaEntry, AptosMethodSubmitVaaAndRegisterEntry:
+		return parts[2], true
+	default:
+		return "", false
+	}
+}
+
+// Close closes the aptos watcher.
+func (w *AptosWatcher) Close() {
+	close(w.close)
+	w.wg.Wait()
+}
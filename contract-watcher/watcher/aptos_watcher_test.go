@@ -0,0 +1,64 @@
+package watcher
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/aptos"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+const (
+	testAptosTokenBridge = "0x576410486a2da45eee6c949c995670112ddf2fbeedab20350d506328eefc9d4f"
+	testRedeemedVaaID    = "1/ec7372995d5cc8732397fb0ad35c0121e0eaa90d26f828a534cab54391b3a4f5/276772"
+)
+
+func Test_getAptosGlobalTransaction(t *testing.T) {
+	data, err := os.ReadFile("testdata/aptos_transactions.json")
+	assert.Nil(t, err)
+	var txs []aptos.Transaction
+	assert.Nil(t, json.Unmarshal(data, &txs))
+	assert.Equal(t, 4, len(txs))
+
+	// completed transfer.
+	globalTx, err := getAptosGlobalTransaction(vaa.ChainIDAptos, testAptosTokenBridge, txs[0])
+	assert.Nil(t, err)
+	assert.NotNil(t, globalTx)
+	assert.Equal(t, testRedeemedVaaID, globalTx.ID)
+	assert.Equal(t, vaa.ChainIDAptos, globalTx.Destination.ChainID)
+	assert.Equal(t, domain.DstTxStatusConfirmed, globalTx.Destination.Status)
+	assert.Equal(t, AptosMethodSubmitVaaAndRegisterEntry, globalTx.Destination.Method)
+	assert.Equal(t, "9f4b4f8e0d8b7e0f0f1f4c5b2e9a3c7d1e6b5a4f3c2d1e0f9a8b7c6d5e4f3a2b", globalTx.Destination.TxHash)
+	assert.Equal(t, "0x7f2ed3f5a1b8d9a0c6e4b2f1a3c5d7e9b1a2c3d4e5f6a7b8c9d0e1f2a3b4c5d6", globalTx.Destination.From)
+	assert.Equal(t, "1574291021", globalTx.Destination.BlockNumber)
+	assert.Equal(t, int64(1711999990123456), globalTx.Destination.Timestamp.UnixMicro())
+
+	// failed transfer.
+	globalTx, err = getAptosGlobalTransaction(vaa.ChainIDAptos, testAptosTokenBridge, txs[1])
+	assert.Nil(t, err)
+	assert.NotNil(t, globalTx)
+	assert.Equal(t, domain.DstTxStatusFailedToProcess, globalTx.Destination.Status)
+	assert.Equal(t, AptosMethodSubmitVaaEntry, globalTx.Destination.Method)
+
+	// other transactions.
+	for _, tx := range txs[2:] {
+		globalTx, err = getAptosGlobalTransaction(vaa.ChainIDAptos, testAptosTokenBridge, tx)
+		assert.Nil(t, err)
+		assert.Nil(t, globalTx)
+	}
+}
+
+func Test_getAptosCompleteTransferMethod(t *testing.T) {
+	method, ok := getAptosCompleteTransferMethod(testAptosTokenBridge, testAptosTokenBridge+"::complete_transfer::submit_vaa_entry")
+	assert.True(t, ok)
+	assert.Equal(t, AptosMethodSubmitVaaEntry, method)
+
+	_, ok = getAptosCompleteTransferMethod(testAptosTokenBridge, testAptosTokenBridge+"::transfer_tokens::transfer_tokens_entry")
+	assert.False(t, ok)
+
+	_, ok = getAptosCompleteTransferMethod(testAptosTokenBridge, "0x1::complete_transfer::submit_vaa_entry")
+	assert.False(t, ok)
+}
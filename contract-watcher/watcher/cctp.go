@@ -0,0 +1,43 @@
+package watcher
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+)
+
+// circle message header: version (4 bytes), source domain (4 bytes), destination domain (4 bytes) and nonce (8 bytes).
+const cctpMessageHeaderLength = 20
+
+var errInvalidCctpMessage = errors.New("invalid circle message")
+
+// cctpMessage is the header of a message received by the circle message transmitter.
+type cctpMessage struct {
+	Version           uint32
+	SourceDomain      uint32
+	DestinationDomain uint32
+	Nonce             uint64
+}
+
+func parseCctpMessage(message []byte) (*cctpMessage, error) {
+	if len(message) < cctpMessageHeaderLength {
+		return nil, errInvalidCctpMessage
+	}
+	return &cctpMessage{
+		Version:           binary.BigEndian.Uint32(message[0:4]),
+		SourceDomain:      binary.BigEndian.Uint32(message[4:8]),
+		DestinationDomain: binary.BigEndian.Uint32(message[8:12]),
+		Nonce:             binary.BigEndian.Uint64(message[12:20]),
+	}, nil
+}
+
+// getVaaIDByCctpMessage maps a circle message to the VAA of the wormhole CCTP integration
+// deposit with the same source domain and nonce. Circle messages not sent through wormhole
+// return storage.ErrDocNotFound.
+func getVaaIDByCctpMessage(ctx context.Context, message []byte, repository transactionRepository) (string, error) {
+	m, err := parseCctpMessage(message)
+	if err != nil {
+		return "", err
+	}
+	return repository.GetVaaIDByCctpNonce(ctx, m.SourceDomain, m.Nonce)
+}
@@ -3,6 +3,8 @@ package watcher
 import (
 	"context"
 	"encoding/hex"
+	"errors"
+	"math/big"
 	"strconv"
 	"strings"
 	"time"
@@ -28,6 +30,11 @@ type EVMParams struct {
 	WaitSeconds      uint16
 	InitialBlock     int64
 	MethodsByAddress map[string][]config.BlockchainMethod
+	// ConfirmationBlocks is the number of blocks to wait before processing a block.
+	ConfirmationBlocks uint64
+	// TokenBridgeAddress and CoreAddress are only used by the log based watcher.
+	TokenBridgeAddress string
+	CoreAddress        string
 }
 
 type EVMAddressesParams struct {
@@ -87,6 +94,91 @@ func parseInput(input string) (*vaa.VAA, error) {
 	return vaa, nil
 }
 
+// getVaaIDByInput returns the id of the VAA redeemed by a transaction from its input.
+func getVaaIDByInput(ctx context.Context, method config.BlockchainMethod, input string, repository transactionRepository) (string, error) {
+	switch method.ID {
+	case config.MethodIDCctpReceiveMessage:
+		// receiveMessage(bytes message, bytes attestation) does not include the VAA, it is found by the circle message nonce.
+		args, err := getInputArgs(input)
+		if err != nil {
+			return "", err
+		}
+		message, err := getAbiBytes(args, 0, 0)
+		if err != nil {
+			return "", err
+		}
+		return getVaaIDByCctpMessage(ctx, message, repository)
+	case config.MethodIDRedeemTokensWithPayload:
+		// redeemTokensWithPayload((bytes encodedWormholeMessage, bytes circleBridgeMessage, bytes circleAttestation))
+		args, err := getInputArgs(input)
+		if err != nil {
+			return "", err
+		}
+		tuple, err := getAbiOffset(args, 0)
+		if err != nil {
+			return "", err
+		}
+		vaaBytes, err := getAbiBytes(args, tuple, tuple)
+		if err != nil {
+			return "", err
+		}
+		v, err := vaa.Unmarshal(vaaBytes)
+		if err != nil {
+			return "", err
+		}
+		return v.MessageID(), nil
+	default:
+		v, err := parseInput(input)
+		if err != nil {
+			return "", err
+		}
+		return v.MessageID(), nil
+	}
+}
+
+var errInvalidAbiInput = errors.New("invalid abi encoded input")
+
+// getInputArgs returns the abi encoded arguments of a transaction input.
+func getInputArgs(input string) ([]byte, error) {
+	data, err := hex.DecodeString(utils.Remove0x(input))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 4 {
+		return nil, errInvalidAbiInput
+	}
+	return data[4:], nil
+}
+
+// getAbiOffset reads the offset of a dynamic value stored in the 32 bytes word at position.
+func getAbiOffset(data []byte, position int) (int, error) {
+	if position < 0 || position+32 > len(data) {
+		return 0, errInvalidAbiInput
+	}
+	offset := new(big.Int).SetBytes(data[position : position+32])
+	if !offset.IsInt64() || offset.Int64() > int64(len(data)) {
+		return 0, errInvalidAbiInput
+	}
+	return int(offset.Int64()), nil
+}
+
+// getAbiBytes reads a dynamic bytes value whose offset, relative to base, is stored at position.
+func getAbiBytes(data []byte, base int, position int) ([]byte, error) {
+	offset, err := getAbiOffset(data, position)
+	if err != nil {
+		return nil, err
+	}
+	length, err := getAbiOffset(data, base+offset)
+	if err != nil {
+		return nil, err
+	}
+	start := base + offset + 32
+	if start+length > len(data) {
+		return nil, errInvalidAbiInput
+	}
+	return data[start : start+length], nil
+}
+
 func getBlockNumber(s string, logger *zap.Logger) string {
 	value, err := strconv.ParseInt(utils.Remove0x(s), 16, 64)
 	if err != nil {
@@ -106,7 +198,7 @@ func getTimestamp(s string, logger *zap.Logger) *time.Time {
 	return &tm
 }
 
-func processTransaction(ctx context.Context, chainID vaa.ChainID, tx *EvmTransaction, methodsByAddress map[string][]config.BlockchainMethod, repository *storage.Repository, logger *zap.Logger) {
+func processTransaction(ctx context.Context, chainID vaa.ChainID, tx *EvmTransaction, methodsByAddress map[string][]config.BlockchainMethod, repository transactionRepository, logger *zap.Logger) {
 	// get methodID from the transaction.
 	txMethod := getMethodIDByInput(tx.Input)
 
@@ -125,12 +217,15 @@ func processTransaction(ctx context.Context, chainID vaa.ChainID, tx *EvmTransac
 
 	for _, method := range methods {
 		if method.ID == txMethod {
-			// get vaa from transaction input
-			vaa, err := parseInput(tx.Input)
+			// get vaa id from transaction input
+			vaaID, err := getVaaIDByInput(ctx, method, tx.Input, repository)
 			if err != nil {
+				if errors.Is(err, storage.ErrDocNotFound) {
+					log.Debug("circle message not sent through wormhole")
+					return
+				}
 				log.Error("cannot parse VAA", zap.Error(err))
 				return
-
 			}
 
 			// get evm blockchain status code
@@ -142,7 +237,7 @@ func processTransaction(ctx context.Context, chainID vaa.ChainID, tx *EvmTransac
 
 			updatedAt := time.Now()
 			globalTx := storage.TransactionUpdate{
-				ID: vaa.MessageID(),
+				ID: vaaID,
 				Destination: storage.DestinationTx{
 					ChainID:     chainID,
 					Status:      getTxStatus(txStatusCode),
@@ -153,6 +248,7 @@ func processTransaction(ctx context.Context, chainID vaa.ChainID, tx *EvmTransac
 					BlockNumber: getBlockNumber(tx.BlockNumber, log),
 					Timestamp:   getTimestamp(tx.BlockTimestamp, log),
 					UpdatedAt:   &updatedAt,
+					Protocol:    method.Protocol,
 				},
 			}
 
@@ -0,0 +1,334 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/utils"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/config"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/evm"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/storage"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// EvmLogWatcher detects redeems from the token bridge TransferRedeemed event instead of
+// matching the method id of the transactions sent to the contract. This way redeems done
+// through routers, multisigs or unknown wrapper contracts are also tracked.
+type EvmLogWatcher struct {
+	client             *evm.EvmSDK
+	chainID            vaa.ChainID
+	blockchain         string
+	tokenBridgeAddress string
+	coreAddress        string
+	maxBlocks          uint64
+	waitSeconds        uint16
+	initialBlock       int64
+	confirmations      uint64
+	repository         *storage.Repository
+	logger             *zap.Logger
+	close              chan bool
+	wg                 sync.WaitGroup
+	metrics            metrics.Metrics
+}
+
+// evmLogTx groups the wormhole logs emitted by a single transaction.
+type evmLogTx struct {
+	hash        string
+	blockNumber string
+	redeemed    []string
+	published   []string
+}
+
+func NewEvmLogWatcher(client *evm.EvmSDK, params EVMParams, repo *storage.Repository, metrics metrics.Metrics, logger *zap.Logger) *EvmLogWatcher {
+	return &EvmLogWatcher{
+		client:             client,
+		chainID:            params.ChainID,
+		blockchain:         params.Blockchain,
+		tokenBridgeAddress: strings.ToLower(params.TokenBridgeAddress),
+		coreAddress:        strings.ToLower(params.CoreAddress),
+		maxBlocks:          uint64(params.SizeBlocks),
+		waitSeconds:        params.WaitSeconds,
+		initialBlock:       params.InitialBlock,
+		confirmations:      params.ConfirmationBlocks,
+		repository:         repo,
+		metrics:            metrics,
+		close:              make(chan bool),
+		logger:             logger.With(zap.String("blockchain", params.Blockchain), zap.Uint16("chainId", uint16(params.ChainID))),
+	}
+}
+
+func (w *EvmLogWatcher) GetBlockchain() string {
+	return w.blockchain
+}
+
+func (w *EvmLogWatcher) Start(ctx context.Context) error {
+	// get the current block for the chain.
+	cBlock, err := w.repository.GetCurrentBlock(ctx, w.blockchain, w.initialBlock)
+	if err != nil {
+		w.logger.Error("cannot get current block", zap.Error(err))
+		return err
+	}
+	currentBlock := uint64(cBlock)
+	w.wg.Add(1)
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("clossing watcher by context")
+			w.wg.Done()
+			return nil
+		case <-w.close:
+			w.logger.Info("clossing watcher")
+			w.wg.Done()
+			return nil
+		default:
+			// get the latest block for the chain.
+			latestBlock, err := w.client.GetLatestBlock(ctx)
+			if err != nil {
+				w.logger.Error("cannot get latest block", zap.Error(err))
+			}
+			// only process blocks with the configured confirmation depth.
+			lastBlock := getSafeBlock(latestBlock, w.confirmations)
+			w.logger.Debug("current block", zap.Uint64("current", currentBlock), zap.Uint64("last", lastBlock))
+
+			if currentBlock < lastBlock {
+				w.metrics.SetLastBlock(w.chainID, latestBlock)
+				totalBlocks := getTotalBlocks(lastBlock, currentBlock, w.maxBlocks)
+				for i := uint64(0); i < totalBlocks; i++ {
+					fromBlock, toBlock := getPage(currentBlock, i, w.maxBlocks, lastBlock)
+					w.logger.Debug("processing blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
+					if err := w.processBlocks(ctx, fromBlock, toBlock, true); err != nil {
+						// the cursor is not advanced past the failed page, so it is processed again.
+						w.logger.Error("cannot process blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock), zap.Error(err))
+						w.repository.SendProcessBlocksAlert(ctx, w.chainID, fromBlock, toBlock, err)
+						lastBlock = fromBlock
+						break
+					}
+					w.logger.Debug("blocks processed", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
+				}
+			} else {
+				w.logger.Debug("waiting for new blocks")
+				select {
+				case <-ctx.Done():
+					w.wg.Done()
+					return nil
+				case <-time.After(time.Duration(w.waitSeconds) * time.Second):
+				}
+			}
+			if lastBlock > currentBlock {
+				currentBlock = lastBlock
+			}
+		}
+	}
+}
+
+func (w *EvmLogWatcher) Backfill(ctx context.Context, fromBlock uint64, toBlock uint64, pageSize uint64, persistBlock bool) error {
+	totalBlocks := getTotalBlocks(toBlock, fromBlock, pageSize)
+	for i := uint64(0); i < totalBlocks; i++ {
+		fromBlock, toBlock := getPage(fromBlock, i, pageSize, toBlock)
+		w.logger.Info("processing blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
+		if err := w.processBlocks(ctx, fromBlock, toBlock, persistBlock); err != nil {
+			// stop at the failed page, the next pages are not processed so the persisted block does not skip it.
+			w.logger.Error("cannot process blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock), zap.Error(err))
+			w.repository.SendProcessBlocksAlert(ctx, w.chainID, fromBlock, toBlock, err)
+			return err
+		}
+		w.logger.Info("blocks processed", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
+	}
+	return nil
+}
+
+// processBlocks processes the logs of the blocks between fromBlock and toBlock. It fails when the logs cannot be
+// processed after retrying, in which case the watcher block is not updated.
+func (w *EvmLogWatcher) processBlocks(ctx context.Context, fromBlock uint64, toBlock uint64, updateWatcherBlock bool) error {
+	return retry.Do(
+		func() error {
+			// a failure here would skip every redeem in the range, so any error is retried.
+			logs, err := w.client.GetLogs(ctx, fromBlock, toBlock,
+				[]string{w.tokenBridgeAddress, w.coreAddress},
+				[]string{config.EventTopicTransferRedeemed, config.EventTopicLogMessagePublished})
+			if err != nil {
+				w.logger.Error("cannot get logs", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock), zap.Error(err))
+				return err
+			}
+
+			txs := groupLogsByTx(w.chainID, w.tokenBridgeAddress, w.coreAddress, logs, w.logger)
+			blocks := make(map[string]*evm.GetBlockResult)
+			for _, tx := range txs {
+				if len(tx.redeemed) == 0 {
+					continue
+				}
+				block, ok := blocks[tx.blockNumber]
+				if !ok {
+					block, err = w.getBlock(ctx, tx.blockNumber)
+					if err != nil {
+						return err
+					}
+					blocks[tx.blockNumber] = block
+				}
+				w.processTx(ctx, tx, block)
+			}
+
+			if updateWatcherBlock {
+				// update the last block number processed in the database.
+				watcherBlock := storage.WatcherBlock{
+					ID:          w.blockchain,
+					BlockNumber: int64(toBlock),
+					UpdatedAt:   time.Now(),
+				}
+				return w.repository.UpdateWatcherBlock(ctx, w.chainID, watcherBlock)
+			}
+			return nil
+		},
+		retry.Attempts(evmMaxRetries),
+		retry.Delay(evmRetryDelay),
+	)
+}
+
+func (w *EvmLogWatcher) getBlock(ctx context.Context, blockNumber string) (*evm.GetBlockResult, error) {
+	number, err := utils.DecodeUint64(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	block, err := w.client.GetBlock(ctx, number)
+	if err != nil {
+		w.logger.Error("cannot get block", zap.Uint64("block", number), zap.Error(err))
+		return nil, err
+	}
+	return block, nil
+}
+
+func (w *EvmLogWatcher) processTx(ctx context.Context, tx *evmLogTx, block *evm.GetBlockResult) {
+	log := w.logger.With(
+		zap.String("txHash", tx.hash),
+		zap.String("block", tx.blockNumber))
+
+	// get the sender and the called contract, which may be a router or any wrapper contract.
+	var from, to string
+	for _, t := range block.Transactions {
+		if strings.EqualFold(t.Hash, tx.hash) {
+			from, to = t.From, t.To
+			break
+		}
+	}
+
+	if len(tx.published) > 0 {
+		log.Debug("redeem tx published wormhole messages",
+			zap.Strings("redeemed", tx.redeemed),
+			zap.Strings("published", tx.published))
+	}
+
+	for _, vaaID := range tx.redeemed {
+		// logs are only emitted by successful transactions.
+		updatedAt := time.Now()
+		globalTx := storage.TransactionUpdate{
+			ID: vaaID,
+			Destination: storage.DestinationTx{
+				ChainID:     w.chainID,
+				Status:      domain.DstTxStatusConfirmed,
+				Method:      config.MethodTransferRedeemed,
+				TxHash:      utils.Remove0x(tx.hash),
+				To:          to,
+				From:        from,
+				BlockNumber: getBlockNumber(tx.blockNumber, log),
+				Timestamp:   getTimestamp(block.Timestamp, log),
+				UpdatedAt:   &updatedAt,
+			},
+		}
+		updateGlobalTransaction(ctx, w.chainID, globalTx, w.repository, log)
+	}
+}
+
+func (w *EvmLogWatcher) Close() {
+	close(w.close)
+	w.wg.Wait()
+}
+
+// groupLogsByTx correlates the token bridge and core logs to VAA ids and groups them by transaction,
+// keeping the order in which the transactions were emitted.
+func groupLogsByTx(chainID vaa.ChainID, tokenBridgeAddress, coreAddress string, logs []evm.Log, logger *zap.Logger) []*evmLogTx {
+	txs := make([]*evmLogTx, 0)
+	txByHash := make(map[string]*evmLogTx)
+	for _, l := range logs {
+		// removed logs belong to a reorganized block.
+		if l.Removed || len(l.Topics) == 0 {
+			continue
+		}
+
+		hash := strings.ToLower(l.TransactionHash)
+		tx, ok := txByHash[hash]
+		if !ok {
+			tx = &evmLogTx{hash: l.TransactionHash, blockNumber: l.BlockNumber}
+			txByHash[hash] = tx
+			txs = append(txs, tx)
+		}
+
+		address := strings.ToLower(l.Address)
+		switch {
+		case address == tokenBridgeAddress && l.Topics[0] == config.EventTopicTransferRedeemed:
+			vaaID, err := getVaaIDFromTransferRedeemed(l)
+			if err != nil {
+				logger.Error("cannot parse TransferRedeemed log", zap.String("txHash", l.TransactionHash), zap.Error(err))
+				continue
+			}
+			tx.redeemed = append(tx.redeemed, vaaID)
+		case address == coreAddress && l.Topics[0] == config.EventTopicLogMessagePublished:
+			vaaID, err := getVaaIDFromLogMessagePublished(chainID, l)
+			if err != nil {
+				logger.Error("cannot parse LogMessagePublished log", zap.String("txHash", l.TransactionHash), zap.Error(err))
+				continue
+			}
+			tx.published = append(tx.published, vaaID)
+		}
+	}
+	return txs
+}
+
+// getVaaIDFromTransferRedeemed builds the id of the redeemed VAA from the indexed
+// emitterChainId, emitterAddress and sequence of a TransferRedeemed log.
+func getVaaIDFromTransferRedeemed(l evm.Log) (string, error) {
+	if len(l.Topics) != 4 {
+		return "", fmt.Errorf("invalid number of topics: %d", len(l.Topics))
+	}
+	emitterChain, err := strconv.ParseUint(utils.Remove0x(l.Topics[1]), 16, 16)
+	if err != nil {
+		return "", fmt.Errorf("invalid emitter chain: %w", err)
+	}
+	emitterAddress, err := vaa.StringToAddress(utils.Remove0x(l.Topics[2]))
+	if err != nil {
+		return "", fmt.Errorf("invalid emitter address: %w", err)
+	}
+	sequence, err := strconv.ParseUint(utils.Remove0x(l.Topics[3]), 16, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid sequence: %w", err)
+	}
+	return fmt.Sprintf("%d/%s/%d", emitterChain, emitterAddress.String(), sequence), nil
+}
+
+// getVaaIDFromLogMessagePublished builds the id of the VAA that will be signed for a
+// LogMessagePublished log. The emitter is the indexed sender and the sequence is the first data word.
+func getVaaIDFromLogMessagePublished(chainID vaa.ChainID, l evm.Log) (string, error) {
+	if len(l.Topics) != 2 {
+		return "", fmt.Errorf("invalid number of topics: %d", len(l.Topics))
+	}
+	emitterAddress, err := vaa.StringToAddress(utils.Remove0x(l.Topics[1]))
+	if err != nil {
+		return "", fmt.Errorf("invalid emitter address: %w", err)
+	}
+	data := utils.Remove0x(l.Data)
+	if len(data) < 64 {
+		return "", fmt.Errorf("invalid data length: %d", len(data))
+	}
+	sequence, err := strconv.ParseUint(data[:64], 16, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid sequence: %w", err)
+	}
+	return fmt.Sprintf("%d/%s/%d", chainID, emitterAddress.String(), sequence), nil
+}
@@ -0,0 +1,89 @@
+package watcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/config"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/evm"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+const (
+	testTokenBridgeAddress = "0x8d2de8d2f73f1f4cab472ac9a881c9b123c79627"
+	testCoreAddress        = "0xbebdb6c8ddc678ffa9f8748f85c815c556dd8ac6"
+)
+
+func newTransferRedeemedLog(txHash string) evm.Log {
+	return evm.Log{
+		Address: "0x8d2de8d2F73F1F4cAB472AC9A881C9b123C79627",
+		Topics: []string{
+			config.EventTopicTransferRedeemed,
+			"0x0000000000000000000000000000000000000000000000000000000000000002",
+			"0x0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585",
+			"0x000000000000000000000000000000000000000000000000000000000001e240",
+		},
+		BlockNumber:     "0x10",
+		TransactionHash: txHash,
+	}
+}
+
+func newLogMessagePublishedLog(txHash string) evm.Log {
+	return evm.Log{
+		Address: testCoreAddress,
+		Topics: []string{
+			config.EventTopicLogMessagePublished,
+			"0x0000000000000000000000008d2de8d2f73f1f4cab472ac9a881c9b123c79627",
+		},
+		Data:            "0x0000000000000000000000000000000000000000000000000000000000000007",
+		BlockNumber:     "0x10",
+		TransactionHash: txHash,
+	}
+}
+
+func Test_getVaaIDFromTransferRedeemed(t *testing.T) {
+	vaaID, err := getVaaIDFromTransferRedeemed(newTransferRedeemedLog("0x01"))
+	assert.Nil(t, err)
+	assert.Equal(t, "2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/123456", vaaID)
+
+	invalid := newTransferRedeemedLog("0x01")
+	invalid.Topics = invalid.Topics[:3]
+	_, err = getVaaIDFromTransferRedeemed(invalid)
+	assert.NotNil(t, err)
+}
+
+func Test_getVaaIDFromLogMessagePublished(t *testing.T) {
+	vaaID, err := getVaaIDFromLogMessagePublished(vaa.ChainIDBase, newLogMessagePublishedLog("0x01"))
+	assert.Nil(t, err)
+	assert.Equal(t, "30/0000000000000000000000008d2de8d2f73f1f4cab472ac9a881c9b123c79627/7", vaaID)
+}
+
+func Test_groupLogsByTx(t *testing.T) {
+	removed := newTransferRedeemedLog("0x03")
+	removed.Removed = true
+	unknownContract := newTransferRedeemedLog("0x04")
+	unknownContract.Address = "0x0000000000000000000000000000000000000001"
+
+	logs := []evm.Log{
+		newTransferRedeemedLog("0x01"),
+		newLogMessagePublishedLog("0x01"),
+		newLogMessagePublishedLog("0x02"),
+		removed,
+		unknownContract,
+	}
+
+	txs := groupLogsByTx(vaa.ChainIDBase, testTokenBridgeAddress, testCoreAddress, logs, zap.NewNop())
+	assert.Equal(t, 3, len(txs))
+
+	assert.Equal(t, "0x01", txs[0].hash)
+	assert.Equal(t, []string{"2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/123456"}, txs[0].redeemed)
+	assert.Equal(t, 1, len(txs[0].published))
+
+	assert.Equal(t, "0x02", txs[1].hash)
+	assert.Equal(t, 0, len(txs[1].redeemed))
+	assert.Equal(t, 1, len(txs[1].published))
+
+	assert.Equal(t, "0x04", txs[2].hash)
+	assert.Equal(t, 0, len(txs[2].redeemed))
+}
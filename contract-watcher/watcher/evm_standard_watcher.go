@@ -2,6 +2,7 @@ package watcher
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"sync"
 	"time"
@@ -20,6 +21,15 @@ const (
 	evmRetryDelay = 5 * time.Second
 )
 
+// evmStandardRepository is the storage used by the evm standard watcher.
+type evmStandardRepository interface {
+	transactionRepository
+	GetCurrentBlock(ctx context.Context, blockchain string, defaultBlock int64) (int64, error)
+	GetWatcherBlock(ctx context.Context, blockchain string) (*storage.WatcherBlock, error)
+	UpdateWatcherBlock(ctx context.Context, chainID vaa.ChainID, watcherBlock storage.WatcherBlock) error
+	RollbackDestinationTxs(ctx context.Context, chainID vaa.ChainID, blockNumbers []string) (int64, error)
+}
+
 type EvmStandardWatcher struct {
 	client           *evm.EvmSDK
 	chainID          vaa.ChainID
@@ -29,7 +39,9 @@ type EvmStandardWatcher struct {
 	maxBlocks        uint64
 	waitSeconds      uint16
 	initialBlock     int64
-	repository       *storage.Repository
+	confirmations    uint64
+	tracker          *blockTracker
+	repository       evmStandardRepository
 	logger           *zap.Logger
 	close            chan bool
 	wg               sync.WaitGroup
@@ -50,6 +62,8 @@ func NewEvmStandardWatcher(client *evm.EvmSDK, params EVMParams, repo *storage.R
 		maxBlocks:        uint64(params.SizeBlocks),
 		waitSeconds:      params.WaitSeconds,
 		initialBlock:     params.InitialBlock,
+		confirmations:    params.ConfirmationBlocks,
+		tracker:          newBlockTracker(evmTrackedBlocks, nil),
 		repository:       repo,
 		metrics:          metrics,
 		logger:           logger.With(zap.String("blockchain", params.Blockchain), zap.Uint16("chainId", uint16(params.ChainID))),
@@ -68,6 +82,13 @@ func (w *EvmStandardWatcher) Start(ctx context.Context) error {
 		return err
 	}
 	currentBlock := uint64(cBlock)
+
+	// load the hashes of the last processed blocks to detect reorgs.
+	watcherBlock, err := w.repository.GetWatcherBlock(ctx, w.blockchain)
+	if err == nil {
+		w.tracker = newBlockTracker(evmTrackedBlocks, watcherBlock.RecentBlocks)
+	}
+
 	w.wg.Add(1)
 	for {
 		select {
@@ -81,19 +102,23 @@ func (w *EvmStandardWatcher) Start(ctx context.Context) error {
 			return nil
 		default:
 			// get the latest block for the chain.
-			lastBlock, err := w.client.GetLatestBlock(ctx)
+			latestBlock, err := w.client.GetLatestBlock(ctx)
 			if err != nil {
 				w.logger.Error("cannot get latest block", zap.Error(err))
 			}
+			// only process blocks with the configured confirmation depth.
+			lastBlock := getSafeBlock(latestBlock, w.confirmations)
 			w.logger.Debug("current block", zap.Uint64("current", currentBlock), zap.Uint64("last", lastBlock))
 
 			if currentBlock < lastBlock {
-				w.metrics.SetLastBlock(w.chainID, lastBlock)
+				w.metrics.SetLastBlock(w.chainID, latestBlock)
 				totalBlocks := getTotalBlocks(lastBlock, currentBlock, w.maxBlocks)
 				for i := uint64(0); i < totalBlocks; i++ {
 					fromBlock, toBlock := getPage(currentBlock, i, w.maxBlocks, lastBlock)
 					w.logger.Debug("processing blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
-					w.processBlock(ctx, fromBlock, toBlock, true)
+					if err := w.processBlock(ctx, fromBlock, toBlock, true, true); err != nil {
+						w.logger.Error("cannot process blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock), zap.Error(err))
+					}
 					w.logger.Debug("blocks processed", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
 				}
 				// process all the blocks between current and last block.
@@ -114,20 +139,30 @@ func (w *EvmStandardWatcher) Start(ctx context.Context) error {
 
 }
 
-func (w *EvmStandardWatcher) Backfill(ctx context.Context, fromBlock uint64, toBlock uint64, pageSize uint64, persistBlock bool) {
+func (w *EvmStandardWatcher) Backfill(ctx context.Context, fromBlock uint64, toBlock uint64, pageSize uint64, persistBlock bool) error {
 	totalBlocks := getTotalBlocks(toBlock, fromBlock, pageSize)
 	for i := uint64(0); i < totalBlocks; i++ {
 		fromBlock, toBlock := getPage(fromBlock, i, pageSize, toBlock)
 		w.logger.Info("processing blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
-		w.processBlock(ctx, fromBlock, toBlock, persistBlock)
+		if err := w.processBlock(ctx, fromBlock, toBlock, persistBlock, false); err != nil {
+			w.logger.Error("cannot process blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock), zap.Error(err))
+			return err
+		}
 		w.logger.Info("blocks processed", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
 	}
+	return nil
 }
 
-func (w *EvmStandardWatcher) processBlock(ctx context.Context, fromBlock uint64, toBlock uint64, updateWatcherBlock bool) {
+// processBlock processes the blocks between fromBlock and toBlock. When trackBlocks is set the block hashes
+// are tracked to detect reorgs, which is only done for the blocks processed by the watcher and not by backfills.
+// The blocks that cannot be read are skipped, and the error of the first one is returned.
+func (w *EvmStandardWatcher) processBlock(ctx context.Context, fromBlock uint64, toBlock uint64, updateWatcherBlock bool, trackBlocks bool) error {
+	var firstErr error
 	for block := fromBlock; block <= toBlock; block++ {
 		w.logger.Debug("processing block", zap.Uint64("block", block))
-		retry.Do(
+		var reorg bool
+		var blockErr error
+		err := retry.Do(
 			func() error {
 				// get the transactions for the block.
 				blockResult, err := w.client.GetBlock(ctx, block)
@@ -136,6 +171,13 @@ func (w *EvmStandardWatcher) processBlock(ctx context.Context, fromBlock uint64,
 					if err == evm.ErrTooManyRequests {
 						return err
 					}
+					blockErr = err
+					return nil
+				}
+				blockErr = nil
+
+				if trackBlocks && w.tracker.isReorg(block, blockResult.Hash, blockResult.ParentHash) {
+					reorg = true
 					return nil
 				}
 
@@ -190,6 +232,10 @@ func (w *EvmStandardWatcher) processBlock(ctx context.Context, fromBlock uint64,
 						BlockNumber: int64(block),
 						UpdatedAt:   time.Now(),
 					}
+					if trackBlocks {
+						w.tracker.add(block, blockResult.Hash)
+						watcherBlock.RecentBlocks = w.tracker.snapshot()
+					}
 					return w.repository.UpdateWatcherBlock(ctx, w.chainID, watcherBlock)
 				}
 				return nil
@@ -197,7 +243,80 @@ func (w *EvmStandardWatcher) processBlock(ctx context.Context, fromBlock uint64,
 			retry.Attempts(evmMaxRetries),
 			retry.Delay(evmRetryDelay),
 		)
+		if err == nil {
+			err = blockErr
+		}
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("block %d: %w", block, err)
+		}
+
+		if reorg {
+			// continue processing from the block after the common ancestor.
+			block = w.handleReorg(ctx, block)
+		}
+	}
+	return firstErr
+}
+
+// handleReorg finds the newest tracked block that is still in the canonical chain, rolls back the
+// destination txs of the orphaned blocks and returns the common ancestor. When the ancestor cannot be
+// found all the tracked blocks are rolled back.
+func (w *EvmStandardWatcher) handleReorg(ctx context.Context, block uint64) uint64 {
+	tracked := w.tracker.descending()
+	ancestor := uint64(0)
+	if oldest := tracked[len(tracked)-1].Number; oldest > 0 {
+		ancestor = oldest - 1
+	}
+
+	for _, b := range tracked {
+		var hash string
+		err := retry.Do(
+			func() error {
+				blockResult, err := w.client.GetBlock(ctx, b.Number)
+				if err != nil {
+					return err
+				}
+				hash = strings.ToLower(blockResult.Hash)
+				return nil
+			},
+			retry.Attempts(evmMaxRetries),
+			retry.Delay(evmRetryDelay),
+		)
+		if err != nil {
+			w.logger.Error("cannot get block to find reorg common ancestor", zap.Uint64("block", b.Number), zap.Error(err))
+			break
+		}
+		if hash == b.Hash {
+			ancestor = b.Number
+			break
+		}
+	}
+
+	orphaned := w.tracker.rewind(ancestor)
+	w.metrics.ObserveReorgDepth(w.chainID, uint64(len(orphaned)))
+	w.logger.Warn("reorg detected",
+		zap.Uint64("block", block),
+		zap.Uint64("ancestor", ancestor),
+		zap.Int("depth", len(orphaned)))
+
+	count, err := w.repository.RollbackDestinationTxs(ctx, w.chainID, getBlockNumbers(orphaned))
+	if err != nil {
+		w.logger.Error("cannot rollback destination txs", zap.Uint64("ancestor", ancestor), zap.Error(err))
+	} else {
+		w.metrics.IncRollbackDestinationTrx(w.chainID, count)
+		w.logger.Info("destination txs rolled back", zap.Uint64("ancestor", ancestor), zap.Int64("count", count))
+	}
+
+	watcherBlock := storage.WatcherBlock{
+		ID:           w.blockchain,
+		BlockNumber:  int64(ancestor),
+		UpdatedAt:    time.Now(),
+		RecentBlocks: w.tracker.snapshot(),
+	}
+	if err := w.repository.UpdateWatcherBlock(ctx, w.chainID, watcherBlock); err != nil {
+		w.logger.Error("cannot update watcher block after reorg", zap.Uint64("ancestor", ancestor), zap.Error(err))
 	}
+	return ancestor
 }
 
 func (w *EvmStandardWatcher) Close() {
@@ -0,0 +1,133 @@
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/utils"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/evm"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/storage"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/ratelimit"
+	"go.uber.org/zap"
+)
+
+type evmStandardRepositoryMock struct {
+	rolledBack    []string
+	watcherBlocks []storage.WatcherBlock
+}
+
+func (m *evmStandardRepositoryMock) GetGlobalTransactionByID(_ context.Context, _ string) (storage.TransactionUpdate, error) {
+	return storage.TransactionUpdate{}, storage.ErrDocNotFound
+}
+
+func (m *evmStandardRepositoryMock) UpsertGlobalTransaction(_ context.Context, _ vaa.ChainID, _ storage.TransactionUpdate) error {
+	return nil
+}
+
+func (m *evmStandardRepositoryMock) GetVaaIDByCctpNonce(_ context.Context, _ uint32, _ uint64) (string, error) {
+	return "", storage.ErrDocNotFound
+}
+
+func (m *evmStandardRepositoryMock) GetCurrentBlock(_ context.Context, _ string, defaultBlock int64) (int64, error) {
+	return defaultBlock, nil
+}
+
+func (m *evmStandardRepositoryMock) GetWatcherBlock(_ context.Context, _ string) (*storage.WatcherBlock, error) {
+	return nil, storage.ErrDocNotFound
+}
+
+func (m *evmStandardRepositoryMock) RollbackDestinationTxs(_ context.Context, _ vaa.ChainID, blockNumbers []string) (int64, error) {
+	m.rolledBack = append(m.rolledBack, blockNumbers...)
+	return int64(len(blockNumbers)), nil
+}
+
+func (m *evmStandardRepositoryMock) UpdateWatcherBlock(_ context.Context, _ vaa.ChainID, watcherBlock storage.WatcherBlock) error {
+	m.watcherBlocks = append(m.watcherBlocks, watcherBlock)
+	return nil
+}
+
+// newEvmBlocksServer serves the hashes of the canonical chain by block number.
+func newEvmBlocksServer(t *testing.T, hashes map[uint64]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, "eth_getBlockByNumber", request.Method)
+		var hexNumber string
+		assert.Nil(t, json.Unmarshal(request.Params[0], &hexNumber))
+		number, err := utils.DecodeUint64(hexNumber)
+		assert.Nil(t, err)
+
+		rw.Header().Set("Content-Type", "application/json")
+		block := evm.GetBlockResult{Hash: hashes[number], Number: hexNumber}
+		json.NewEncoder(rw).Encode(map[string]any{"jsonrpc": "2.0", "id": 1, "result": block})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestEvmStandardWatcher_handleReorg(t *testing.T) {
+	tracked := []storage.ProcessedBlock{
+		{Number: 10, Hash: "0xa"},
+		{Number: 11, Hash: "0xb"},
+		{Number: 12, Hash: "0xc"},
+		{Number: 13, Hash: "0xd"},
+	}
+
+	var tests = []struct {
+		name               string
+		canonical          map[uint64]string
+		expectedAncestor   uint64
+		expectedRolledBack []string
+		expectedTracked    []storage.ProcessedBlock
+	}{
+		{
+			name:               "common ancestor found",
+			canonical:          map[uint64]string{10: "0xa", 11: "0xb", 12: "0xc2", 13: "0xd2"},
+			expectedAncestor:   11,
+			expectedRolledBack: []string{"12", "13"},
+			expectedTracked:    tracked[:2],
+		},
+		{
+			name:               "common ancestor not tracked",
+			canonical:          map[uint64]string{10: "0xa2", 11: "0xb2", 12: "0xc2", 13: "0xd2"},
+			expectedAncestor:   9,
+			expectedRolledBack: []string{"10", "11", "12", "13"},
+			expectedTracked:    []storage.ProcessedBlock{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newEvmBlocksServer(t, tc.canonical)
+			client := evm.NewEvmSDK(server.URL, ratelimit.NewUnlimited(), metrics.NewNoopMetrics())
+			params := EVMParams{ChainID: vaa.ChainIDEthereum, Blockchain: "ethereum"}
+			w := NewEvmStandardWatcher(client, params, nil, metrics.NewNoopMetrics(), zap.NewNop())
+			repository := &evmStandardRepositoryMock{}
+			w.repository = repository
+			w.tracker = newBlockTracker(evmTrackedBlocks, tracked)
+
+			ancestor := w.handleReorg(context.Background(), 14)
+
+			assert.Equal(t, tc.expectedAncestor, ancestor)
+			// the destination txs of the orphaned blocks are marked to be processed again.
+			assert.Equal(t, tc.expectedRolledBack, repository.rolledBack)
+			assert.Equal(t, tc.expectedTracked, w.tracker.snapshot())
+			// the watcher block is reset to the common ancestor.
+			if assert.Len(t, repository.watcherBlocks, 1) {
+				watcherBlock := repository.watcherBlocks[0]
+				assert.Equal(t, "ethereum", watcherBlock.ID)
+				assert.Equal(t, int64(tc.expectedAncestor), watcherBlock.BlockNumber)
+				assert.Equal(t, tc.expectedTracked, watcherBlock.RecentBlocks)
+			}
+		})
+	}
+}
@@ -1,13 +1,87 @@
 package watcher
 
 import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/config"
 )
 
+const testVaaHex = "01000000030d001752692f4c9833d07d300d083af2485690244e432c8874577735982f7f5222d77a27ee7703bf15263be091cf76d5e7f614d1b273228aabe022779e803e731a7e0001a9aa6115f959f70d20b0c5d48db0c8bd5ab646fde61b3a8260f1afe77bae5dc11e48d43275ee32107f52f8eec12b0a454218d74a74073fec861f250bc8472e6d0006671461db8795cba03f7f0990875319171314d3182b1273805a521f240917be65634b9f2118dafe5845aeda5e7051de4284013802de32ccf8666f3d5a1a4b6ae901070c56fea7f99f1709ac593bf86a115b5adc0b865c3a832c21457fa8445b273cd714994c1dfeeece9aa36b7ca06914cfc7cffaf8b69afcf2f3fb8161f193c6231d0008a4b00cf265c31a5d5a93feebb75e2a32b69d0797d74a48d64529335cd9eb9c3368f45a57fbf03f149726bce3f0c5e95bf1affa8f73c8b6337a8d6b7bf7d56eaa010958c78eafaa1dbb7584dfb1f1336a9c0336b08e81b2c38e1f874d5a3596608f901959b3f43b453c14a35e0d801eb6aee9f63f3e302436780733926f83cf0186b9000a7ee0949495ae6d7b4a9c9669289c69d94b984dc3f7a5ad7d2e0c64b587db2d393eb6b791acc359c809bc04b78a3d7da1e71bc568eaf158da9e8a39ca58fe2a5d000b49b0beb343015b1fff9520f0749a89ecf4582a8a064b0dcc26afd8d6a6cad5f96ed990c9098df0cbe0cbded34608066942e0d8b3295a2fe81ececbc843d18379010c80a5bbb3504afd60cf290b6a7dd5b79fb39b082864aa0ae049e3ee939830730913ac155431479796b8f704abf65caa89cafa90b6c0c8a732cdb5a24b21661698000d523336c7038d5079b8107507dc659a6ffc7e975a036024f322159e40126708405f90c8ee86ec766a6fae9c25fb62f88f4a03a27a8f4972f083bd9d9287668aa7011079ebe7ed680ed5ca973f68ba93b7ea01cadf2e0b6686083c2bb8ed04a0cbf9356b198588d9fbcdd4eb519b63416aecf1924bd826c9ad277209c173408d16a65d0011f8e3eb4770c9e4e3ce2592970126037caee2f3121e78301edb3eed86645e54db0e4d14b093d526f154c9e0cbb2744eadd91970e506167b9e7730177aa22015b00012ef1e55545b5b4b1ed2cf18c1012838a1c02e586b015a1ec996bdbaa7ce6026f31ee78cfcb125cc732f58adbd5604998cac3f9deb4d07205e0fdfc94a18e98e780064053c510000247f0001ec7372995d5cc8732397fb0ad35c0121e0eaa90d26f828a534cab54391b3a4f50000000000043924200100000000000000000000000000000000000000000000000000000021596de513000000000000000000000000bba39fd2935d5769116ce38d46a71bde9cf030990002000000000000000000000000cc35f4c022992cdb0ab7b19fb45d4173a34fde0200020000000000000000000000000000000000000000000000000000000000000000"
+
+// abiEncodeBytes encodes a list of dynamic bytes values as abi arguments.
+func abiEncodeBytes(values ...[]byte) []byte {
+	var head, tail []byte
+	for _, value := range values {
+		head = append(head, abiWord(32*len(values)+len(tail))...)
+		tail = append(tail, abiWord(len(value))...)
+		padded := make([]byte, (len(value)+31)/32*32)
+		copy(padded, value)
+		tail = append(tail, padded...)
+	}
+	return append(head, tail...)
+}
+
+func abiWord(n int) []byte {
+	word := make([]byte, 32)
+	binary.BigEndian.PutUint64(word[24:], uint64(n))
+	return word
+}
+
 func Test_parseInput(t *testing.T) {
 	_, err := parseInput("0xc68785190000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000041801000000030d001752692f4c9833d07d300d083af2485690244e432c8874577735982f7f5222d77a27ee7703bf15263be091cf76d5e7f614d1b273228aabe022779e803e731a7e0001a9aa6115f959f70d20b0c5d48db0c8bd5ab646fde61b3a8260f1afe77bae5dc11e48d43275ee32107f52f8eec12b0a454218d74a74073fec861f250bc8472e6d0006671461db8795cba03f7f0990875319171314d3182b1273805a521f240917be65634b9f2118dafe5845aeda5e7051de4284013802de32ccf8666f3d5a1a4b6ae901070c56fea7f99f1709ac593bf86a115b5adc0b865c3a832c21457fa8445b273cd714994c1dfeeece9aa36b7ca06914cfc7cffaf8b69afcf2f3fb8161f193c6231d0008a4b00cf265c31a5d5a93feebb75e2a32b69d0797d74a48d64529335cd9eb9c3368f45a57fbf03f149726bce3f0c5e95bf1affa8f73c8b6337a8d6b7bf7d56eaa010958c78eafaa1dbb7584dfb1f1336a9c0336b08e81b2c38e1f874d5a3596608f901959b3f43b453c14a35e0d801eb6aee9f63f3e302436780733926f83cf0186b9000a7ee0949495ae6d7b4a9c9669289c69d94b984dc3f7a5ad7d2e0c64b587db2d393eb6b791acc359c809bc04b78a3d7da1e71bc568eaf158da9e8a39ca58fe2a5d000b49b0beb343015b1fff9520f0749a89ecf4582a8a064b0dcc26afd8d6a6cad5f96ed990c9098df0cbe0cbded34608066942e0d8b3295a2fe81ececbc843d18379010c80a5bbb3504afd60cf290b6a7dd5b79fb39b082864aa0ae049e3ee939830730913ac155431479796b8f704abf65caa89cafa90b6c0c8a732cdb5a24b21661698000d523336c7038d5079b8107507dc659a6ffc7e975a036024f322159e40126708405f90c8ee86ec766a6fae9c25fb62f88f4a03a27a8f4972f083bd9d9287668aa7011079ebe7ed680ed5ca973f68ba93b7ea01cadf2e0b6686083c2bb8ed04a0cbf9356b198588d9fbcdd4eb519b63416aecf1924bd826c9ad277209c173408d16a65d0011f8e3eb4770c9e4e3ce2592970126037caee2f3121e78301edb3eed86645e54db0e4d14b093d526f154c9e0cbb2744eadd91970e506167b9e7730177aa22015b00012ef1e55545b5b4b1ed2cf18c1012838a1c02e586b015a1ec996bdbaa7ce6026f31ee78cfcb125cc732f58adbd5604998cac3f9deb4d07205e0fdfc94a18e98e780064053c510000247f0001ec7372995d5cc8732397fb0ad35c0121e0eaa90d26f828a534cab54391b3a4f50000000000043924200100000000000000000000000000000000000000000000000000000021596de513000000000000000000000000bba39fd2935d5769116ce38d46a71bde9cf030990002000000000000000000000000cc35f4c022992cdb0ab7b19fb45d4173a34fde02000200000000000000000000000000000000000000000000000000000000000000000000000000000000")
 	assert.Nil(t, err)
 
 }
+
+func Test_getVaaIDByInput_RedeemTokensWithPayload(t *testing.T) {
+	vaaBytes, _ := hex.DecodeString(testVaaHex)
+	tuple := abiEncodeBytes(vaaBytes, []byte("circle message"), []byte("attestation"))
+	input := "0x57bf927b" + hex.EncodeToString(append(abiWord(32), tuple...))
+
+	method := config.BlockchainMethod{ID: config.MethodIDRedeemTokensWithPayload, Protocol: config.ProtocolCCTP}
+	vaaID, err := getVaaIDByInput(context.Background(), method, input, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "1/ec7372995d5cc8732397fb0ad35c0121e0eaa90d26f828a534cab54391b3a4f5/276772", vaaID)
+}
+
+func Test_getVaaIDByInput_NttReceiveMessage(t *testing.T) {
+	vaaBytes, _ := hex.DecodeString(testVaaHex)
+	input := "0xf953cec7" + hex.EncodeToString(abiEncodeBytes(vaaBytes))
+
+	method := config.BlockchainMethod{ID: config.MethodIDNttReceiveMessage, Protocol: config.ProtocolNTT}
+	vaaID, err := getVaaIDByInput(context.Background(), method, input, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "1/ec7372995d5cc8732397fb0ad35c0121e0eaa90d26f828a534cab54391b3a4f5/276772", vaaID)
+}
+
+func Test_getAbiBytes_CctpReceiveMessage(t *testing.T) {
+	message := make([]byte, 116)
+	binary.BigEndian.PutUint32(message[4:8], 6)
+	binary.BigEndian.PutUint32(message[8:12], 0)
+	binary.BigEndian.PutUint64(message[12:20], 123456)
+	input := "0x57ecfd28" + hex.EncodeToString(abiEncodeBytes(message, make([]byte, 65)))
+
+	args, err := getInputArgs(input)
+	assert.Nil(t, err)
+	result, err := getAbiBytes(args, 0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, message, result)
+
+	m, err := parseCctpMessage(result)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(6), m.SourceDomain)
+	assert.Equal(t, uint32(0), m.DestinationDomain)
+	assert.Equal(t, uint64(123456), m.Nonce)
+}
+
+func Test_getAbiBytes_InvalidInput(t *testing.T) {
+	_, err := getAbiBytes(abiWord(32), 0, 0)
+	assert.ErrorIs(t, err, errInvalidAbiInput)
+
+	_, err = parseCctpMessage([]byte{0x00})
+	assert.ErrorIs(t, err, errInvalidCctpMessage)
+}
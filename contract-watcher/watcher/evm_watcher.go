@@ -95,7 +95,9 @@ func (w *EVMWatcher) Start(ctx context.Context) error {
 						toBlock = lastBlock
 					}
 					w.logger.Debug("processing blocks", zap.Int64("from", fromBlock), zap.Int64("to", toBlock))
-					w.processBlock(ctx, fromBlock, toBlock, true)
+					if err := w.processBlock(ctx, fromBlock, toBlock, true); err != nil {
+						w.logger.Error("cannot process blocks", zap.Int64("from", fromBlock), zap.Int64("to", toBlock), zap.Error(err))
+					}
 					w.logger.Debug("blocks processed", zap.Int64("from", fromBlock), zap.Int64("to", toBlock))
 				}
 				// process all the blocks between current and last block.
@@ -116,17 +118,21 @@ func (w *EVMWatcher) Start(ctx context.Context) error {
 
 }
 
-func (w *EVMWatcher) Backfill(ctx context.Context, fromBlock uint64, toBlock uint64, pageSize uint64, persistBlock bool) {
+func (w *EVMWatcher) Backfill(ctx context.Context, fromBlock uint64, toBlock uint64, pageSize uint64, persistBlock bool) error {
 	totalBlocks := getTotalBlocks(toBlock, fromBlock, pageSize)
 	for i := uint64(0); i < totalBlocks; i++ {
 		fromBlock, toBlock := getPage(fromBlock, i, pageSize, toBlock)
 		w.logger.Info("processing blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
-		w.processBlock(ctx, int64(fromBlock), int64(toBlock), persistBlock)
+		if err := w.processBlock(ctx, int64(fromBlock), int64(toBlock), persistBlock); err != nil {
+			w.logger.Error("cannot process blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock), zap.Error(err))
+			return err
+		}
 		w.logger.Info("blocks processed", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
 	}
+	return nil
 }
 
-func (w *EVMWatcher) processBlock(ctx context.Context, currentBlock int64, lastBlock int64, updateWatcherBlock bool) {
+func (w *EVMWatcher) processBlock(ctx context.Context, currentBlock int64, lastBlock int64, updateWatcherBlock bool) error {
 	pageToken := ""
 	hasPage := true
 
@@ -143,7 +149,7 @@ func (w *EVMWatcher) processBlock(ctx context.Context, currentBlock int64, lastB
 		r, err := w.client.GetTransactionsByAddress(ctx, *request)
 		if err != nil {
 			w.logger.Error("cannot get transactions by address", zap.Error(err))
-			time.Sleep(10 * time.Second)
+			return err
 		}
 
 		var lastBlockNumberHex string
@@ -196,6 +202,7 @@ func (w *EVMWatcher) processBlock(ctx context.Context, currentBlock int64, lastB
 			hasPage = false
 		}
 	}
+	return nil
 }
 
 func (w *EVMWatcher) Close() {
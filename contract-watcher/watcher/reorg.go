@@ -0,0 +1,112 @@
+package watcher
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/storage"
+)
+
+// evmTrackedBlocks is the number of processed blocks whose hash is tracked to detect reorgs.
+const evmTrackedBlocks = 128
+
+// blockTracker keeps the hashes of the last processed blocks ordered by block number.
+type blockTracker struct {
+	size   int
+	blocks []storage.ProcessedBlock
+}
+
+func newBlockTracker(size int, blocks []storage.ProcessedBlock) *blockTracker {
+	t := &blockTracker{size: size}
+	for _, b := range blocks {
+		t.add(b.Number, b.Hash)
+	}
+	return t
+}
+
+// add tracks the hash of a processed block. A block already tracked is replaced.
+func (t *blockTracker) add(number uint64, hash string) {
+	i := sort.Search(len(t.blocks), func(i int) bool { return t.blocks[i].Number >= number })
+	block := storage.ProcessedBlock{Number: number, Hash: strings.ToLower(hash)}
+	switch {
+	case i < len(t.blocks) && t.blocks[i].Number == number:
+		t.blocks[i] = block
+	default:
+		t.blocks = append(t.blocks, storage.ProcessedBlock{})
+		copy(t.blocks[i+1:], t.blocks[i:])
+		t.blocks[i] = block
+	}
+	if len(t.blocks) > t.size {
+		t.blocks = t.blocks[len(t.blocks)-t.size:]
+	}
+}
+
+// hashOf returns the tracked hash of a block.
+func (t *blockTracker) hashOf(number uint64) (string, bool) {
+	i := sort.Search(len(t.blocks), func(i int) bool { return t.blocks[i].Number >= number })
+	if i < len(t.blocks) && t.blocks[i].Number == number {
+		return t.blocks[i].Hash, true
+	}
+	return "", false
+}
+
+// isReorg checks if a block is not consistent with the tracked blocks, either because
+// the block itself was already processed with another hash or its parent hash changed.
+func (t *blockTracker) isReorg(number uint64, hash, parentHash string) bool {
+	if h, ok := t.hashOf(number); ok && h != strings.ToLower(hash) {
+		return true
+	}
+	if number == 0 {
+		return false
+	}
+	if h, ok := t.hashOf(number - 1); ok && h != strings.ToLower(parentHash) {
+		return true
+	}
+	return false
+}
+
+// rewind removes the tracked blocks after the given block number and returns
+// the removed block numbers.
+func (t *blockTracker) rewind(number uint64) []uint64 {
+	i := sort.Search(len(t.blocks), func(i int) bool { return t.blocks[i].Number > number })
+	removed := make([]uint64, 0, len(t.blocks)-i)
+	for _, b := range t.blocks[i:] {
+		removed = append(removed, b.Number)
+	}
+	t.blocks = t.blocks[:i]
+	return removed
+}
+
+// descending returns the tracked blocks from the newest to the oldest.
+func (t *blockTracker) descending() []storage.ProcessedBlock {
+	result := make([]storage.ProcessedBlock, 0, len(t.blocks))
+	for i := len(t.blocks) - 1; i >= 0; i-- {
+		result = append(result, t.blocks[i])
+	}
+	return result
+}
+
+// snapshot returns a copy of the tracked blocks to be persisted.
+func (t *blockTracker) snapshot() []storage.ProcessedBlock {
+	result := make([]storage.ProcessedBlock, len(t.blocks))
+	copy(result, t.blocks)
+	return result
+}
+
+// getBlockNumbers formats block numbers as they are stored in the destination txs.
+func getBlockNumbers(numbers []uint64) []string {
+	result := make([]string, 0, len(numbers))
+	for _, n := range numbers {
+		result = append(result, strconv.FormatUint(n, 10))
+	}
+	return result
+}
+
+// getSafeBlock returns the latest block with the given confirmation depth.
+func getSafeBlock(lastBlock, confirmations uint64) uint64 {
+	if lastBlock < confirmations {
+		return 0
+	}
+	return lastBlock - confirmations
+}
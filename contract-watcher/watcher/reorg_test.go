@@ -0,0 +1,71 @@
+package watcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/storage"
+)
+
+func Test_blockTracker(t *testing.T) {
+	tracker := newBlockTracker(3, []storage.ProcessedBlock{
+		{Number: 10, Hash: "0xa"},
+		{Number: 11, Hash: "0xb"},
+	})
+
+	// a block already tracked is replaced and the oldest blocks are discarded.
+	tracker.add(12, "0xC")
+	tracker.add(13, "0xd")
+	tracker.add(13, "0xe")
+	assert.Equal(t, []storage.ProcessedBlock{
+		{Number: 11, Hash: "0xb"},
+		{Number: 12, Hash: "0xc"},
+		{Number: 13, Hash: "0xe"},
+	}, tracker.snapshot())
+
+	hash, ok := tracker.hashOf(12)
+	assert.True(t, ok)
+	assert.Equal(t, "0xc", hash)
+	_, ok = tracker.hashOf(10)
+	assert.False(t, ok)
+
+	assert.Equal(t, uint64(13), tracker.descending()[0].Number)
+
+	removed := tracker.rewind(11)
+	assert.Equal(t, []uint64{12, 13}, removed)
+	assert.Equal(t, []string{"12", "13"}, getBlockNumbers(removed))
+	assert.Equal(t, []storage.ProcessedBlock{{Number: 11, Hash: "0xb"}}, tracker.snapshot())
+}
+
+func Test_blockTracker_isReorg(t *testing.T) {
+	tracker := newBlockTracker(evmTrackedBlocks, []storage.ProcessedBlock{
+		{Number: 10, Hash: "0xa"},
+		{Number: 11, Hash: "0xb"},
+	})
+
+	testCases := []struct {
+		name       string
+		number     uint64
+		hash       string
+		parentHash string
+		expected   bool
+	}{
+		{name: "next block with the same parent", number: 12, hash: "0xc", parentHash: "0xB", expected: false},
+		{name: "next block with another parent", number: 12, hash: "0xc", parentHash: "0xf", expected: true},
+		{name: "block already processed with the same hash", number: 11, hash: "0xb", parentHash: "0xa", expected: false},
+		{name: "block already processed with another hash", number: 11, hash: "0xf", parentHash: "0xa", expected: true},
+		{name: "block without tracked parent", number: 20, hash: "0xf", parentHash: "0xe", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tracker.isReorg(tc.number, tc.hash, tc.parentHash))
+		})
+	}
+}
+
+func Test_getSafeBlock(t *testing.T) {
+	assert.Equal(t, uint64(90), getSafeBlock(100, 10))
+	assert.Equal(t, uint64(100), getSafeBlock(100, 0))
+	assert.Equal(t, uint64(0), getSafeBlock(5, 10))
+}
@@ -1,7 +1,9 @@
 package watcher
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"strconv"
@@ -13,6 +15,7 @@ import (
 	"github.com/gagliardetto/solana-go/rpc"
 	"github.com/near/borsh-go"
 	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/config"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/solana"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/storage"
@@ -53,30 +56,50 @@ const (
 	postVAATypeIndex    = 0x2
 )
 
+// NTT and CCTP anchor instructions used to redeem a VAA.
+const (
+	nttReceiveWormholeMessageInstruction = "receiveWormholeMessage"
+	// nttVaaAccountIndex is the index of the posted VAA in the receive_wormhole_message accounts.
+	nttVaaAccountIndex = 3
+)
+
+var (
+	// anchor discriminator of the NTT wormhole transceiver receive_wormhole_message instruction.
+	nttReceiveWormholeMessageDiscriminator = []byte{134, 213, 143, 68, 235, 102, 232, 96}
+	// anchor discriminator of the circle message transmitter receive_message instruction.
+	cctpReceiveMessageDiscriminator = []byte{38, 144, 127, 225, 31, 225, 238, 25}
+)
+
 const maxRetries = 3
 const retryDelay = 5 * time.Second
 
 type SolanaWatcher struct {
-	client          *solana.SolanaSDK
-	chainID         vaa.ChainID
-	blockchain      string
-	contractAddress solana_types.PublicKey
-	sizeBlocks      uint8
-	waitSeconds     uint16
-	initialBlock    int64
-	repository      *storage.Repository
-	logger          *zap.Logger
-	close           chan bool
-	wg              sync.WaitGroup
-	wgBlock         sync.WaitGroup
-	metrics         metrics.Metrics
+	client                 *solana.SolanaSDK
+	chainID                vaa.ChainID
+	blockchain             string
+	contractAddress        solana_types.PublicKey
+	nttPrograms            []solana_types.PublicKey
+	cctpMessageTransmitter solana_types.PublicKey
+	sizeBlocks             uint8
+	waitSeconds            uint16
+	initialBlock           int64
+	repository             *storage.Repository
+	logger                 *zap.Logger
+	close                  chan bool
+	wg                     sync.WaitGroup
+	wgBlock                sync.WaitGroup
+	metrics                metrics.Metrics
 }
 type SolanaParams struct {
 	Blockchain      string
 	ContractAddress solana_types.PublicKey
-	SizeBlocks      uint8
-	WaitSeconds     uint16
-	InitialBlock    int64
+	// NttPrograms are the NTT programs whose wormhole transceiver redeems are tracked.
+	NttPrograms []solana_types.PublicKey
+	// CctpMessageTransmitter is the circle message transmitter program.
+	CctpMessageTransmitter solana_types.PublicKey
+	SizeBlocks             uint8
+	WaitSeconds            uint16
+	InitialBlock           int64
 }
 
 type postVAAData struct {
@@ -102,16 +125,18 @@ func (p *postVAAData) MessageID() string {
 
 func NewSolanaWatcher(client *solana.SolanaSDK, repo *storage.Repository, params SolanaParams, metrics metrics.Metrics, logger *zap.Logger) *SolanaWatcher {
 	return &SolanaWatcher{
-		client:          client,
-		chainID:         vaa.ChainIDSolana,
-		blockchain:      params.Blockchain,
-		contractAddress: params.ContractAddress,
-		sizeBlocks:      params.SizeBlocks,
-		waitSeconds:     params.WaitSeconds,
-		initialBlock:    params.InitialBlock,
-		repository:      repo,
-		metrics:         metrics,
-		logger:          logger.With(zap.String("blockchain", params.Blockchain), zap.Uint16("chainId", uint16(vaa.ChainIDSolana))),
+		client:                 client,
+		chainID:                vaa.ChainIDSolana,
+		blockchain:             params.Blockchain,
+		contractAddress:        params.ContractAddress,
+		nttPrograms:            params.NttPrograms,
+		cctpMessageTransmitter: params.CctpMessageTransmitter,
+		sizeBlocks:             params.SizeBlocks,
+		waitSeconds:            params.WaitSeconds,
+		initialBlock:           params.InitialBlock,
+		repository:             repo,
+		metrics:                metrics,
+		logger:                 logger.With(zap.String("blockchain", params.Blockchain), zap.Uint16("chainId", uint16(vaa.ChainIDSolana))),
 	}
 }
 
@@ -156,7 +181,9 @@ func (w *SolanaWatcher) Start(ctx context.Context) error {
 						toBlock = lastestBlock.Block
 					}
 					w.logger.Debug("processing blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
-					w.processMultipleBlocks(ctx, fromBlock, toBlock, lastestBlock, true)
+					if err := w.processMultipleBlocks(ctx, fromBlock, toBlock, lastestBlock, true); err != nil {
+						w.logger.Error("cannot process blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock), zap.Error(err))
+					}
 					w.logger.Debug("blocks processed", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
 				}
 				// process all the blocks between current and last block.
@@ -181,27 +208,45 @@ func (w *SolanaWatcher) Close() {
 	w.wg.Wait()
 }
 
-func (w *SolanaWatcher) Backfill(ctx context.Context, fromBlock uint64, toBlock uint64, pageSize uint64, persistBlock bool) {
+func (w *SolanaWatcher) Backfill(ctx context.Context, fromBlock uint64, toBlock uint64, pageSize uint64, persistBlock bool) error {
 	totalBlocks := getTotalBlocks(toBlock, fromBlock, pageSize)
 	for i := uint64(0); i < totalBlocks; i++ {
 		fromBlock, toBlock := getPage(fromBlock, i, pageSize, toBlock)
 		w.logger.Info("processing blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
 		latest := solana.GetLatestBlockResult{Block: toBlock + 1000, Timestamp: time.Now()}
-		w.processMultipleBlocks(ctx, fromBlock, toBlock, &latest, persistBlock)
+		if err := w.processMultipleBlocks(ctx, fromBlock, toBlock, &latest, persistBlock); err != nil {
+			w.logger.Error("cannot process blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock), zap.Error(err))
+			return err
+		}
 		w.logger.Info("blocks processed", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
 	}
+	return nil
 }
 
-func (w *SolanaWatcher) processMultipleBlocks(ctx context.Context, fromBlock uint64, toBlock uint64, latestBlock *solana.GetLatestBlockResult, updateWatcherBlock bool) {
-
+// processMultipleBlocks processes the blocks between fromBlock and toBlock concurrently. It returns the error of the
+// first block that cannot be processed, in which case the last block processed is not updated.
+func (w *SolanaWatcher) processMultipleBlocks(ctx context.Context, fromBlock uint64, toBlock uint64, latestBlock *solana.GetLatestBlockResult, updateWatcherBlock bool) error {
+	var mu sync.Mutex
+	var firstErr error
 	for block := fromBlock; block <= toBlock; block++ {
 		logger := w.logger.With(zap.Uint64("block", block), zap.Uint64("lastBlock", latestBlock.Block))
 		logger.Debug("processing block")
 		w.wgBlock.Add(1)
 		_block := block
-		go w.processBlock(ctx, _block, latestBlock, logger)
+		go func() {
+			if err := w.processBlock(ctx, _block, latestBlock, logger); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("block %d: %w", _block, err)
+				}
+				mu.Unlock()
+			}
+		}()
 	}
 	w.wgBlock.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
 
 	// update the last block number processed in the database.
 	if updateWatcherBlock {
@@ -210,11 +255,12 @@ func (w *SolanaWatcher) processMultipleBlocks(ctx context.Context, fromBlock uin
 			BlockNumber: int64(toBlock),
 			UpdatedAt:   time.Now(),
 		}
-		w.repository.UpdateWatcherBlock(ctx, w.chainID, watcherBlock)
+		return w.repository.UpdateWatcherBlock(ctx, w.chainID, watcherBlock)
 	}
+	return nil
 }
 
-func (w *SolanaWatcher) processBlock(ctx context.Context, block uint64, latestBlock *solana.GetLatestBlockResult, logger *zap.Logger) {
+func (w *SolanaWatcher) processBlock(ctx context.Context, block uint64, latestBlock *solana.GetLatestBlockResult, logger *zap.Logger) error {
 	err := retry.Do(
 		func() error {
 			waitForSolanaBlock(block, latestBlock)
@@ -248,6 +294,7 @@ func (w *SolanaWatcher) processBlock(ctx context.Context, block uint64, latestBl
 		logger.Error("processing block", zap.Error(err))
 	}
 	w.wgBlock.Done()
+	return err
 }
 
 func (w *SolanaWatcher) processTransaction(ctx context.Context, txRpc *rpc.TransactionWithMeta, block uint64, txNum int, blockTime *time.Time) {
@@ -262,6 +309,10 @@ func (w *SolanaWatcher) processTransaction(ctx context.Context, txRpc *rpc.Trans
 		return
 	}
 	txSignature := tx.Signatures[0]
+
+	// redeems of the protocols built on top of wormhole.
+	w.processProtocolInstructions(ctx, txRpc, tx, block, blockTime)
+
 	programIndex := int16(-1)
 	for n, key := range tx.Message.AccountKeys {
 		if key.Equals(w.contractAddress) {
@@ -290,81 +341,207 @@ func (w *SolanaWatcher) processTransaction(ctx context.Context, txRpc *rpc.Trans
 			continue
 		}
 
-		signaturesByAccount, err := w.client.GetSignaturesForAddress(ctx, *accountAddress)
-		if err != nil {
-			w.logger.Error("getting signatures for address failed",
-				zap.Stringer("txSignature", txSignature),
-				zap.Uint64("block", block),
-				zap.String("accountAddress", accountAddress.String()),
-				zap.Error(err))
-			continue
+		log := w.logger.With(
+			zap.Stringer("txSignature", txSignature),
+			zap.Uint64("block", block),
+			zap.String("accountAddress", accountAddress.String()),
+		)
+		for _, data := range w.getPostedVaas(ctx, txSignature, *accountAddress, log) {
+			updatedAt := time.Now()
+			globalTx := storage.TransactionUpdate{
+				ID: data.MessageID(),
+				Destination: storage.DestinationTx{
+					ChainID:     w.chainID,
+					Status:      w.getStatus(txRpc),
+					Method:      instruccionID.Name(),
+					TxHash:      txSignature.String(),
+					BlockNumber: strconv.FormatUint(block, 10),
+					Timestamp:   blockTime,
+					UpdatedAt:   &updatedAt,
+				},
+			}
+
+			// update global transaction and check if it should be updated.
+			updateGlobalTransaction(ctx, w.chainID, globalTx, w.repository, log)
 		}
+	}
+}
 
-		for _, signatureByAccount := range signaturesByAccount {
-			txSignatureAccount := signatureByAccount.Signature
-			if !txSignature.Equals(txSignatureAccount) {
-				log := w.logger.With(
-					zap.Stringer("txSignature", txSignature),
-					zap.Stringer("txSignatureAccount", txSignatureAccount),
-					zap.Uint64("block", block),
-					zap.String("accountAddress", accountAddress.String()),
-				)
-
-				result, err := w.client.GetTransaction(ctx, txSignatureAccount)
-				if err != nil {
-					log.Error("getting transaction failed", zap.Error(err))
-					continue
-				}
+// getPostedVaas returns the VAAs posted to the core bridge account by the transactions,
+// other than the redeem transaction, that used the account.
+func (w *SolanaWatcher) getPostedVaas(ctx context.Context, txSignature solana_types.Signature, accountAddress solana_types.PublicKey, logger *zap.Logger) []postVAAData {
+	signaturesByAccount, err := w.client.GetSignaturesForAddress(ctx, accountAddress)
+	if err != nil {
+		logger.Error("getting signatures for address failed", zap.Error(err))
+		return nil
+	}
 
-				if result.Transaction == nil {
-					log.Error("transaction not found")
-					continue
-				}
+	var result []postVAAData
+	for _, signatureByAccount := range signaturesByAccount {
+		txSignatureAccount := signatureByAccount.Signature
+		if txSignature.Equals(txSignatureAccount) {
+			continue
+		}
+		log := logger.With(zap.Stringer("txSignatureAccount", txSignatureAccount))
 
-				t, err := result.Transaction.GetTransaction()
-				if err != nil {
-					log.Error("getting transaction detail failed", zap.Error(err))
-					continue
-				}
-				for i, instruccion := range t.Message.Instructions {
-					if len(instruccion.Data) == 0 {
-						log.Debug("instruction data is empty")
-						continue
-					}
+		txResult, err := w.client.GetTransaction(ctx, txSignatureAccount)
+		if err != nil {
+			log.Error("getting transaction failed", zap.Error(err))
+			continue
+		}
 
-					if instruccion.Data[0] != postVAATypeIndex {
-						log.Debug("invalid instruction data type", zap.Uint8("type", uint8(instruccion.Data[0])))
-						continue
-					}
+		if txResult.Transaction == nil {
+			log.Error("transaction not found")
+			continue
+		}
 
-					var data postVAAData
-					if err := borsh.Deserialize(&data, instruccion.Data[1:]); err != nil {
-						log.Error("failed to deserialize instruction data", zap.Error(err), zap.Int("index", i))
-						continue
-					}
+		t, err := txResult.Transaction.GetTransaction()
+		if err != nil {
+			log.Error("getting transaction detail failed", zap.Error(err))
+			continue
+		}
+		for i, instruccion := range t.Message.Instructions {
+			if len(instruccion.Data) == 0 {
+				log.Debug("instruction data is empty")
+				continue
+			}
 
-					updatedAt := time.Now()
-					globalTx := storage.TransactionUpdate{
-						ID: data.MessageID(),
-						Destination: storage.DestinationTx{
-							ChainID:     w.chainID,
-							Status:      w.getStatus(txRpc),
-							Method:      instruccionID.Name(),
-							TxHash:      txSignature.String(),
-							BlockNumber: strconv.FormatUint(block, 10),
-							Timestamp:   blockTime,
-							UpdatedAt:   &updatedAt,
-						},
-					}
+			if instruccion.Data[0] != postVAATypeIndex {
+				log.Debug("invalid instruction data type", zap.Uint8("type", uint8(instruccion.Data[0])))
+				continue
+			}
+
+			var data postVAAData
+			if err := borsh.Deserialize(&data, instruccion.Data[1:]); err != nil {
+				log.Error("failed to deserialize instruction data", zap.Error(err), zap.Int("index", i))
+				continue
+			}
+			result = append(result, data)
+			break
+		}
+	}
+	return result
+}
 
-					// update global transaction and check if it should be updated.
-					updateGlobalTransaction(ctx, w.chainID, globalTx, w.repository, log)
+// processProtocolInstructions detects the NTT wormhole transceiver and circle message transmitter redeems
+// of a transaction, including the instructions invoked by other programs.
+func (w *SolanaWatcher) processProtocolInstructions(ctx context.Context, txRpc *rpc.TransactionWithMeta, tx *solana_types.Transaction, block uint64, blockTime *time.Time) {
+	if len(w.nttPrograms) == 0 && w.cctpMessageTransmitter.IsZero() {
+		return
+	}
+	txSignature := tx.Signatures[0]
+	for _, inst := range getSolanaInstructions(tx, txRpc.Meta) {
+		if int(inst.ProgramIDIndex) >= len(tx.Message.AccountKeys) {
+			continue
+		}
+		programID := tx.Message.AccountKeys[inst.ProgramIDIndex]
+		log := w.logger.With(
+			zap.Stringer("txSignature", txSignature),
+			zap.Uint64("block", block),
+			zap.Stringer("programId", programID),
+		)
 
-					break
+		var vaaIDs []string
+		var method, protocol string
+		switch {
+		case w.isNttProgram(programID) && bytes.HasPrefix(inst.Data, nttReceiveWormholeMessageDiscriminator):
+			method, protocol = nttReceiveWormholeMessageInstruction, config.ProtocolNTT
+			accountAddress, ok := getInstructionAccount(tx, inst, nttVaaAccountIndex)
+			if !ok {
+				log.Error("invalid number of accounts", zap.Int("instructionAccounts", len(inst.Accounts)))
+				continue
+			}
+			for _, data := range w.getPostedVaas(ctx, txSignature, accountAddress, log) {
+				vaaIDs = append(vaaIDs, data.MessageID())
+			}
+		case !w.cctpMessageTransmitter.IsZero() && programID.Equals(w.cctpMessageTransmitter) &&
+			bytes.HasPrefix(inst.Data, cctpReceiveMessageDiscriminator):
+			method, protocol = config.MethodReceiveMessage, config.ProtocolCCTP
+			message, err := getSolanaCctpMessage(inst.Data)
+			if err != nil {
+				log.Error("cannot get circle message", zap.Error(err))
+				continue
+			}
+			vaaID, err := getVaaIDByCctpMessage(ctx, message, w.repository)
+			if err != nil {
+				if errors.Is(err, storage.ErrDocNotFound) {
+					log.Debug("circle message not sent through wormhole")
+				} else {
+					log.Error("cannot get VAA id by circle message", zap.Error(err))
 				}
+				continue
+			}
+			vaaIDs = append(vaaIDs, vaaID)
+		default:
+			continue
+		}
+
+		for _, vaaID := range vaaIDs {
+			updatedAt := time.Now()
+			globalTx := storage.TransactionUpdate{
+				ID: vaaID,
+				Destination: storage.DestinationTx{
+					ChainID:     w.chainID,
+					Status:      w.getStatus(txRpc),
+					Method:      method,
+					TxHash:      txSignature.String(),
+					BlockNumber: strconv.FormatUint(block, 10),
+					Timestamp:   blockTime,
+					UpdatedAt:   &updatedAt,
+					Protocol:    protocol,
+				},
 			}
+
+			// update global transaction and check if it should be updated.
+			updateGlobalTransaction(ctx, w.chainID, globalTx, w.repository, log)
+		}
+	}
+}
+
+func (w *SolanaWatcher) isNttProgram(programID solana_types.PublicKey) bool {
+	for _, program := range w.nttPrograms {
+		if program.Equals(programID) {
+			return true
 		}
 	}
+	return false
+}
+
+// getSolanaInstructions returns the top-level instructions of a transaction followed by its inner instructions.
+func getSolanaInstructions(tx *solana_types.Transaction, meta *rpc.TransactionMeta) []solana_types.CompiledInstruction {
+	instructions := append([]solana_types.CompiledInstruction{}, tx.Message.Instructions...)
+	if meta == nil {
+		return instructions
+	}
+	for _, inner := range meta.InnerInstructions {
+		instructions = append(instructions, inner.Instructions...)
+	}
+	return instructions
+}
+
+// getInstructionAccount returns the account at the given index of an instruction.
+func getInstructionAccount(tx *solana_types.Transaction, inst solana_types.CompiledInstruction, index int) (solana_types.PublicKey, bool) {
+	if len(inst.Accounts) <= index || int(inst.Accounts[index]) >= len(tx.Message.AccountKeys) {
+		return solana_types.PublicKey{}, false
+	}
+	return tx.Message.AccountKeys[inst.Accounts[index]], true
+}
+
+// getSolanaCctpMessage returns the circle message of a receive_message instruction. The instruction
+// data is the anchor discriminator followed by the borsh encoded message and attestation.
+func getSolanaCctpMessage(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, cctpReceiveMessageDiscriminator) {
+		return nil, errInvalidCctpMessage
+	}
+	data = data[len(cctpReceiveMessageDiscriminator):]
+	if len(data) < 4 {
+		return nil, errInvalidCctpMessage
+	}
+	length := binary.LittleEndian.Uint32(data[:4])
+	if uint64(len(data)-4) < uint64(length) {
+		return nil, errInvalidCctpMessage
+	}
+	return data[4 : 4+length], nil
 }
 
 func getTransferInstruction(inst solana_types.CompiledInstruction, programIndex int16) instructionID {
@@ -1,9 +1,11 @@
 package watcher
 
 import (
+	"encoding/binary"
 	"testing"
 	"time"
 
+	solana_types "github.com/gagliardetto/solana-go"
 	"github.com/test-go/testify/assert"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/solana"
 )
@@ -27,3 +29,37 @@ func Test_noWaitForSolanaBlock(t *testing.T) {
 	waitForSolanaBlock(block, lastestBlock)
 	assert.Equal(t, time.Since(lastestBlock.Timestamp) < 1*time.Second, true)
 }
+
+func Test_getSolanaCctpMessage(t *testing.T) {
+	message := []byte("circle message")
+	data := append([]byte{}, cctpReceiveMessageDiscriminator...)
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(message)))
+	data = append(data, message...)
+	data = binary.LittleEndian.AppendUint32(data, 2)
+	data = append(data, 0x01, 0x02)
+
+	result, err := getSolanaCctpMessage(data)
+	assert.Nil(t, err)
+	assert.Equal(t, message, result)
+
+	_, err = getSolanaCctpMessage(data[:len(cctpReceiveMessageDiscriminator)+6])
+	assert.Equal(t, errInvalidCctpMessage, err)
+
+	_, err = getSolanaCctpMessage(nttReceiveWormholeMessageDiscriminator)
+	assert.Equal(t, errInvalidCctpMessage, err)
+}
+
+func Test_getInstructionAccount(t *testing.T) {
+	keys := []solana_types.PublicKey{solana_types.NewWallet().PublicKey(), solana_types.NewWallet().PublicKey()}
+	tx := &solana_types.Transaction{Message: solana_types.Message{AccountKeys: keys}}
+
+	account, ok := getInstructionAccount(tx, solana_types.CompiledInstruction{Accounts: []uint16{0, 0, 0, 1}}, nttVaaAccountIndex)
+	assert.True(t, ok)
+	assert.Equal(t, keys[1], account)
+
+	_, ok = getInstructionAccount(tx, solana_types.CompiledInstruction{Accounts: []uint16{0, 1}}, nttVaaAccountIndex)
+	assert.False(t, ok)
+
+	_, ok = getInstructionAccount(tx, solana_types.CompiledInstruction{Accounts: []uint16{0, 0, 0, 5}}, nttVaaAccountIndex)
+	assert.False(t, ok)
+}
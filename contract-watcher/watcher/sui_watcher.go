@@ -0,0 +1,381 @@
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/sui"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/storage"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// Sui token bridge move call to complete a transfer.
+const (
+	suiCompleteTransferModule  = "complete_transfer"
+	SuiMethodAuthorizeTransfer = "authorize_transfer"
+	suiProgrammableTransaction = "ProgrammableTransaction"
+	suiPureInput               = "pure"
+	suiVectorU8                = "vector<u8>"
+	suiStatusSuccess           = "success"
+	suiQueryPageSize           = 50
+)
+
+var errSuiVaaNotFound = errors.New("cannot find VAA in transaction inputs")
+
+// SuiWatcher is a watcher for the sui chain. The complete transfers are queried by move function
+// and the last checkpoint processed is persisted.
+type SuiWatcher struct {
+	client       *sui.SuiSDK
+	chainID      vaa.ChainID
+	blockchain   string
+	packageIDs   []string
+	waitSeconds  uint16
+	initialBlock int64
+	repository   *storage.Repository
+	logger       *zap.Logger
+	close        chan bool
+	wg           sync.WaitGroup
+	metrics      metrics.Metrics
+}
+
+// SuiParams are the params for the sui watcher.
+type SuiParams struct {
+	Blockchain string
+	// ContractAddress is the token bridge package id.
+	ContractAddress string
+	// UpgradedAddresses are the package ids of the upgraded versions of the token bridge,
+	// the move calls of a transaction use the id of the version they call.
+	UpgradedAddresses []string
+	WaitSeconds       uint16
+	InitialBlock      int64
+}
+
+// suiCompleteTransfer is a complete transfer transaction and its checkpoint.
+type suiCompleteTransfer struct {
+	checkpoint uint64
+	tx         sui.TransactionBlock
+}
+
+// NewSuiWatcher creates a new sui watcher.
+func NewSuiWatcher(client *sui.SuiSDK, params SuiParams, repo *storage.Repository, metrics metrics.Metrics, logger *zap.Logger) *SuiWatcher {
+	var packageIDs []string
+	seen := make(map[string]bool)
+	for _, packageID := range append([]string{params.ContractAddress}, params.UpgradedAddresses...) {
+		packageID = strings.ToLower(strings.TrimSpace(packageID))
+		if packageID != "" && !seen[packageID] {
+			seen[packageID] = true
+			packageIDs = append(packageIDs, packageID)
+		}
+	}
+	return &SuiWatcher{
+		client:       client,
+		chainID:      vaa.ChainIDSui,
+		blockchain:   params.Blockchain,
+		packageIDs:   packageIDs,
+		waitSeconds:  params.WaitSeconds,
+		initialBlock: params.InitialBlock,
+		repository:   repo,
+		metrics:      metrics,
+		close:        make(chan bool),
+		logger:       logger.With(zap.String("blockchain", params.Blockchain), zap.Uint16("chainId", uint16(vaa.ChainIDSui))),
+	}
+}
+
+// GetBlockchain returns the blockchain name.
+func (w *SuiWatcher) GetBlockchain() string {
+	return w.blockchain
+}
+
+// Start starts the sui watcher.
+func (w *SuiWatcher) Start(ctx context.Context) error {
+	// get the current checkpoint for the chain.
+	cCheckpoint, err := w.repository.GetCurrentBlock(ctx, w.blockchain, w.initialBlock)
+	if err != nil {
+		w.logger.Error("cannot get current checkpoint", zap.Error(err))
+		return err
+	}
+	currentCheckpoint := uint64(cCheckpoint)
+	w.wg.Add(1)
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("clossing sui watcher by context")
+			w.wg.Done()
+			return nil
+		case <-w.close:
+			w.logger.Info("clossing sui watcher")
+			w.wg.Done()
+			return nil
+		default:
+			// get the latest checkpoint for the chain.
+			lastCheckpoint, err := w.client.GetLatestCheckpoint(ctx)
+			if err != nil {
+				w.logger.Error("cannot get sui latest checkpoint", zap.Error(err))
+			}
+
+			if currentCheckpoint < lastCheckpoint {
+				w.metrics.SetLastBlock(w.chainID, lastCheckpoint)
+				fromCheckpoint := currentCheckpoint + 1
+				w.logger.Debug("processing checkpoints", zap.Uint64("from", fromCheckpoint), zap.Uint64("to", lastCheckpoint))
+				err := w.processCheckpoints(ctx, fromCheckpoint, lastCheckpoint, lastCheckpoint-currentCheckpoint, true)
+				if err == nil {
+					currentCheckpoint = lastCheckpoint
+					continue
+				}
+				// the current checkpoint is not advanced, so the checkpoints are processed again.
+				w.logger.Error("cannot process checkpoints", zap.Uint64("from", fromCheckpoint), zap.Uint64("to", lastCheckpoint), zap.Error(err))
+				w.repository.SendProcessBlocksAlert(ctx, w.chainID, fromCheckpoint, lastCheckpoint, err)
+			} else {
+				w.logger.Debug("waiting for new sui checkpoints")
+			}
+			select {
+			case <-ctx.Done():
+				w.wg.Done()
+				return nil
+			case <-time.After(time.Duration(w.waitSeconds) * time.Second):
+			}
+		}
+	}
+}
+
+// Backfill processes the checkpoints between fromCheckpoint and toCheckpoint.
+func (w *SuiWatcher) Backfill(ctx context.Context, fromCheckpoint uint64, toCheckpoint uint64, pageSize uint64, persistBlock bool) error {
+	w.logger.Info("processing checkpoints", zap.Uint64("from", fromCheckpoint), zap.Uint64("to", toCheckpoint))
+	if err := w.processCheckpoints(ctx, fromCheckpoint, toCheckpoint, pageSize, persistBlock); err != nil {
+		w.logger.Error("cannot process checkpoints", zap.Uint64("from", fromCheckpoint), zap.Uint64("to", toCheckpoint), zap.Error(err))
+		w.repository.SendProcessBlocksAlert(ctx, w.chainID, fromCheckpoint, toCheckpoint, err)
+		return err
+	}
+	w.logger.Info("checkpoints processed", zap.Uint64("from", fromCheckpoint), zap.Uint64("to", toCheckpoint))
+	return nil
+}
+
+// processCheckpoints processes the complete transfers between fromCheckpoint and toCheckpoint. The watcher block
+// is updated after each page of pageSize checkpoints, and it is not updated past a page that cannot be processed.
+func (w *SuiWatcher) processCheckpoints(ctx context.Context, fromCheckpoint uint64, toCheckpoint uint64, pageSize uint64, updateWatcherBlock bool) error {
+	var transfers []suiCompleteTransfer
+	err := retry.Do(
+		func() error {
+			var err error
+			transfers, err = w.getCompleteTransfers(ctx, fromCheckpoint, toCheckpoint)
+			if err != nil {
+				w.logger.Error("cannot get complete transfers", zap.Uint64("from", fromCheckpoint), zap.Uint64("to", toCheckpoint), zap.Error(err))
+			}
+			return err
+		},
+		retry.Attempts(evmMaxRetries),
+		retry.Delay(evmRetryDelay),
+		retry.Context(ctx),
+	)
+	if err != nil {
+		return err
+	}
+
+	next := 0
+	totalPages := getTotalBlocks(toCheckpoint, fromCheckpoint, pageSize)
+	for i := uint64(0); i < totalPages; i++ {
+		_, pageTo := getPage(fromCheckpoint, i, pageSize, toCheckpoint)
+		for ; next < len(transfers) && transfers[next].checkpoint <= pageTo; next++ {
+			tx := transfers[next].tx
+			globalTx, err := getSuiGlobalTransaction(w.chainID, w.packageIDs, tx)
+			if err != nil {
+				w.logger.Error("cannot get transaction data", zap.String("txHash", tx.Digest), zap.String("checkpoint", tx.Checkpoint), zap.Error(err))
+				continue
+			}
+			if globalTx == nil {
+				continue
+			}
+			// update global transaction and check if it should be updated.
+			updateGlobalTransaction(ctx, w.chainID, *globalTx, w.repository, w.logger)
+		}
+
+		if updateWatcherBlock {
+			// update the last checkpoint processed in the database.
+			watcherBlock := storage.WatcherBlock{
+				ID:          w.blockchain,
+				BlockNumber: int64(pageTo),
+				UpdatedAt:   time.Now(),
+			}
+			if err := w.repository.UpdateWatcherBlock(ctx, w.chainID, watcherBlock); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getCompleteTransfers returns the transactions calling the complete transfer function of the token bridge
+// packages between fromCheckpoint and toCheckpoint, sorted by checkpoint. The transactions of each package are
+// queried in ascending order from the last transaction before fromCheckpoint until one after toCheckpoint is found.
+func (w *SuiWatcher) getCompleteTransfers(ctx context.Context, fromCheckpoint uint64, toCheckpoint uint64) ([]suiCompleteTransfer, error) {
+	startCursor, err := w.getStartCursor(ctx, fromCheckpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var transfers []suiCompleteTransfer
+	digests := make(map[string]bool)
+	for _, packageID := range w.packageIDs {
+		query := sui.TransactionBlockQuery{
+			Filter: sui.TransactionFilter{
+				MoveFunction: &sui.MoveFunctionFilter{
+					Package:  packageID,
+					Module:   suiCompleteTransferModule,
+					Function: SuiMethodAuthorizeTransfer,
+				},
+			},
+			Options: sui.TransactionBlockOptions{ShowInput: true, ShowEffects: true},
+		}
+
+		cursor := startCursor
+		for done := false; !done; {
+			page, err := w.client.QueryTransactionBlocks(ctx, query, cursor, suiQueryPageSize, false)
+			if err != nil {
+				return nil, err
+			}
+			for _, tx := range page.Data {
+				checkpoint, err := strconv.ParseUint(tx.Checkpoint, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid checkpoint %q of transaction %s: %w", tx.Checkpoint, tx.Digest, err)
+				}
+				if checkpoint > toCheckpoint {
+					done = true
+					break
+				}
+				if checkpoint < fromCheckpoint || digests[tx.Digest] {
+					continue
+				}
+				digests[tx.Digest] = true
+				transfers = append(transfers, suiCompleteTransfer{checkpoint: checkpoint, tx: tx})
+			}
+			if !page.HasNextPage || page.NextCursor == nil {
+				break
+			}
+			cursor = *page.NextCursor
+		}
+	}
+
+	sort.SliceStable(transfers, func(i, j int) bool {
+		return transfers[i].checkpoint < transfers[j].checkpoint
+	})
+	return transfers, nil
+}
+
+// getStartCursor returns the cursor of the transaction queries from fromCheckpoint, which is the last transaction
+// of the previous checkpoint. The queries start from the first transaction when fromCheckpoint is the genesis.
+func (w *SuiWatcher) getStartCursor(ctx context.Context, fromCheckpoint uint64) (string, error) {
+	if fromCheckpoint == 0 {
+		return "", nil
+	}
+	checkpoint, err := w.client.GetCheckpoint(ctx, fromCheckpoint-1)
+	if err != nil {
+		return "", err
+	}
+	if len(checkpoint.Transactions) == 0 {
+		return "", fmt.Errorf("checkpoint %d has no transactions", fromCheckpoint-1)
+	}
+	return checkpoint.Transactions[len(checkpoint.Transactions)-1], nil
+}
+
+// getSuiGlobalTransaction returns the destination tx of a token bridge complete transfer.
+// If the transaction does not complete a transfer it returns nil.
+func getSuiGlobalTransaction(chainID vaa.ChainID, packageIDs []string, tx sui.TransactionBlock) (*storage.TransactionUpdate, error) {
+	kind := tx.Transaction.Data.Transaction
+	if kind.Kind != suiProgrammableTransaction {
+		return nil, nil
+	}
+	packageID, ok := getSuiCompleteTransferPackage(packageIDs, kind.Transactions)
+	if !ok {
+		return nil, nil
+	}
+
+	// the VAA is a pure vector<u8> input parsed by the core bridge in the same transaction.
+	v, err := getSuiVaa(kind.Inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	status := domain.DstTxStatusFailedToProcess
+	if tx.Effects.Status.Status == suiStatusSuccess {
+		status = domain.DstTxStatusConfirmed
+	}
+
+	var timestamp *time.Time
+	if ms, err := strconv.ParseInt(tx.TimestampMs, 10, 64); err == nil {
+		tm := time.UnixMilli(ms)
+		timestamp = &tm
+	}
+
+	updatedAt := time.Now()
+	return &storage.TransactionUpdate{
+		ID: v.MessageID(),
+		Destination: storage.DestinationTx{
+			ChainID:     chainID,
+			Status:      status,
+			Method:      SuiMethodAuthorizeTransfer,
+			TxHash:      tx.Digest,
+			From:        tx.Transaction.Data.Sender,
+			To:          packageID,
+			BlockNumber: tx.Checkpoint,
+			Timestamp:   timestamp,
+			UpdatedAt:   &updatedAt,
+		},
+	}, nil
+}
+
+// getSuiCompleteTransferPackage returns the token bridge package called to complete a transfer, if any.
+func getSuiCompleteTransferPackage(packageIDs []string, commands []sui.Command) (string, bool) {
+	for _, command := range commands {
+		call := command.MoveCall
+		if call == nil || call.Module != suiCompleteTransferModule || call.Function != SuiMethodAuthorizeTransfer {
+			continue
+		}
+		packageID := strings.ToLower(call.Package)
+		for _, id := range packageIDs {
+			if id == packageID {
+				return packageID, true
+			}
+		}
+	}
+	return "", false
+}
+
+func getSuiVaa(inputs []sui.Input) (*vaa.VAA, error) {
+	for _, input := range inputs {
+		if input.Type != suiPureInput || input.ValueType != suiVectorU8 {
+			continue
+		}
+		// vector<u8> values are json arrays of numbers.
+		var values []uint16
+		if err := json.Unmarshal(input.Value, &values); err != nil {
+			continue
+		}
+		data := make([]byte, 0, len(values))
+		for _, value := range values {
+			data = append(data, byte(value))
+		}
+		v, err := vaa.Unmarshal(data)
+		if err != nil {
+			continue
+		}
+		return v, nil
+	}
+	return nil, errSuiVaaNotFound
+}
+
+// Close closes the sui watcher.
+func (w *SuiWatcher) Close() {
+	close(w.close)
+	w.wg.Wait()
+}
@@ -0,0 +1,140 @@
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/sui"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/ratelimit"
+	"go.uber.org/zap"
+)
+
+const testSuiTokenBridge = "0x26efee2b51c911237888e5dc6702868abca3c7ac12c53f76ef8eba0697695e3d"
+
+func Test_getSuiGlobalTransaction(t *testing.T) {
+	data, err := os.ReadFile("testdata/sui_transaction_blocks.json")
+	assert.Nil(t, err)
+	var response struct {
+		Result []sui.TransactionBlock `json:"result"`
+	}
+	assert.Nil(t, json.Unmarshal(data, &response))
+	txs := response.Result
+	assert.Equal(t, 3, len(txs))
+
+	// completed transfer.
+	globalTx, err := getSuiGlobalTransaction(vaa.ChainIDSui, []string{testSuiTokenBridge}, txs[0])
+	assert.Nil(t, err)
+	assert.NotNil(t, globalTx)
+	assert.Equal(t, testRedeemedVaaID, globalTx.ID)
+	assert.Equal(t, vaa.ChainIDSui, globalTx.Destination.ChainID)
+	assert.Equal(t, domain.DstTxStatusConfirmed, globalTx.Destination.Status)
+	assert.Equal(t, SuiMethodAuthorizeTransfer, globalTx.Destination.Method)
+	assert.Equal(t, "5YQkTR3bBBCzVb3fHnbGJxYHD6vsJv9mBhQc3sPmTGCS", globalTx.Destination.TxHash)
+	assert.Equal(t, "0x9a3c1e2f7b8d4a6c5e0f1b2d3c4a5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d", globalTx.Destination.From)
+	assert.Equal(t, "30211345", globalTx.Destination.BlockNumber)
+	assert.Equal(t, int64(1711999990123), globalTx.Destination.Timestamp.UnixMilli())
+
+	// other transactions.
+	for _, tx := range txs[1:] {
+		globalTx, err = getSuiGlobalTransaction(vaa.ChainIDSui, []string{testSuiTokenBridge}, tx)
+		assert.Nil(t, err)
+		assert.Nil(t, globalTx)
+	}
+
+	// a transfer completed by another package is ignored.
+	globalTx, err = getSuiGlobalTransaction(vaa.ChainIDSui, []string{"0x01"}, txs[0])
+	assert.Nil(t, err)
+	assert.Nil(t, globalTx)
+
+	// a transfer completed by an upgraded package.
+	globalTx, err = getSuiGlobalTransaction(vaa.ChainIDSui, []string{"0x01", testSuiTokenBridge}, txs[0])
+	assert.Nil(t, err)
+	assert.NotNil(t, globalTx)
+	assert.Equal(t, testSuiTokenBridge, globalTx.Destination.To)
+}
+
+func TestSuiWatcher_getCompleteTransfers(t *testing.T) {
+	const upgradedPackage = "0x02"
+	// the last transaction of the checkpoint before the first one, where the queries start.
+	const startCursor = "99/last"
+	// complete transfers of each package, from the oldest one.
+	pages := map[string][][]string{
+		testSuiTokenBridge: {
+			{"100", "105", "110"},
+			{"115", "120", "125"},
+			{"130"},
+		},
+		upgradedPackage: {
+			{"108"},
+		},
+	}
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&request))
+		rw.Header().Set("Content-Type", "application/json")
+
+		if request.Method == "sui_getCheckpoint" {
+			assert.Equal(t, `"99"`, string(request.Params[0]))
+			checkpoint := sui.Checkpoint{SequenceNumber: "99", Transactions: []string{"99/first", startCursor}}
+			json.NewEncoder(rw).Encode(map[string]any{"jsonrpc": "2.0", "id": 1, "result": checkpoint})
+			return
+		}
+
+		assert.Equal(t, "suix_queryTransactionBlocks", request.Method)
+		var query sui.TransactionBlockQuery
+		assert.Nil(t, json.Unmarshal(request.Params[0], &query))
+		assert.Equal(t, suiCompleteTransferModule, query.Filter.MoveFunction.Module)
+		assert.Equal(t, SuiMethodAuthorizeTransfer, query.Filter.MoveFunction.Function)
+		assert.Equal(t, "false", string(request.Params[3]))
+		var cursor *string
+		assert.Nil(t, json.Unmarshal(request.Params[1], &cursor))
+		if !assert.NotNil(t, cursor) {
+			return
+		}
+
+		packageID := query.Filter.MoveFunction.Package
+		index := 0
+		if *cursor != startCursor {
+			index, _ = strconv.Atoi(*cursor)
+		}
+		requests = append(requests, fmt.Sprintf("%s/%d", packageID, index))
+
+		page := sui.TransactionBlocksPage{HasNextPage: index+1 < len(pages[packageID])}
+		if page.HasNextPage {
+			next := strconv.Itoa(index + 1)
+			page.NextCursor = &next
+		}
+		for _, checkpoint := range pages[packageID][index] {
+			page.Data = append(page.Data, sui.TransactionBlock{Digest: packageID + "/" + checkpoint, Checkpoint: checkpoint})
+		}
+		json.NewEncoder(rw).Encode(map[string]any{"jsonrpc": "2.0", "id": 1, "result": page})
+	}))
+	defer server.Close()
+
+	client := sui.NewSuiSDK(server.URL, ratelimit.NewUnlimited(), metrics.NewNoopMetrics())
+	w := NewSuiWatcher(client, SuiParams{ContractAddress: testSuiTokenBridge, UpgradedAddresses: []string{upgradedPackage, testSuiTokenBridge}}, nil, metrics.NewNoopMetrics(), zap.NewNop())
+
+	transfers, err := w.getCompleteTransfers(context.Background(), 100, 115)
+	assert.Nil(t, err)
+	var checkpoints []uint64
+	for _, transfer := range transfers {
+		checkpoints = append(checkpoints, transfer.checkpoint)
+	}
+	assert.Equal(t, []uint64{100, 105, 108, 110, 115}, checkpoints)
+	// the pages newer than the last checkpoint are not requested.
+	assert.Equal(t, []string{testSuiTokenBridge + "/0", testSuiTokenBridge + "/1", upgradedPackage + "/0"}, requests)
+}
@@ -132,13 +132,15 @@ func (w *TerraWatcher) Start(ctx context.Context) error {
 	}
 }
 
-func (w *TerraWatcher) Backfill(ctx context.Context, fromBlock uint64, toBlock uint64, pageSize uint64, persistBlock bool) {
+func (w *TerraWatcher) Backfill(ctx context.Context, fromBlock uint64, toBlock uint64, pageSize uint64, persistBlock bool) error {
 	totalBlocks := getTotalBlocks(toBlock, fromBlock, pageSize)
 	for i := uint64(0); i < totalBlocks; i++ {
 		fromBlock, toBlock := getPage(fromBlock, i, pageSize, toBlock)
 		w.logger.Debug("processing blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
 		for block := fromBlock; block <= toBlock; block++ {
-			w.processBlock(ctx, int64(block))
+			if err := w.processBlock(ctx, int64(block)); err != nil {
+				return err
+			}
 			if persistBlock {
 				// update block watcher
 				watcherBlock := storage.WatcherBlock{
@@ -151,9 +153,11 @@ func (w *TerraWatcher) Backfill(ctx context.Context, fromBlock uint64, toBlock u
 		}
 		w.logger.Debug("blocks processed", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
 	}
+	return nil
 }
 
-func (w *TerraWatcher) processBlock(ctx context.Context, block int64) {
+// processBlock processes the transactions of a block, the requests that fail are retried until the context is done.
+func (w *TerraWatcher) processBlock(ctx context.Context, block int64) error {
 	var offset *int
 	hasPage := true
 	for hasPage {
@@ -162,6 +166,9 @@ func (w *TerraWatcher) processBlock(ctx context.Context, block int64) {
 		transactions, err := w.terraSDK.GetTransactionsByBlockHeight(ctx, block, offset)
 		if err != nil {
 			w.logger.Error("cannot get transactions by address", zap.Error(err), zap.Int64("block", block))
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
 			time.Sleep(10 * time.Second)
 			continue
 		}
@@ -247,6 +254,7 @@ func (w *TerraWatcher) processBlock(ctx context.Context, block int64) {
 			offset = transactions.NextOffset
 		}
 	}
+	return nil
 }
 
 func (w *TerraWatcher) checkTransactionContractAddress(tx terra.WormholeTerraTx) bool {
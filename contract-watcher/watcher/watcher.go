@@ -18,7 +18,14 @@ var (
 
 type FuncGetGlobalTransactionById func(ctx context.Context, id string) (storage.TransactionUpdate, error)
 
-func updateGlobalTransaction(ctx context.Context, chainID sdk.ChainID, tx storage.TransactionUpdate, r *storage.Repository, log *zap.Logger) {
+// transactionRepository is the storage of the destination txs found by the watchers.
+type transactionRepository interface {
+	GetGlobalTransactionByID(ctx context.Context, id string) (storage.TransactionUpdate, error)
+	UpsertGlobalTransaction(ctx context.Context, chainID sdk.ChainID, globalTx storage.TransactionUpdate) error
+	GetVaaIDByCctpNonce(ctx context.Context, sourceDomain uint32, nonce uint64) (string, error)
+}
+
+func updateGlobalTransaction(ctx context.Context, chainID sdk.ChainID, tx storage.TransactionUpdate, r transactionRepository, log *zap.Logger) {
 	updateGlobalTx, err := checkTxShouldBeUpdated(ctx, tx, r.GetGlobalTransactionByID)
 	if !updateGlobalTx {
 		log.Info("tx can not be updated",
@@ -13,6 +13,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/sns"
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/sqs"
 	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
 	"github.com/wormhole-foundation/wormhole-explorer/common/health"
@@ -35,6 +36,7 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/http/infrastructure"
 	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/http/vaa"
 	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/notifier"
 )
 
 func Run() {
@@ -75,8 +77,18 @@ func Run() {
 	}
 
 	// create a new processor
-	dupVaaProcessor := vaaprocessor.NewProcessor(guardianApiProviderPool, repository, logger, metrics)
-	governorProcessor := governorProcessor.NewProcessor(repository, createTxHashFunc, logger, metrics)
+	resolutionConfig := vaaprocessor.ResolutionConfig{
+		GuardianQueries:    cfg.DuplicateVaaGuardianQueries,
+		AgreementThreshold: cfg.DuplicateVaaAgreementThreshold,
+	}
+	dupVaaProcessor := vaaprocessor.NewProcessor(guardianApiProviderPool, repository, resolutionConfig, logger, metrics)
+	governorVaaReleasedNotifier, err := newGovernorVaaReleasedNotifier(rootCtx, cfg, logger)
+	if err != nil {
+		logger.Fatal("failed to initialize governor vaa released notifier", zap.Error(err))
+	}
+	releaseConfig := governorProcessor.ReleaseConfig{Quorum: cfg.GovernorReleaseQuorum}
+	governorProcessor := governorProcessor.NewProcessor(repository, createTxHashFunc,
+		governorVaaReleasedNotifier, releaseConfig, logger, metrics)
 
 	// start serving /health and /ready endpoints
 	healthChecks, err := makeHealthChecks(rootCtx, cfg, db.Database)
@@ -268,3 +280,34 @@ func newCreateTxHashFunc(
 	}
 	return createTxHashClient.CreateTxHash, nil
 }
+
+// newGovernorVaaReleasedNotifier creates the notifier of the governor vaa releases with
+// the SNS topic and the webhook that are configured.
+func newGovernorVaaReleasedNotifier(
+	ctx context.Context,
+	cfg *config.ServiceConfiguration,
+	logger *zap.Logger,
+) (*notifier.Notifier, error) {
+
+	var publishers []notifier.Publisher
+	if cfg.GovernorReleasedSNSUrl != "" {
+		awsConfig, err := newAwsConfig(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		producer, err := sns.NewProducer(awsConfig, cfg.GovernorReleasedSNSUrl)
+		if err != nil {
+			return nil, err
+		}
+		publishers = append(publishers, notifier.NewSNSPublisher(producer))
+	}
+	if cfg.GovernorReleasedWebhookUrl != "" {
+		timeout := time.Duration(cfg.GovernorReleasedWebhookTimeout) * time.Second
+		publishers = append(publishers, notifier.NewWebhookPublisher(
+			cfg.GovernorReleasedWebhookUrl, cfg.GovernorReleasedWebhookSecret, timeout))
+	}
+	if len(publishers) == 0 {
+		logger.Warn("governor vaa released notifications are disabled")
+	}
+	return notifier.NewNotifier(logger, publishers...), nil
+}
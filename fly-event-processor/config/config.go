@@ -53,6 +53,17 @@ type ServiceConfiguration struct {
 	// Guardian api provider configuration
 	GuardianAPIProviderPath       string `env:"GUARDIAN_API_PROVIDER_PATH,required"`
 	*GuardianAPIConfigurationJson `required:"false"`
+
+	// Duplicate vaa resolution configuration
+	DuplicateVaaGuardianQueries    int `env:"DUPLICATE_VAA_GUARDIAN_QUERIES,default=3"`
+	DuplicateVaaAgreementThreshold int `env:"DUPLICATE_VAA_AGREEMENT_THRESHOLD,default=2"`
+
+	// Governor vaa release configuration
+	GovernorReleaseQuorum          int    `env:"GOVERNOR_RELEASE_QUORUM,default=13"`
+	GovernorReleasedSNSUrl         string `env:"GOVERNOR_RELEASED_SNS_URL"`
+	GovernorReleasedWebhookUrl     string `env:"GOVERNOR_RELEASED_WEBHOOK_URL"`
+	GovernorReleasedWebhookSecret  string `env:"GOVERNOR_RELEASED_WEBHOOK_SECRET"`
+	GovernorReleasedWebhookTimeout int64  `env:"GOVERNOR_RELEASED_WEBHOOK_TIMEOUT,default=10"`
 }
 
 type GuardianAPIConfigurationJson struct {
@@ -90,5 +101,23 @@ func New(ctx context.Context) (*ServiceConfiguration, error) {
 		return nil, fmt.Errorf("guardian API provider settings file is required")
 	}
 
+	// Validate the duplicate vaa resolution agreement.
+	if configuration.DuplicateVaaAgreementThreshold < 1 {
+		return nil, fmt.Errorf("duplicate vaa agreement threshold must be greater than zero")
+	}
+	if configuration.DuplicateVaaAgreementThreshold > configuration.DuplicateVaaGuardianQueries {
+		return nil, fmt.Errorf("duplicate vaa agreement threshold (%d) is greater than the guardian queries (%d)",
+			configuration.DuplicateVaaAgreementThreshold, configuration.DuplicateVaaGuardianQueries)
+	}
+	if providers := len(configuration.GuardianAPIConfigurationJson.GuardianProviders); configuration.DuplicateVaaAgreementThreshold > providers {
+		return nil, fmt.Errorf("duplicate vaa agreement threshold (%d) is greater than the guardian API providers (%d)",
+			configuration.DuplicateVaaAgreementThreshold, providers)
+	}
+
+	// Validate the governor vaa release quorum.
+	if configuration.GovernorReleaseQuorum < 1 {
+		return nil, fmt.Errorf("governor release quorum must be greater than zero")
+	}
+
 	return &configuration, nil
 }
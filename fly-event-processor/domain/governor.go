@@ -16,6 +16,8 @@ type Node struct {
 
 type NodeGovernorVaa struct {
 	Node
+	// Timestamp is the time the guardian reported its governor status.
+	Timestamp    time.Time
 	GovernorVaas map[string]GovernorVaa
 }
 
@@ -74,6 +76,16 @@ func ConvertEventToGovernorVaa(event *queue.EventGovernorStatus) *NodeGovernorVa
 			Name:    event.Data.NodeName,
 			Address: event.Data.NodeAddress,
 		},
+		Timestamp:    toStatusTime(event.Data.Timestamp),
 		GovernorVaas: governorVaas,
 	}
 }
+
+// toStatusTime converts the unix time of a governor status, in nanoseconds, to a time.
+// The current time is used when the status has no timestamp.
+func toStatusTime(timestamp int64) time.Time {
+	if timestamp <= 0 {
+		return time.Now()
+	}
+	return time.Unix(0, timestamp)
+}
@@ -42,3 +42,9 @@ func (d *DummyMetrics) IncGovernorVaaAdded(chainID sdk.ChainID) {}
 
 // IndGovenorVaaDeleted dummy implementation.
 func (d *DummyMetrics) IndGovenorVaaDeleted(chainID sdk.ChainID) {}
+
+// IncGovernorVaaReleased dummy implementation.
+func (d *DummyMetrics) IncGovernorVaaReleased(chainID sdk.ChainID) {}
+
+// IncGovernorVaaReleaseNotifyFailed dummy implementation.
+func (d *DummyMetrics) IncGovernorVaaReleaseNotifyFailed(chainID sdk.ChainID) {}
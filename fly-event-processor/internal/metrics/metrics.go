@@ -16,6 +16,8 @@ type Metrics interface {
 	IncGovernorStatusExpired(node string, address string)
 	IncGovernorVaaAdded(chainID sdk.ChainID)
 	IndGovenorVaaDeleted(chainID sdk.ChainID)
+	IncGovernorVaaReleased(chainID sdk.ChainID)
+	IncGovernorVaaReleaseNotifyFailed(chainID sdk.ChainID)
 }
 
 // IncDuplicatedVaaConsumedQueue increments the counter of consumed queue
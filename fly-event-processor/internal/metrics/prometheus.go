@@ -106,3 +106,15 @@ func (m *PrometheusMetrics) IndGovenorVaaDeleted(chainID sdk.ChainID) {
 	chain := chainID.String()
 	m.governorVaaCount.WithLabelValues(chain, "deleted").Inc()
 }
+
+// IncGovernorVaaReleased increments the total number of governor VAA released.
+func (m *PrometheusMetrics) IncGovernorVaaReleased(chainID sdk.ChainID) {
+	chain := chainID.String()
+	m.governorVaaCount.WithLabelValues(chain, "released").Inc()
+}
+
+// IncGovernorVaaReleaseNotifyFailed increments the total number of governor VAA release notifications failed.
+func (m *PrometheusMetrics) IncGovernorVaaReleaseNotifyFailed(chainID sdk.ChainID) {
+	chain := chainID.String()
+	m.governorVaaCount.WithLabelValues(chain, "release_notify_failed").Inc()
+}
@@ -0,0 +1,69 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/events"
+	"go.uber.org/zap"
+)
+
+const eventSource = "fly-event-processor"
+
+// Publisher publishes a notification event.
+type Publisher interface {
+	// Name identifies the publisher in the pending notifications.
+	Name() string
+	Publish(ctx context.Context, event *events.NotificationEvent, groupID string) error
+}
+
+// Notifier sends the notification events to the configured publishers.
+type Notifier struct {
+	publishers map[string]Publisher
+	names      []string
+	logger     *zap.Logger
+}
+
+// NewNotifier creates a new notifier. Without publishers the events are discarded.
+func NewNotifier(logger *zap.Logger, publishers ...Publisher) *Notifier {
+	n := &Notifier{
+		publishers: make(map[string]Publisher, len(publishers)),
+		logger:     logger,
+	}
+	for _, p := range publishers {
+		n.publishers[p.Name()] = p
+		n.names = append(n.names, p.Name())
+	}
+	return n
+}
+
+// Publishers returns the names of the configured publishers.
+func (n *Notifier) Publishers() []string {
+	return n.names
+}
+
+// NotifyGovernorVaaReleased publishes a GovernorVaaReleased event with the given publisher.
+// The publishers are notified one by one, so that a failing one is retried without notifying the others again.
+// An event for a publisher that is no longer configured is discarded.
+func (n *Notifier) NotifyGovernorVaaReleased(ctx context.Context, publisher string, data *events.GovernorVaaReleased) error {
+	p, ok := n.publishers[publisher]
+	if !ok {
+		n.logger.Warn("discarding governor vaa released event of a publisher that is not configured",
+			zap.String("publisher", publisher), zap.String("vaaId", data.ID))
+		return nil
+	}
+
+	trackID := fmt.Sprintf("%s-%s", events.GovernorVaaReleasedType, data.ID)
+	event, err := events.NewNotificationEvent[events.GovernorVaaReleased](
+		trackID, eventSource, events.GovernorVaaReleasedType, *data)
+	if err != nil {
+		return err
+	}
+
+	if err := p.Publish(ctx, event, data.ID); err != nil {
+		n.logger.Error("failed to publish governor vaa released event",
+			zap.String("publisher", publisher), zap.String("vaaId", data.ID), zap.Error(err))
+		return err
+	}
+	return nil
+}
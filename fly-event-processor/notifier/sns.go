@@ -0,0 +1,34 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/sns"
+	"github.com/wormhole-foundation/wormhole-explorer/common/events"
+)
+
+// SNSPublisher publishes the notification events to a SNS topic.
+type SNSPublisher struct {
+	producer *sns.Producer
+}
+
+// NewSNSPublisher creates a new SNS publisher.
+func NewSNSPublisher(producer *sns.Producer) *SNSPublisher {
+	return &SNSPublisher{producer: producer}
+}
+
+// Name returns the name of the SNS publisher.
+func (p *SNSPublisher) Name() string {
+	return "sns"
+}
+
+// Publish sends the event to the SNS topic. The group id is also the deduplication id,
+// so that the retries of the same notification are discarded by the topic.
+func (p *SNSPublisher) Publish(ctx context.Context, event *events.NotificationEvent, groupID string) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return p.producer.SendMessage(ctx, groupID, groupID, string(body))
+}
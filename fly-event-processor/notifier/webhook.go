@@ -0,0 +1,75 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/events"
+)
+
+// SignatureHeader is the header with the HMAC-SHA256 of the body, hex encoded, when the webhook has a secret.
+const SignatureHeader = "X-Wormholescan-Signature"
+
+// WebhookPublisher posts the notification events to a webhook.
+type WebhookPublisher struct {
+	client *http.Client
+	url    string
+	secret string
+}
+
+// NewWebhookPublisher creates a new webhook publisher. The secret is optional.
+func NewWebhookPublisher(url, secret string, timeout time.Duration) *WebhookPublisher {
+	return &WebhookPublisher{
+		client: &http.Client{Timeout: timeout},
+		url:    url,
+		secret: secret,
+	}
+}
+
+// Name returns the name of the webhook publisher.
+func (p *WebhookPublisher) Name() string {
+	return "webhook"
+}
+
+// Publish posts the event to the webhook. Any response status other than 2xx is an error.
+func (p *WebhookPublisher) Publish(ctx context.Context, event *events.NotificationEvent, _ string) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.secret != "" {
+		req.Header.Set(SignatureHeader, sign(p.secret, body))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -14,16 +14,20 @@ import (
 
 // Processor is a governor processor.
 type Processor struct {
-	repository       *storage.Repository
+	repository       Repository
 	createTxHashFunc txTracker.CreateTxHashFunc
+	notifier         Notifier
+	config           ReleaseConfig
 	logger           *zap.Logger
 	metrics          metrics.Metrics
 }
 
 // NewProcessor creates a new governor processor.
 func NewProcessor(
-	repository *storage.Repository,
+	repository Repository,
 	createTxHashFunc txTracker.CreateTxHashFunc,
+	notifier Notifier,
+	config ReleaseConfig,
 	logger *zap.Logger,
 	metrics metrics.Metrics,
 ) *Processor {
@@ -31,6 +35,8 @@ func NewProcessor(
 	return &Processor{
 		repository:       repository,
 		createTxHashFunc: createTxHashFunc,
+		notifier:         notifier,
+		config:           config,
 		logger:           logger,
 		metrics:          metrics,
 	}
@@ -94,10 +100,28 @@ func (p *Processor) Process(
 	if !changeNodeGovernorVaas && !changeGovernorVaas {
 		logger.Info("no changes in governor",
 			zap.String("nodeAddress", node.Address))
+		p.notifyReleases(ctx, logger)
 		return nil
 	}
 
-	// 6. Update governor data for the node.
+	// 6. Track the enqueue and release times of the governor vaas. The releases are recorded before
+	// the governor data is updated, because the data of the released vaas is taken from the governor vaas.
+	// Both steps are idempotent, so a failed event is tracked again with the next attempt.
+	err = p.trackReleases(ctx,
+		params.NodeGovernorVaa,
+		nodeGovernorVaasToAdd,
+		governorVaasToAdd,
+		nodeGovernorVaaIdsToDelete,
+		logger)
+	if err != nil {
+		logger.Error("failed to track governorVaa releases",
+			zap.Error(err),
+			zap.String("nodeAddress", node.Address),
+			zap.String("node", node.Name))
+		return err
+	}
+
+	// 7. Update governor data for the node.
 	err = p.updateGovernor(ctx,
 		node,
 		nodeGovernorVaasToAdd,
@@ -112,6 +136,10 @@ func (p *Processor) Process(
 		return err
 	}
 
+	// 8. Notify the releases. A failed notification is retried for the failed publisher only,
+	// with the next governor event, so it does not fail the event.
+	p.notifyReleases(ctx, logger)
+
 	return nil
 }
 
@@ -294,15 +322,7 @@ func (p *Processor) updateGovernor(ctx context.Context,
 	// convert governorVaasToAdd to []storage.GovernorVaaDoc
 	var governorVaasToAddDoc []storage.GovernorVaaDoc
 	for _, governorVaa := range governorVaasToAdd {
-		governorVaasToAddDoc = append(governorVaasToAddDoc, storage.GovernorVaaDoc{
-			ID:             governorVaa.ID,
-			ChainID:        governorVaa.ChainID,
-			EmitterAddress: governorVaa.EmitterAddress,
-			Sequence:       governorVaa.Sequence,
-			TxHash:         governorVaa.TxHash,
-			ReleaseTime:    governorVaa.ReleaseTime,
-			Amount:         storage.Uint64(governorVaa.Amount),
-		})
+		governorVaasToAddDoc = append(governorVaasToAddDoc, toGovernorVaaDoc(governorVaa))
 	}
 
 	// convert nodeGovernorVaas vaaIds to ids
@@ -318,3 +338,16 @@ func (p *Processor) updateGovernor(ctx context.Context,
 		governorVaasToAddDoc,
 		governorVaaIdsToDelete.ToSlice())
 }
+
+// toGovernorVaaDoc converts a domain.GovernorVaa to a storage.GovernorVaaDoc.
+func toGovernorVaaDoc(governorVaa domain.GovernorVaa) storage.GovernorVaaDoc {
+	return storage.GovernorVaaDoc{
+		ID:             governorVaa.ID,
+		ChainID:        governorVaa.ChainID,
+		EmitterAddress: governorVaa.EmitterAddress,
+		Sequence:       governorVaa.Sequence,
+		TxHash:         governorVaa.TxHash,
+		ReleaseTime:    governorVaa.ReleaseTime,
+		Amount:         storage.Uint64(governorVaa.Amount),
+	}
+}
@@ -0,0 +1,258 @@
+package governor
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/events"
+	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/storage"
+	"go.uber.org/zap"
+)
+
+// pendingNotificationsLimit is the maximum number of releases notified with each governor event.
+const pendingNotificationsLimit = 100
+
+// trackReleases records the time the node enqueued and released the governor vaas, and marks
+// the release of the vaas released by a quorum of guardians or whose signed vaa is already stored.
+func (p *Processor) trackReleases(
+	ctx context.Context,
+	nodeGovernorVaa *domain.NodeGovernorVaa,
+	nodeGovernorVaasToAdd map[string]domain.GovernorVaa,
+	governorVaasToAdd []domain.GovernorVaa,
+	nodeGovernorVaaIdsToDelete Set[string],
+	logger *zap.Logger,
+) error {
+
+	node := nodeGovernorVaa.Node
+	statusTime := nodeGovernorVaa.Timestamp
+
+	// 1. record the enqueue of the new node governor vaas, the txHash of the new governor vaas is already fixed.
+	txHashByVaaID := make(map[string]string)
+	for _, governorVaa := range governorVaasToAdd {
+		txHashByVaaID[governorVaa.ID] = governorVaa.TxHash
+	}
+	for vaaID, governorVaa := range nodeGovernorVaasToAdd {
+		governorVaaDoc := toGovernorVaaDoc(governorVaa)
+		if txHash, ok := txHashByVaaID[vaaID]; ok {
+			governorVaaDoc.TxHash = txHash
+		}
+		err := p.repository.UpsertGovernorVaaEnqueue(ctx, &governorVaaDoc, node.Address, node.Name, statusTime)
+		if err != nil {
+			logger.Error("failed to record governorVaa enqueue",
+				zap.Error(err),
+				zap.String("vaaID", vaaID))
+			return err
+		}
+	}
+
+	if nodeGovernorVaaIdsToDelete.Len() == 0 {
+		return nil
+	}
+
+	// 2. record the release of the deleted node governor vaas. The governor vaas are still stored,
+	// so the data of the vaas enqueued before the releases were tracked is taken from them.
+	governorVaas, err := p.repository.FindGovernorVaaByVaaIDs(ctx, nodeGovernorVaaIdsToDelete.ToSlice())
+	if err != nil {
+		logger.Error("failed to find governor vaas by a list of vaaIDs", zap.Error(err))
+		return err
+	}
+	governorVaaByID := make(map[string]storage.GovernorVaaDoc)
+	for _, governorVaa := range governorVaas {
+		governorVaaByID[governorVaa.ID] = governorVaa
+	}
+
+	for vaaID := range nodeGovernorVaaIdsToDelete {
+		governorVaa, ok := governorVaaByID[vaaID]
+		if !ok {
+			governorVaa = storage.GovernorVaaDoc{ID: vaaID}
+		}
+		release, err := p.repository.UpsertGovernorVaaNodeRelease(ctx, &governorVaa, node.Address, node.Name, statusTime)
+		if err != nil {
+			logger.Error("failed to record governorVaa release",
+				zap.Error(err),
+				zap.String("vaaID", vaaID))
+			return err
+		}
+		if err := p.checkRelease(ctx, release, logger); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRelease updates the release skew of the guardians and, if the vaa is released and it was not marked yet,
+// marks the release with the publishers to notify.
+func (p *Processor) checkRelease(ctx context.Context, release *storage.GovernorVaaReleaseDoc, logger *zap.Logger) error {
+
+	logger = logger.With(zap.String("vaaID", release.ID))
+
+	// the skews are updated after the release too, to track the guardians that release the vaa later.
+	skews := getReleaseSkews(release.Nodes)
+	if err := p.repository.UpdateGovernorVaaReleaseSkews(ctx, release.ID, skews); err != nil {
+		logger.Error("failed to update governorVaa release skews", zap.Error(err))
+	}
+
+	if release.ReleaseSource != "" {
+		return nil
+	}
+
+	releasedAt, releaseSource, err := p.getRelease(ctx, release)
+	if err != nil {
+		logger.Error("failed to check governorVaa release", zap.Error(err))
+		return err
+	}
+	if releaseSource == "" {
+		return nil
+	}
+
+	marked, err := p.repository.UpdateGovernorVaaReleased(ctx, release.ID, releasedAt, releaseSource, p.notifier.Publishers())
+	if err != nil {
+		logger.Error("failed to update governorVaa release", zap.Error(err))
+		return err
+	}
+	if !marked {
+		return nil
+	}
+
+	p.metrics.IncGovernorVaaReleased(release.ChainID)
+	logger.Info("governorVaa released",
+		zap.String("releaseSource", releaseSource),
+		zap.Time("releasedAt", releasedAt))
+	return nil
+}
+
+// notifyReleases notifies the released governor vaas to the publishers that were not notified yet.
+func (p *Processor) notifyReleases(ctx context.Context, logger *zap.Logger) {
+	releases, err := p.repository.FindGovernorVaaReleasesPendingNotification(ctx, pendingNotificationsLimit)
+	if err != nil {
+		logger.Error("failed to find governorVaa releases pending notification", zap.Error(err))
+		return
+	}
+	for i := range releases {
+		p.notifyRelease(ctx, &releases[i], logger)
+	}
+}
+
+// notifyRelease publishes the GovernorVaaReleased event to each pending publisher of a release and
+// removes the publishers that were notified from the pending notifications.
+func (p *Processor) notifyRelease(ctx context.Context, release *storage.GovernorVaaReleaseDoc, logger *zap.Logger) {
+
+	logger = logger.With(zap.String("vaaID", release.ID))
+	if release.ReleasedAt == nil {
+		logger.Error("governorVaa release pending notification without release time")
+		return
+	}
+
+	data := newGovernorVaaReleased(release, *release.ReleasedAt, release.ReleaseSource)
+	pending := len(release.PendingNotifications)
+	for _, publisher := range release.PendingNotifications {
+		if err := p.notifier.NotifyGovernorVaaReleased(ctx, publisher, data); err != nil {
+			logger.Error("failed to notify governorVaa release", zap.String("publisher", publisher), zap.Error(err))
+			p.metrics.IncGovernorVaaReleaseNotifyFailed(release.ChainID)
+			continue
+		}
+		err := p.repository.CompleteGovernorVaaReleaseNotification(ctx, release.ID, publisher, pending == 1)
+		if err != nil {
+			logger.Error("failed to update governorVaa release notification", zap.String("publisher", publisher), zap.Error(err))
+			continue
+		}
+		pending--
+	}
+
+	if pending == 0 {
+		logger.Info("governorVaa release notified",
+			zap.String("releaseSource", release.ReleaseSource),
+			zap.Int64("waitSeconds", data.WaitSeconds))
+	}
+}
+
+// getRelease returns the release time and the release source of a governor vaa.
+// The release source is empty when the vaa is not released yet.
+func (p *Processor) getRelease(ctx context.Context, release *storage.GovernorVaaReleaseDoc) (time.Time, string, error) {
+
+	var releaseTimes []time.Time
+	for _, n := range release.Nodes {
+		if n.ReleasedAt != nil {
+			releaseTimes = append(releaseTimes, *n.ReleasedAt)
+		}
+	}
+	sort.Slice(releaseTimes, func(i, j int) bool {
+		return releaseTimes[i].Before(releaseTimes[j])
+	})
+
+	// the vaa is released when the quorum of guardians releases it.
+	if p.config.Quorum > 0 && len(releaseTimes) >= p.config.Quorum {
+		return releaseTimes[p.config.Quorum-1], storage.GovernorVaaReleaseSourceQuorum, nil
+	}
+
+	// the quorum may not be reached if some guardians do not report their status.
+	exists, err := p.repository.ExistsVAA(ctx, release.ID)
+	if err != nil || !exists {
+		return time.Time{}, "", err
+	}
+	if len(releaseTimes) > 0 {
+		return releaseTimes[0], storage.GovernorVaaReleaseSourceSignedVaa, nil
+	}
+	return time.Now(), storage.GovernorVaaReleaseSourceSignedVaa, nil
+}
+
+// getReleaseSkews returns the delay of each guardian release from the first guardian release, by guardian address.
+func getReleaseSkews(nodes map[string]storage.GovernorVaaNodeRelease) map[string]int64 {
+	var first *time.Time
+	for _, n := range nodes {
+		if n.ReleasedAt != nil && (first == nil || n.ReleasedAt.Before(*first)) {
+			first = n.ReleasedAt
+		}
+	}
+	if first == nil {
+		return nil
+	}
+
+	skews := make(map[string]int64)
+	for nodeAddress, n := range nodes {
+		if n.ReleasedAt != nil {
+			skews[nodeAddress] = n.ReleasedAt.Sub(*first).Milliseconds()
+		}
+	}
+	return skews
+}
+
+// newGovernorVaaReleased creates the GovernorVaaReleased event data of a governor vaa release.
+func newGovernorVaaReleased(release *storage.GovernorVaaReleaseDoc, releasedAt time.Time, releaseSource string) *events.GovernorVaaReleased {
+
+	guardians := make([]events.GovernorVaaGuardianState, 0, len(release.Nodes))
+	for nodeAddress, n := range release.Nodes {
+		guardians = append(guardians, events.GovernorVaaGuardianState{
+			Name:          n.NodeName,
+			Address:       nodeAddress,
+			EnqueuedAt:    n.EnqueuedAt,
+			ReleasedAt:    n.ReleasedAt,
+			ReleaseSkewMs: n.ReleaseSkewMs,
+		})
+	}
+	sort.Slice(guardians, func(i, j int) bool {
+		return guardians[i].Name < guardians[j].Name
+	})
+
+	var waitSeconds int64
+	if release.EnqueuedAt != nil {
+		waitSeconds = int64(releasedAt.Sub(*release.EnqueuedAt).Seconds())
+	}
+
+	return &events.GovernorVaaReleased{
+		ID:                   release.ID,
+		EmitterChain:         uint16(release.ChainID),
+		EmitterAddress:       release.EmitterAddress,
+		Sequence:             release.Sequence,
+		TxHash:               release.TxHash,
+		Amount:               uint64(release.Amount),
+		ScheduledReleaseTime: release.ReleaseTime,
+		EnqueuedAt:           release.EnqueuedAt,
+		ReleasedAt:           releasedAt,
+		WaitSeconds:          waitSeconds,
+		ReleaseSource:        releaseSource,
+		Guardians:            guardians,
+	}
+}
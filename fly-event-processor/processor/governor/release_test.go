@@ -0,0 +1,446 @@
+package governor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/events"
+	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/storage"
+	"go.uber.org/zap"
+)
+
+type releaseUpdate struct {
+	vaaID         string
+	releasedAt    time.Time
+	releaseSource string
+	publishers    []string
+}
+
+type fakeRepository struct {
+	existsVAA       bool
+	existsVAAErr    error
+	nodeGovernorVaa []storage.NodeGovernorVaaDoc
+	releases        map[string]*storage.GovernorVaaReleaseDoc
+	skews           map[string]map[string]int64
+	released        []releaseUpdate
+	completed       []string
+	governorUpdates int
+}
+
+func newFakeRepository(releases ...*storage.GovernorVaaReleaseDoc) *fakeRepository {
+	r := &fakeRepository{
+		releases: make(map[string]*storage.GovernorVaaReleaseDoc),
+		skews:    make(map[string]map[string]int64),
+	}
+	for _, release := range releases {
+		r.releases[release.ID] = release
+	}
+	return r
+}
+
+func (r *fakeRepository) FindNodeGovernorVaaByNodeAddress(ctx context.Context, nodeAddress string) ([]storage.NodeGovernorVaaDoc, error) {
+	var docs []storage.NodeGovernorVaaDoc
+	for _, doc := range r.nodeGovernorVaa {
+		if doc.NodeAddress == nodeAddress {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+func (r *fakeRepository) FindNodeGovernorVaaByVaaIDs(ctx context.Context, vaaIDs []string) ([]storage.NodeGovernorVaaDoc, error) {
+	var docs []storage.NodeGovernorVaaDoc
+	for _, doc := range r.nodeGovernorVaa {
+		for _, vaaID := range vaaIDs {
+			if doc.VaaID == vaaID {
+				docs = append(docs, doc)
+			}
+		}
+	}
+	return docs, nil
+}
+
+func (r *fakeRepository) FindGovernorVaaByVaaIDs(ctx context.Context, vaaIDs []string) ([]storage.GovernorVaaDoc, error) {
+	return nil, nil
+}
+
+func (r *fakeRepository) UpdateGovernor(ctx context.Context, nodeGovernorVaas []storage.NodeGovernorVaaDoc, nodeGovernorVaaIds []string, governorVaas []storage.GovernorVaaDoc, governorVaaIds []string) error {
+	r.governorUpdates++
+	return nil
+}
+
+func (r *fakeRepository) UpsertGovernorVaaEnqueue(ctx context.Context, governorVaa *storage.GovernorVaaDoc, nodeAddress, nodeName string, enqueuedAt time.Time) error {
+	return nil
+}
+
+func (r *fakeRepository) UpsertGovernorVaaNodeRelease(ctx context.Context, governorVaa *storage.GovernorVaaDoc, nodeAddress, nodeName string, releasedAt time.Time) (*storage.GovernorVaaReleaseDoc, error) {
+	release, ok := r.releases[governorVaa.ID]
+	if !ok {
+		release = &storage.GovernorVaaReleaseDoc{ID: governorVaa.ID, Nodes: make(map[string]storage.GovernorVaaNodeRelease)}
+		r.releases[governorVaa.ID] = release
+	}
+	release.Nodes[nodeAddress] = storage.GovernorVaaNodeRelease{NodeName: nodeName, ReleasedAt: &releasedAt}
+	return release, nil
+}
+
+func (r *fakeRepository) UpdateGovernorVaaReleaseSkews(ctx context.Context, vaaID string, skews map[string]int64) error {
+	r.skews[vaaID] = skews
+	return nil
+}
+
+func (r *fakeRepository) UpdateGovernorVaaReleased(ctx context.Context, vaaID string, releasedAt time.Time, releaseSource string, publishers []string) (bool, error) {
+	release := r.releases[vaaID]
+	if release != nil && release.ReleaseSource != "" {
+		return false, nil
+	}
+	r.released = append(r.released, releaseUpdate{vaaID, releasedAt, releaseSource, publishers})
+	if release != nil {
+		release.ReleasedAt = &releasedAt
+		release.ReleaseSource = releaseSource
+		release.PendingNotifications = publishers
+	}
+	return true, nil
+}
+
+func (r *fakeRepository) FindGovernorVaaReleasesPendingNotification(ctx context.Context, limit int64) ([]storage.GovernorVaaReleaseDoc, error) {
+	var releases []storage.GovernorVaaReleaseDoc
+	for _, release := range r.releases {
+		if len(release.PendingNotifications) > 0 {
+			releases = append(releases, *release)
+		}
+	}
+	return releases, nil
+}
+
+func (r *fakeRepository) CompleteGovernorVaaReleaseNotification(ctx context.Context, vaaID, publisher string, last bool) error {
+	r.completed = append(r.completed, publisher)
+	release := r.releases[vaaID]
+	var pending []string
+	for _, p := range release.PendingNotifications {
+		if p != publisher {
+			pending = append(pending, p)
+		}
+	}
+	release.PendingNotifications = pending
+	if last {
+		now := time.Now()
+		release.NotifiedAt = &now
+	}
+	return nil
+}
+
+func (r *fakeRepository) ExistsVAA(ctx context.Context, vaaID string) (bool, error) {
+	return r.existsVAA, r.existsVAAErr
+}
+
+type fakeNotifier struct {
+	publishers []string
+	failing    map[string]bool
+	notified   map[string][]*events.GovernorVaaReleased
+}
+
+func newFakeNotifier(publishers ...string) *fakeNotifier {
+	return &fakeNotifier{
+		publishers: publishers,
+		failing:    make(map[string]bool),
+		notified:   make(map[string][]*events.GovernorVaaReleased),
+	}
+}
+
+func (n *fakeNotifier) Publishers() []string {
+	return n.publishers
+}
+
+func (n *fakeNotifier) NotifyGovernorVaaReleased(ctx context.Context, publisher string, data *events.GovernorVaaReleased) error {
+	if n.failing[publisher] {
+		return errors.New("publisher unavailable")
+	}
+	n.notified[publisher] = append(n.notified[publisher], data)
+	return nil
+}
+
+func newTestProcessor(repository Repository, notifier Notifier, quorum int) *Processor {
+	return NewProcessor(repository, nil, notifier, ReleaseConfig{Quorum: quorum}, zap.NewNop(), metrics.NewDummyMetrics())
+}
+
+func timeRef(t time.Time) *time.Time {
+	return &t
+}
+
+func TestGetReleaseSkews(t *testing.T) {
+	base := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		name     string
+		nodes    map[string]storage.GovernorVaaNodeRelease
+		expected map[string]int64
+	}{
+		{
+			name:     "no nodes",
+			nodes:    nil,
+			expected: nil,
+		},
+		{
+			name: "no releases",
+			nodes: map[string]storage.GovernorVaaNodeRelease{
+				"g1": {EnqueuedAt: timeRef(base)},
+			},
+			expected: nil,
+		},
+		{
+			name: "skews from the first release",
+			nodes: map[string]storage.GovernorVaaNodeRelease{
+				"g1": {ReleasedAt: timeRef(base.Add(2 * time.Second))},
+				"g2": {ReleasedAt: timeRef(base)},
+				"g3": {ReleasedAt: timeRef(base.Add(1500 * time.Millisecond))},
+				"g4": {EnqueuedAt: timeRef(base)},
+			},
+			expected: map[string]int64{"g1": 2000, "g2": 0, "g3": 1500},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, getReleaseSkews(tc.nodes))
+		})
+	}
+}
+
+func TestProcessor_getRelease(t *testing.T) {
+	base := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	nodes := map[string]storage.GovernorVaaNodeRelease{
+		"g1": {ReleasedAt: timeRef(base.Add(3 * time.Second))},
+		"g2": {ReleasedAt: timeRef(base)},
+		"g3": {ReleasedAt: timeRef(base.Add(time.Second))},
+		"g4": {EnqueuedAt: timeRef(base)},
+	}
+
+	var tests = []struct {
+		name           string
+		quorum         int
+		nodes          map[string]storage.GovernorVaaNodeRelease
+		existsVAA      bool
+		existsVAAErr   error
+		expectedErr    bool
+		expectedSource string
+		expectedTime   time.Time
+	}{
+		{
+			name:           "quorum reached",
+			quorum:         2,
+			nodes:          nodes,
+			expectedSource: storage.GovernorVaaReleaseSourceQuorum,
+			expectedTime:   base.Add(time.Second),
+		},
+		{
+			name:           "quorum reached by all the releases",
+			quorum:         3,
+			nodes:          nodes,
+			expectedSource: storage.GovernorVaaReleaseSourceQuorum,
+			expectedTime:   base.Add(3 * time.Second),
+		},
+		{
+			name:           "quorum not reached",
+			quorum:         4,
+			nodes:          nodes,
+			expectedSource: "",
+		},
+		{
+			name:           "quorum not reached with signed vaa",
+			quorum:         4,
+			nodes:          nodes,
+			existsVAA:      true,
+			expectedSource: storage.GovernorVaaReleaseSourceSignedVaa,
+			expectedTime:   base,
+		},
+		{
+			name:           "quorum disabled with signed vaa",
+			quorum:         0,
+			nodes:          nodes,
+			existsVAA:      true,
+			expectedSource: storage.GovernorVaaReleaseSourceSignedVaa,
+			expectedTime:   base,
+		},
+		{
+			name:         "signed vaa check fails",
+			quorum:       4,
+			nodes:        nodes,
+			existsVAAErr: errors.New("connection refused"),
+			expectedErr:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repository := newFakeRepository()
+			repository.existsVAA = tc.existsVAA
+			repository.existsVAAErr = tc.existsVAAErr
+			p := newTestProcessor(repository, newFakeNotifier(), tc.quorum)
+
+			releasedAt, source, err := p.getRelease(context.Background(), &storage.GovernorVaaReleaseDoc{ID: "1/emitter/1", Nodes: tc.nodes})
+			assert.Equal(t, tc.expectedErr, err != nil)
+			assert.Equal(t, tc.expectedSource, source)
+			if tc.expectedSource != "" {
+				assert.Equal(t, tc.expectedTime, releasedAt)
+			}
+		})
+	}
+
+	t.Run("signed vaa without guardian releases", func(t *testing.T) {
+		repository := newFakeRepository()
+		repository.existsVAA = true
+		p := newTestProcessor(repository, newFakeNotifier(), 2)
+
+		before := time.Now()
+		releasedAt, source, err := p.getRelease(context.Background(), &storage.GovernorVaaReleaseDoc{ID: "1/emitter/1"})
+		assert.NoError(t, err)
+		assert.Equal(t, storage.GovernorVaaReleaseSourceSignedVaa, source)
+		assert.False(t, releasedAt.Before(before))
+	})
+}
+
+func TestProcessor_checkRelease(t *testing.T) {
+	base := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		name             string
+		release          *storage.GovernorVaaReleaseDoc
+		expectedReleased []releaseUpdate
+		expectedSkews    map[string]int64
+	}{
+		{
+			name: "released by quorum",
+			release: &storage.GovernorVaaReleaseDoc{
+				ID: "1/emitter/1",
+				Nodes: map[string]storage.GovernorVaaNodeRelease{
+					"g1": {ReleasedAt: timeRef(base)},
+					"g2": {ReleasedAt: timeRef(base.Add(time.Second))},
+				},
+			},
+			expectedReleased: []releaseUpdate{{
+				vaaID:         "1/emitter/1",
+				releasedAt:    base.Add(time.Second),
+				releaseSource: storage.GovernorVaaReleaseSourceQuorum,
+				publishers:    []string{"sns", "webhook"},
+			}},
+			expectedSkews: map[string]int64{"g1": 0, "g2": 1000},
+		},
+		{
+			name: "not released",
+			release: &storage.GovernorVaaReleaseDoc{
+				ID: "1/emitter/1",
+				Nodes: map[string]storage.GovernorVaaNodeRelease{
+					"g1": {ReleasedAt: timeRef(base)},
+				},
+			},
+			expectedSkews: map[string]int64{"g1": 0},
+		},
+		{
+			name: "already released",
+			release: &storage.GovernorVaaReleaseDoc{
+				ID:            "1/emitter/1",
+				ReleasedAt:    timeRef(base),
+				ReleaseSource: storage.GovernorVaaReleaseSourceQuorum,
+				Nodes: map[string]storage.GovernorVaaNodeRelease{
+					"g1": {ReleasedAt: timeRef(base)},
+					"g2": {ReleasedAt: timeRef(base)},
+					"g3": {ReleasedAt: timeRef(base.Add(time.Minute))},
+				},
+			},
+			expectedSkews: map[string]int64{"g1": 0, "g2": 0, "g3": 60000},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repository := newFakeRepository()
+			notifier := newFakeNotifier("sns", "webhook")
+			p := newTestProcessor(repository, notifier, 2)
+
+			err := p.checkRelease(context.Background(), tc.release, zap.NewNop())
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedReleased, repository.released)
+			assert.Equal(t, tc.expectedSkews, repository.skews[tc.release.ID])
+			// the release is only marked, the publishers are notified by notifyReleases.
+			assert.Empty(t, notifier.notified)
+		})
+	}
+}
+
+func TestProcessor_notifyReleasesRetriesFailedPublishers(t *testing.T) {
+	releasedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	release := &storage.GovernorVaaReleaseDoc{
+		ID:                   "1/emitter/1",
+		ReleasedAt:           &releasedAt,
+		ReleaseSource:        storage.GovernorVaaReleaseSourceQuorum,
+		PendingNotifications: []string{"sns", "webhook"},
+	}
+	repository := newFakeRepository(release)
+	notifier := newFakeNotifier("sns", "webhook")
+	notifier.failing["webhook"] = true
+	p := newTestProcessor(repository, notifier, 2)
+
+	p.notifyReleases(context.Background(), zap.NewNop())
+	assert.Len(t, notifier.notified["sns"], 1)
+	assert.Empty(t, notifier.notified["webhook"])
+	assert.Equal(t, []string{"webhook"}, release.PendingNotifications)
+	assert.Nil(t, release.NotifiedAt)
+
+	// the retry only notifies the publisher that failed.
+	notifier.failing["webhook"] = false
+	p.notifyReleases(context.Background(), zap.NewNop())
+	assert.Len(t, notifier.notified["sns"], 1)
+	assert.Len(t, notifier.notified["webhook"], 1)
+	assert.Equal(t, "1/emitter/1", notifier.notified["webhook"][0].ID)
+	assert.Equal(t, releasedAt, notifier.notified["webhook"][0].ReleasedAt)
+	assert.Empty(t, release.PendingNotifications)
+	assert.NotNil(t, release.NotifiedAt)
+	assert.Equal(t, []string{"sns", "webhook"}, repository.completed)
+}
+
+func TestProcessor_ProcessUpdatesGovernorWhenNotifyFails(t *testing.T) {
+	repository := newFakeRepository()
+	repository.nodeGovernorVaa = []storage.NodeGovernorVaaDoc{
+		{ID: "g1-1/emitter/1", NodeAddress: "g1", VaaID: "1/emitter/1"},
+		{ID: "g2-1/emitter/1", NodeAddress: "g2", VaaID: "1/emitter/1"},
+	}
+	repository.releases["1/emitter/1"] = &storage.GovernorVaaReleaseDoc{
+		ID: "1/emitter/1",
+		Nodes: map[string]storage.GovernorVaaNodeRelease{
+			"g1": {ReleasedAt: timeRef(time.Now().Add(-time.Minute))},
+		},
+	}
+	notifier := newFakeNotifier("sns")
+	notifier.failing["sns"] = true
+	p := newTestProcessor(repository, notifier, 2)
+
+	// the node g2 releases the vaa, which is the quorum.
+	params := &Params{
+		TrackID: "track-1",
+		NodeGovernorVaa: &domain.NodeGovernorVaa{
+			Node:         domain.Node{Name: "guardian-2", Address: "g2"},
+			GovernorVaas: map[string]domain.GovernorVaa{},
+			Timestamp:    time.Now(),
+		},
+	}
+	err := p.Process(context.Background(), params)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, repository.governorUpdates)
+	if assert.Len(t, repository.released, 1) {
+		assert.Equal(t, storage.GovernorVaaReleaseSourceQuorum, repository.released[0].releaseSource)
+	}
+	assert.Equal(t, []string{"sns"}, repository.releases["1/emitter/1"].PendingNotifications)
+
+	// the next governor event retries the notification, although there are no changes in governor.
+	notifier.failing["sns"] = false
+	repository.nodeGovernorVaa = repository.nodeGovernorVaa[:1]
+	err = p.Process(context.Background(), params)
+	assert.NoError(t, err)
+	assert.Len(t, notifier.notified["sns"], 1)
+	assert.Empty(t, repository.releases["1/emitter/1"].PendingNotifications)
+}
@@ -2,8 +2,11 @@ package governor
 
 import (
 	"context"
+	"time"
 
+	"github.com/wormhole-foundation/wormhole-explorer/common/events"
 	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/storage"
 )
 
 // Set generic type definition.
@@ -34,6 +37,12 @@ func (s Set[T]) ToSlice() []T {
 	return slice
 }
 
+// ReleaseConfig is the configuration of the governor vaa release detection.
+type ReleaseConfig struct {
+	// Quorum is the number of guardians that must release an enqueued vaa to consider it released.
+	Quorum int
+}
+
 type Params struct {
 	TrackID         string
 	NodeGovernorVaa *domain.NodeGovernorVaa
@@ -41,3 +50,24 @@ type Params struct {
 
 // ProcessorFunc is a function to process a governor message.
 type ProcessorFunc func(context.Context, *Params) error
+
+// Repository is the storage of the governor vaas and their releases.
+type Repository interface {
+	FindNodeGovernorVaaByNodeAddress(ctx context.Context, nodeAddress string) ([]storage.NodeGovernorVaaDoc, error)
+	FindNodeGovernorVaaByVaaIDs(ctx context.Context, vaaID []string) ([]storage.NodeGovernorVaaDoc, error)
+	FindGovernorVaaByVaaIDs(ctx context.Context, vaaID []string) ([]storage.GovernorVaaDoc, error)
+	UpdateGovernor(ctx context.Context, nodeGovernorVaas []storage.NodeGovernorVaaDoc, nodeGovernorVaaIds []string, governorVaas []storage.GovernorVaaDoc, governorVaaIds []string) error
+	UpsertGovernorVaaEnqueue(ctx context.Context, governorVaa *storage.GovernorVaaDoc, nodeAddress, nodeName string, enqueuedAt time.Time) error
+	UpsertGovernorVaaNodeRelease(ctx context.Context, governorVaa *storage.GovernorVaaDoc, nodeAddress, nodeName string, releasedAt time.Time) (*storage.GovernorVaaReleaseDoc, error)
+	UpdateGovernorVaaReleaseSkews(ctx context.Context, vaaID string, skews map[string]int64) error
+	UpdateGovernorVaaReleased(ctx context.Context, vaaID string, releasedAt time.Time, releaseSource string, publishers []string) (bool, error)
+	FindGovernorVaaReleasesPendingNotification(ctx context.Context, limit int64) ([]storage.GovernorVaaReleaseDoc, error)
+	CompleteGovernorVaaReleaseNotification(ctx context.Context, vaaID, publisher string, last bool) error
+	ExistsVAA(ctx context.Context, vaaID string) (bool, error)
+}
+
+// Notifier notifies the governor vaa releases to each of its publishers.
+type Notifier interface {
+	Publishers() []string
+	NotifyGovernorVaaReleased(ctx context.Context, publisher string, data *events.GovernorVaaReleased) error
+}
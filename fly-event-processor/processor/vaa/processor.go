@@ -2,33 +2,39 @@ package vaa
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/guardian"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	"github.com/wormhole-foundation/wormhole-explorer/common/pool"
 	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/storage"
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.uber.org/zap"
 	"golang.org/x/net/context"
 )
 
 type Processor struct {
 	guardianPool *pool.Pool
-	repository   *storage.Repository
+	repository   Repository
+	config       ResolutionConfig
 	logger       *zap.Logger
 	metrics      metrics.Metrics
 }
 
 func NewProcessor(
 	guardianPool *pool.Pool,
-	repository *storage.Repository,
+	repository Repository,
+	config ResolutionConfig,
 	logger *zap.Logger,
 	metrics metrics.Metrics,
 ) *Processor {
 	return &Processor{
 		guardianPool: guardianPool,
 		repository:   repository,
+		config:       config,
 		logger:       logger,
 		metrics:      metrics,
 	}
@@ -64,49 +70,77 @@ func (p *Processor) Process(ctx context.Context, params *Params) error {
 		return errors.New("event time has not reached the finality time")
 	}
 
-	// 1.3 call guardian api to get signed_vaa.
-	guardians := p.guardianPool.GetItems()
-	var signedVaa *guardian.SignedVaa
-	for _, g := range guardians {
-		g.Wait(ctx)
-		guardianAPIClient, err := guardian.NewGuardianAPIClient(
-			guardian.DefaultTimeout,
-			g.Id,
-			logger)
-		if err != nil {
-			logger.Error("error creating guardian api client", zap.Error(err))
-			continue
-		}
-		signedVaa, err = guardianAPIClient.GetSignedVAA(params.VaaID)
-		if err != nil {
-			logger.Error("error getting signed vaa from guardian api", zap.Error(err))
-			continue
-		}
-		break
-	}
-
-	if signedVaa == nil {
+	// 1.3 call several guardian apis to get the digest of the signed_vaa.
+	answers, digests := p.getGuardianAnswers(ctx, params.VaaID, logger)
+	if digests == 0 {
 		logger.Error("error getting signed vaa from guardian api")
 		return errors.New("error getting signed vaa from guardian api")
 	}
 
-	// 1.4 compare digest from vaa and signedVaa
-	guardianVAA, err := sdk.Unmarshal(signedVaa.VaaBytes)
+	// 1.4 get the candidates: the vaa stored in the vaas collection and its duplicates.
+	vaa, err := sdk.Unmarshal(vaaDoc.Vaa)
 	if err != nil {
-		logger.Error("error unmarshalling guardian signed vaa", zap.Error(err))
+		logger.Error("error unmarshalling vaa", zap.Error(err))
 		return err
 	}
 
-	vaa, err := sdk.Unmarshal(vaaDoc.Vaa)
+	duplicateVaaDocs, err := p.repository.FindDuplicateVAAs(ctx, params.VaaID)
 	if err != nil {
-		logger.Error("error unmarshalling vaa", zap.Error(err))
+		logger.Error("error getting duplicate vaas from collection", zap.Error(err))
 		return err
 	}
 
+	candidates := []storage.DuplicateVaaCandidate{{
+		ID:        domain.CreateUniqueVaaID(vaa),
+		Digest:    vaa.HexDigest(),
+		TxHash:    vaaDoc.TxHash,
+		Timestamp: vaaDoc.Timestamp,
+		Stored:    true,
+	}}
+	for _, duplicateVaaDoc := range duplicateVaaDocs {
+		duplicateVaa, err := sdk.Unmarshal(duplicateVaaDoc.Vaa)
+		if err != nil {
+			logger.Error("error unmarshalling vaa", zap.Error(err))
+			return err
+		}
+		candidates = append(candidates, storage.DuplicateVaaCandidate{
+			ID:        duplicateVaaDoc.ID,
+			Digest:    duplicateVaa.HexDigest(),
+			TxHash:    duplicateVaaDoc.TxHash,
+			Timestamp: duplicateVaaDoc.Timestamp,
+		})
+	}
+
+	resolution := &storage.DuplicateVaaResolutionDoc{
+		ID:              primitive.NewObjectID().Hex(),
+		VaaID:           params.VaaID,
+		TrackID:         params.TrackID,
+		EmitterChain:    params.ChainID,
+		Candidates:      candidates,
+		GuardianAnswers: answers,
+		Threshold:       p.config.AgreementThreshold,
+		PreviousDigest:  vaa.HexDigest(),
+	}
+
+	// 1.5 the guardians must agree on the digest of the vaa.
+	digest, votes := agreedDigest(answers)
+	if digest == "" || votes < p.config.AgreementThreshold {
+		logger.Info("guardian agreement not reached",
+			zap.Int("votes", votes), zap.Int("threshold", p.config.AgreementThreshold))
+		resolution.Decision = storage.DuplicateVaaDecisionUnresolved
+		resolution.Reason = fmt.Sprintf("guardian agreement not reached: %d of %d answers with the same digest", votes, p.config.AgreementThreshold)
+		p.saveResolution(ctx, resolution, logger)
+		p.metrics.IncDuplicatedVaaCanNotFixed(params.ChainID)
+		return errors.New("guardian agreement not reached")
+	}
+	resolution.ChosenDigest = digest
+
 	// If the guardian digest is the same that the vaa digest,
 	// the stored vaa in the vaas collection is the correct one.
-	if guardianVAA.HexDigest() == vaa.HexDigest() {
+	if digest == vaa.HexDigest() {
 		logger.Info("vaa stored in vaas collections is the correct")
+		resolution.Decision = storage.DuplicateVaaDecisionKept
+		p.saveResolution(ctx, resolution, logger)
 		return nil
 	}
 
@@ -118,37 +152,111 @@ func (p *Processor) Process(ctx context.Context, params *Params) error {
 		return errors.New("vaa txHash is empty")
 	}
 
-	// 2.2 Get all duplicate vaas by vaaId
-	duplicateVaaDocs, err := p.repository.FindDuplicateVAAs(ctx, params.VaaID)
-	if err != nil {
-		logger.Error("error getting duplicate vaas from collection", zap.Error(err))
-		return err
-	}
-
-	// 2.3 Check each duplicate VAA to detect which is the correct one.
-	for _, duplicateVaaDoc := range duplicateVaaDocs {
-		duplicateVaa, err := sdk.Unmarshal(duplicateVaaDoc.Vaa)
+	// 2.2 Fix the vaa with the duplicate VAA the guardians agreed on.
+	for _, candidate := range candidates[1:] {
+		if candidate.Digest != digest {
+			continue
+		}
+		err := p.repository.FixVAA(ctx, params.VaaID, candidate.ID)
 		if err != nil {
-			logger.Error("error unmarshalling vaa", zap.Error(err))
+			logger.Error("error fixing vaa", zap.Error(err))
 			return err
 		}
-
-		if guardianVAA.HexDigest() == duplicateVaa.HexDigest() {
-			err := p.repository.FixVAA(ctx, params.VaaID, duplicateVaaDoc.ID)
-			if err != nil {
-				logger.Error("error fixing vaa", zap.Error(err))
-				return err
-			}
-			logger.Info("vaa fixed")
-			return nil
-		}
+		logger.Info("vaa fixed", zap.String("previousDigest", resolution.PreviousDigest), zap.String("digest", digest))
+		resolution.Decision = storage.DuplicateVaaDecisionFixed
+		p.saveResolution(ctx, resolution, logger)
+		return nil
 	}
 
 	logger.Info("can't fix duplicate vaa")
+	resolution.Decision = storage.DuplicateVaaDecisionUnresolved
+	resolution.Reason = "the digest agreed by the guardians does not match any candidate"
+	p.saveResolution(ctx, resolution, logger)
 	p.metrics.IncDuplicatedVaaCanNotFixed(params.ChainID)
 	return errors.New("can't fix duplicate vaa")
 }
 
+// getGuardianAnswers asks the guardian apis of the pool for the signed vaa until the configured number
+// of digests is collected or there are no more guardian apis. It returns the answers and the number of digests.
+func (p *Processor) getGuardianAnswers(ctx context.Context, vaaID string, logger *zap.Logger) ([]storage.GuardianDigestAnswer, int) {
+	var answers []storage.GuardianDigestAnswer
+	var digests int
+	for _, g := range p.guardianPool.GetItems() {
+		if digests >= p.config.GuardianQueries {
+			break
+		}
+		g.Wait(ctx)
+		guardianAPIClient, err := guardian.NewGuardianAPIClient(
+			guardian.DefaultTimeout,
+			g.Id,
+			logger)
+		if err != nil {
+			logger.Error("error creating guardian api client", zap.Error(err))
+			continue
+		}
+
+		answer := storage.GuardianDigestAnswer{Guardian: g.Description}
+		digest, err := getSignedVaaDigest(&guardianAPIClient, vaaID)
+		if err != nil {
+			logger.Error("error getting signed vaa from guardian api",
+				zap.String("guardian", g.Description), zap.Error(err))
+			answer.Error = err.Error()
+		} else {
+			answer.Digest = digest
+			digests++
+		}
+		answers = append(answers, answer)
+	}
+	return answers, digests
+}
+
+func (p *Processor) saveResolution(ctx context.Context, resolution *storage.DuplicateVaaResolutionDoc, logger *zap.Logger) {
+	resolution.CreatedAt = time.Now()
+	if err := p.repository.InsertDuplicateVaaResolution(ctx, resolution); err != nil {
+		logger.Error("error saving duplicate vaa resolution",
+			zap.String("decision", resolution.Decision), zap.Error(err))
+	}
+}
+
+func getSignedVaaDigest(client *guardian.GuardianAPIClient, vaaID string) (string, error) {
+	signedVaa, err := client.GetSignedVAA(vaaID)
+	if err != nil {
+		return "", err
+	}
+	guardianVAA, err := sdk.Unmarshal(signedVaa.VaaBytes)
+	if err != nil {
+		return "", fmt.Errorf("error unmarshalling guardian signed vaa: %w", err)
+	}
+	return guardianVAA.HexDigest(), nil
+}
+
+// agreedDigest returns the digest returned by most guardians and its number of votes.
+// No digest is returned when there is a tie, the guardians do not agree on a single digest.
+func agreedDigest(answers []storage.GuardianDigestAnswer) (string, int) {
+	votesByDigest := make(map[string]int)
+	for _, answer := range answers {
+		if answer.Digest != "" {
+			votesByDigest[answer.Digest]++
+		}
+	}
+
+	var digest string
+	var votes int
+	var tie bool
+	for d, n := range votesByDigest {
+		switch {
+		case n > votes:
+			digest, votes, tie = d, n, false
+		case n == votes:
+			tie = true
+		}
+	}
+	if tie {
+		return "", votes
+	}
+	return digest, votes
+}
+
 func getFinalityTimeByChainID(chainID sdk.ChainID) time.Duration {
 	// Time to finalize for each chain.
 	// ref: https://docs.wormhole.com/wormhole/reference/constants
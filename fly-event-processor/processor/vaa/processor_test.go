@@ -0,0 +1,315 @@
+package vaa
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/guardian"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/pool"
+	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/storage"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+func TestAgreedDigest(t *testing.T) {
+	var tests = []struct {
+		name          string
+		answers       []storage.GuardianDigestAnswer
+		expectedVotes int
+		expected      string
+	}{
+		{
+			name:          "no answers",
+			answers:       nil,
+			expectedVotes: 0,
+			expected:      "",
+		},
+		{
+			name: "only errors",
+			answers: []storage.GuardianDigestAnswer{
+				{Guardian: "g1", Error: "timeout"},
+				{Guardian: "g2", Error: "not found"},
+			},
+			expectedVotes: 0,
+			expected:      "",
+		},
+		{
+			name: "single answer",
+			answers: []storage.GuardianDigestAnswer{
+				{Guardian: "g1", Digest: "a"},
+			},
+			expectedVotes: 1,
+			expected:      "a",
+		},
+		{
+			name: "unanimous",
+			answers: []storage.GuardianDigestAnswer{
+				{Guardian: "g1", Digest: "a"},
+				{Guardian: "g2", Digest: "a"},
+				{Guardian: "g3", Digest: "a"},
+			},
+			expectedVotes: 3,
+			expected:      "a",
+		},
+		{
+			name: "majority with a tie below it",
+			answers: []storage.GuardianDigestAnswer{
+				{Guardian: "g1", Digest: "b"},
+				{Guardian: "g2", Digest: "c"},
+				{Guardian: "g3", Digest: "a"},
+				{Guardian: "g4", Digest: "a"},
+			},
+			expectedVotes: 2,
+			expected:      "a",
+		},
+		{
+			name: "errors are not votes",
+			answers: []storage.GuardianDigestAnswer{
+				{Guardian: "g1", Digest: "a"},
+				{Guardian: "g2", Error: "timeout"},
+				{Guardian: "g3", Error: "timeout"},
+			},
+			expectedVotes: 1,
+			expected:      "a",
+		},
+		{
+			name: "tie",
+			answers: []storage.GuardianDigestAnswer{
+				{Guardian: "g1", Digest: "a"},
+				{Guardian: "g2", Digest: "b"},
+				{Guardian: "g3", Digest: "a"},
+				{Guardian: "g4", Digest: "b"},
+			},
+			expectedVotes: 2,
+			expected:      "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			digest, votes := agreedDigest(tc.answers)
+			assert.Equal(t, tc.expected, digest)
+			assert.Equal(t, tc.expectedVotes, votes)
+		})
+	}
+}
+
+type fakeRepository struct {
+	vaa         *storage.VaaDoc
+	duplicates  []storage.DuplicateVaaDoc
+	fixErr      error
+	fixed       []string
+	resolutions []*storage.DuplicateVaaResolutionDoc
+}
+
+func (r *fakeRepository) FindVAAById(ctx context.Context, vaaID string) (*storage.VaaDoc, error) {
+	return r.vaa, nil
+}
+
+func (r *fakeRepository) FindDuplicateVAAs(ctx context.Context, vaaID string) ([]storage.DuplicateVaaDoc, error) {
+	return r.duplicates, nil
+}
+
+func (r *fakeRepository) FixVAA(ctx context.Context, vaaID, duplicateID string) error {
+	if r.fixErr != nil {
+		return r.fixErr
+	}
+	r.fixed = append(r.fixed, duplicateID)
+	return nil
+}
+
+func (r *fakeRepository) InsertDuplicateVaaResolution(ctx context.Context, doc *storage.DuplicateVaaResolutionDoc) error {
+	r.resolutions = append(r.resolutions, doc)
+	return nil
+}
+
+func newTestVaa(payload byte) *sdk.VAA {
+	return &sdk.VAA{
+		Version:          1,
+		GuardianSetIndex: 4,
+		Timestamp:        time.Unix(1700000000, 0),
+		Nonce:            1,
+		Sequence:         10,
+		EmitterChain:     sdk.ChainIDSolana,
+		EmitterAddress:   sdk.Address{1},
+		Payload:          []byte{payload},
+	}
+}
+
+func marshalTestVaa(t *testing.T, v *sdk.VAA) []byte {
+	data, err := v.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// newGuardianPool starts a guardian api for each signed vaa, a nil vaa is an api that fails.
+func newGuardianPool(t *testing.T, signedVaas ...[]byte) *pool.Pool {
+	var cfg []pool.Config
+	for i, signedVaa := range signedVaas {
+		signedVaa := signedVaa
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if signedVaa == nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(guardian.SignedVaa{VaaBytes: signedVaa})
+		}))
+		t.Cleanup(server.Close)
+		cfg = append(cfg, pool.Config{
+			Id:                server.URL,
+			Description:       server.URL,
+			Priority:          uint8(i),
+			RequestsPerMinute: 60000,
+		})
+	}
+	return pool.NewPool(cfg)
+}
+
+func TestProcessor_Process(t *testing.T) {
+	stored := newTestVaa(1)
+	duplicate := newTestVaa(2)
+	other := newTestVaa(3)
+	storedBytes := marshalTestVaa(t, stored)
+	duplicateBytes := marshalTestVaa(t, duplicate)
+	otherBytes := marshalTestVaa(t, other)
+	timestamp := time.Now().Add(-time.Hour)
+	vaaID := stored.MessageID()
+
+	var tests = []struct {
+		name             string
+		guardianAnswers  [][]byte
+		threshold        int
+		expectedErr      bool
+		expectedDecision string
+		expectedDigest   string
+		expectedReason   string
+		expectedFixed    []string
+	}{
+		{
+			name:             "guardians agree on the stored vaa",
+			guardianAnswers:  [][]byte{storedBytes, storedBytes, duplicateBytes},
+			threshold:        2,
+			expectedDecision: storage.DuplicateVaaDecisionKept,
+			expectedDigest:   stored.HexDigest(),
+		},
+		{
+			name:             "guardians agree on the duplicate vaa",
+			guardianAnswers:  [][]byte{duplicateBytes, nil, duplicateBytes},
+			threshold:        2,
+			expectedDecision: storage.DuplicateVaaDecisionFixed,
+			expectedDigest:   duplicate.HexDigest(),
+			expectedFixed:    []string{"duplicate-1"},
+		},
+		{
+			name:             "threshold not reached",
+			guardianAnswers:  [][]byte{duplicateBytes, nil, nil},
+			threshold:        2,
+			expectedErr:      true,
+			expectedDecision: storage.DuplicateVaaDecisionUnresolved,
+			expectedReason:   "guardian agreement not reached: 1 of 2 answers with the same digest",
+		},
+		{
+			name:             "tie",
+			guardianAnswers:  [][]byte{storedBytes, duplicateBytes},
+			threshold:        1,
+			expectedErr:      true,
+			expectedDecision: storage.DuplicateVaaDecisionUnresolved,
+			expectedReason:   "guardian agreement not reached: 1 of 1 answers with the same digest",
+		},
+		{
+			name:             "agreed digest does not match any candidate",
+			guardianAnswers:  [][]byte{otherBytes, otherBytes},
+			threshold:        2,
+			expectedErr:      true,
+			expectedDecision: storage.DuplicateVaaDecisionUnresolved,
+			expectedDigest:   other.HexDigest(),
+			expectedReason:   "the digest agreed by the guardians does not match any candidate",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repository := &fakeRepository{
+				vaa: &storage.VaaDoc{
+					ID:        vaaID,
+					Vaa:       storedBytes,
+					TxHash:    "stored-tx",
+					Timestamp: &timestamp,
+				},
+				duplicates: []storage.DuplicateVaaDoc{{
+					ID:        "duplicate-1",
+					VaaID:     vaaID,
+					Vaa:       duplicateBytes,
+					TxHash:    "duplicate-tx",
+					Timestamp: &timestamp,
+				}},
+			}
+			config := ResolutionConfig{GuardianQueries: len(tc.guardianAnswers), AgreementThreshold: tc.threshold}
+			p := NewProcessor(newGuardianPool(t, tc.guardianAnswers...), repository, config, zap.NewNop(), metrics.NewDummyMetrics())
+
+			err := p.Process(context.Background(), &Params{TrackID: "track-1", VaaID: vaaID, ChainID: sdk.ChainIDSolana})
+			assert.Equal(t, tc.expectedErr, err != nil)
+			assert.Equal(t, tc.expectedFixed, repository.fixed)
+
+			if !assert.Len(t, repository.resolutions, 1) {
+				return
+			}
+			resolution := repository.resolutions[0]
+			assert.Equal(t, vaaID, resolution.VaaID)
+			assert.Equal(t, "track-1", resolution.TrackID)
+			assert.Equal(t, sdk.ChainIDSolana, resolution.EmitterChain)
+			assert.Equal(t, tc.threshold, resolution.Threshold)
+			assert.Equal(t, stored.HexDigest(), resolution.PreviousDigest)
+			assert.Equal(t, tc.expectedDecision, resolution.Decision)
+			assert.Equal(t, tc.expectedDigest, resolution.ChosenDigest)
+			assert.Equal(t, tc.expectedReason, resolution.Reason)
+			assert.False(t, resolution.CreatedAt.IsZero())
+			assert.Equal(t, []storage.DuplicateVaaCandidate{
+				{ID: domain.CreateUniqueVaaID(stored), Digest: stored.HexDigest(), TxHash: "stored-tx", Timestamp: &timestamp, Stored: true},
+				{ID: "duplicate-1", Digest: duplicate.HexDigest(), TxHash: "duplicate-tx", Timestamp: &timestamp},
+			}, resolution.Candidates)
+			if assert.Len(t, resolution.GuardianAnswers, len(tc.guardianAnswers)) {
+				for i, answer := range resolution.GuardianAnswers {
+					if tc.guardianAnswers[i] == nil {
+						assert.NotEmpty(t, answer.Error)
+						assert.Empty(t, answer.Digest)
+						continue
+					}
+					v, _ := sdk.Unmarshal(tc.guardianAnswers[i])
+					assert.Equal(t, v.HexDigest(), answer.Digest)
+				}
+			}
+		})
+	}
+}
+
+func TestProcessor_ProcessFixError(t *testing.T) {
+	stored := newTestVaa(1)
+	duplicate := newTestVaa(2)
+	duplicateBytes := marshalTestVaa(t, duplicate)
+	timestamp := time.Now().Add(-time.Hour)
+	repository := &fakeRepository{
+		vaa: &storage.VaaDoc{ID: stored.MessageID(), Vaa: marshalTestVaa(t, stored), TxHash: "stored-tx", Timestamp: &timestamp},
+		duplicates: []storage.DuplicateVaaDoc{{
+			ID: "duplicate-1", VaaID: stored.MessageID(), Vaa: duplicateBytes, TxHash: "duplicate-tx", Timestamp: &timestamp,
+		}},
+		fixErr: errors.New("transaction aborted"),
+	}
+	config := ResolutionConfig{GuardianQueries: 1, AgreementThreshold: 1}
+	p := NewProcessor(newGuardianPool(t, duplicateBytes), repository, config, zap.NewNop(), metrics.NewDummyMetrics())
+
+	err := p.Process(context.Background(), &Params{TrackID: "track-1", VaaID: stored.MessageID(), ChainID: sdk.ChainIDSolana})
+	assert.Error(t, err)
+	// no resolution is recorded when the vaa cannot be fixed, the event is retried.
+	assert.Empty(t, repository.resolutions)
+}
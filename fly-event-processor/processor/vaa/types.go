@@ -1,6 +1,7 @@
 package vaa
 
 import (
+	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/storage"
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"golang.org/x/net/context"
 )
@@ -13,3 +14,19 @@ type Params struct {
 
 // ProcessorFunc is a function to process vaa message.
 type ProcessorFunc func(context.Context, *Params) error
+
+// ResolutionConfig is the guardian agreement required to resolve a duplicate vaa.
+type ResolutionConfig struct {
+	// GuardianQueries is the number of guardian api answers requested for each vaa.
+	GuardianQueries int
+	// AgreementThreshold is the minimum number of guardian answers with the same digest.
+	AgreementThreshold int
+}
+
+// Repository is the storage used to resolve duplicate vaas.
+type Repository interface {
+	FindVAAById(ctx context.Context, vaaID string) (*storage.VaaDoc, error)
+	FindDuplicateVAAs(ctx context.Context, vaaID string) ([]storage.DuplicateVaaDoc, error)
+	FixVAA(ctx context.Context, vaaID, duplicateID string) error
+	InsertDuplicateVaaResolution(ctx context.Context, doc *storage.DuplicateVaaResolutionDoc) error
+}
@@ -2,30 +2,37 @@ package storage
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	commonRepo "github.com/wormhole-foundation/wormhole-explorer/common/repository"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
 	"gopkg.in/mgo.v2/bson"
 )
 
 // Repository exposes operations over the `globalTransactions` collection.
 type Repository struct {
-	logger           *zap.Logger
-	vaas             *mongo.Collection
-	duplicateVaas    *mongo.Collection
-	nodeGovernorVaas *mongo.Collection
-	governorVaas     *mongo.Collection
+	logger                  *zap.Logger
+	vaas                    *mongo.Collection
+	duplicateVaas           *mongo.Collection
+	duplicateVaaResolutions *mongo.Collection
+	nodeGovernorVaas        *mongo.Collection
+	governorVaas            *mongo.Collection
+	governorVaaReleases     *mongo.Collection
 }
 
 // New creates a new repository.
 func NewRepository(logger *zap.Logger, db *mongo.Database) *Repository {
 	r := Repository{
-		logger:           logger,
-		vaas:             db.Collection(commonRepo.Vaas),
-		duplicateVaas:    db.Collection(commonRepo.DuplicateVaas),
-		nodeGovernorVaas: db.Collection(commonRepo.NodeGovernorVaas),
-		governorVaas:     db.Collection(commonRepo.GovernorVaas),
+		logger:                  logger,
+		vaas:                    db.Collection(commonRepo.Vaas),
+		duplicateVaas:           db.Collection(commonRepo.DuplicateVaas),
+		duplicateVaaResolutions: db.Collection(commonRepo.DuplicateVaaResolutions),
+		nodeGovernorVaas:        db.Collection(commonRepo.NodeGovernorVaas),
+		governorVaas:            db.Collection(commonRepo.GovernorVaas),
+		governorVaaReleases:     db.Collection(commonRepo.GovernorVaaReleases),
 	}
 	return &r
 }
@@ -130,6 +137,12 @@ func (r *Repository) FixVAA(ctx context.Context, vaaID, duplicateID string) erro
 	return nil
 }
 
+// InsertDuplicateVaaResolution inserts the audit record of a duplicate vaa resolution.
+func (r *Repository) InsertDuplicateVaaResolution(ctx context.Context, doc *DuplicateVaaResolutionDoc) error {
+	_, err := r.duplicateVaaResolutions.InsertOne(ctx, doc)
+	return err
+}
+
 // FindNodeGovernorVaaByNodeAddress find governor vaas by node address.
 func (r *Repository) FindNodeGovernorVaaByNodeAddress(ctx context.Context, nodeAddress string) ([]NodeGovernorVaaDoc, error) {
 	var nodeGovernorVaa []NodeGovernorVaaDoc
@@ -253,3 +266,131 @@ func (r *Repository) UpdateGovernor(
 
 	return nil
 }
+
+// UpsertGovernorVaaEnqueue records the time a guardian enqueued a governor vaa.
+func (r *Repository) UpsertGovernorVaaEnqueue(ctx context.Context, governorVaa *GovernorVaaDoc, nodeAddress, nodeName string, enqueuedAt time.Time) error {
+	node := fmt.Sprintf("nodes.%s", nodeAddress)
+	update := bson.M{
+		"$setOnInsert": newGovernorVaaReleaseFields(governorVaa),
+		"$min": bson.M{
+			"enqueuedAt":         enqueuedAt,
+			node + ".enqueuedAt": enqueuedAt,
+		},
+		"$set": bson.M{
+			node + ".nodeName": nodeName,
+			"updatedAt":        time.Now(),
+		},
+	}
+	_, err := r.governorVaaReleases.UpdateOne(ctx, bson.M{"_id": governorVaa.ID}, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// UpsertGovernorVaaNodeRelease records the time a guardian released a governor vaa and returns the updated document.
+func (r *Repository) UpsertGovernorVaaNodeRelease(ctx context.Context, governorVaa *GovernorVaaDoc, nodeAddress, nodeName string, releasedAt time.Time) (*GovernorVaaReleaseDoc, error) {
+	node := fmt.Sprintf("nodes.%s", nodeAddress)
+	update := bson.M{
+		"$setOnInsert": newGovernorVaaReleaseFields(governorVaa),
+		"$min": bson.M{
+			node + ".releasedAt": releasedAt,
+		},
+		"$set": bson.M{
+			node + ".nodeName": nodeName,
+			"updatedAt":        time.Now(),
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	var doc GovernorVaaReleaseDoc
+	err := r.governorVaaReleases.FindOneAndUpdate(ctx, bson.M{"_id": governorVaa.ID}, update, opts).Decode(&doc)
+	if err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// UpdateGovernorVaaReleaseSkews updates the release skew of the guardians of a governor vaa, by guardian address.
+func (r *Repository) UpdateGovernorVaaReleaseSkews(ctx context.Context, vaaID string, skews map[string]int64) error {
+	if len(skews) == 0 {
+		return nil
+	}
+	set := bson.M{}
+	for nodeAddress, skew := range skews {
+		set[fmt.Sprintf("nodes.%s.releaseSkewMs", nodeAddress)] = skew
+	}
+	_, err := r.governorVaaReleases.UpdateOne(ctx, bson.M{"_id": vaaID}, bson.M{"$set": set})
+	return err
+}
+
+// UpdateGovernorVaaReleased marks a governor vaa as released and records the publishers to notify.
+// A release is marked once, the result is false if the vaa was already released.
+func (r *Repository) UpdateGovernorVaaReleased(ctx context.Context, vaaID string, releasedAt time.Time, releaseSource string, publishers []string) (bool, error) {
+	now := time.Now()
+	set := bson.M{
+		"releasedAt":    releasedAt,
+		"releaseSource": releaseSource,
+		"updatedAt":     now,
+	}
+	if len(publishers) > 0 {
+		set["pendingNotifications"] = publishers
+	} else {
+		set["notifiedAt"] = now
+	}
+	filter := bson.M{"_id": vaaID, "releaseSource": bson.M{"$exists": false}}
+	result, err := r.governorVaaReleases.UpdateOne(ctx, filter, bson.M{"$set": set})
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// FindGovernorVaaReleasesPendingNotification finds the released governor vaas with publishers that were not notified yet.
+func (r *Repository) FindGovernorVaaReleasesPendingNotification(ctx context.Context, limit int64) ([]GovernorVaaReleaseDoc, error) {
+	var releases []GovernorVaaReleaseDoc
+	filter := bson.M{"pendingNotifications": bson.M{"$exists": true}}
+	cursor, err := r.governorVaaReleases.Find(ctx, filter, options.Find().SetLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	if err = cursor.All(ctx, &releases); err != nil {
+		return nil, err
+	}
+	return releases, nil
+}
+
+// CompleteGovernorVaaReleaseNotification removes a publisher from the pending notifications of a governor vaa release.
+// When it is the last pending publisher, the release is marked as notified.
+func (r *Repository) CompleteGovernorVaaReleaseNotification(ctx context.Context, vaaID, publisher string, last bool) error {
+	now := time.Now()
+	update := bson.M{
+		"$pull": bson.M{"pendingNotifications": publisher},
+		"$set":  bson.M{"updatedAt": now},
+	}
+	if last {
+		update = bson.M{
+			"$unset": bson.M{"pendingNotifications": ""},
+			"$set":   bson.M{"notifiedAt": now, "updatedAt": now},
+		}
+	}
+	_, err := r.governorVaaReleases.UpdateOne(ctx, bson.M{"_id": vaaID}, update)
+	return err
+}
+
+// newGovernorVaaReleaseFields returns the fields of a governor vaa release document taken from the governor vaa.
+func newGovernorVaaReleaseFields(governorVaa *GovernorVaaDoc) bson.M {
+	return bson.M{
+		"chainId":        governorVaa.ChainID,
+		"emitterAddress": governorVaa.EmitterAddress,
+		"sequence":       governorVaa.Sequence,
+		"txHash":         governorVaa.TxHash,
+		"amount":         governorVaa.Amount,
+		"releaseTime":    governorVaa.ReleaseTime,
+	}
+}
+
+// ExistsVAA checks if a signed vaa is stored in the vaas collection.
+func (r *Repository) ExistsVAA(ctx context.Context, vaaID string) (bool, error) {
+	count, err := r.vaas.CountDocuments(ctx, bson.M{"_id": vaaID}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
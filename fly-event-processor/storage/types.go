@@ -49,6 +49,50 @@ type DuplicateVaaDoc struct {
 	UpdatedAt        *time.Time  `bson:"updatedAt"`
 }
 
+// duplicate vaa resolution decisions.
+const (
+	// DuplicateVaaDecisionKept means the guardians agreed on the digest of the vaa stored in the vaas collection.
+	DuplicateVaaDecisionKept = "kept"
+	// DuplicateVaaDecisionFixed means the guardians agreed on the digest of a duplicate vaa, which replaced the stored vaa.
+	DuplicateVaaDecisionFixed = "fixed"
+	// DuplicateVaaDecisionUnresolved means the guardians did not reach the agreement threshold
+	// or the digest they agreed on does not match any candidate.
+	DuplicateVaaDecisionUnresolved = "unresolved"
+)
+
+// DuplicateVaaResolutionDoc is the audit record of a duplicate vaa resolution.
+type DuplicateVaaResolutionDoc struct {
+	ID              string                  `bson:"_id"`
+	VaaID           string                  `bson:"vaaId"`
+	TrackID         string                  `bson:"trackId"`
+	EmitterChain    sdk.ChainID             `bson:"emitterChain"`
+	Candidates      []DuplicateVaaCandidate `bson:"candidates"`
+	GuardianAnswers []GuardianDigestAnswer  `bson:"guardianAnswers"`
+	Threshold       int                     `bson:"threshold"`
+	ChosenDigest    string                  `bson:"chosenDigest,omitempty"`
+	PreviousDigest  string                  `bson:"previousDigest"`
+	Decision        string                  `bson:"decision"`
+	Reason          string                  `bson:"reason,omitempty"`
+	CreatedAt       time.Time               `bson:"createdAt"`
+}
+
+// DuplicateVaaCandidate is one of the vaas with the same vaa id, stored either in the vaas or in the duplicateVaas collection.
+type DuplicateVaaCandidate struct {
+	ID        string     `bson:"id"`
+	Digest    string     `bson:"digest"`
+	TxHash    string     `bson:"txHash,omitempty"`
+	Timestamp *time.Time `bson:"timestamp"`
+	// Stored is true for the candidate stored in the vaas collection when the decision was made.
+	Stored bool `bson:"stored"`
+}
+
+// GuardianDigestAnswer is the digest of the signed vaa returned by a guardian api, or the error returned instead.
+type GuardianDigestAnswer struct {
+	Guardian string `bson:"guardian"`
+	Digest   string `bson:"digest,omitempty"`
+	Error    string `bson:"error,omitempty"`
+}
+
 type NodeGovernorVaaDoc struct {
 	ID          string `bson:"_id"`
 	NodeName    string `bson:"nodeName"`
@@ -66,6 +110,45 @@ type GovernorVaaDoc struct {
 	Amount         Uint64      `bson:"amount"`
 }
 
+// governor vaa release sources.
+const (
+	// GovernorVaaReleaseSourceQuorum means a quorum of guardians removed the vaa from their enqueued vaas.
+	GovernorVaaReleaseSourceQuorum = "quorum"
+	// GovernorVaaReleaseSourceSignedVaa means the signed vaa was found in the vaas collection.
+	GovernorVaaReleaseSourceSignedVaa = "signed-vaa"
+)
+
+// GovernorVaaReleaseDoc tracks an enqueued governor vaa from the first time a guardian enqueues it until it is released.
+type GovernorVaaReleaseDoc struct {
+	ID             string      `bson:"_id"`
+	ChainID        sdk.ChainID `bson:"chainId"`
+	EmitterAddress string      `bson:"emitterAddress"`
+	Sequence       string      `bson:"sequence"`
+	TxHash         string      `bson:"txHash"`
+	Amount         Uint64      `bson:"amount"`
+	// ReleaseTime is the scheduled release time reported by the guardians.
+	ReleaseTime   time.Time  `bson:"releaseTime"`
+	EnqueuedAt    *time.Time `bson:"enqueuedAt"`
+	ReleasedAt    *time.Time `bson:"releasedAt"`
+	ReleaseSource string     `bson:"releaseSource,omitempty"`
+	// PendingNotifications are the publishers that were not notified of the release yet.
+	PendingNotifications []string `bson:"pendingNotifications,omitempty"`
+	// NotifiedAt is the time all the publishers were notified of the release.
+	NotifiedAt *time.Time `bson:"notifiedAt"`
+	// Nodes are the enqueue and release times reported by each guardian, by guardian address.
+	Nodes     map[string]GovernorVaaNodeRelease `bson:"nodes"`
+	UpdatedAt time.Time                         `bson:"updatedAt"`
+}
+
+// GovernorVaaNodeRelease is the enqueue and release time of a governor vaa reported by a guardian.
+type GovernorVaaNodeRelease struct {
+	NodeName   string     `bson:"nodeName"`
+	EnqueuedAt *time.Time `bson:"enqueuedAt,omitempty"`
+	ReleasedAt *time.Time `bson:"releasedAt,omitempty"`
+	// ReleaseSkewMs is the delay of the guardian release from the first guardian release.
+	ReleaseSkewMs *int64 `bson:"releaseSkewMs,omitempty"`
+}
+
 func (d *DuplicateVaaDoc) ToVaaDoc(duplicatedFixed bool) *VaaDoc {
 	return &VaaDoc{
 		ID:               d.VaaID,
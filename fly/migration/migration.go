@@ -10,6 +10,9 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// governorStatusHistoryRetention is the time in seconds the governor status history is kept.
+const governorStatusHistoryRetention int32 = 35 * 24 * 60 * 60
+
 // TODO: move this to migration tool that support mongodb.
 func Run(db *mongo.Database) error {
 	// Created governorConfig collection.
@@ -114,6 +117,16 @@ func Run(db *mongo.Database) error {
 		return err
 	}
 
+	// create index in vaas collection by indexedAt to fill the gaps of the change streams.
+	indexVaaByIndexedAt := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "indexedAt", Value: 1},
+		}}
+	_, err = db.Collection("vaas").Indexes().CreateOne(context.TODO(), indexVaaByIndexedAt)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
 	// create index in observations collection by indexedAt.
 	indexObservationsByIndexedAt := mongo.IndexModel{Keys: bson.D{{Key: "indexedAt", Value: 1}}}
 	_, err = db.Collection(repository.Observations).Indexes().CreateOne(context.TODO(), indexObservationsByIndexedAt)
@@ -149,6 +162,14 @@ func Run(db *mongo.Database) error {
 		return err
 	}
 
+	// create index in txHashFixQueue collection to get the pending items to retry.
+	indexTxHashFixQueueByNextRetryAt := mongo.IndexModel{
+		Keys: bson.D{{Key: "nextRetryAt", Value: 1}}}
+	_, err = db.Collection(repository.TxHashFixQueue).Indexes().CreateOne(context.TODO(), indexTxHashFixQueueByNextRetryAt)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
 	// create index in globalTransactions collect.
 	indexGlobalTransactionsByOriginTx := mongo.IndexModel{
 		Keys: bson.D{{Key: "originTx.from", Value: 1}}}
@@ -189,6 +210,14 @@ func Run(db *mongo.Database) error {
 		return err
 	}
 
+	// create index in globalTransactions collection by destination chainId and blockNumber, to rollback reorged blocks.
+	indexGlobalTransactionsByDestinationBlock := mongo.IndexModel{
+		Keys: bson.D{{Key: "destinationTx.chainId", Value: 1}, {Key: "destinationTx.blockNumber", Value: 1}}}
+	_, err = db.Collection("globalTransactions").Indexes().CreateOne(context.TODO(), indexGlobalTransactionsByDestinationBlock)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
 	// create index in globalTransactions collection by timestamp/_id sort.
 	indexGlobalTransactionsByTimestampAndId := mongo.IndexModel{
 		Keys: bson.D{{Key: "originTx.timestamp", Value: -1}, {Key: "_id", Value: -1}}}
@@ -258,6 +287,19 @@ func Run(db *mongo.Database) error {
 		return err
 	}
 
+	// create index in parsedVaa collection by CCTP source domain and nonce, to match circle messages with their VAA.
+	indexParsedVaaByCctpNonce := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "appIds", Value: 1},
+			{Key: "parsedPayload.sourceDomain", Value: 1},
+			{Key: "parsedPayload.nonce", Value: 1},
+		},
+	}
+	_, err = db.Collection(repository.ParsedVaa).Indexes().CreateOne(context.TODO(), indexParsedVaaByCctpNonce)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
 	// create index for duplicateVaas by vaaId
 	indexDuplicateVaasByVaadID := mongo.IndexModel{
 		Keys: bson.D{
@@ -269,6 +311,79 @@ func Run(db *mongo.Database) error {
 		return err
 	}
 
+	// create index for duplicateVaaResolutions by vaaId and createdAt
+	indexDuplicateVaaResolutionsByVaaID := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "vaaId", Value: 1},
+			{Key: "createdAt", Value: -1},
+		},
+	}
+	_, err = db.Collection(repository.DuplicateVaaResolutions).Indexes().CreateOne(context.TODO(), indexDuplicateVaaResolutionsByVaaID)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
+	// create index in governorStatusHistory collection to get the history of a chain in a time range.
+	indexGovernorStatusHistoryByChainIdAndBucket := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "chainId", Value: 1},
+			{Key: "bucket", Value: 1},
+		},
+	}
+	_, err = db.Collection(repository.GovernorStatusHistory).Indexes().CreateOne(context.TODO(), indexGovernorStatusHistoryByChainIdAndBucket)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
+	// create ttl index in governorStatusHistory collection, the api serves up to one month of history.
+	indexGovernorStatusHistoryByBucketTTL := mongo.IndexModel{
+		Keys:    bson.D{{Key: "bucket", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(governorStatusHistoryRetention),
+	}
+	_, err = db.Collection(repository.GovernorStatusHistory).Indexes().CreateOne(context.TODO(), indexGovernorStatusHistoryByBucketTTL)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
+	// create index in unknownTokenTransfers collection to page and delete the transfers of a token.
+	indexUnknownTokenTransfersByToken := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "tokenChain", Value: 1},
+			{Key: "tokenAddress", Value: 1},
+			{Key: "_id", Value: 1},
+		},
+	}
+	_, err = db.Collection(repository.UnknownTokenTransfers).Indexes().CreateOne(context.TODO(), indexUnknownTokenTransfersByToken)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
+	// create unique index in tokenMappings collection, a token is mapped to a single coingecko id.
+	indexTokenMappingsByToken := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "tokenChain", Value: 1},
+			{Key: "tokenAddress", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	}
+	_, err = db.Collection(repository.TokenMappings).Indexes().CreateOne(context.TODO(), indexTokenMappingsByToken)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
+	// create index in transferPrices collection to recompute the volume of a token in a time range.
+	indexTransferPricesByTokenAndTimestamp := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "tokenChain", Value: 1},
+			{Key: "tokenAddress", Value: 1},
+			{Key: "timestamp", Value: 1},
+		},
+	}
+	_, err = db.Collection(repository.TransferPrices).Indexes().CreateOne(context.TODO(), indexTransferPricesByTokenAndTimestamp)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
 	// create index in nodeGovernorVaas collection by vaaId.
 	indexNodeGovernorVaasByVaaId := mongo.IndexModel{
 		Keys: bson.D{{Key: "vaaId", Value: 1}}}
@@ -277,6 +392,16 @@ func Run(db *mongo.Database) error {
 		return err
 	}
 
+	// create index in governorVaaReleases collection to get the releases with pending notifications.
+	indexGovernorVaaReleasesByPendingNotifications := mongo.IndexModel{
+		Keys:    bson.D{{Key: "pendingNotifications", Value: 1}},
+		Options: options.Index().SetSparse(true),
+	}
+	_, err = db.Collection(repository.GovernorVaaReleases).Indexes().CreateOne(context.TODO(), indexGovernorVaaReleasesByPendingNotifications)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
 	return nil
 }
 
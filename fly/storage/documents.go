@@ -124,6 +124,9 @@ type MongoConnections struct {
 	TotalCreated int32 `bson:"totalCreated"`
 }
 
+// GovernorStatusHistoryBucket is the time resolution of the governor status history.
+const GovernorStatusHistoryBucket = 15 * time.Minute
+
 type GovernorStatusUpdate struct {
 	NodeName  string                      `bson:"nodename"`
 	Counter   int64                       `bson:"counter"`
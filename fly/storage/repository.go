@@ -39,14 +39,15 @@ type Repository struct {
 	eventDispatcher event.EventDispatcher
 	log             *zap.Logger
 	collections     struct {
-		vaas           *mongo.Collection
-		heartbeats     *mongo.Collection
-		observations   *mongo.Collection
-		governorConfig *mongo.Collection
-		governorStatus *mongo.Collection
-		vaasPythnet    *mongo.Collection
-		vaaCounts      *mongo.Collection
-		duplicateVaas  *mongo.Collection
+		vaas                  *mongo.Collection
+		heartbeats            *mongo.Collection
+		observations          *mongo.Collection
+		governorConfig        *mongo.Collection
+		governorStatus        *mongo.Collection
+		governorStatusHistory *mongo.Collection
+		vaasPythnet           *mongo.Collection
+		vaaCounts             *mongo.Collection
+		duplicateVaas         *mongo.Collection
 	}
 }
 
@@ -58,23 +59,25 @@ func NewRepository(alertService alert.AlertClient, metrics metrics.Metrics,
 	eventDispatcher event.EventDispatcher,
 	log *zap.Logger) *Repository {
 	return &Repository{alertService, metrics, db, vaaTopicFunc, txHashStore, eventDispatcher, log, struct {
-		vaas           *mongo.Collection
-		heartbeats     *mongo.Collection
-		observations   *mongo.Collection
-		governorConfig *mongo.Collection
-		governorStatus *mongo.Collection
-		vaasPythnet    *mongo.Collection
-		vaaCounts      *mongo.Collection
-		duplicateVaas  *mongo.Collection
+		vaas                  *mongo.Collection
+		heartbeats            *mongo.Collection
+		observations          *mongo.Collection
+		governorConfig        *mongo.Collection
+		governorStatus        *mongo.Collection
+		governorStatusHistory *mongo.Collection
+		vaasPythnet           *mongo.Collection
+		vaaCounts             *mongo.Collection
+		duplicateVaas         *mongo.Collection
 	}{
-		vaas:           db.Collection(repository.Vaas),
-		heartbeats:     db.Collection("heartbeats"),
-		observations:   db.Collection(repository.Observations),
-		governorConfig: db.Collection("governorConfig"),
-		governorStatus: db.Collection("governorStatus"),
-		vaasPythnet:    db.Collection("vaasPythnet"),
-		vaaCounts:      db.Collection("vaaCounts"),
-		duplicateVaas:  db.Collection(repository.DuplicateVaas)}}
+		vaas:                  db.Collection(repository.Vaas),
+		heartbeats:            db.Collection("heartbeats"),
+		observations:          db.Collection(repository.Observations),
+		governorConfig:        db.Collection("governorConfig"),
+		governorStatus:        db.Collection("governorStatus"),
+		governorStatusHistory: db.Collection(repository.GovernorStatusHistory),
+		vaasPythnet:           db.Collection("vaasPythnet"),
+		vaaCounts:             db.Collection("vaaCounts"),
+		duplicateVaas:         db.Collection(repository.DuplicateVaas)}}
 }
 
 func (s *Repository) UpsertVaa(ctx context.Context, v *vaa.VAA, serializedVaa []byte) error {
@@ -349,6 +352,9 @@ func (s *Repository) UpsertGovernorStatus(govS *gossipv1.SignedChainGovernorStat
 		return err2
 	}
 
+	// keep track of the available notional of each chain over time.
+	s.upsertGovernorStatusHistory(context.TODO(), id, status, now)
+
 	// send governor status to topic.
 	err3 := s.eventDispatcher.NewGovernorStatus(context.TODO(), event.GovernorStatus{
 		NodeAddress: id,
@@ -366,6 +372,33 @@ func (s *Repository) UpsertGovernorStatus(govS *gossipv1.SignedChainGovernorStat
 	return err3
 }
 
+// upsertGovernorStatusHistory stores the remaining available notional reported by a guardian for each chain
+// in the time bucket of the update. The minimum value reported during the bucket is also tracked.
+func (s *Repository) upsertGovernorStatusHistory(ctx context.Context, guardianAddr string, status *GovernorStatusUpdate, now time.Time) {
+	bucket := now.UTC().Truncate(GovernorStatusHistoryBucket)
+	for _, c := range status.Chains {
+		id := fmt.Sprintf("%d/%d", c.ChainId, bucket.Unix())
+		update := bson.D{
+			{Key: "$set", Value: bson.D{
+				{Key: "guardians." + guardianAddr, Value: c.RemainingAvailableNotional},
+				{Key: "updatedAt", Value: now},
+			}},
+			{Key: "$min", Value: bson.D{{Key: "minAvailableNotional", Value: c.RemainingAvailableNotional}}},
+			{Key: "$setOnInsert", Value: bson.D{
+				{Key: "chainId", Value: c.ChainId},
+				{Key: "bucket", Value: bucket},
+			}},
+		}
+		_, err := s.collections.governorStatusHistory.UpdateByID(ctx, id, update, options.Update().SetUpsert(true))
+		if err != nil {
+			s.log.Error("Error upserting governor status history",
+				zap.String("guardian", status.NodeName),
+				zap.Uint32("chainId", c.ChainId),
+				zap.Error(err))
+		}
+	}
+}
+
 func (s *Repository) updateVAACount(chainID vaa.ChainID) {
 	update := bson.D{{Key: "$inc", Value: bson.D{{Key: "count", Value: uint64(1)}}}}
 	opts := options.Update().SetUpsert(true)
@@ -14,6 +14,7 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/parser/config"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/parser"
+	"github.com/wormhole-foundation/wormhole-explorer/parser/payload"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/processor"
 	"go.uber.org/zap"
 )
@@ -77,7 +78,11 @@ func Run(config *config.BackfillerConfiguration) {
 	tokenProvider := domain.NewTokenProvider(config.P2pNetwork)
 
 	//create a processor
-	eventProcessor := processor.New(parserVAAAPIClient, parserRepository, alert.NewDummyClient(), metrics.NewDummyMetrics(), tokenProvider, logger)
+	var nativeParser *payload.Parser
+	if config.NativeParserEnabled {
+		nativeParser = payload.New(domain.NewProtocolRegistry(config.P2pNetwork))
+	}
+	eventProcessor := processor.New(nativeParser, parserVAAAPIClient, parserRepository, alert.NewDummyClient(), metrics.NewDummyMetrics(), tokenProvider, logger)
 
 	logger.Info("Started wormhole-explorer-parser as backfiller")
 
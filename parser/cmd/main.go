@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/cmd/backfiller"
+	"github.com/wormhole-foundation/wormhole-explorer/parser/cmd/reparse"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/cmd/service"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/config"
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
@@ -26,6 +27,7 @@ func execute() error {
 
 	addServiceCommand(root)
 	addBackfiller(root)
+	addReparse(root)
 
 	return root.Execute()
 }
@@ -45,6 +47,7 @@ func addBackfiller(root *cobra.Command) {
 	var mongoUri, mongoDb, p2pNetwork, vaaPayloadParserURL, logLevel, startTime, endTime, sort, emitterAddress, sequence string
 	var vaaPayloadParserTimeout, pageSize int64
 	var emitterChainID uint16
+	var nativeParserEnabled bool
 
 	sortAsc := false
 	if strings.ToLower(sort) == "asc" {
@@ -61,6 +64,7 @@ func addBackfiller(root *cobra.Command) {
 				P2pNetwork:              p2pNetwork,
 				VaaPayloadParserURL:     vaaPayloadParserURL,
 				VaaPayloadParserTimeout: vaaPayloadParserTimeout,
+				NativeParserEnabled:     nativeParserEnabled,
 				StartTime:               startTime,
 				EndTime:                 endTime,
 				PageSize:                pageSize,
@@ -86,6 +90,7 @@ func addBackfiller(root *cobra.Command) {
 	backfillerCommand.Flags().StringVar(&p2pNetwork, "p2p-network", "", "P2P network")
 	backfillerCommand.Flags().StringVar(&vaaPayloadParserURL, "vaa-payload-parser-url", "", "VAA payload parser service URL")
 	backfillerCommand.Flags().Int64Var(&vaaPayloadParserTimeout, "vaa-payload-parser-timeout", 10, "maximum waiting time in call to VAA payload service in seconds")
+	backfillerCommand.Flags().BoolVar(&nativeParserEnabled, "native-parser", true, "parse the payloads of the known protocols without calling the VAA payload service")
 	backfillerCommand.Flags().StringVar(&startTime, "start-time", "1970-01-01T00:00:00Z", "minimum VAA timestamp to process")
 	backfillerCommand.Flags().StringVar(&endTime, "end-time", "", "maximum VAA timestamp to process (default now)")
 	backfillerCommand.Flags().Int64Var(&pageSize, "page-size", 100, "number of documents retrieved at a time")
@@ -102,3 +107,76 @@ func addBackfiller(root *cobra.Command) {
 
 	root.AddCommand(backfillerCommand)
 }
+
+func addReparse(root *cobra.Command) {
+	var mongoUri, mongoDb, p2pNetwork, vaaPayloadParserURL, logLevel, startTime, endTime, emitterAddress, appID, parserVersion string
+	var vaaPayloadParserTimeout, pageSize int64
+	var emitterChainID uint16
+	var transformVersion, workers int
+	var nativeParserEnabled, outdated, dryRun bool
+
+	reparseCommand := &cobra.Command{
+		Use:   "reparse",
+		Short: "Run reparse to parse again the already parsed VAAs",
+		Run: func(cmd *cobra.Command, _ []string) {
+			cfg := &config.ReparseConfiguration{
+				LogLevel:                logLevel,
+				MongoURI:                mongoUri,
+				MongoDatabase:           mongoDb,
+				P2pNetwork:              p2pNetwork,
+				VaaPayloadParserURL:     vaaPayloadParserURL,
+				VaaPayloadParserTimeout: vaaPayloadParserTimeout,
+				NativeParserEnabled:     nativeParserEnabled,
+				StartTime:               startTime,
+				EndTime:                 endTime,
+				Outdated:                outdated,
+				PageSize:                pageSize,
+				Workers:                 workers,
+				DryRun:                  dryRun,
+			}
+
+			if emitterChainID != 0 {
+				eci := sdk.ChainID(emitterChainID)
+				cfg.EmitterChainID = &eci
+			}
+			if emitterAddress != "" {
+				cfg.EmitterAddress = &emitterAddress
+			}
+			if appID != "" {
+				cfg.AppID = &appID
+			}
+			if cmd.Flags().Changed("parser-version") {
+				cfg.ParserVersion = &parserVersion
+			}
+			if cmd.Flags().Changed("transform-version") {
+				cfg.TransformVersion = &transformVersion
+			}
+			reparse.Run(cfg)
+		},
+	}
+	reparseCommand.Flags().StringVar(&logLevel, "log-level", "INFO", "log level")
+	reparseCommand.Flags().StringVar(&mongoUri, "mongo-uri", "", "Mongo connection")
+	reparseCommand.Flags().StringVar(&mongoDb, "mongo-database", "", "Mongo database")
+	reparseCommand.Flags().StringVar(&p2pNetwork, "p2p-network", "", "P2P network")
+	reparseCommand.Flags().StringVar(&vaaPayloadParserURL, "vaa-payload-parser-url", "", "VAA payload parser service URL")
+	reparseCommand.Flags().Int64Var(&vaaPayloadParserTimeout, "vaa-payload-parser-timeout", 10, "maximum waiting time in call to VAA payload service in seconds")
+	reparseCommand.Flags().BoolVar(&nativeParserEnabled, "native-parser", true, "parse the payloads of the known protocols without calling the VAA payload service")
+	reparseCommand.Flags().StringVar(&startTime, "start-time", "", "minimum VAA timestamp to process")
+	reparseCommand.Flags().StringVar(&endTime, "end-time", "", "maximum VAA timestamp to process")
+	reparseCommand.Flags().Uint16Var(&emitterChainID, "emitter-chain", 0, "emitter chain id")
+	reparseCommand.Flags().StringVar(&emitterAddress, "emitter-address", "", "emitter address")
+	reparseCommand.Flags().StringVar(&appID, "app-id", "", "app id")
+	reparseCommand.Flags().StringVar(&parserVersion, "parser-version", "", "parser version of the VAAs to process (empty selects the VAAs without version)")
+	reparseCommand.Flags().IntVar(&transformVersion, "transform-version", 0, "standardized properties transform version of the VAAs to process")
+	reparseCommand.Flags().BoolVar(&outdated, "outdated", false, "process only the VAAs parsed with a previous parser or transform version")
+	reparseCommand.Flags().Int64Var(&pageSize, "page-size", 100, "number of documents retrieved at a time")
+	reparseCommand.Flags().IntVar(&workers, "workers", 10, "number of VAAs processed concurrently")
+	reparseCommand.Flags().BoolVar(&dryRun, "dry-run", false, "report the changed fields without persisting the new result")
+
+	reparseCommand.MarkFlagRequired("mongo-uri")
+	reparseCommand.MarkFlagRequired("mongo-database")
+	reparseCommand.MarkFlagRequired("p2p-network")
+	reparseCommand.MarkFlagRequired("vaa-payload-parser-url")
+
+	root.AddCommand(reparseCommand)
+}
@@ -0,0 +1,223 @@
+package reparse
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/alert"
+	vaaPayloadParser "github.com/wormhole-foundation/wormhole-explorer/common/client/parser"
+	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	"github.com/wormhole-foundation/wormhole-explorer/parser/config"
+	"github.com/wormhole-foundation/wormhole-explorer/parser/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/parser/parser"
+	"github.com/wormhole-foundation/wormhole-explorer/parser/payload"
+	"github.com/wormhole-foundation/wormhole-explorer/parser/processor"
+	"go.uber.org/zap"
+)
+
+// report accumulates the result of a reparse.
+type report struct {
+	sync.Mutex
+	processed     int
+	changed       int
+	unparsed      int
+	failed        int
+	changedFields map[string]int
+}
+
+func (r *report) add(fields []string) {
+	r.Lock()
+	defer r.Unlock()
+	r.processed++
+	if len(fields) > 0 {
+		r.changed++
+	}
+	for _, f := range fields {
+		r.changedFields[f]++
+	}
+}
+
+func (r *report) addUnparsed() {
+	r.Lock()
+	defer r.Unlock()
+	r.processed++
+	r.unparsed++
+}
+
+func (r *report) addFailed() {
+	r.Lock()
+	defer r.Unlock()
+	r.processed++
+	r.failed++
+}
+
+func Run(config *config.ReparseConfiguration) {
+
+	rootCtx := context.Background()
+
+	logger := logger.New("wormhole-explorer-parser", logger.WithLevel(config.LogLevel))
+
+	logger.Info("Starting wormhole-explorer-parser as reparse ...")
+
+	query := parser.ParsedVaaQuery{
+		EmitterChainID:   config.EmitterChainID,
+		EmitterAddress:   config.EmitterAddress,
+		AppID:            config.AppID,
+		ParserVersion:    config.ParserVersion,
+		TransformVersion: config.TransformVersion,
+	}
+
+	if config.StartTime != "" {
+		startTime, err := time.Parse(time.RFC3339, config.StartTime)
+		if err != nil {
+			logger.Fatal("Failed to parse start time", zap.Error(err))
+		}
+		query.StartTime = &startTime
+	}
+	if config.EndTime != "" {
+		endTime, err := time.Parse(time.RFC3339, config.EndTime)
+		if err != nil {
+			logger.Fatal("Failed to parse end time", zap.Error(err))
+		}
+		query.EndTime = &endTime
+	}
+
+	if config.Outdated {
+		parserVersions := []string{processor.ParserVersionVaaPayloadParser}
+		if config.NativeParserEnabled {
+			parserVersions = append(parserVersions, processor.ParserVersionNative)
+		}
+		query.Outdated = &parser.Versions{
+			ParserVersions:   parserVersions,
+			TransformVersion: processor.TransformVersion,
+		}
+	}
+
+	//setup DB connection
+	db, err := dbutil.Connect(rootCtx, logger, config.MongoURI, config.MongoDatabase, false)
+	if err != nil {
+		logger.Fatal("Failed to connect MongoDB", zap.Error(err))
+	}
+
+	parserVAAAPIClient, err := vaaPayloadParser.NewParserVAAAPIClient(config.VaaPayloadParserTimeout, config.VaaPayloadParserURL, logger)
+	if err != nil {
+		logger.Fatal("Failed to create parse vaa api client")
+	}
+
+	parserRepository := parser.NewRepository(db.Database, logger)
+	vaaRepository := repository.NewVaaRepository(db.Database, logger)
+
+	// create a token provider
+	tokenProvider := domain.NewTokenProvider(config.P2pNetwork)
+
+	//create a processor
+	var nativeParser *payload.Parser
+	if config.NativeParserEnabled {
+		nativeParser = payload.New(domain.NewProtocolRegistry(config.P2pNetwork))
+	}
+	eventProcessor := processor.New(nativeParser, parserVAAAPIClient, parserRepository, alert.NewDummyClient(), metrics.NewDummyMetrics(), tokenProvider, logger)
+
+	logger.Info("Started wormhole-explorer-parser as reparse", zap.Bool("dryRun", config.DryRun), zap.Int("workers", config.Workers))
+
+	result := &report{changedFields: make(map[string]int)}
+	parsedVaas := make(chan parser.ParsedVaaUpdate, config.PageSize)
+
+	// start the workers.
+	var wg sync.WaitGroup
+	for i := 0; i < config.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for stored := range parsedVaas {
+				reparse(rootCtx, logger, vaaRepository, eventProcessor, config.DryRun, &stored, result)
+			}
+		}()
+	}
+
+	// send the selected parsed VAAs to the workers.
+	var lastID string
+	for {
+		page, err := parserRepository.FindPage(rootCtx, query, lastID, config.PageSize)
+		if err != nil {
+			logger.Error("Failed to get parsed vaas", zap.Error(err))
+			break
+		}
+		if len(page) == 0 {
+			break
+		}
+		for _, v := range page {
+			parsedVaas <- v
+		}
+		lastID = page[len(page)-1].ID
+		logger.Info("Processing page", zap.String("lastId", lastID), zap.Int("size", len(page)))
+	}
+	close(parsedVaas)
+	wg.Wait()
+
+	fields := make([]string, 0, len(result.changedFields))
+	for f := range result.changedFields {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	for _, f := range fields {
+		logger.Info("Changed field", zap.String("field", f), zap.Int("count", result.changedFields[f]))
+	}
+	logger.Info("Reparse summary",
+		zap.Bool("dryRun", config.DryRun),
+		zap.Int("processed", result.processed),
+		zap.Int("changed", result.changed),
+		zap.Int("unparsed", result.unparsed),
+		zap.Int("failed", result.failed))
+
+	logger.Info("closing MongoDB connection...")
+	db.DisconnectWithTimeout(10 * time.Second)
+
+	logger.Info("Finish wormhole-explorer-parser as reparse")
+}
+
+// reparse parses a stored VAA again and reports the fields that changed.
+// The new result is only persisted when dryRun is false.
+func reparse(ctx context.Context, logger *zap.Logger, vaaRepository *repository.VaaRepository, eventProcessor *processor.Processor,
+	dryRun bool, stored *parser.ParsedVaaUpdate, result *report) {
+
+	v, err := vaaRepository.FindById(ctx, stored.ID)
+	if err != nil {
+		logger.Error("Failed to get vaa", zap.String("id", stored.ID), zap.Error(err))
+		result.addFailed()
+		return
+	}
+
+	p := &processor.Params{Vaa: v.Vaa, TrackID: fmt.Sprintf("reparse-%s", v.ID)}
+	var parsed *parser.ParsedVaaUpdate
+	if dryRun {
+		parsed, err = eventProcessor.Parse(ctx, p)
+	} else {
+		parsed, err = eventProcessor.Process(ctx, p)
+	}
+	if err != nil {
+		logger.Error("Failed to process vaa", zap.String("id", v.ID), zap.Error(err))
+		result.addFailed()
+		return
+	}
+	if parsed == nil {
+		result.addUnparsed()
+		return
+	}
+
+	fields, err := parser.ChangedFields(stored, parsed)
+	if err != nil {
+		logger.Error("Failed to compare parsed vaa", zap.String("id", v.ID), zap.Error(err))
+		result.addFailed()
+		return
+	}
+	if len(fields) > 0 {
+		logger.Info("Parsed vaa changed", zap.String("id", v.ID), zap.Strings("fields", fields))
+	}
+	result.add(fields)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/parser/internal/sqs"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/migration"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/parser"
+	"github.com/wormhole-foundation/wormhole-explorer/parser/payload"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/processor"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/queue"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -104,7 +105,7 @@ func Run() {
 	tokenProvider := domain.NewTokenProvider(config.P2pNetwork)
 
 	//create a processor
-	processor := processor.New(parserVAAAPIClient, repository, alertClient, metrics, tokenProvider, logger)
+	processor := processor.New(newNativeParser(config), parserVAAAPIClient, repository, alertClient, metrics, tokenProvider, logger)
 
 	// create and start a vaaConsumer
 	vaaConsumer := consumer.New(vaaConsumeFunc, processor.Process, metrics, logger)
@@ -220,6 +221,14 @@ func newMetrics(cfg *config.ServiceConfiguration) metrics.Metrics {
 	return metrics.NewPrometheusMetrics(cfg.Environment)
 }
 
+// Creates a native payload parser if it is enabled, otherwise all the VAAs are parsed by the vaa-payload-parser.
+func newNativeParser(cfg *config.ServiceConfiguration) *payload.Parser {
+	if !cfg.NativeParserEnabled {
+		return nil
+	}
+	return payload.New(domain.NewProtocolRegistry(cfg.P2pNetwork))
+}
+
 func newAlertClient(cfg *config.ServiceConfiguration) (alert.AlertClient, error) {
 	if !cfg.AlertEnabled {
 		return alert.NewDummyClient(), nil
@@ -31,6 +31,7 @@ type ServiceConfiguration struct {
 	NotificationsSQSUrl     string `env:"NOTIFICATIONS_SQS_URL"`
 	VaaPayloadParserURL     string `env:"VAA_PAYLOAD_PARSER_URL, required"`
 	VaaPayloadParserTimeout int64  `env:"VAA_PAYLOAD_PARSER_TIMEOUT, required"`
+	NativeParserEnabled     bool   `env:"NATIVE_PARSER_ENABLED,default=true"`
 	PprofEnabled            bool   `env:"PPROF_ENABLED,default=false"`
 	P2pNetwork              string `env:"P2P_NETWORK,required"`
 	AlertEnabled            bool   `env:"ALERT_ENABLED,default=false"`
@@ -50,6 +51,7 @@ type BackfillerConfiguration struct {
 	NotionalUrl             string
 	VaaPayloadParserURL     string
 	VaaPayloadParserTimeout int64
+	NativeParserEnabled     bool
 	StartTime               string
 	EndTime                 string
 	EmitterChainID          *sdk.ChainID
@@ -58,6 +60,28 @@ type BackfillerConfiguration struct {
 	SortAsc                 bool
 }
 
+// ReparseConfiguration represents the application configuration when running as reparse with default values.
+type ReparseConfiguration struct {
+	LogLevel                string
+	MongoURI                string
+	MongoDatabase           string
+	P2pNetwork              string
+	VaaPayloadParserURL     string
+	VaaPayloadParserTimeout int64
+	NativeParserEnabled     bool
+	StartTime               string
+	EndTime                 string
+	EmitterChainID          *sdk.ChainID
+	EmitterAddress          *string
+	AppID                   *string
+	ParserVersion           *string
+	TransformVersion        *int
+	Outdated                bool
+	PageSize                int64
+	Workers                 int
+	DryRun                  bool
+}
+
 // New creates a configuration with the values from .env file and environment variables.
 func New(ctx context.Context) (*ServiceConfiguration, error) {
 	_ = godotenv.Load(".env", "../.env")
@@ -36,6 +36,15 @@ func (d *DummyMetrics) IncVaaPayloadParserSuccessCount(chainID uint16) {}
 // IncVaaPayloadParserSuccessCount increments the number of vaa payload parser success.
 func (d *DummyMetrics) IncVaaPayloadParserNotFoundCount(chainID uint16) {}
 
+// IncVaaNativeParserSuccessCount increments the number of vaa parsed by the native parser.
+func (d *DummyMetrics) IncVaaNativeParserSuccessCount(chainID uint16) {}
+
+// IncVaaNativeParserErrorCount increments the number of vaa the native parser failed to parse.
+func (d *DummyMetrics) IncVaaNativeParserErrorCount(chainID uint16) {}
+
+// IncVaaNativeParserUnknownCount increments the number of vaa not supported by the native parser.
+func (d *DummyMetrics) IncVaaNativeParserUnknownCount(chainID uint16) {}
+
 // IncExpiredMessage increments the number of expired message.
 func (p *DummyMetrics) IncExpiredMessage(chain, source string) {}
 
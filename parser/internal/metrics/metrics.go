@@ -13,6 +13,10 @@ type Metrics interface {
 	IncVaaPayloadParserNotFoundCount(chainID uint16)
 	IncVaaPayloadParserSuccessCount(chainID uint16)
 
+	IncVaaNativeParserSuccessCount(chainID uint16)
+	IncVaaNativeParserErrorCount(chainID uint16)
+	IncVaaNativeParserUnknownCount(chainID uint16)
+
 	IncExpiredMessage(chain, source string)
 	IncUnprocessedMessage(chain, source string)
 	IncProcessedMessage(chain, source string)
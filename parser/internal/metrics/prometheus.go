@@ -11,6 +11,7 @@ type PrometheusMetrics struct {
 	vaaParseCount                 *prometheus.CounterVec
 	vaaPayloadParserRequest       *prometheus.CounterVec
 	vaaPayloadParserResponseCount *prometheus.CounterVec
+	vaaNativeParserCount          *prometheus.CounterVec
 	processedMessage              *prometheus.CounterVec
 }
 
@@ -43,6 +44,15 @@ func NewPrometheusMetrics(environment string) *PrometheusMetrics {
 				"service":     serviceName,
 			},
 		}, []string{"chain", "status"})
+	vaaNativeParserCount := promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "parse_vaa_native_parser_count_by_chain",
+			Help: "Total number of vaa parsed by the native parser by chain",
+			ConstLabels: map[string]string{
+				"environment": environment,
+				"service":     serviceName,
+			},
+		}, []string{"chain", "status"})
 	processedMessage := promauto.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "processed_message",
@@ -58,6 +68,7 @@ func NewPrometheusMetrics(environment string) *PrometheusMetrics {
 		vaaParseCount:                 vaaParseCount,
 		vaaPayloadParserRequest:       vaaPayloadParserRequestCount,
 		vaaPayloadParserResponseCount: vaaPayloadParserResponseCount,
+		vaaNativeParserCount:          vaaNativeParserCount,
 		processedMessage:              processedMessage,
 	}
 }
@@ -116,6 +127,24 @@ func (m *PrometheusMetrics) IncVaaPayloadParserNotFoundCount(chainID uint16) {
 	m.vaaPayloadParserResponseCount.WithLabelValues(chain, "not_found").Inc()
 }
 
+// IncVaaNativeParserSuccessCount increments the number of vaa parsed by the native parser.
+func (m *PrometheusMetrics) IncVaaNativeParserSuccessCount(chainID uint16) {
+	chain := vaa.ChainID(chainID).String()
+	m.vaaNativeParserCount.WithLabelValues(chain, "success").Inc()
+}
+
+// IncVaaNativeParserErrorCount increments the number of vaa the native parser failed to parse.
+func (m *PrometheusMetrics) IncVaaNativeParserErrorCount(chainID uint16) {
+	chain := vaa.ChainID(chainID).String()
+	m.vaaNativeParserCount.WithLabelValues(chain, "failed").Inc()
+}
+
+// IncVaaNativeParserUnknownCount increments the number of vaa not supported by the native parser.
+func (m *PrometheusMetrics) IncVaaNativeParserUnknownCount(chainID uint16) {
+	chain := vaa.ChainID(chainID).String()
+	m.vaaNativeParserCount.WithLabelValues(chain, "unknown").Inc()
+}
+
 // IncExpiredMessage increments the number of expired message.
 func (p *PrometheusMetrics) IncExpiredMessage(chain, source string) {
 	p.processedMessage.WithLabelValues(chain, source, "expired").Inc()
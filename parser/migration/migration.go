@@ -24,6 +24,13 @@ func Run(db *mongo.Database) error {
 		return err
 	}
 
+	// create index in parsedVaa collection by parser and transform versions.
+	indexVersions := mongo.IndexModel{Keys: bson.D{{Key: "parserVersion", Value: 1}, {Key: "transformVersion", Value: 1}}}
+	_, err = db.Collection(parser.ParsedVAACollection).Indexes().CreateOne(context.TODO(), indexVersions)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
 	return nil
 }
 
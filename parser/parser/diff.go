@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"reflect"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ChangedFields returns the fields that differ between a stored parsed VAA and a new parse result.
+// The parsed payload and the standardized properties are compared field by field.
+func ChangedFields(stored, parsed *ParsedVaaUpdate) ([]string, error) {
+	var changed []string
+	fields := []struct {
+		name   string
+		stored interface{}
+		parsed interface{}
+	}{
+		{"appIds", stored.AppIDs, parsed.AppIDs},
+		{"parsedPayload", stored.ParsedPayload, parsed.ParsedPayload},
+		{"rawStandardizedProperties", stored.RawStandardizedProperties, parsed.RawStandardizedProperties},
+		{"standardizedProperties", stored.StandardizedProperties, parsed.StandardizedProperties},
+		{"parserVersion", stored.ParserVersion, parsed.ParserVersion},
+		{"transformVersion", stored.TransformVersion, parsed.TransformVersion},
+	}
+	for _, f := range fields {
+		a, err := normalize(f.stored)
+		if err != nil {
+			return nil, err
+		}
+		b, err := normalize(f.parsed)
+		if err != nil {
+			return nil, err
+		}
+		changed = append(changed, diff(f.name, a, b)...)
+	}
+	return changed, nil
+}
+
+// diff compares two normalized values. Documents are compared by their top level keys.
+func diff(name string, a, b interface{}) []string {
+	docA, okA := a.(map[string]interface{})
+	docB, okB := b.(map[string]interface{})
+	if !okA || !okB {
+		if reflect.DeepEqual(a, b) {
+			return nil
+		}
+		return []string{name}
+	}
+
+	keys := make(map[string]struct{}, len(docA)+len(docB))
+	for k := range docA {
+		keys[k] = struct{}{}
+	}
+	for k := range docB {
+		keys[k] = struct{}{}
+	}
+	var changed []string
+	for k := range keys {
+		if !reflect.DeepEqual(docA[k], docB[k]) {
+			changed = append(changed, name+"."+k)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// normalize converts a value to the representation it has once stored in the database,
+// so that a parse result can be compared with a decoded document.
+func normalize(v interface{}) (interface{}, error) {
+	data, err := bson.Marshal(bson.M{"v": v})
+	if err != nil {
+		return nil, err
+	}
+	var doc bson.D
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return toPlain(doc[0].Value), nil
+}
+
+func toPlain(v interface{}) interface{} {
+	switch t := v.(type) {
+	case primitive.D:
+		m := make(map[string]interface{}, len(t))
+		for _, e := range t {
+			m[e.Key] = toPlain(e.Value)
+		}
+		return m
+	case primitive.M:
+		m := make(map[string]interface{}, len(t))
+		for k, e := range t {
+			m[k] = toPlain(e)
+		}
+		return m
+	case primitive.A:
+		a := make([]interface{}, len(t))
+		for i, e := range t {
+			a[i] = toPlain(e)
+		}
+		return a
+	default:
+		return v
+	}
+}
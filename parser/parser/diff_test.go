@@ -0,0 +1,78 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vaaPayloadParser "github.com/wormhole-foundation/wormhole-explorer/common/client/parser"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestChangedFields(t *testing.T) {
+	stored := &ParsedVaaUpdate{
+		AppIDs: []string{"PORTAL_TOKEN_BRIDGE"},
+		ParsedPayload: primitive.D{
+			{Key: "payloadType", Value: int32(1)},
+			{Key: "amount", Value: "100"},
+			{Key: "toChain", Value: int32(2)},
+		},
+		StandardizedProperties: vaaPayloadParser.StandardizedProperties{
+			AppIds: []string{"PORTAL_TOKEN_BRIDGE"},
+			Amount: "100",
+		},
+	}
+	parsed := &ParsedVaaUpdate{
+		AppIDs: []string{"PORTAL_TOKEN_BRIDGE"},
+		ParsedPayload: map[string]interface{}{
+			"payloadType": 1,
+			"amount":      "100",
+			"toChain":     4,
+		},
+		StandardizedProperties: vaaPayloadParser.StandardizedProperties{
+			AppIds: []string{"PORTAL_TOKEN_BRIDGE"},
+			Amount: "10000",
+		},
+		ParserVersion:    "native-v1",
+		TransformVersion: 1,
+	}
+
+	changed, err := ChangedFields(stored, parsed)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"parsedPayload.toChain",
+		"standardizedProperties.amount",
+		"parserVersion",
+		"transformVersion",
+	}, changed)
+}
+
+func TestChangedFieldsUnchanged(t *testing.T) {
+	stored := &ParsedVaaUpdate{
+		AppIDs:           []string{"CCTP_WORMHOLE_INTEGRATION"},
+		ParsedPayload:    primitive.D{{Key: "amount", Value: "1"}, {Key: "nonce", Value: primitive.A{"a", "b"}}},
+		ParserVersion:    "native-v1",
+		TransformVersion: 1,
+	}
+	parsed := &ParsedVaaUpdate{
+		AppIDs:           []string{"CCTP_WORMHOLE_INTEGRATION"},
+		ParsedPayload:    map[string]interface{}{"nonce": []string{"a", "b"}, "amount": "1"},
+		ParserVersion:    "native-v1",
+		TransformVersion: 1,
+	}
+
+	changed, err := ChangedFields(stored, parsed)
+	assert.NoError(t, err)
+	assert.Empty(t, changed)
+}
+
+func TestParsedVaaQueryOutdated(t *testing.T) {
+	appID := "PORTAL_TOKEN_BRIDGE"
+	q := ParsedVaaQuery{
+		AppID:    &appID,
+		Outdated: &Versions{ParserVersions: []string{"native-v1"}, TransformVersion: 1},
+	}
+	filter := q.toFilter()
+	assert.Len(t, filter, 2)
+	assert.Equal(t, "appIds", filter[0].Key)
+	assert.Equal(t, "$or", filter[1].Key)
+}
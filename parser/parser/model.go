@@ -17,6 +17,8 @@ type ParsedVaaUpdate struct {
 	ParsedPayload             interface{}                             `bson:"parsedPayload" json:"parsedPayload"`
 	RawStandardizedProperties vaaPayloadParser.StandardizedProperties `bson:"rawStandardizedProperties" json:"rawStandardizedProperties"`
 	StandardizedProperties    vaaPayloadParser.StandardizedProperties `bson:"standardizedProperties" json:"standardizedProperties"`
+	ParserVersion             string                                  `bson:"parserVersion" json:"parserVersion"`
+	TransformVersion          int                                     `bson:"transformVersion" json:"transformVersion"`
 	UpdatedAt                 *time.Time                              `bson:"updatedAt" json:"updatedAt"`
 	Timestamp                 time.Time                               `bson:"timestamp" json:"timestamp"`
 }
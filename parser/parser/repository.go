@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -48,6 +49,87 @@ func (s *Repository) UpsertParsedVaa(ctx context.Context, parsedVAA ParsedVaaUpd
 	return err
 }
 
+// ParsedVaaQuery is a query for parsed VAAs.
+type ParsedVaaQuery struct {
+	StartTime      *time.Time
+	EndTime        *time.Time
+	EmitterChainID *sdk.ChainID
+	EmitterAddress *string
+	AppID          *string
+	// ParserVersion selects the documents parsed with a parser version.
+	// An empty version selects the documents without version.
+	ParserVersion *string
+	// TransformVersion selects the documents with a standardized properties transform version.
+	TransformVersion *int
+	// Outdated selects the documents whose versions differ from the given ones.
+	Outdated *Versions
+}
+
+// Versions are the parser and transform versions of the current parsing rules.
+type Versions struct {
+	ParserVersions   []string
+	TransformVersion int
+}
+
+func (q *ParsedVaaQuery) toFilter() bson.D {
+	filter := bson.D{}
+
+	if q.StartTime != nil || q.EndTime != nil {
+		rangeTimestamp := bson.M{}
+		if q.StartTime != nil {
+			rangeTimestamp["$gte"] = q.StartTime
+		}
+		if q.EndTime != nil {
+			rangeTimestamp["$lt"] = q.EndTime
+		}
+		filter = append(filter, bson.E{Key: "timestamp", Value: rangeTimestamp})
+	}
+	if q.EmitterChainID != nil {
+		filter = append(filter, bson.E{Key: "emitterChain", Value: q.EmitterChainID})
+	}
+	if q.EmitterAddress != nil {
+		filter = append(filter, bson.E{Key: "emitterAddr", Value: q.EmitterAddress})
+	}
+	if q.AppID != nil {
+		filter = append(filter, bson.E{Key: "appIds", Value: q.AppID})
+	}
+	if q.ParserVersion != nil {
+		if *q.ParserVersion == "" {
+			filter = append(filter, bson.E{Key: "parserVersion", Value: bson.M{"$exists": false}})
+		} else {
+			filter = append(filter, bson.E{Key: "parserVersion", Value: q.ParserVersion})
+		}
+	}
+	if q.TransformVersion != nil {
+		filter = append(filter, bson.E{Key: "transformVersion", Value: q.TransformVersion})
+	}
+	if q.Outdated != nil {
+		filter = append(filter, bson.E{Key: "$or", Value: bson.A{
+			bson.M{"parserVersion": bson.M{"$nin": q.Outdated.ParserVersions}},
+			bson.M{"transformVersion": bson.M{"$ne": q.Outdated.TransformVersion}},
+		}})
+	}
+	return filter
+}
+
+// FindPage finds the parsed VAAs matching the query with an id greater than lastID, sorted by id.
+// Paginating by id keeps the pages consistent while the returned documents are updated.
+func (s *Repository) FindPage(ctx context.Context, query ParsedVaaQuery, lastID string, pageSize int64) ([]ParsedVaaUpdate, error) {
+	filter := query.toFilter()
+	if lastID != "" {
+		filter = append(filter, bson.E{Key: "_id", Value: bson.M{"$gt": lastID}})
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(pageSize)
+	cur, err := s.collections.parsedVaa.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var parsedVaas []ParsedVaaUpdate
+	err = cur.All(ctx, &parsedVaas)
+	return parsedVaas, err
+}
+
 func indexedAt(t time.Time) IndexingTimestamps {
 	return IndexingTimestamps{
 		IndexedAt: t,
@@ -0,0 +1,74 @@
+package payload
+
+import (
+	vaaPayloadParser "github.com/wormhole-foundation/wormhole-explorer/common/client/parser"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+const cctpDeposit = 1
+
+// cctpDomains maps the circle domains to wormhole chain IDs.
+var cctpDomains = map[uint32]vaa.ChainID{
+	0: vaa.ChainIDEthereum,
+	1: vaa.ChainIDAvalanche,
+	2: vaa.ChainIDOptimism,
+	3: vaa.ChainIDArbitrum,
+	5: vaa.ChainIDSolana,
+	6: vaa.ChainIDBase,
+	7: vaa.ChainIDPolygon,
+}
+
+// CCTPDeposit is a deposit with payload of the wormhole CCTP integration (payload type 1).
+type CCTPDeposit struct {
+	PayloadType   uint8  `json:"payloadType" bson:"payloadType"`
+	TokenAddress  string `json:"tokenAddress" bson:"tokenAddress"`
+	Amount        string `json:"amount" bson:"amount"`
+	SourceDomain  uint32 `json:"sourceDomain" bson:"sourceDomain"`
+	TargetDomain  uint32 `json:"targetDomain" bson:"targetDomain"`
+	Nonce         string `json:"nonce" bson:"nonce"`
+	FromAddress   string `json:"fromAddress" bson:"fromAddress"`
+	MintRecipient string `json:"mintRecipient" bson:"mintRecipient"`
+	Payload       string `json:"payload" bson:"payload"`
+}
+
+func parseCCTP(v *vaa.VAA) (*vaaPayloadParser.ParseVaaWithStandarizedPropertiesdResponse, error) {
+	if payloadType := firstByte(v.Payload); payloadType != cctpDeposit {
+		return nil, unknownPayloadType(v, payloadType)
+	}
+
+	r := newReader(v.Payload)
+	p := CCTPDeposit{PayloadType: r.uint8()}
+	tokenAddress := r.address()
+	p.TokenAddress = toHex(tokenAddress.Bytes())
+	p.Amount = r.uint256()
+	p.SourceDomain = r.uint32()
+	p.TargetDomain = r.uint32()
+	p.Nonce = formatUint(r.uint64())
+	fromAddress := r.address()
+	p.FromAddress = toHex(fromAddress.Bytes())
+	mintRecipient := r.address()
+	p.MintRecipient = toHex(mintRecipient.Bytes())
+	p.Payload = toHex(r.bytes16())
+	if r.err != nil {
+		return nil, payloadError(v, r.err)
+	}
+
+	sp := vaaPayloadParser.StandardizedProperties{
+		AppIds:       []string{domain.AppIdCCTP},
+		FromChain:    v.EmitterChain,
+		FromAddress:  nativeAddress(v.EmitterChain, fromAddress),
+		TokenChain:   v.EmitterChain,
+		TokenAddress: nativeAddress(v.EmitterChain, tokenAddress),
+		Amount:       p.Amount,
+	}
+	if toChain, ok := cctpDomains[p.TargetDomain]; ok {
+		sp.ToChain = toChain
+		sp.ToAddress = nativeAddress(toChain, mintRecipient)
+	}
+
+	return &vaaPayloadParser.ParseVaaWithStandarizedPropertiesdResponse{
+		ParsedPayload:          p,
+		StandardizedProperties: sp,
+	}, nil
+}
@@ -0,0 +1,56 @@
+package payload
+
+import (
+	vaaPayloadParser "github.com/wormhole-foundation/wormhole-explorer/common/client/parser"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+const nftBridgeTransfer = 1
+
+// NftBridgeTransfer is a NFT bridge transfer (payload type 1).
+type NftBridgeTransfer struct {
+	PayloadType  uint8       `json:"payloadType" bson:"payloadType"`
+	TokenAddress string      `json:"tokenAddress" bson:"tokenAddress"`
+	TokenChain   vaa.ChainID `json:"tokenChain" bson:"tokenChain"`
+	Symbol       string      `json:"symbol" bson:"symbol"`
+	Name         string      `json:"name" bson:"name"`
+	TokenID      string      `json:"tokenId" bson:"tokenId"`
+	URI          string      `json:"uri" bson:"uri"`
+	ToAddress    string      `json:"toAddress" bson:"toAddress"`
+	ToChain      vaa.ChainID `json:"toChain" bson:"toChain"`
+}
+
+func parseNftBridge(v *vaa.VAA) (*vaaPayloadParser.ParseVaaWithStandarizedPropertiesdResponse, error) {
+	if payloadType := firstByte(v.Payload); payloadType != nftBridgeTransfer {
+		return nil, unknownPayloadType(v, payloadType)
+	}
+
+	r := newReader(v.Payload)
+	p := NftBridgeTransfer{PayloadType: r.uint8()}
+	tokenAddress := r.address()
+	p.TokenAddress = toHex(tokenAddress.Bytes())
+	p.TokenChain = r.chainID()
+	p.Symbol = r.fixedString()
+	p.Name = r.fixedString()
+	p.TokenID = r.uint256()
+	p.URI = string(r.bytes8())
+	toAddress := r.address()
+	p.ToAddress = toHex(toAddress.Bytes())
+	p.ToChain = r.chainID()
+	if r.err != nil {
+		return nil, payloadError(v, r.err)
+	}
+
+	return &vaaPayloadParser.ParseVaaWithStandarizedPropertiesdResponse{
+		ParsedPayload: p,
+		StandardizedProperties: vaaPayloadParser.StandardizedProperties{
+			AppIds:       []string{domain.AppIdPortalNftBridge},
+			FromChain:    v.EmitterChain,
+			ToChain:      p.ToChain,
+			ToAddress:    nativeAddress(p.ToChain, toAddress),
+			TokenChain:   p.TokenChain,
+			TokenAddress: nativeAddress(p.TokenChain, tokenAddress),
+		},
+	}, nil
+}
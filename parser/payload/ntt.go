@@ -0,0 +1,83 @@
+package payload
+
+import (
+	"bytes"
+
+	vaaPayloadParser "github.com/wormhole-foundation/wormhole-explorer/common/client/parser"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+var (
+	// wormholeTransceiverPrefix is the prefix of the messages sent by the NTT wormhole transceivers.
+	wormholeTransceiverPrefix = []byte{0x99, 0x45, 0xff, 0x10}
+	// nativeTokenTransferPrefix is the prefix of the NTT manager token transfer payloads.
+	nativeTokenTransferPrefix = []byte{0x99, 0x4e, 0x54, 0x54}
+)
+
+// NativeTokenTransfer is a token transfer sent through a NTT wormhole transceiver.
+type NativeTokenTransfer struct {
+	SourceNttManager    string      `json:"sourceNttManager" bson:"sourceNttManager"`
+	RecipientNttManager string      `json:"recipientNttManager" bson:"recipientNttManager"`
+	MessageID           string      `json:"messageId" bson:"messageId"`
+	Sender              string      `json:"sender" bson:"sender"`
+	Decimals            uint8       `json:"decimals" bson:"decimals"`
+	Amount              string      `json:"amount" bson:"amount"`
+	SourceToken         string      `json:"sourceToken" bson:"sourceToken"`
+	ToAddress           string      `json:"toAddress" bson:"toAddress"`
+	ToChain             vaa.ChainID `json:"toChain" bson:"toChain"`
+}
+
+func parseNativeTokenTransfer(v *vaa.VAA) (*vaaPayloadParser.ParseVaaWithStandarizedPropertiesdResponse, error) {
+	r := newReader(v.Payload)
+	r.next(len(wormholeTransceiverPrefix))
+	p := NativeTokenTransfer{
+		SourceNttManager:    toHex(r.next(32)),
+		RecipientNttManager: toHex(r.next(32)),
+	}
+	managerPayload := r.bytes16()
+	if r.err != nil {
+		return nil, payloadError(v, r.err)
+	}
+
+	// decode the NTT manager message.
+	m := newReader(managerPayload)
+	p.MessageID = toHex(m.next(32))
+	sender := m.address()
+	p.Sender = toHex(sender.Bytes())
+	transfer := m.bytes16()
+	if m.err != nil {
+		return nil, payloadError(v, m.err)
+	}
+	if !bytes.HasPrefix(transfer, nativeTokenTransferPrefix) {
+		return nil, payloadError(v, ErrUnknownPayload)
+	}
+
+	// decode the token transfer. The amount is trimmed to the given decimals.
+	t := newReader(transfer)
+	t.next(len(nativeTokenTransferPrefix))
+	p.Decimals = t.uint8()
+	p.Amount = formatUint(t.uint64())
+	sourceToken := t.address()
+	p.SourceToken = toHex(sourceToken.Bytes())
+	toAddress := t.address()
+	p.ToAddress = toHex(toAddress.Bytes())
+	p.ToChain = t.chainID()
+	if t.err != nil {
+		return nil, payloadError(v, t.err)
+	}
+
+	return &vaaPayloadParser.ParseVaaWithStandarizedPropertiesdResponse{
+		ParsedPayload: p,
+		StandardizedProperties: vaaPayloadParser.StandardizedProperties{
+			AppIds:       []string{domain.AppIdNativeTokenTransfer},
+			FromChain:    v.EmitterChain,
+			FromAddress:  nativeAddress(v.EmitterChain, sender),
+			ToChain:      p.ToChain,
+			ToAddress:    nativeAddress(p.ToChain, toAddress),
+			TokenChain:   v.EmitterChain,
+			TokenAddress: nativeAddress(v.EmitterChain, sourceToken),
+			Amount:       p.Amount,
+		},
+	}, nil
+}
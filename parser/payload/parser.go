@@ -0,0 +1,98 @@
+// Package payload implements in-process decoders for the payloads of the core Wormhole protocols.
+//
+// The decoders produce the same parsed payload and standardized properties as the vaa-payload-parser
+// service, so they can be used in its place for the known protocols.
+package payload
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	vaaPayloadParser "github.com/wormhole-foundation/wormhole-explorer/common/client/parser"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// Version is the version of the decoding rules of the native parser.
+// It must be increased every time the output of a decoder changes, so that the parsed VAAs can be re-parsed.
+const Version = 2
+
+// ErrUnknownEmitter is returned when the VAA emitter is not handled by any native decoder.
+var ErrUnknownEmitter = errors.New("unknown emitter")
+
+// ErrUnknownPayload is returned when the payload type of a known emitter is not supported.
+var ErrUnknownPayload = errors.New("unknown payload")
+
+// ErrUnknownApp is returned when the app of a VAA can only be identified by the vaa-payload-parser.
+var ErrUnknownApp = errors.New("unknown app")
+
+// Parser decodes the payloads of the VAAs emitted by the token bridge, the NFT bridge, the generic
+// relayer, the CCTP wormhole integration and the native token transfers (NTT) transceivers.
+type Parser struct {
+	registry *domain.ProtocolRegistry
+}
+
+// New creates a new Parser that identifies the protocol of a VAA emitter with the given registry.
+func New(registry *domain.ProtocolRegistry) *Parser {
+	return &Parser{registry: registry}
+}
+
+// ParseVaaWithStandarizedProperties decodes the payload of a VAA.
+// It returns ErrUnknownEmitter if the VAA was not emitted by a supported protocol, and ErrUnknownApp if the
+// VAA is a token bridge transfer with payload, whose app depends on the receiving contract.
+func (p *Parser) ParseVaaWithStandarizedProperties(v *vaa.VAA) (*vaaPayloadParser.ParseVaaWithStandarizedPropertiesdResponse, error) {
+	protocol, ok := p.registry.GetProtocolByEmitter(v.EmitterChain, v.EmitterAddress.String())
+	if !ok {
+		// NTT transceivers are deployed per token, so they are identified by the payload prefix.
+		if bytes.HasPrefix(v.Payload, wormholeTransceiverPrefix) {
+			return parseNativeTokenTransfer(v)
+		}
+		return nil, ErrUnknownEmitter
+	}
+
+	switch protocol.PayloadType {
+	case domain.PayloadTypeTokenBridge:
+		return parseTokenBridge(v)
+	case domain.PayloadTypeNftBridge:
+		return parseNftBridge(v)
+	case domain.PayloadTypeGenericRelayer:
+		return parseGenericRelayer(v)
+	case domain.PayloadTypeCCTP:
+		return parseCCTP(v)
+	case domain.PayloadTypeNTT:
+		return parseNativeTokenTransfer(v)
+	default:
+		return nil, ErrUnknownEmitter
+	}
+}
+
+// nativeAddress converts a wormhole address to the native address format of a chain.
+// If the address cannot be converted, the 0x-prefixed hex representation is returned.
+func nativeAddress(chainID vaa.ChainID, addr vaa.Address) string {
+	native, err := domain.TranslateEmitterAddress(chainID, addr.String())
+	if err != nil {
+		return toHex(addr.Bytes())
+	}
+	return native
+}
+
+func payloadError(v *vaa.VAA, err error) error {
+	return fmt.Errorf("failed to parse payload of vaa %s: %w", v.MessageID(), err)
+}
+
+func unknownPayloadType(v *vaa.VAA, payloadType uint8) error {
+	return fmt.Errorf("%w: vaa %s has payload type %d", ErrUnknownPayload, v.MessageID(), payloadType)
+}
+
+func firstByte(payload []byte) uint8 {
+	if len(payload) == 0 {
+		return 0
+	}
+	return payload[0]
+}
+
+func isZero(s string) bool {
+	return strings.Trim(s, "0") == ""
+}
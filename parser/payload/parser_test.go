@@ -0,0 +1,235 @@
+package payload
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	sdk "github.com/wormhole-foundation/wormhole/sdk"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// payloadBuilder builds big-endian encoded payloads.
+type payloadBuilder []byte
+
+func (b payloadBuilder) u8(v uint8) payloadBuilder { return append(b, v) }
+
+func (b payloadBuilder) u16(v uint16) payloadBuilder { return binary.BigEndian.AppendUint16(b, v) }
+
+func (b payloadBuilder) u32(v uint32) payloadBuilder { return binary.BigEndian.AppendUint32(b, v) }
+
+func (b payloadBuilder) u64(v uint64) payloadBuilder { return binary.BigEndian.AppendUint64(b, v) }
+
+func (b payloadBuilder) u256(v int64) payloadBuilder {
+	return append(b, big.NewInt(v).FillBytes(make([]byte, 32))...)
+}
+
+func (b payloadBuilder) raw(v []byte) payloadBuilder { return append(b, v...) }
+
+func (b payloadBuilder) address(s string) payloadBuilder {
+	addr, _ := vaa.StringToAddress(s)
+	return append(b, addr.Bytes()...)
+}
+
+func (b payloadBuilder) fixedString(s string) payloadBuilder {
+	out := make([]byte, 32)
+	copy(out, s)
+	return append(b, out...)
+}
+
+func emitter(emitters map[vaa.ChainID][]byte, chainID vaa.ChainID) vaa.Address {
+	var addr vaa.Address
+	e := emitters[chainID]
+	copy(addr[32-len(e):], e)
+	return addr
+}
+
+func newVaa(chainID vaa.ChainID, emitterAddress vaa.Address, payload []byte) *vaa.VAA {
+	return &vaa.VAA{EmitterChain: chainID, EmitterAddress: emitterAddress, Sequence: 1, Payload: payload}
+}
+
+const (
+	usdtAddress = "000000000000000000000000dac17f958d2ee523a2206206994597c13d831ec7"
+	toAddress   = "0000000000000000000000000ff664edd699bd85610c2782d9dbbbad704b6fc5"
+	fromAddress = "000000000000000000000000e9d87dd072b0bce6aa9335d590cfb0342870d7b0"
+)
+
+func TestParseTokenBridgeTransfer(t *testing.T) {
+	payload := payloadBuilder{}.u8(1).u256(10000000).address(usdtAddress).u16(2).address(toAddress).u16(5).u256(0)
+	v := newVaa(vaa.ChainIDEthereum, emitter(sdk.KnownTokenbridgeEmitters, vaa.ChainIDEthereum), payload)
+
+	result, err := New(domain.NewProtocolRegistry(domain.P2pMainNet)).ParseVaaWithStandarizedProperties(v)
+
+	assert.NoError(t, err)
+	p := result.ParsedPayload.(TokenBridgeTransfer)
+	assert.Equal(t, uint8(1), p.PayloadType)
+	assert.Equal(t, "10000000", p.Amount)
+	assert.Equal(t, "0x"+usdtAddress, p.TokenAddress)
+	assert.Equal(t, vaa.ChainIDPolygon, p.ToChain)
+
+	sp := result.StandardizedProperties
+	assert.Equal(t, []string{domain.AppIdPortalTokenBridge}, sp.AppIds)
+	assert.Equal(t, vaa.ChainIDEthereum, sp.FromChain)
+	assert.Equal(t, vaa.ChainIDPolygon, sp.ToChain)
+	assert.Equal(t, "0x0ff664edd699bd85610c2782d9dbbbad704b6fc5", sp.ToAddress)
+	assert.Equal(t, vaa.ChainIDEthereum, sp.TokenChain)
+	assert.Equal(t, "0xdac17f958d2ee523a2206206994597c13d831ec7", sp.TokenAddress)
+	assert.Equal(t, "10000000", sp.Amount)
+	assert.Equal(t, "", sp.Fee)
+}
+
+func TestParseTokenBridgeTransferWithPayload(t *testing.T) {
+	payload := payloadBuilder{}.u8(3).u256(500).address(usdtAddress).u16(2).address(toAddress).u16(6).
+		address(fromAddress)
+	v := newVaa(vaa.ChainIDEthereum, emitter(sdk.KnownTokenbridgeEmitters, vaa.ChainIDEthereum), payload)
+
+	result, err := New(domain.NewProtocolRegistry(domain.P2pMainNet)).ParseVaaWithStandarizedProperties(v)
+
+	assert.NoError(t, err)
+	p := result.ParsedPayload.(TokenBridgeTransfer)
+	assert.Equal(t, uint8(3), p.PayloadType)
+	assert.Equal(t, "0x", p.Payload)
+	assert.Equal(t, []string{domain.AppIdPortalTokenBridge}, result.StandardizedProperties.AppIds)
+	assert.Equal(t, "0xe9d87dd072b0bce6aa9335d590cfb0342870d7b0", result.StandardizedProperties.FromAddress)
+	assert.Equal(t, vaa.ChainIDAvalanche, result.StandardizedProperties.ToChain)
+}
+
+func TestParseTokenBridgeTransferWithPayloadUnknownApp(t *testing.T) {
+	payload := payloadBuilder{}.u8(3).u256(500).address(usdtAddress).u16(2).address(toAddress).u16(6).
+		address(fromAddress).raw([]byte{0xca, 0xfe})
+	v := newVaa(vaa.ChainIDEthereum, emitter(sdk.KnownTokenbridgeEmitters, vaa.ChainIDEthereum), payload)
+
+	_, err := New(domain.NewProtocolRegistry(domain.P2pMainNet)).ParseVaaWithStandarizedProperties(v)
+
+	// the app of the transfers with payload depends on the receiving contract, so they are parsed by the vaa-payload-parser.
+	assert.ErrorIs(t, err, ErrUnknownApp)
+}
+
+func TestParseTokenBridgeAttestation(t *testing.T) {
+	payload := payloadBuilder{}.u8(2).address(usdtAddress).u16(2).u8(6).fixedString("USDT").fixedString("Tether USD")
+	v := newVaa(vaa.ChainIDEthereum, emitter(sdk.KnownTokenbridgeEmitters, vaa.ChainIDEthereum), payload)
+
+	result, err := New(domain.NewProtocolRegistry(domain.P2pMainNet)).ParseVaaWithStandarizedProperties(v)
+
+	assert.NoError(t, err)
+	p := result.ParsedPayload.(TokenBridgeAttestation)
+	assert.Equal(t, uint8(6), p.Decimals)
+	assert.Equal(t, "USDT", p.Symbol)
+	assert.Equal(t, "Tether USD", p.Name)
+	assert.Equal(t, "0xdac17f958d2ee523a2206206994597c13d831ec7", result.StandardizedProperties.TokenAddress)
+}
+
+func TestParseTokenBridgeMalformedPayload(t *testing.T) {
+	payload := payloadBuilder{}.u8(1).u256(10000000)
+	v := newVaa(vaa.ChainIDEthereum, emitter(sdk.KnownTokenbridgeEmitters, vaa.ChainIDEthereum), payload)
+
+	_, err := New(domain.NewProtocolRegistry(domain.P2pMainNet)).ParseVaaWithStandarizedProperties(v)
+
+	assert.ErrorIs(t, err, errShortPayload)
+}
+
+func TestParseNftBridgeTransfer(t *testing.T) {
+	payload := payloadBuilder{}.u8(1).address(usdtAddress).u16(2).fixedString("NFT").fixedString("My NFT").
+		u256(42).u8(4).raw([]byte("ipfs")).address(toAddress).u16(5)
+	v := newVaa(vaa.ChainIDEthereum, emitter(sdk.KnownNFTBridgeEmitters, vaa.ChainIDEthereum), payload)
+
+	result, err := New(domain.NewProtocolRegistry(domain.P2pMainNet)).ParseVaaWithStandarizedProperties(v)
+
+	assert.NoError(t, err)
+	p := result.ParsedPayload.(NftBridgeTransfer)
+	assert.Equal(t, "42", p.TokenID)
+	assert.Equal(t, "ipfs", p.URI)
+	assert.Equal(t, vaa.ChainIDPolygon, p.ToChain)
+	assert.Equal(t, []string{domain.AppIdPortalNftBridge}, result.StandardizedProperties.AppIds)
+}
+
+func TestParseGenericRelayerDelivery(t *testing.T) {
+	relayer, _ := vaa.StringToAddress(sdk.KnownAutomaticRelayerEmitters[0].Addr)
+	payload := payloadBuilder{}.u8(1).u16(5).address(toAddress).u32(2).raw([]byte{0x01, 0x02}).u256(0).u256(0).
+		u32(0).u16(5).address(toAddress).address(toAddress).address(toAddress).address(fromAddress).
+		u8(1).u8(1).u16(2).address(usdtAddress).u64(99)
+	v := newVaa(sdk.KnownAutomaticRelayerEmitters[0].ChainId, relayer, payload)
+
+	result, err := New(domain.NewProtocolRegistry(domain.P2pMainNet)).ParseVaaWithStandarizedProperties(v)
+
+	assert.NoError(t, err)
+	p := result.ParsedPayload.(RelayerDeliveryInstruction)
+	assert.Equal(t, "0x0102", p.Payload)
+	assert.Len(t, p.MessageKeys, 1)
+	assert.Equal(t, vaa.ChainIDEthereum, p.MessageKeys[0].ChainID)
+	assert.Equal(t, "99", p.MessageKeys[0].Sequence)
+
+	sp := result.StandardizedProperties
+	assert.Equal(t, []string{domain.AppIdGenericRelayer}, sp.AppIds)
+	assert.Equal(t, vaa.ChainIDPolygon, sp.ToChain)
+	assert.Equal(t, "0x0ff664edd699bd85610c2782d9dbbbad704b6fc5", sp.ToAddress)
+	assert.Equal(t, "0xe9d87dd072b0bce6aa9335d590cfb0342870d7b0", sp.FromAddress)
+}
+
+func TestParseCCTPDeposit(t *testing.T) {
+	usdc := "000000000000000000000000a0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+	cctpEmitter, _ := vaa.StringToAddress("000000000000000000000000aada05bd399372f0b0463744c09113c137636f6a")
+	payload := payloadBuilder{}.u8(1).address(usdc).u256(1000000).u32(0).u32(1).u64(7).
+		address(fromAddress).address(toAddress).u16(0)
+	v := newVaa(vaa.ChainIDEthereum, cctpEmitter, payload)
+
+	result, err := New(domain.NewProtocolRegistry(domain.P2pMainNet)).ParseVaaWithStandarizedProperties(v)
+
+	assert.NoError(t, err)
+	p := result.ParsedPayload.(CCTPDeposit)
+	assert.Equal(t, "7", p.Nonce)
+	sp := result.StandardizedProperties
+	assert.Equal(t, []string{domain.AppIdCCTP}, sp.AppIds)
+	assert.Equal(t, vaa.ChainIDAvalanche, sp.ToChain)
+	assert.Equal(t, vaa.ChainIDEthereum, sp.TokenChain)
+	assert.Equal(t, "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", sp.TokenAddress)
+	assert.Equal(t, "1000000", sp.Amount)
+}
+
+func TestParseNativeTokenTransfer(t *testing.T) {
+	transfer := payloadBuilder{}.raw(nativeTokenTransferPrefix).u8(8).u64(123456789).address(usdtAddress).
+		address(toAddress).u16(23)
+	managerMessage := payloadBuilder{}.u256(1).address(fromAddress).u16(uint16(len(transfer))).raw(transfer)
+	payload := payloadBuilder{}.raw(wormholeTransceiverPrefix).address(usdtAddress).address(toAddress).
+		u16(uint16(len(managerMessage))).raw(managerMessage).u16(0)
+	transceiver, _ := vaa.StringToAddress(fromAddress)
+	v := newVaa(vaa.ChainIDEthereum, transceiver, payload)
+
+	result, err := New(domain.NewProtocolRegistry(domain.P2pMainNet)).ParseVaaWithStandarizedProperties(v)
+
+	assert.NoError(t, err)
+	p := result.ParsedPayload.(NativeTokenTransfer)
+	assert.Equal(t, uint8(8), p.Decimals)
+	assert.Equal(t, "123456789", p.Amount)
+	sp := result.StandardizedProperties
+	assert.Equal(t, []string{domain.AppIdNativeTokenTransfer}, sp.AppIds)
+	assert.Equal(t, vaa.ChainIDArbitrum, sp.ToChain)
+	assert.Equal(t, "0xe9d87dd072b0bce6aa9335d590cfb0342870d7b0", sp.FromAddress)
+	assert.Equal(t, "0xdac17f958d2ee523a2206206994597c13d831ec7", sp.TokenAddress)
+}
+
+func TestParseUnknownEmitter(t *testing.T) {
+	addr, _ := vaa.StringToAddress(fromAddress)
+	v := newVaa(vaa.ChainIDEthereum, addr, []byte{0x01})
+
+	_, err := New(domain.NewProtocolRegistry(domain.P2pMainNet)).ParseVaaWithStandarizedProperties(v)
+
+	assert.True(t, errors.Is(err, ErrUnknownEmitter))
+}
+
+func TestParseUnknownPayloadType(t *testing.T) {
+	v := newVaa(vaa.ChainIDEthereum, emitter(sdk.KnownTokenbridgeEmitters, vaa.ChainIDEthereum), []byte{0x09})
+
+	_, err := New(domain.NewProtocolRegistry(domain.P2pMainNet)).ParseVaaWithStandarizedProperties(v)
+
+	assert.True(t, errors.Is(err, ErrUnknownPayload))
+}
+
+func TestNativeAddressFallback(t *testing.T) {
+	addr, _ := vaa.StringToAddress(usdtAddress)
+	assert.Equal(t, "0x"+hex.EncodeToString(addr.Bytes()), nativeAddress(vaa.ChainIDSui, addr))
+}
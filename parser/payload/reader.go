@@ -0,0 +1,108 @@
+package payload
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+var errShortPayload = errors.New("payload too short")
+
+// reader reads big-endian encoded fields from a payload.
+// The first read error is kept and all the following reads return zero values.
+type reader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func newReader(data []byte) *reader {
+	return &reader{data: data}
+}
+
+func (r *reader) next(n int) []byte {
+	if r.err == nil && len(r.data)-r.pos < n {
+		r.err = errShortPayload
+	}
+	if r.err != nil {
+		// fixed size fields are at most 32 bytes long.
+		return make([]byte, 32)
+	}
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return b
+}
+
+func (r *reader) uint8() uint8 {
+	return r.next(1)[0]
+}
+
+func (r *reader) uint16() uint16 {
+	return binary.BigEndian.Uint16(r.next(2))
+}
+
+func (r *reader) uint32() uint32 {
+	return binary.BigEndian.Uint32(r.next(4))
+}
+
+func (r *reader) uint64() uint64 {
+	return binary.BigEndian.Uint64(r.next(8))
+}
+
+// uint256 reads a 32 bytes unsigned integer and returns it as a decimal string.
+func (r *reader) uint256() string {
+	return new(big.Int).SetBytes(r.next(32)).String()
+}
+
+func (r *reader) address() sdk.Address {
+	var addr sdk.Address
+	copy(addr[:], r.next(32))
+	return addr
+}
+
+func (r *reader) chainID() sdk.ChainID {
+	return sdk.ChainID(r.uint16())
+}
+
+// fixedString reads a 32 bytes string padded with null bytes.
+func (r *reader) fixedString() string {
+	return strings.TrimRight(string(r.next(32)), "\x00")
+}
+
+// bytes8 reads a byte array prefixed with its length as uint8.
+func (r *reader) bytes8() []byte {
+	return r.next(int(r.uint8()))
+}
+
+// bytes16 reads a byte array prefixed with its length as uint16.
+func (r *reader) bytes16() []byte {
+	return r.next(int(r.uint16()))
+}
+
+// bytes32 reads a byte array prefixed with its length as uint32.
+func (r *reader) bytes32() []byte {
+	return r.next(int(r.uint32()))
+}
+
+// rest returns the unread bytes of the payload.
+func (r *reader) rest() []byte {
+	if r.err != nil {
+		return nil
+	}
+	b := r.data[r.pos:]
+	r.pos = len(r.data)
+	return b
+}
+
+func toHex(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+func formatUint(v uint64) string {
+	return strconv.FormatUint(v, 10)
+}
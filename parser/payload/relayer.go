@@ -0,0 +1,139 @@
+package payload
+
+import (
+	vaaPayloadParser "github.com/wormhole-foundation/wormhole-explorer/common/client/parser"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// Generic relayer payload types.
+const (
+	relayerDeliveryInstruction   = 1
+	relayerRedeliveryInstruction = 2
+)
+
+const relayerVaaKeyType = 1
+
+// RelayerMessageKey is a message key of a generic relayer delivery instruction.
+type RelayerMessageKey struct {
+	KeyType        uint8       `json:"keyType" bson:"keyType"`
+	ChainID        vaa.ChainID `json:"chainId,omitempty" bson:"chainId,omitempty"`
+	EmitterAddress string      `json:"emitterAddress,omitempty" bson:"emitterAddress,omitempty"`
+	Sequence       string      `json:"sequence,omitempty" bson:"sequence,omitempty"`
+	Key            string      `json:"key,omitempty" bson:"key,omitempty"`
+}
+
+// RelayerDeliveryInstruction is a generic relayer delivery instruction (payload type 1).
+type RelayerDeliveryInstruction struct {
+	PayloadType            uint8               `json:"payloadType" bson:"payloadType"`
+	TargetChainID          vaa.ChainID         `json:"targetChainId" bson:"targetChainId"`
+	TargetAddress          string              `json:"targetAddress" bson:"targetAddress"`
+	Payload                string              `json:"payload" bson:"payload"`
+	RequestedReceiverValue string              `json:"requestedReceiverValue" bson:"requestedReceiverValue"`
+	ExtraReceiverValue     string              `json:"extraReceiverValue" bson:"extraReceiverValue"`
+	ExecutionInfo          string              `json:"executionInfo" bson:"executionInfo"`
+	RefundChainID          vaa.ChainID         `json:"refundChainId" bson:"refundChainId"`
+	RefundAddress          string              `json:"refundAddress" bson:"refundAddress"`
+	RefundDeliveryProvider string              `json:"refundDeliveryProvider" bson:"refundDeliveryProvider"`
+	SourceDeliveryProvider string              `json:"sourceDeliveryProvider" bson:"sourceDeliveryProvider"`
+	SenderAddress          string              `json:"senderAddress" bson:"senderAddress"`
+	MessageKeys            []RelayerMessageKey `json:"messageKeys" bson:"messageKeys"`
+}
+
+// RelayerRedeliveryInstruction is a generic relayer redelivery instruction (payload type 2).
+type RelayerRedeliveryInstruction struct {
+	PayloadType               uint8             `json:"payloadType" bson:"payloadType"`
+	DeliveryVaaKey            RelayerMessageKey `json:"deliveryVaaKey" bson:"deliveryVaaKey"`
+	TargetChainID             vaa.ChainID       `json:"targetChainId" bson:"targetChainId"`
+	NewRequestedReceiverValue string            `json:"newRequestedReceiverValue" bson:"newRequestedReceiverValue"`
+	NewExecutionInfo          string            `json:"newExecutionInfo" bson:"newExecutionInfo"`
+	NewSourceDeliveryProvider string            `json:"newSourceDeliveryProvider" bson:"newSourceDeliveryProvider"`
+	NewSenderAddress          string            `json:"newSenderAddress" bson:"newSenderAddress"`
+}
+
+func parseGenericRelayer(v *vaa.VAA) (*vaaPayloadParser.ParseVaaWithStandarizedPropertiesdResponse, error) {
+	switch payloadType := firstByte(v.Payload); payloadType {
+	case relayerDeliveryInstruction:
+		return parseRelayerDelivery(v)
+	case relayerRedeliveryInstruction:
+		return parseRelayerRedelivery(v)
+	default:
+		return nil, unknownPayloadType(v, payloadType)
+	}
+}
+
+func parseRelayerDelivery(v *vaa.VAA) (*vaaPayloadParser.ParseVaaWithStandarizedPropertiesdResponse, error) {
+	r := newReader(v.Payload)
+	p := RelayerDeliveryInstruction{PayloadType: r.uint8()}
+	p.TargetChainID = r.chainID()
+	targetAddress := r.address()
+	p.TargetAddress = toHex(targetAddress.Bytes())
+	p.Payload = toHex(r.bytes32())
+	p.RequestedReceiverValue = r.uint256()
+	p.ExtraReceiverValue = r.uint256()
+	p.ExecutionInfo = toHex(r.bytes32())
+	p.RefundChainID = r.chainID()
+	p.RefundAddress = toHex(r.next(32))
+	p.RefundDeliveryProvider = toHex(r.next(32))
+	p.SourceDeliveryProvider = toHex(r.next(32))
+	senderAddress := r.address()
+	p.SenderAddress = toHex(senderAddress.Bytes())
+	count := int(r.uint8())
+	p.MessageKeys = make([]RelayerMessageKey, 0, count)
+	for i := 0; i < count && r.err == nil; i++ {
+		p.MessageKeys = append(p.MessageKeys, readMessageKey(r))
+	}
+	if r.err != nil {
+		return nil, payloadError(v, r.err)
+	}
+
+	return &vaaPayloadParser.ParseVaaWithStandarizedPropertiesdResponse{
+		ParsedPayload: p,
+		StandardizedProperties: vaaPayloadParser.StandardizedProperties{
+			AppIds:      []string{domain.AppIdGenericRelayer},
+			FromChain:   v.EmitterChain,
+			FromAddress: nativeAddress(v.EmitterChain, senderAddress),
+			ToChain:     p.TargetChainID,
+			ToAddress:   nativeAddress(p.TargetChainID, targetAddress),
+		},
+	}, nil
+}
+
+func parseRelayerRedelivery(v *vaa.VAA) (*vaaPayloadParser.ParseVaaWithStandarizedPropertiesdResponse, error) {
+	r := newReader(v.Payload)
+	p := RelayerRedeliveryInstruction{PayloadType: r.uint8()}
+	p.DeliveryVaaKey = readMessageKey(r)
+	p.TargetChainID = r.chainID()
+	p.NewRequestedReceiverValue = r.uint256()
+	p.NewExecutionInfo = toHex(r.bytes32())
+	p.NewSourceDeliveryProvider = toHex(r.next(32))
+	senderAddress := r.address()
+	p.NewSenderAddress = toHex(senderAddress.Bytes())
+	if r.err != nil {
+		return nil, payloadError(v, r.err)
+	}
+
+	return &vaaPayloadParser.ParseVaaWithStandarizedPropertiesdResponse{
+		ParsedPayload: p,
+		StandardizedProperties: vaaPayloadParser.StandardizedProperties{
+			AppIds:      []string{domain.AppIdGenericRelayer},
+			FromChain:   v.EmitterChain,
+			FromAddress: nativeAddress(v.EmitterChain, senderAddress),
+			ToChain:     p.TargetChainID,
+		},
+	}, nil
+}
+
+// readMessageKey reads a message key. VAA keys are decoded, other key types are kept as raw bytes.
+func readMessageKey(r *reader) RelayerMessageKey {
+	key := RelayerMessageKey{KeyType: r.uint8()}
+	if key.KeyType == relayerVaaKeyType {
+		key.ChainID = r.chainID()
+		emitter := r.address()
+		key.EmitterAddress = emitter.String()
+		key.Sequence = formatUint(r.uint64())
+		return key
+	}
+	key.Key = toHex(r.bytes32())
+	return key
+}
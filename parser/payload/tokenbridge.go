@@ -0,0 +1,134 @@
+package payload
+
+import (
+	"fmt"
+
+	vaaPayloadParser "github.com/wormhole-foundation/wormhole-explorer/common/client/parser"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// Token bridge payload types.
+const (
+	tokenBridgeTransfer            = 1
+	tokenBridgeAttestation         = 2
+	tokenBridgeTransferWithPayload = 3
+)
+
+// TokenBridgeTransfer is a token bridge transfer (payload type 1) or transfer with payload (payload type 3).
+type TokenBridgeTransfer struct {
+	PayloadType  uint8       `json:"payloadType" bson:"payloadType"`
+	Amount       string      `json:"amount" bson:"amount"`
+	TokenAddress string      `json:"tokenAddress" bson:"tokenAddress"`
+	TokenChain   vaa.ChainID `json:"tokenChain" bson:"tokenChain"`
+	ToAddress    string      `json:"toAddress" bson:"toAddress"`
+	ToChain      vaa.ChainID `json:"toChain" bson:"toChain"`
+	Fee          string      `json:"fee,omitempty" bson:"fee,omitempty"`
+	FromAddress  string      `json:"fromAddress,omitempty" bson:"fromAddress,omitempty"`
+	Payload      string      `json:"payload,omitempty" bson:"payload,omitempty"`
+}
+
+// TokenBridgeAttestation is a token bridge asset metadata attestation (payload type 2).
+type TokenBridgeAttestation struct {
+	PayloadType  uint8       `json:"payloadType" bson:"payloadType"`
+	TokenAddress string      `json:"tokenAddress" bson:"tokenAddress"`
+	TokenChain   vaa.ChainID `json:"tokenChain" bson:"tokenChain"`
+	Decimals     uint8       `json:"decimals" bson:"decimals"`
+	Symbol       string      `json:"symbol" bson:"symbol"`
+	Name         string      `json:"name" bson:"name"`
+}
+
+func parseTokenBridge(v *vaa.VAA) (*vaaPayloadParser.ParseVaaWithStandarizedPropertiesdResponse, error) {
+	switch payloadType := firstByte(v.Payload); payloadType {
+	case tokenBridgeTransfer, tokenBridgeTransferWithPayload:
+		return parseTokenBridgeTransfer(v)
+	case tokenBridgeAttestation:
+		return parseTokenBridgeAttestation(v)
+	default:
+		return nil, unknownPayloadType(v, payloadType)
+	}
+}
+
+func parseTokenBridgeTransfer(v *vaa.VAA) (*vaaPayloadParser.ParseVaaWithStandarizedPropertiesdResponse, error) {
+	r := newReader(v.Payload)
+	payloadType := r.uint8()
+	amount := r.uint256()
+	tokenAddress := r.address()
+	tokenChain := r.chainID()
+	toAddress := r.address()
+	toChain := r.chainID()
+
+	p := TokenBridgeTransfer{
+		PayloadType:  payloadType,
+		Amount:       amount,
+		TokenAddress: toHex(tokenAddress.Bytes()),
+		TokenChain:   tokenChain,
+		ToAddress:    toHex(toAddress.Bytes()),
+		ToChain:      toChain,
+	}
+	var fromAddress string
+	var payload []byte
+	if payloadType == tokenBridgeTransfer {
+		p.Fee = r.uint256()
+	} else {
+		from := r.address()
+		payload = r.rest()
+		p.FromAddress = toHex(from.Bytes())
+		p.Payload = toHex(payload)
+		fromAddress = nativeAddress(v.EmitterChain, from)
+	}
+	if r.err != nil {
+		return nil, payloadError(v, r.err)
+	}
+	// the transfers with payload are classified by the contract that receives them (Connect, Mayan, ...),
+	// which is only known by the vaa-payload-parser.
+	if len(payload) > 0 {
+		return nil, fmt.Errorf("%w: vaa %s is a token bridge transfer with payload to %s on chain %d",
+			ErrUnknownApp, v.MessageID(), toHex(toAddress.Bytes()), toChain)
+	}
+
+	sp := vaaPayloadParser.StandardizedProperties{
+		AppIds:       []string{domain.AppIdPortalTokenBridge},
+		FromChain:    v.EmitterChain,
+		FromAddress:  fromAddress,
+		ToChain:      toChain,
+		ToAddress:    nativeAddress(toChain, toAddress),
+		TokenChain:   tokenChain,
+		TokenAddress: nativeAddress(tokenChain, tokenAddress),
+		Amount:       amount,
+	}
+	if p.Fee != "" && !isZero(p.Fee) {
+		sp.FeeChain = tokenChain
+		sp.FeeAddress = sp.TokenAddress
+		sp.Fee = p.Fee
+	}
+
+	return &vaaPayloadParser.ParseVaaWithStandarizedPropertiesdResponse{
+		ParsedPayload:          p,
+		StandardizedProperties: sp,
+	}, nil
+}
+
+func parseTokenBridgeAttestation(v *vaa.VAA) (*vaaPayloadParser.ParseVaaWithStandarizedPropertiesdResponse, error) {
+	r := newReader(v.Payload)
+	p := TokenBridgeAttestation{PayloadType: r.uint8()}
+	tokenAddress := r.address()
+	p.TokenAddress = toHex(tokenAddress.Bytes())
+	p.TokenChain = r.chainID()
+	p.Decimals = r.uint8()
+	p.Symbol = r.fixedString()
+	p.Name = r.fixedString()
+	if r.err != nil {
+		return nil, payloadError(v, r.err)
+	}
+
+	return &vaaPayloadParser.ParseVaaWithStandarizedPropertiesdResponse{
+		ParsedPayload: p,
+		StandardizedProperties: vaaPayloadParser.StandardizedProperties{
+			AppIds:       []string{domain.AppIdPortalTokenBridge},
+			FromChain:    v.EmitterChain,
+			TokenChain:   p.TokenChain,
+			TokenAddress: nativeAddress(p.TokenChain, tokenAddress),
+		},
+	}, nil
+}
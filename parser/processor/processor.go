@@ -14,11 +14,24 @@ import (
 	parserAlert "github.com/wormhole-foundation/wormhole-explorer/parser/internal/alert"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/parser"
+	"github.com/wormhole-foundation/wormhole-explorer/parser/payload"
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
 )
 
+const (
+	// ParserVersionVaaPayloadParser is the parser version of the VAAs parsed by the vaa-payload-parser service.
+	ParserVersionVaaPayloadParser = "vaa-payload-parser"
+	// TransformVersion is the version of the standardized properties transformation.
+	// It must be increased every time the rules of transformStandarizedProperties change.
+	TransformVersion = 1
+)
+
+// ParserVersionNative is the parser version of the VAAs parsed by the native parser.
+var ParserVersionNative = fmt.Sprintf("native-v%d", payload.Version)
+
 type Processor struct {
+	nativeParser  *payload.Parser
 	parser        vaaPayloadParser.ParserVAAAPIClient
 	repository    *parser.Repository
 	alert         alert.AlertClient
@@ -27,8 +40,11 @@ type Processor struct {
 	logger        *zap.Logger
 }
 
-func New(parser vaaPayloadParser.ParserVAAAPIClient, repository *parser.Repository, alert alert.AlertClient, metrics metrics.Metrics, tokenProvider *domain.TokenProvider, logger *zap.Logger) *Processor {
+// New creates a new Processor.
+// If nativeParser is not nil, it is used first and the vaa-payload-parser is only called for the VAAs it can not parse.
+func New(nativeParser *payload.Parser, parser vaaPayloadParser.ParserVAAAPIClient, repository *parser.Repository, alert alert.AlertClient, metrics metrics.Metrics, tokenProvider *domain.TokenProvider, logger *zap.Logger) *Processor {
 	return &Processor{
+		nativeParser:  nativeParser,
 		parser:        parser,
 		repository:    repository,
 		alert:         alert,
@@ -38,57 +54,96 @@ func New(parser vaaPayloadParser.ParserVAAAPIClient, repository *parser.Reposito
 	}
 }
 
+// Process parses a VAA and persists the result.
 func (p *Processor) Process(ctx context.Context, params *Params) (*parser.ParsedVaaUpdate, error) {
+	vaaParsed, err := p.Parse(ctx, params)
+	if err != nil || vaaParsed == nil {
+		return nil, err
+	}
+
+	err = p.repository.UpsertParsedVaa(ctx, *vaaParsed)
+	if err != nil {
+		p.logger.Error("Error inserting vaa in repository",
+			zap.String("trackId", params.TrackID),
+			zap.String("id", vaaParsed.ID),
+			zap.Error(err))
+		// send alert when exists and error inserting parsed vaa.
+		alertContext := alert.AlertContext{
+			Details: map[string]string{
+				"trackID":        params.TrackID,
+				"chainID":        vaaParsed.EmitterChain.String(),
+				"emitterAddress": vaaParsed.EmitterAddr,
+				"sequence":       vaaParsed.Sequence,
+				"appIDs":         strings.Join(vaaParsed.AppIDs, ", "),
+			},
+			Error: err}
+		p.alert.CreateAndSend(ctx, parserAlert.AlertKeyInsertParsedVaaError, alertContext)
+		return nil, err
+	}
+	p.metrics.IncVaaParsedInserted(uint16(vaaParsed.EmitterChain))
+
+	p.logger.Info("parsed VAA was successfully persisted", zap.String("trackId", params.TrackID), zap.String("id", vaaParsed.ID))
+	return vaaParsed, nil
+}
+
+// Parse parses a VAA without persisting the result.
+// It returns nil if the VAA payload cannot be parsed.
+func (p *Processor) Parse(ctx context.Context, params *Params) (*parser.ParsedVaaUpdate, error) {
 	// unmarshal vaa.
 	vaa, err := sdk.Unmarshal(params.Vaa)
 	if err != nil {
 		return nil, err
 	}
 
-	// call vaa-payload-parser api to parse a VAA.
 	chainID := uint16(vaa.EmitterChain)
 	emitterAddress := vaa.EmitterAddress.String()
 	sequence := fmt.Sprintf("%d", vaa.Sequence)
 
-	p.metrics.IncVaaPayloadParserRequestCount(chainID)
-	vaaParseResponse, err := p.parser.ParseVaaWithStandarizedProperties(vaa)
+	// parse the VAA with the native parser, and call vaa-payload-parser api if it can not be parsed.
+	parserVersion := ParserVersionNative
+	vaaParseResponse, err := p.parseNative(params.TrackID, vaa)
 	if err != nil {
-		// split metrics error not found and others errors.
-		if errors.Is(err, vaaPayloadParser.ErrNotFound) {
-			p.metrics.IncVaaPayloadParserNotFoundCount(chainID)
-		} else {
-			p.metrics.IncVaaPayloadParserErrorCount(chainID)
-		}
+		parserVersion = ParserVersionVaaPayloadParser
+		p.metrics.IncVaaPayloadParserRequestCount(chainID)
+		vaaParseResponse, err = p.parser.ParseVaaWithStandarizedProperties(vaa)
+		if err != nil {
+			// split metrics error not found and others errors.
+			if errors.Is(err, vaaPayloadParser.ErrNotFound) {
+				p.metrics.IncVaaPayloadParserNotFoundCount(chainID)
+			} else {
+				p.metrics.IncVaaPayloadParserErrorCount(chainID)
+			}
 
-		// if error is ErrInternalError or ErrCallEndpoint return error in order to retry.
-		if errors.Is(err, vaaPayloadParser.ErrInternalError) || errors.Is(err, vaaPayloadParser.ErrCallEndpoint) {
-			// send alert when exists and error calling vaa-payload-parser component.
-			alertContext := alert.AlertContext{
-				Details: map[string]string{
-					"trackID":        params.TrackID,
-					"chainID":        vaa.EmitterChain.String(),
-					"emitterAddress": emitterAddress,
-					"sequence":       sequence,
-				},
-				Error: err,
+			// if error is ErrInternalError or ErrCallEndpoint return error in order to retry.
+			if errors.Is(err, vaaPayloadParser.ErrInternalError) || errors.Is(err, vaaPayloadParser.ErrCallEndpoint) {
+				// send alert when exists and error calling vaa-payload-parser component.
+				alertContext := alert.AlertContext{
+					Details: map[string]string{
+						"trackID":        params.TrackID,
+						"chainID":        vaa.EmitterChain.String(),
+						"emitterAddress": emitterAddress,
+						"sequence":       sequence,
+					},
+					Error: err,
+				}
+				p.alert.CreateAndSend(ctx, parserAlert.AlertKeyVaaPayloadParserError, alertContext)
+				return nil, err
 			}
-			p.alert.CreateAndSend(ctx, parserAlert.AlertKeyVaaPayloadParserError, alertContext)
-			return nil, err
-		}
 
-		p.logger.Info("VAA cannot be parsed", zap.Error(err),
-			zap.String("trackId", params.TrackID),
-			zap.Uint16("chainId", chainID),
-			zap.String("address", emitterAddress),
-			zap.String("sequence", sequence))
-		return nil, nil
+			p.logger.Info("VAA cannot be parsed", zap.Error(err),
+				zap.String("trackId", params.TrackID),
+				zap.Uint16("chainId", chainID),
+				zap.String("address", emitterAddress),
+				zap.String("sequence", sequence))
+			return nil, nil
+		}
+		p.metrics.IncVaaPayloadParserSuccessCount(chainID)
 	}
-	p.metrics.IncVaaPayloadParserSuccessCount(chainID)
 	p.metrics.IncVaaParsed(chainID)
 
 	standardizedProperties := p.transformStandarizedProperties(params.TrackID, vaa.MessageID(), vaaParseResponse.StandardizedProperties)
 
-	// create ParsedVaaUpdate to upsert.
+	// create ParsedVaaUpdate.
 	now := time.Now()
 	vaaParsed := parser.ParsedVaaUpdate{
 		ID:                        vaa.MessageID(),
@@ -99,33 +154,36 @@ func (p *Processor) Process(ctx context.Context, params *Params) (*parser.Parsed
 		ParsedPayload:             vaaParseResponse.ParsedPayload,
 		RawStandardizedProperties: vaaParseResponse.StandardizedProperties,
 		StandardizedProperties:    standardizedProperties,
+		ParserVersion:             parserVersion,
+		TransformVersion:          TransformVersion,
 		Timestamp:                 vaa.Timestamp,
 		UpdatedAt:                 &now,
 	}
 
-	err = p.repository.UpsertParsedVaa(ctx, vaaParsed)
+	return &vaaParsed, nil
+}
+
+// parseNative parse a VAA with the native parser.
+func (p *Processor) parseNative(trackID string, vaa *sdk.VAA) (*vaaPayloadParser.ParseVaaWithStandarizedPropertiesdResponse, error) {
+	if p.nativeParser == nil {
+		return nil, payload.ErrUnknownEmitter
+	}
+	chainID := uint16(vaa.EmitterChain)
+	result, err := p.nativeParser.ParseVaaWithStandarizedProperties(vaa)
 	if err != nil {
-		p.logger.Error("Error inserting vaa in repository",
-			zap.String("trackId", params.TrackID),
-			zap.String("id", vaaParsed.ID),
-			zap.Error(err))
-		// send alert when exists and error inserting parsed vaa.
-		alertContext := alert.AlertContext{
-			Details: map[string]string{
-				"trackID":        params.TrackID,
-				"chainID":        vaa.EmitterChain.String(),
-				"emitterAddress": emitterAddress,
-				"sequence":       sequence,
-				"appIDs":         strings.Join(standardizedProperties.AppIds, ", "),
-			},
-			Error: err}
-		p.alert.CreateAndSend(ctx, parserAlert.AlertKeyInsertParsedVaaError, alertContext)
+		if errors.Is(err, payload.ErrUnknownEmitter) || errors.Is(err, payload.ErrUnknownApp) {
+			p.metrics.IncVaaNativeParserUnknownCount(chainID)
+		} else {
+			p.metrics.IncVaaNativeParserErrorCount(chainID)
+			p.logger.Warn("VAA cannot be parsed by the native parser, falling back to vaa-payload-parser",
+				zap.Error(err),
+				zap.String("trackId", trackID),
+				zap.String("id", vaa.MessageID()))
+		}
 		return nil, err
 	}
-	p.metrics.IncVaaParsedInserted(chainID)
-
-	p.logger.Info("parsed VAA was successfully persisted", zap.String("trackId", params.TrackID), zap.String("id", vaaParsed.ID))
-	return &vaaParsed, nil
+	p.metrics.IncVaaNativeParserSuccessCount(chainID)
+	return result, nil
 }
 
 // transformStandarizedProperties transform amount and fee amount.
@@ -84,9 +84,22 @@ func Run() {
 
 	// create and start a new tx hash handler.
 	quit := make(chan bool)
-	txHashHandler := pipeline.NewTxHashHandler(repository, pushFunc, alertClient, metrics, logger, quit)
+	txHashFixConfig := pipeline.TxHashFixConfig{
+		MaxRetries:   config.TxHashFix.MaxRetries,
+		InitialDelay: config.TxHashFix.InitialDelay,
+		MaxDelay:     config.TxHashFix.MaxDelay,
+		PollInterval: config.TxHashFix.PollInterval,
+	}
+	txHashHandler := pipeline.NewTxHashHandler(repository, txHashFixConfig, pushFunc, alertClient, metrics, logger, quit)
 	go txHashHandler.Run(rootCtx)
 
+	// fix the queued vaas as soon as their txhash is inserted.
+	txHashWatcher := watcher.NewTxHashWatcher(db.Database, config.MongoDatabase, txHashHandler.NotifyVaaIdTxHash, logger)
+	err = txHashWatcher.Start(rootCtx)
+	if err != nil {
+		logger.Fatal("failed to watch vaaIdTxHash", zap.Error(err))
+	}
+
 	// create a new publisher.
 	publisher := pipeline.NewPublisher(pushFunc, metrics, repository, config.P2pNetwork, txHashHandler, logger)
 	watcher := watcher.NewWatcher(rootCtx, db.Database, config.MongoDatabase, publisher.Publish, alertClient, metrics, logger)
@@ -95,7 +108,7 @@ func Run() {
 		logger.Fatal("failed to watch MongoDB", zap.Error(err))
 	}
 
-	server := infrastructure.NewServer(logger, config.Port, config.PprofEnabled, healthChecks...)
+	server := infrastructure.NewServer(logger, config.Port, config.PprofEnabled, txHashHandler, healthChecks...)
 	server.Start()
 
 	logger.Info("Started wormhole-explorer-pipeline")
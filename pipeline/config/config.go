@@ -2,6 +2,7 @@ package config
 
 import (
 	"context"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/sethvargo/go-envconfig"
@@ -24,6 +25,15 @@ type Configuration struct {
 	AlertEnabled       bool   `env:"ALERT_ENABLED,default=false"`
 	AlertApiKey        string `env:"ALERT_API_KEY"`
 	MetricsEnabled     bool   `env:"METRICS_ENABLED,default=false"`
+	TxHashFix          TxHashFixConfiguration
+}
+
+// TxHashFixConfiguration is the retry policy to fix the txhash of the vaas without it.
+type TxHashFixConfiguration struct {
+	MaxRetries   int           `env:"TX_HASH_FIX_MAX_RETRIES,default=10"`
+	InitialDelay time.Duration `env:"TX_HASH_FIX_INITIAL_DELAY,default=2s"`
+	MaxDelay     time.Duration `env:"TX_HASH_FIX_MAX_DELAY,default=5m"`
+	PollInterval time.Duration `env:"TX_HASH_FIX_POLL_INTERVAL,default=1s"`
 }
 
 type Backfiller struct {
@@ -2,21 +2,27 @@ package infrastructure
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/wormhole-foundation/wormhole-explorer/pipeline/healthcheck"
+	"github.com/wormhole-foundation/wormhole-explorer/pipeline/pipeline"
 	"go.uber.org/zap"
 )
 
+// txHashFixItemsLimit is the maximum number of queued items returned by the txhash fix debug endpoint.
+const txHashFixItemsLimit = 100
+
 // Controller definition.
 type Controller struct {
-	checks []healthcheck.Check
-	logger *zap.Logger
+	checks        []healthcheck.Check
+	txHashHandler *pipeline.TxHashHandler
+	logger        *zap.Logger
 }
 
 // NewController creates a Controller instance.
-func NewController(checks []healthcheck.Check, logger *zap.Logger) *Controller {
-	return &Controller{checks: checks, logger: logger}
+func NewController(checks []healthcheck.Check, txHashHandler *pipeline.TxHashHandler, logger *zap.Logger) *Controller {
+	return &Controller{checks: checks, txHashHandler: txHashHandler, logger: logger}
 }
 
 // HealthCheck handler for the endpoint /health.
@@ -44,3 +50,28 @@ func (c *Controller) ReadyCheck(ctx *fiber.Ctx) error {
 	}{Ready: "OK"})
 
 }
+
+// TxHashFixBacklog handler for the endpoint /debug/txhash-fix.
+// It returns the size and age of the txhash fix queue and its oldest items.
+func (c *Controller) TxHashFixBacklog(ctx *fiber.Ctx) error {
+	backlog, items, err := c.txHashHandler.Backlog(ctx.Context(), txHashFixItemsLimit)
+	if err != nil {
+		c.logger.Error("Error getting txhash fix backlog", zap.Error(err))
+		return err
+	}
+	var oldestAge string
+	if backlog.OldestCreatedAt != nil {
+		oldestAge = time.Since(*backlog.OldestCreatedAt).Round(time.Second).String()
+	}
+	return ctx.JSON(struct {
+		Size            int64                    `json:"size"`
+		OldestCreatedAt *time.Time               `json:"oldestCreatedAt,omitempty"`
+		OldestAge       string                   `json:"oldestAge,omitempty"`
+		Items           []pipeline.TxHashFixItem `json:"items"`
+	}{
+		Size:            backlog.Size,
+		OldestCreatedAt: backlog.OldestCreatedAt,
+		OldestAge:       oldestAge,
+		Items:           items,
+	})
+}
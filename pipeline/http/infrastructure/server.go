@@ -5,6 +5,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/pprof"
 	"github.com/wormhole-foundation/wormhole-explorer/pipeline/healthcheck"
+	"github.com/wormhole-foundation/wormhole-explorer/pipeline/pipeline"
 	"go.uber.org/zap"
 )
 
@@ -14,7 +15,7 @@ type Server struct {
 	logger *zap.Logger
 }
 
-func NewServer(logger *zap.Logger, port string, pprofEnabled bool, checks ...healthcheck.Check) *Server {
+func NewServer(logger *zap.Logger, port string, pprofEnabled bool, txHashHandler *pipeline.TxHashHandler, checks ...healthcheck.Check) *Server {
 	app := fiber.New(fiber.Config{DisableStartupMessage: true})
 
 	// config use of middlware.
@@ -26,10 +27,11 @@ func NewServer(logger *zap.Logger, port string, pprofEnabled bool, checks ...hea
 		app.Use(pprof.New())
 	}
 
-	ctrl := NewController(checks, logger)
+	ctrl := NewController(checks, txHashHandler, logger)
 	api := app.Group("/api")
 	api.Get("/health", ctrl.HealthCheck)
 	api.Get("/ready", ctrl.ReadyCheck)
+	api.Get("/debug/txhash-fix", ctrl.TxHashFixBacklog)
 
 	return &Server{
 		app:    app,
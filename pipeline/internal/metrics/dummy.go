@@ -1,5 +1,7 @@
 package metrics
 
+import "time"
+
 // DummyMetrics is a dummy implementation of Metric interface.
 type DummyMetrics struct {
 }
@@ -20,3 +22,9 @@ func (m *DummyMetrics) IncVaaWithoutTxHash(chainID uint16) {}
 
 // IncVaaWithTxHashFixed increments the vaa received count with tx hash fixed.
 func (m *DummyMetrics) IncVaaWithTxHashFixed(chainID uint16) {}
+
+// IncVaaWithTxHashFixFailed increments the vaa received count published without tx hash after the fix retries.
+func (m *DummyMetrics) IncVaaWithTxHashFixFailed(chainID uint16) {}
+
+// SetTxHashFixBacklog sets the size and the age of the oldest item of the tx hash fix queue.
+func (m *DummyMetrics) SetTxHashFixBacklog(size int64, oldestAge time.Duration) {}
@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
@@ -10,6 +12,7 @@ import (
 type PrometheusMetrics struct {
 	vaaReceivedCount *prometheus.CounterVec
 	vaaTxHashCount   *prometheus.CounterVec
+	txHashFixBacklog *prometheus.GaugeVec
 }
 
 // NewPrometheusMetrics creates a new PrometheusMetrics.
@@ -34,9 +37,20 @@ func NewPrometheusMetrics(environment string) *PrometheusMetrics {
 			},
 		}, []string{"chain", "type"})
 
+	txHashFixBacklog := promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "vaa_txhash_fix_backlog",
+			Help: "Pending vaas of the txhash fix queue",
+			ConstLabels: map[string]string{
+				"environment": environment,
+				"service":     serviceName,
+			},
+		}, []string{"type"})
+
 	return &PrometheusMetrics{
 		vaaReceivedCount: vaaReceivedCount,
 		vaaTxHashCount:   vaaTxHashCount,
+		txHashFixBacklog: txHashFixBacklog,
 	}
 }
 
@@ -63,3 +77,15 @@ func (m *PrometheusMetrics) IncVaaWithTxHashFixed(chainID uint16) {
 	chain := vaa.ChainID(chainID).String()
 	m.vaaTxHashCount.WithLabelValues(chain, "vaa-with-txhash-fixed").Inc()
 }
+
+// IncVaaWithTxHashFixFailed increments the vaa received count published without tx hash after the fix retries.
+func (m *PrometheusMetrics) IncVaaWithTxHashFixFailed(chainID uint16) {
+	chain := vaa.ChainID(chainID).String()
+	m.vaaTxHashCount.WithLabelValues(chain, "vaa-with-txhash-fix-failed").Inc()
+}
+
+// SetTxHashFixBacklog sets the size and the age of the oldest item of the tx hash fix queue.
+func (m *PrometheusMetrics) SetTxHashFixBacklog(size int64, oldestAge time.Duration) {
+	m.txHashFixBacklog.WithLabelValues("size").Set(float64(size))
+	m.txHashFixBacklog.WithLabelValues("oldest-age-seconds").Set(oldestAge.Seconds())
+}
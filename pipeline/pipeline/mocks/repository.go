@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	pipeline "github.com/wormhole-foundation/wormhole-explorer/pipeline/pipeline"
@@ -35,6 +36,80 @@ func (m *MockIRepository) EXPECT() *MockIRepositoryMockRecorder {
 	return m.recorder
 }
 
+// DeleteTxHashFixItem mocks base method.
+func (m *MockIRepository) DeleteTxHashFixItem(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTxHashFixItem", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTxHashFixItem indicates an expected call of DeleteTxHashFixItem.
+func (mr *MockIRepositoryMockRecorder) DeleteTxHashFixItem(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTxHashFixItem", reflect.TypeOf((*MockIRepository)(nil).DeleteTxHashFixItem), ctx, id)
+}
+
+// FindDueTxHashFixItems mocks base method.
+func (m *MockIRepository) FindDueTxHashFixItems(ctx context.Context, now time.Time, limit int64) ([]pipeline.TxHashFixItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDueTxHashFixItems", ctx, now, limit)
+	ret0, _ := ret[0].([]pipeline.TxHashFixItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDueTxHashFixItems indicates an expected call of FindDueTxHashFixItems.
+func (mr *MockIRepositoryMockRecorder) FindDueTxHashFixItems(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDueTxHashFixItems", reflect.TypeOf((*MockIRepository)(nil).FindDueTxHashFixItems), ctx, now, limit)
+}
+
+// FindTxHashFixItems mocks base method.
+func (m *MockIRepository) FindTxHashFixItems(ctx context.Context, limit int64) ([]pipeline.TxHashFixItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTxHashFixItems", ctx, limit)
+	ret0, _ := ret[0].([]pipeline.TxHashFixItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTxHashFixItems indicates an expected call of FindTxHashFixItems.
+func (mr *MockIRepositoryMockRecorder) FindTxHashFixItems(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTxHashFixItems", reflect.TypeOf((*MockIRepository)(nil).FindTxHashFixItems), ctx, limit)
+}
+
+// GetTxHashFixBacklog mocks base method.
+func (m *MockIRepository) GetTxHashFixBacklog(ctx context.Context) (*pipeline.TxHashFixBacklog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTxHashFixBacklog", ctx)
+	ret0, _ := ret[0].(*pipeline.TxHashFixBacklog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTxHashFixBacklog indicates an expected call of GetTxHashFixBacklog.
+func (mr *MockIRepositoryMockRecorder) GetTxHashFixBacklog(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTxHashFixBacklog", reflect.TypeOf((*MockIRepository)(nil).GetTxHashFixBacklog), ctx)
+}
+
+// GetTxHashFixItem mocks base method.
+func (m *MockIRepository) GetTxHashFixItem(ctx context.Context, id string) (*pipeline.TxHashFixItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTxHashFixItem", ctx, id)
+	ret0, _ := ret[0].(*pipeline.TxHashFixItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTxHashFixItem indicates an expected call of GetTxHashFixItem.
+func (mr *MockIRepositoryMockRecorder) GetTxHashFixItem(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTxHashFixItem", reflect.TypeOf((*MockIRepository)(nil).GetTxHashFixItem), ctx, id)
+}
+
 // GetVaaIdTxHash mocks base method.
 func (m *MockIRepository) GetVaaIdTxHash(ctx context.Context, id string) (*pipeline.VaaIdTxHash, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +125,34 @@ func (mr *MockIRepositoryMockRecorder) GetVaaIdTxHash(ctx, id interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVaaIdTxHash", reflect.TypeOf((*MockIRepository)(nil).GetVaaIdTxHash), ctx, id)
 }
 
+// InsertTxHashFixItem mocks base method.
+func (m *MockIRepository) InsertTxHashFixItem(ctx context.Context, item *pipeline.TxHashFixItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertTxHashFixItem", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertTxHashFixItem indicates an expected call of InsertTxHashFixItem.
+func (mr *MockIRepositoryMockRecorder) InsertTxHashFixItem(ctx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertTxHashFixItem", reflect.TypeOf((*MockIRepository)(nil).InsertTxHashFixItem), ctx, item)
+}
+
+// UpdateTxHashFixItemRetry mocks base method.
+func (m *MockIRepository) UpdateTxHashFixItemRetry(ctx context.Context, id string, retries int, nextRetryAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTxHashFixItemRetry", ctx, id, retries, nextRetryAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTxHashFixItemRetry indicates an expected call of UpdateTxHashFixItemRetry.
+func (mr *MockIRepositoryMockRecorder) UpdateTxHashFixItemRetry(ctx, id, retries, nextRetryAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTxHashFixItemRetry", reflect.TypeOf((*MockIRepository)(nil).UpdateTxHashFixItemRetry), ctx, id, retries, nextRetryAt)
+}
+
 // UpdateVaaDocTxHash mocks base method.
 func (m *MockIRepository) UpdateVaaDocTxHash(ctx context.Context, id, txhash string) error {
 	m.ctrl.T.Helper()
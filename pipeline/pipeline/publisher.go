@@ -66,7 +66,7 @@ func (p *Publisher) Publish(ctx context.Context, e *watcher.Event) {
 			// add the event to the txhash handler.
 			// the handler will try to get the txhash for the vaa
 			// and publish the event with the txhash.
-			p.txHashHandler.AddVaaFixItem(event)
+			p.txHashHandler.AddVaaFixItem(ctx, event)
 			return
 		}
 	}
@@ -6,9 +6,11 @@ import (
 	"time"
 
 	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	"github.com/wormhole-foundation/wormhole-explorer/pipeline/topic"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
 )
 
@@ -16,6 +18,13 @@ import (
 type IRepository interface {
 	GetVaaIdTxHash(ctx context.Context, id string) (*VaaIdTxHash, error)
 	UpdateVaaDocTxHash(ctx context.Context, id string, txhash string) error
+	InsertTxHashFixItem(ctx context.Context, item *TxHashFixItem) error
+	GetTxHashFixItem(ctx context.Context, id string) (*TxHashFixItem, error)
+	FindDueTxHashFixItems(ctx context.Context, now time.Time, limit int64) ([]TxHashFixItem, error)
+	FindTxHashFixItems(ctx context.Context, limit int64) ([]TxHashFixItem, error)
+	UpdateTxHashFixItemRetry(ctx context.Context, id string, retries int, nextRetryAt time.Time) error
+	DeleteTxHashFixItem(ctx context.Context, id string) error
+	GetTxHashFixBacklog(ctx context.Context) (*TxHashFixBacklog, error)
 }
 
 // Repository is the repository data access layer.
@@ -23,19 +32,22 @@ type Repository struct {
 	db          *mongo.Database
 	log         *zap.Logger
 	collections struct {
-		vaas        *mongo.Collection
-		vaaIdTxHash *mongo.Collection
+		vaas           *mongo.Collection
+		vaaIdTxHash    *mongo.Collection
+		txHashFixQueue *mongo.Collection
 	}
 }
 
 // NewRepository creates a new repository.
 func NewRepository(db *mongo.Database, log *zap.Logger) *Repository {
 	return &Repository{db, log, struct {
-		vaas        *mongo.Collection
-		vaaIdTxHash *mongo.Collection
+		vaas           *mongo.Collection
+		vaaIdTxHash    *mongo.Collection
+		txHashFixQueue *mongo.Collection
 	}{
-		vaas:        db.Collection(repository.Vaas),
-		vaaIdTxHash: db.Collection(repository.VaaIdTxHash),
+		vaas:           db.Collection(repository.Vaas),
+		vaaIdTxHash:    db.Collection(repository.VaaIdTxHash),
+		txHashFixQueue: db.Collection(repository.TxHashFixQueue),
 	}}
 }
 
@@ -77,3 +89,100 @@ func (r *Repository) UpdateVaaDocTxHash(ctx context.Context, id string, txhash s
 	_, err := r.collections.vaas.UpdateByID(ctx, id, update, nil)
 	return err
 }
+
+// TxHashFixItem represents a vaa without txhash pending to be fixed.
+type TxHashFixItem struct {
+	// ID is the unique vaa id, the same id of the vaaIdTxHash document.
+	ID          string      `bson:"_id" json:"id"`
+	Event       topic.Event `bson:"event" json:"event"`
+	Retries     int         `bson:"retries" json:"retries"`
+	NextRetryAt time.Time   `bson:"nextRetryAt" json:"nextRetryAt"`
+	CreatedAt   time.Time   `bson:"createdAt" json:"createdAt"`
+	UpdatedAt   time.Time   `bson:"updatedAt" json:"updatedAt"`
+}
+
+// TxHashFixBacklog represents the pending items of the txhash fix queue.
+type TxHashFixBacklog struct {
+	Size            int64      `json:"size"`
+	OldestCreatedAt *time.Time `json:"oldestCreatedAt,omitempty"`
+}
+
+// InsertTxHashFixItem adds an item to the txhash fix queue. If the item is already queued it is kept unchanged.
+func (r *Repository) InsertTxHashFixItem(ctx context.Context, item *TxHashFixItem) error {
+	update := bson.M{"$setOnInsert": item}
+	_, err := r.collections.txHashFixQueue.UpdateByID(ctx, item.ID, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// GetTxHashFixItem returns an item of the txhash fix queue.
+func (r *Repository) GetTxHashFixItem(ctx context.Context, id string) (*TxHashFixItem, error) {
+	var item TxHashFixItem
+	err := r.collections.txHashFixQueue.FindOne(ctx, bson.M{"_id": id}).Decode(&item)
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// FindDueTxHashFixItems returns the items of the txhash fix queue whose next retry is due.
+func (r *Repository) FindDueTxHashFixItems(ctx context.Context, now time.Time, limit int64) ([]TxHashFixItem, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "nextRetryAt", Value: 1}}).SetLimit(limit)
+	return r.findTxHashFixItems(ctx, bson.M{"nextRetryAt": bson.M{"$lte": now}}, opts)
+}
+
+// FindTxHashFixItems returns the oldest items of the txhash fix queue.
+func (r *Repository) FindTxHashFixItems(ctx context.Context, limit int64) ([]TxHashFixItem, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}}).SetLimit(limit)
+	return r.findTxHashFixItems(ctx, bson.M{}, opts)
+}
+
+func (r *Repository) findTxHashFixItems(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]TxHashFixItem, error) {
+	cur, err := r.collections.txHashFixQueue.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	items := []TxHashFixItem{}
+	if err := cur.All(ctx, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// UpdateTxHashFixItemRetry updates the number of retries and the time of the next retry of a queued item.
+func (r *Repository) UpdateTxHashFixItemRetry(ctx context.Context, id string, retries int, nextRetryAt time.Time) error {
+	update := bson.M{
+		"$set": bson.M{
+			"retries":     retries,
+			"nextRetryAt": nextRetryAt,
+			"updatedAt":   time.Now(),
+		},
+	}
+	_, err := r.collections.txHashFixQueue.UpdateByID(ctx, id, update)
+	return err
+}
+
+// DeleteTxHashFixItem removes an item from the txhash fix queue.
+func (r *Repository) DeleteTxHashFixItem(ctx context.Context, id string) error {
+	_, err := r.collections.txHashFixQueue.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+// GetTxHashFixBacklog returns the size of the txhash fix queue and the creation time of its oldest item.
+func (r *Repository) GetTxHashFixBacklog(ctx context.Context) (*TxHashFixBacklog, error) {
+	size, err := r.collections.txHashFixQueue.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	backlog := &TxHashFixBacklog{Size: size}
+	if size == 0 {
+		return backlog, nil
+	}
+	items, err := r.FindTxHashFixItems(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) > 0 {
+		backlog.OldestCreatedAt = &items[0].CreatedAt
+	}
+	return backlog, nil
+}
@@ -3,6 +3,7 @@ package tests
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -10,55 +11,233 @@ import (
 	"github.com/test-go/testify/assert"
 	"github.com/test-go/testify/require"
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/alert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	"github.com/wormhole-foundation/wormhole-explorer/pipeline/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/pipeline/pipeline"
 	"github.com/wormhole-foundation/wormhole-explorer/pipeline/pipeline/mocks"
 	"github.com/wormhole-foundation/wormhole-explorer/pipeline/topic"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
-	"go.uber.org/zap/zaptest/observer"
 )
 
-func TestNewTxHashHandler(t *testing.T) {
+// fixQueue is an in-memory txhash fix queue used by the repository mock.
+type fixQueue struct {
+	sync.Mutex
+	items map[string]pipeline.TxHashFixItem
+}
+
+func newFixQueue(repo *mocks.MockIRepository) *fixQueue {
+	q := &fixQueue{items: map[string]pipeline.TxHashFixItem{}}
+	repo.EXPECT().InsertTxHashFixItem(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, item *pipeline.TxHashFixItem) error {
+			q.Lock()
+			defer q.Unlock()
+			q.items[item.ID] = *item
+			return nil
+		}).AnyTimes()
+	repo.EXPECT().FindDueTxHashFixItems(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, now time.Time, _ int64) ([]pipeline.TxHashFixItem, error) {
+			q.Lock()
+			defer q.Unlock()
+			var result []pipeline.TxHashFixItem
+			for _, item := range q.items {
+				if !item.NextRetryAt.After(now) {
+					result = append(result, item)
+				}
+			}
+			return result, nil
+		}).AnyTimes()
+	repo.EXPECT().GetTxHashFixItem(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, id string) (*pipeline.TxHashFixItem, error) {
+			q.Lock()
+			defer q.Unlock()
+			item, ok := q.items[id]
+			if !ok {
+				return nil, fmt.Errorf("not found")
+			}
+			return &item, nil
+		}).AnyTimes()
+	repo.EXPECT().UpdateTxHashFixItemRetry(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, id string, retries int, nextRetryAt time.Time) error {
+			q.Lock()
+			defer q.Unlock()
+			item := q.items[id]
+			item.Retries = retries
+			item.NextRetryAt = nextRetryAt
+			q.items[id] = item
+			return nil
+		}).AnyTimes()
+	repo.EXPECT().DeleteTxHashFixItem(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, id string) error {
+			q.Lock()
+			defer q.Unlock()
+			delete(q.items, id)
+			return nil
+		}).AnyTimes()
+	return q
+}
 
+func (q *fixQueue) get(id string) (pipeline.TxHashFixItem, bool) {
+	q.Lock()
+	defer q.Unlock()
+	item, ok := q.items[id]
+	return item, ok
+}
+
+// waitDeleted waits for an item to be removed from the queue, it is removed after the event is published.
+func (q *fixQueue) waitDeleted(id string) bool {
+	for i := 0; i < 100; i++ {
+		if _, ok := q.get(id); !ok {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
+
+func newTestEvent(t *testing.T) (topic.Event, string) {
+	v := &sdk.VAA{
+		Version:          1,
+		GuardianSetIndex: 3,
+		Timestamp:        time.Unix(1700000000, 0),
+		Nonce:            1,
+		Sequence:         10,
+		ConsistencyLevel: 1,
+		EmitterChain:     sdk.ChainIDEthereum,
+		EmitterAddress:   sdk.Address{1},
+		Payload:          []byte{1, 2, 3},
+	}
+	data, err := v.Marshal()
+	require.NoError(t, err)
+	return topic.Event{ID: v.MessageID(), ChainID: uint16(v.EmitterChain), Vaa: data}, domain.CreateUniqueVaaID(v)
+}
+
+func newTestPushFunc() (topic.PushFunc, chan topic.Event) {
+	published := make(chan topic.Event, 1)
+	return func(_ context.Context, e *topic.Event) error {
+		published <- *e
+		return nil
+	}, published
+}
+
+func waitEvent(t *testing.T, published chan topic.Event) topic.Event {
+	select {
+	case e := <-published:
+		return e
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "event not published")
+		return topic.Event{}
+	}
+}
+
+func TestTxHashHandler_FixWithRetries(t *testing.T) {
 	mock := gomock.NewController(t)
 	defer mock.Finish()
-
 	repo := mocks.NewMockIRepository(mock)
+	queue := newFixQueue(repo)
 
-	//	log, _ := zap.NewDevelopment()
-	observedZapCore, observedLogs := observer.New(zap.InfoLevel)
-	observedLogger := zap.New(observedZapCore)
+	event, id := newTestEvent(t)
+	gomock.InOrder(
+		repo.EXPECT().GetVaaIdTxHash(gomock.Any(), id).Return(nil, fmt.Errorf("error")),
+		repo.EXPECT().GetVaaIdTxHash(gomock.Any(), id).Return(&pipeline.VaaIdTxHash{ChainID: 2, TxHash: "0xbabla"}, nil),
+	)
+	repo.EXPECT().UpdateVaaDocTxHash(gomock.Any(), id, "0xbabla").Return(nil)
 
+	config := pipeline.TxHashFixConfig{MaxRetries: 3, InitialDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond, PollInterval: 5 * time.Millisecond}
+	pushFunc, published := newTestPushFunc()
 	quit := make(chan bool)
+	defer close(quit)
+	txHashHandler := pipeline.NewTxHashHandler(repo, config, pushFunc, alert.NewDummyClient(), metrics.NewDummyMetrics(), zap.NewNop(), quit)
 
-	var f = topic.PushFunc(func(context.Context, *topic.Event) error {
-		return nil
-	})
+	ctx := context.Background()
+	txHashHandler.AddVaaFixItem(ctx, event)
+	item, ok := queue.get(id)
+	require.True(t, ok)
+	assert.Equal(t, event.ID, item.Event.ID)
+	assert.Equal(t, 0, item.Retries)
 
-	txHashHandler := pipeline.NewTxHashHandler(repo, f, alert.NewDummyClient(), metrics.NewDummyMetrics(), observedLogger, quit)
-	txHashHandler.AddVaaFixItem(topic.Event{
-		ID: "vaa1",
-	},
-	)
+	go txHashHandler.Run(ctx)
+
+	e := waitEvent(t, published)
+	assert.Equal(t, event.ID, e.ID)
+	assert.Equal(t, "0xbabla", e.TxHash)
+	assert.True(t, queue.waitDeleted(id))
+}
+
+func TestTxHashHandler_FixOnVaaIdTxHashInsert(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+	repo := mocks.NewMockIRepository(mock)
+	queue := newFixQueue(repo)
+
+	event, id := newTestEvent(t)
+	repo.EXPECT().GetVaaIdTxHash(gomock.Any(), id).Return(&pipeline.VaaIdTxHash{ChainID: 2, TxHash: "0xbabla"}, nil)
+	repo.EXPECT().UpdateVaaDocTxHash(gomock.Any(), id, "0xbabla").Return(nil)
+
+	// the items are not polled, they are only fixed by the notification.
+	config := pipeline.TxHashFixConfig{MaxRetries: 3, InitialDelay: time.Hour, MaxDelay: time.Hour, PollInterval: time.Hour}
+	pushFunc, published := newTestPushFunc()
+	quit := make(chan bool)
+	defer close(quit)
+	txHashHandler := pipeline.NewTxHashHandler(repo, config, pushFunc, alert.NewDummyClient(), metrics.NewDummyMetrics(), zap.NewNop(), quit)
 
 	ctx := context.Background()
+	txHashHandler.AddVaaFixItem(ctx, event)
+	go txHashHandler.Run(ctx)
+
+	// a notification of a vaa that is not queued is ignored.
+	txHashHandler.NotifyVaaIdTxHash(ctx, "2/0000000000000000000000000000000000000000000000000000000000000001/1/digest")
+	txHashHandler.NotifyVaaIdTxHash(ctx, id)
 
-	repo.EXPECT().GetVaaIdTxHash(ctx, "vaa1").Return(nil, fmt.Errorf("error"))
-	repo.EXPECT().GetVaaIdTxHash(ctx, "vaa1").Return(&pipeline.VaaIdTxHash{
-		ChainID: 1,
-		TxHash:  "0xbabla",
-	}, nil)
-	repo.EXPECT().UpdateVaaDocTxHash(ctx, "vaa1", "0xbabla").Return(nil)
+	e := waitEvent(t, published)
+	assert.Equal(t, "0xbabla", e.TxHash)
+	assert.True(t, queue.waitDeleted(id))
+}
+
+func TestTxHashHandler_RetriesExhausted(t *testing.T) {
+	mock := gomock.NewController(t)
+	defer mock.Finish()
+	repo := mocks.NewMockIRepository(mock)
+	queue := newFixQueue(repo)
 
+	event, id := newTestEvent(t)
+	repo.EXPECT().GetVaaIdTxHash(gomock.Any(), id).Return(nil, fmt.Errorf("error")).Times(2)
+
+	config := pipeline.TxHashFixConfig{MaxRetries: 2, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond, PollInterval: 5 * time.Millisecond}
+	pushFunc, published := newTestPushFunc()
+	quit := make(chan bool)
+	defer close(quit)
+	txHashHandler := pipeline.NewTxHashHandler(repo, config, pushFunc, alert.NewDummyClient(), metrics.NewDummyMetrics(), zap.NewNop(), quit)
+
+	ctx := context.Background()
+	txHashHandler.AddVaaFixItem(ctx, event)
 	go txHashHandler.Run(ctx)
-	time.Sleep(6 * time.Second)
-	close(quit)
 
-	require.Equal(t, 3, observedLogs.Len())
-	allLogs := observedLogs.All()
-	// first attempt to get txhash should fail
-	assert.Equal(t, "Error while trying to fix vaa txhash", allLogs[1].Message)
-	// second attempt to get txhash should succeed
-	assert.Equal(t, "Vaa txhash fixed", allLogs[2].Message)
+	// the event is published without txhash.
+	e := waitEvent(t, published)
+	assert.Equal(t, event.ID, e.ID)
+	assert.Empty(t, e.TxHash)
+	assert.True(t, queue.waitDeleted(id))
+}
 
+func TestTxHashFixConfig_Backoff(t *testing.T) {
+	config := pipeline.TxHashFixConfig{InitialDelay: 2 * time.Second, MaxDelay: time.Minute}
+	tests := []struct {
+		retries int
+		max     time.Duration
+	}{
+		{retries: 0, max: 2 * time.Second},
+		{retries: 1, max: 4 * time.Second},
+		{retries: 3, max: 16 * time.Second},
+		{retries: 10, max: time.Minute},
+		{retries: 100, max: time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("retries %d", tt.retries), func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				delay := config.Backoff(tt.retries)
+				assert.True(t, delay >= tt.max/2 && delay <= tt.max, "delay %s out of range", delay)
+			}
+		})
+	}
 }
@@ -2,7 +2,9 @@ package pipeline
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
 	"time"
 
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/alert"
@@ -11,93 +13,233 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/pipeline/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/pipeline/topic"
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
 )
 
-type ItemTuple struct {
-	Retries int
-	Event   topic.Event
+const (
+	// txHashFixBatchSize is the maximum number of due items processed in each poll.
+	txHashFixBatchSize = 100
+	// txHashFixBacklogInterval is the interval to update the backlog metrics.
+	txHashFixBacklogInterval = 30 * time.Second
+)
+
+// TxHashFixConfig is the retry policy of the txhash fix queue.
+type TxHashFixConfig struct {
+	// MaxRetries is the number of attempts to fix a vaa txhash before publishing the event without it.
+	MaxRetries int
+	// InitialDelay is the delay before the first attempt, it is doubled on each retry up to MaxDelay.
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	// PollInterval is the interval to check for items whose next retry is due.
+	PollInterval time.Duration
+}
+
+// Backoff returns the delay before the next attempt after the given number of retries.
+// The delay grows exponentially and a random jitter of up to half of it is applied,
+// so that the items queued at the same time are not retried together.
+func (c TxHashFixConfig) Backoff(retries int) time.Duration {
+	delay := c.InitialDelay
+	for i := 0; i < retries && delay < c.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > c.MaxDelay {
+		delay = c.MaxDelay
+	}
+	if delay <= 1 {
+		return delay
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
 }
 
+// TxHashHandler fixes the txhash of the vaas published without it. The pending vaas are persisted
+// in a queue and retried with exponential backoff until the txhash is found or the retries are exhausted.
 type TxHashHandler struct {
-	logger         *zap.Logger
-	repository     IRepository
-	fixItems       map[string]ItemTuple
-	inputQueue     chan topic.Event
-	quit           chan bool
-	sleepTime      time.Duration
-	pushFunc       topic.PushFunc
-	alertClient    alert.AlertClient
-	metrics        metrics.Metrics
-	defaultRetries int
+	logger      *zap.Logger
+	repository  IRepository
+	txHashQueue chan string
+	quit        chan bool
+	config      TxHashFixConfig
+	pushFunc    topic.PushFunc
+	alertClient alert.AlertClient
+	metrics     metrics.Metrics
 }
 
 // NewTxHashHandler creates a new TxHashHandler.
-func NewTxHashHandler(repository IRepository, pushFunc topic.PushFunc, alertClient alert.AlertClient, metrics metrics.Metrics, logger *zap.Logger, quit chan bool) *TxHashHandler {
+func NewTxHashHandler(repository IRepository, config TxHashFixConfig, pushFunc topic.PushFunc, alertClient alert.AlertClient, metrics metrics.Metrics, logger *zap.Logger, quit chan bool) *TxHashHandler {
 	return &TxHashHandler{
-		logger:         logger,
-		repository:     repository,
-		fixItems:       map[string]ItemTuple{},
-		inputQueue:     make(chan topic.Event, 100),
-		sleepTime:      2 * time.Second,
-		pushFunc:       pushFunc,
-		alertClient:    alertClient,
-		metrics:        metrics,
-		defaultRetries: 3,
+		logger:      logger,
+		repository:  repository,
+		txHashQueue: make(chan string, 100),
+		quit:        quit,
+		config:      config,
+		pushFunc:    pushFunc,
+		alertClient: alertClient,
+		metrics:     metrics,
+	}
+}
+
+// AddVaaFixItem adds a vaa without txhash to the fix queue.
+func (t *TxHashHandler) AddVaaFixItem(ctx context.Context, event topic.Event) {
+	vaa, err := sdk.Unmarshal(event.Vaa)
+	if err != nil {
+		t.logger.Error("Error unmarshalling vaa", zap.Error(err), zap.String("vaaId", event.ID))
+		return
+	}
+
+	now := time.Now()
+	item := TxHashFixItem{
+		ID:          domain.CreateUniqueVaaID(vaa),
+		Event:       event,
+		NextRetryAt: now.Add(t.config.Backoff(0)),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := t.repository.InsertTxHashFixItem(ctx, &item); err != nil {
+		// the event is not lost if it cannot be queued, it is published without txhash.
+		t.logger.Error("Error adding vaa to txhash fix queue", zap.Error(err), zap.String("vaaId", event.ID))
+		t.push(ctx, &item.Event)
 	}
 }
 
-// Add a new element to the fixItems array
-func (t *TxHashHandler) AddVaaFixItem(event topic.Event) {
-	t.inputQueue <- event
+// NotifyVaaIdTxHash notifies that the txhash of a vaa is available, so that it is fixed without waiting for the next retry.
+func (t *TxHashHandler) NotifyVaaIdTxHash(ctx context.Context, id string) {
+	select {
+	case t.txHashQueue <- id:
+	default:
+		// the queued item is fixed anyway on its next retry.
+		t.logger.Debug("txhash notification discarded", zap.String("id", id))
+	}
 }
 
+// Run processes the txhash fix queue until the handler is stopped.
 func (t *TxHashHandler) Run(ctx context.Context) {
 	t.logger.Info("TxHashHandler started")
+	pollTicker := time.NewTicker(t.config.PollInterval)
+	defer pollTicker.Stop()
+	backlogTicker := time.NewTicker(txHashFixBacklogInterval)
+	defer backlogTicker.Stop()
+
 	for {
 		select {
 		case <-t.quit:
 			t.logger.Info("stopping txhash handler")
 			return
-		case event := <-t.inputQueue:
-			t.fixItems[event.ID] = ItemTuple{
-				Retries: 5,
-				Event:   event,
-			}
-		default:
-			// no lock needed. the map is never updated while iterating.
-			for vaaID, item := range t.fixItems {
-				if item.Retries > 0 {
-					vaa, err := sdk.Unmarshal(item.Event.Vaa)
-					if err != nil {
-						t.logger.Error("Error unmarshalling vaa", zap.Error(err), zap.String("vaaId", vaaID))
-						delete(t.fixItems, vaaID)
-						continue
-					}
-					uniqueVaaID := domain.CreateUniqueVaaID(vaa)
-					txHash, err := t.handleEmptyVaaTxHash(ctx, uniqueVaaID)
-					if err != nil {
-						t.logger.Error("Error while trying to fix vaa txhash", zap.Int("retries_count", item.Retries), zap.Error(err))
-						item.Retries = item.Retries - 1
-						t.fixItems[vaaID] = item
-					} else {
-						t.logger.Info("Vaa txhash fixed", zap.String("vaaID", vaaID), zap.String("txHash", txHash))
-						item.Event.TxHash = txHash
-						t.pushFunc(ctx, &item.Event)
-						delete(t.fixItems, vaaID)
-						// increment metrics vaa with txhash fixed
-						t.metrics.IncVaaWithTxHashFixed(item.Event.ChainID)
-					}
-				} else {
-					t.logger.Error("Vaa txhash fix failed", zap.String("vaaID", vaaID))
-					// publish the event to the topic anyway
-					t.pushFunc(ctx, &item.Event)
-					delete(t.fixItems, vaaID)
-				}
+		case <-ctx.Done():
+			t.logger.Info("stopping txhash handler by context")
+			return
+		case id := <-t.txHashQueue:
+			t.fixItemByID(ctx, id)
+		case <-pollTicker.C:
+			t.fixDueItems(ctx)
+		case <-backlogTicker.C:
+			t.updateBacklogMetrics(ctx)
+		}
+	}
+}
+
+// Backlog returns the size and age of the txhash fix queue and its oldest items.
+func (t *TxHashHandler) Backlog(ctx context.Context, limit int64) (*TxHashFixBacklog, []TxHashFixItem, error) {
+	backlog, err := t.repository.GetTxHashFixBacklog(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	items, err := t.repository.FindTxHashFixItems(ctx, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	return backlog, items, nil
+}
+
+func (t *TxHashHandler) fixItemByID(ctx context.Context, id string) {
+	item, err := t.repository.GetTxHashFixItem(ctx, id)
+	if err != nil {
+		// most of the vaas are not queued.
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			t.logger.Error("Error getting txhash fix item", zap.Error(err), zap.String("id", id))
+		}
+		return
+	}
+	t.fixItem(ctx, item)
+}
+
+func (t *TxHashHandler) fixDueItems(ctx context.Context) {
+	items, err := t.repository.FindDueTxHashFixItems(ctx, time.Now(), txHashFixBatchSize)
+	if err != nil {
+		t.logger.Error("Error getting txhash fix items", zap.Error(err))
+		return
+	}
+	for i := range items {
+		t.fixItem(ctx, &items[i])
+	}
+}
+
+func (t *TxHashHandler) fixItem(ctx context.Context, item *TxHashFixItem) {
+	vaaID := item.Event.ID
+	txHash, err := t.handleEmptyVaaTxHash(ctx, item.ID)
+	if err != nil {
+		retries := item.Retries + 1
+		if retries >= t.config.MaxRetries {
+			t.logger.Error("Vaa txhash fix failed", zap.String("vaaID", vaaID), zap.Int("retries_count", retries))
+			// publish the event to the topic anyway
+			if !t.push(ctx, &item.Event) {
+				t.retryItem(ctx, item.ID, item.Retries)
+				return
 			}
+			t.deleteItem(ctx, item.ID)
+			t.metrics.IncVaaWithTxHashFixFailed(item.Event.ChainID)
+			return
 		}
-		time.Sleep(t.sleepTime)
+		t.logger.Debug("Error while trying to fix vaa txhash", zap.String("vaaID", vaaID), zap.Int("retries_count", retries), zap.Error(err))
+		t.retryItem(ctx, item.ID, retries)
+		return
+	}
+
+	t.logger.Info("Vaa txhash fixed", zap.String("vaaID", vaaID), zap.String("txHash", txHash))
+	item.Event.TxHash = txHash
+	if !t.push(ctx, &item.Event) {
+		// the txhash is already fixed, the next retry only publishes the event.
+		t.retryItem(ctx, item.ID, item.Retries)
+		return
+	}
+	t.deleteItem(ctx, item.ID)
+	// increment metrics vaa with txhash fixed
+	t.metrics.IncVaaWithTxHashFixed(item.Event.ChainID)
+}
+
+func (t *TxHashHandler) push(ctx context.Context, event *topic.Event) bool {
+	if err := t.pushFunc(ctx, event); err != nil {
+		t.logger.Error("can not push event to topic", zap.Error(err), zap.String("event", event.ID))
+		return false
+	}
+	return true
+}
+
+func (t *TxHashHandler) retryItem(ctx context.Context, id string, retries int) {
+	nextRetryAt := time.Now().Add(t.config.Backoff(retries))
+	if err := t.repository.UpdateTxHashFixItemRetry(ctx, id, retries, nextRetryAt); err != nil {
+		t.logger.Error("Error updating txhash fix item", zap.Error(err), zap.String("id", id))
+	}
+}
+
+func (t *TxHashHandler) deleteItem(ctx context.Context, id string) {
+	if err := t.repository.DeleteTxHashFixItem(ctx, id); err != nil {
+		t.logger.Error("Error deleting txhash fix item", zap.Error(err), zap.String("id", id))
+	}
+}
+
+func (t *TxHashHandler) updateBacklogMetrics(ctx context.Context) {
+	backlog, err := t.repository.GetTxHashFixBacklog(ctx)
+	if err != nil {
+		t.logger.Error("Error getting txhash fix backlog", zap.Error(err))
+		return
+	}
+	var age time.Duration
+	if backlog.OldestCreatedAt != nil {
+		age = time.Since(*backlog.OldestCreatedAt)
 	}
+	t.metrics.SetTxHashFixBacklog(backlog.Size, age)
 }
 
 // handleEmptyVaaTxHash tries to get the txhash for the vaa with the given id.
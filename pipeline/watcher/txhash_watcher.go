@@ -0,0 +1,75 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// txHashChangeStreamID identifies the vaaIdTxHash change stream of the pipeline in the change stream checkpoints.
+const txHashChangeStreamID = "pipeline-vaaIdTxHash"
+
+// TxHashWatcher represents a listener of the vaaIdTxHash inserts.
+type TxHashWatcher struct {
+	db      *mongo.Database
+	dbName  string
+	handler TxHashWatcherFunc
+	logger  *zap.Logger
+}
+
+// TxHashWatcherFunc is a function to send the id of the inserted vaaIdTxHash documents.
+type TxHashWatcherFunc func(ctx context.Context, id string)
+
+const txHashQueryTemplate = `
+	[
+		{
+			"$match" : {
+				"operationType" : "insert",
+				"ns": { "db": "%s", "coll": "vaaIdTxHash" }
+			}
+		}
+	]
+`
+
+// NewTxHashWatcher creates a new vaaIdTxHash event watcher.
+func NewTxHashWatcher(db *mongo.Database, dbName string, handler TxHashWatcherFunc, logger *zap.Logger) *TxHashWatcher {
+	return &TxHashWatcher{
+		db:      db,
+		dbName:  dbName,
+		handler: handler,
+		logger:  logger,
+	}
+}
+
+// Start executes vaaIdTxHash event consumption. The stream is resumed from the last checkpoint
+// and the vaaIdTxHash inserted while it was stopped are also sent.
+func (w *TxHashWatcher) Start(ctx context.Context) error {
+	query := fmt.Sprintf(txHashQueryTemplate, w.dbName)
+	var steps []bson.D
+	err := bson.UnmarshalExtJSON([]byte(query), true, &steps)
+	if err != nil {
+		return err
+	}
+
+	params := dbutil.ChangeStreamParams{
+		ID:                txHashChangeStreamID,
+		Pipeline:          steps,
+		GapFillCollection: repository.VaaIdTxHash,
+	}
+	stream := dbutil.NewResumableChangeStream(w.db, params, w.handle, w.logger)
+	return stream.Start(ctx)
+}
+
+func (w *TxHashWatcher) handle(ctx context.Context, doc bson.Raw) {
+	id, ok := doc.Lookup("_id").StringValueOK()
+	if !ok {
+		w.logger.Error("Error unmarshalling vaaIdTxHash event", zap.String("document", doc.String()))
+		return
+	}
+	w.handler(ctx, id)
+}
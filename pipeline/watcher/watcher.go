@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/alert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
 	pipelineAlert "github.com/wormhole-foundation/wormhole-explorer/pipeline/internal/alert"
 	"github.com/wormhole-foundation/wormhole-explorer/pipeline/internal/metrics"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
@@ -14,6 +16,9 @@ import (
 	"go.uber.org/zap"
 )
 
+// changeStreamID identifies the vaa change stream of the pipeline in the change stream checkpoints.
+const changeStreamID = "pipeline-vaas"
+
 // Watcher represents a listener of database changes.
 type Watcher struct {
 	db          *mongo.Database
@@ -78,7 +83,8 @@ func NewWatcher(ctx context.Context, db *mongo.Database, dbName string, handler
 	}
 }
 
-// Start executes database event consumption.
+// Start executes database event consumption. The stream is resumed from the last checkpoint
+// and the vaas inserted while it was stopped are also sent.
 func (w *Watcher) Start(ctx context.Context) error {
 	query := fmt.Sprintf(queryTemplate, w.dbName, w.dbName)
 	var steps []bson.D
@@ -87,27 +93,29 @@ func (w *Watcher) Start(ctx context.Context) error {
 		return err
 	}
 
-	stream, err := w.db.Watch(ctx, steps)
-	if err != nil {
-		return err
+	params := dbutil.ChangeStreamParams{
+		ID:                changeStreamID,
+		Pipeline:          steps,
+		GapFillCollection: repository.Vaas,
 	}
-	go func() {
-		for stream.Next(ctx) {
-			var e watchEvent
-			if err := stream.Decode(&e); err != nil {
-				w.logger.Error("Error unmarshalling event", zap.Error(err))
-				alertContext := alert.AlertContext{
-					Details: e.toMapAlertDetail(),
-					Error:   err,
-				}
-				w.alertClient.CreateAndSend(ctx, pipelineAlert.ErrorDecodeWatcherEvent, alertContext)
-				continue
-			}
-			w.metrics.IncVaaFromMongoStream(e.DbFullDocument.ChainID)
-			w.handler(ctx, &e.DbFullDocument)
+	stream := dbutil.NewResumableChangeStream(w.db, params, w.handle, w.logger)
+	return stream.Start(ctx)
+}
+
+func (w *Watcher) handle(ctx context.Context, doc bson.Raw) {
+	var e watchEvent
+	if err := bson.Unmarshal(doc, &e.DbFullDocument); err != nil {
+		w.logger.Error("Error unmarshalling event", zap.Error(err))
+		e.DocumentKey.ID = e.DbFullDocument.ID
+		alertContext := alert.AlertContext{
+			Details: e.toMapAlertDetail(),
+			Error:   err,
 		}
-	}()
-	return nil
+		w.alertClient.CreateAndSend(ctx, pipelineAlert.ErrorDecodeWatcherEvent, alertContext)
+		return
+	}
+	w.metrics.IncVaaFromMongoStream(e.DbFullDocument.ChainID)
+	w.handler(ctx, &e.DbFullDocument)
 }
 
 // toAlertDetail returns from the watch event an map with the alert details.
@@ -6,15 +6,20 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/certusone/wormhole/node/pkg/supervisor"
 	"github.com/go-redis/redis/v8"
+	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
 	"github.com/wormhole-foundation/wormhole-explorer/common/health"
 	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
 	"github.com/wormhole-foundation/wormhole-explorer/spy/config"
 	"github.com/wormhole-foundation/wormhole-explorer/spy/grpc"
 	"github.com/wormhole-foundation/wormhole-explorer/spy/http/infraestructure"
+	"github.com/wormhole-foundation/wormhole-explorer/spy/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/spy/source"
+	"github.com/wormhole-foundation/wormhole-explorer/spy/storage"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
 )
 
@@ -32,10 +37,12 @@ func handleExit() {
 func newHealthChecks(
 	ctx context.Context,
 	client *redis.Client,
+	db *mongo.Database,
 ) ([]health.Check, error) {
 
 	healthChecks := []health.Check{
 		health.Redis(client),
+		health.Mongo(db),
 	}
 	return healthChecks, nil
 }
@@ -55,12 +62,34 @@ func main() {
 
 	logger.Info("Starting wormhole-explorer-spy ...")
 
-	svs := grpc.NewSignedVaaSubscribers(logger)
+	// the signed VAAs replayed, the observations, heartbeats and governor status are read from the database of fly.
+	db, err := dbutil.Connect(rootCtx, logger, config.MongoURI, config.MongoDatabase, false)
+	if err != nil {
+		logger.Fatal("failed to connect MongoDB", zap.Error(err))
+	}
+
+	overflowPolicy, err := grpc.ParseOverflowPolicy(config.SubscriberOverflowPolicy)
+	if err != nil {
+		logger.Fatal("failed to parse subscriber overflow policy", zap.Error(err))
+	}
+	bufferConfig := grpc.BufferConfig{Size: config.SubscriberBufferSize, Policy: overflowPolicy}
+	subscriberMetrics := metrics.NewPrometheusMetrics(config.Env)
+
+	svs := grpc.NewSignedVaaSubscribers(bufferConfig, subscriberMetrics, logger)
 	go svs.Start(rootCtx)
 
 	handler := grpc.NewHandler(svs, logger)
 
-	grpcServer, err := grpc.NewServer(handler, logger, config.GrpcAddress)
+	ms := grpc.NewMonitorSubscribers(bufferConfig, subscriberMetrics, logger)
+	go ms.Start(rootCtx)
+
+	monitorHandler := grpc.NewMonitorHandler(ms, logger)
+
+	repository := storage.NewRepository(db.Database, logger)
+	replayMaxAge := time.Duration(config.ReplayMaxAgeHours) * time.Hour
+	replayHandler := grpc.NewReplayHandler(svs, repository, replayMaxAge, logger)
+
+	grpcServer, err := grpc.NewServer(handler, monitorHandler, replayHandler, logger, config.GrpcAddress)
 	if err != nil {
 		logger.Fatal("failed to start RPC server", zap.Error(err))
 	}
@@ -89,14 +118,27 @@ func main() {
 	if err != nil {
 		logger.Fatal("failed to watch MongoDB", zap.Error(err))
 	}
+
+	gossipWatcher := source.NewGossipWatcher(db.Database, config.MongoDatabase, source.GossipHandlers{
+		SignedObservation: ms.HandleSignedObservation,
+		Heartbeat:         ms.HandleHeartbeat,
+		GovernorStatus:    ms.HandleGovernorStatus,
+	}, logger)
+	if err := gossipWatcher.Start(rootCtx); err != nil {
+		logger.Fatal("failed to watch gossip messages in MongoDB", zap.Error(err))
+	}
+
 	// get health check functions.
 	logger.Info("creating health check functions...")
-	healthChecks, err := newHealthChecks(rootCtx, client)
+	healthChecks, err := newHealthChecks(rootCtx, client, db.Database)
 	if err != nil {
 		logger.Fatal("failed to create health checks", zap.Error(err))
 	}
 
-	server := infraestructure.NewServer(logger, config.Port, config.PprofEnabled, healthChecks...)
+	subscriptions := func() []grpc.SubscriptionInfo {
+		return append(svs.Subscriptions(), ms.Subscriptions()...)
+	}
+	server := infraestructure.NewServer(logger, config.Port, config.PprofEnabled, subscriptions, healthChecks...)
 	server.Start()
 
 	logger.Info("Started wormhole-explorer-spy")
@@ -125,6 +167,11 @@ func main() {
 		logger.Error("Error closing redis client", zap.Error(err))
 	}
 
+	logger.Info("Closing MongoDB connection...")
+	if err := db.DisconnectWithTimeout(10 * time.Second); err != nil {
+		logger.Error("Error closing MongoDB connection", zap.Error(err))
+	}
+
 	logger.Info("Closing Http server ...")
 	server.Stop()
 	logger.Info("Finished wormhole-explorer-spy")
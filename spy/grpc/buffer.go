@@ -0,0 +1,201 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// OverflowPolicy is the action taken when the buffer of a subscription is full.
+type OverflowPolicy string
+
+const (
+	// OverflowDropOldest discards the oldest buffered message to add the new one.
+	OverflowDropOldest OverflowPolicy = "drop-oldest"
+	// OverflowDisconnect ends the subscription, so the client has to subscribe again.
+	OverflowDisconnect OverflowPolicy = "disconnect"
+	// OverflowBlock waits until the subscriber has room for the message. It delays the delivery
+	// to every subscriber of the same type, so it must only be used with trusted subscribers.
+	OverflowBlock OverflowPolicy = "block"
+)
+
+var (
+	errBufferOverflow = errors.New("subscriber buffer overflow")
+	errBufferClosed   = errors.New("subscriber buffer closed")
+)
+
+// ParseOverflowPolicy parses an overflow policy.
+func ParseOverflowPolicy(s string) (OverflowPolicy, error) {
+	switch p := OverflowPolicy(s); p {
+	case OverflowDropOldest, OverflowDisconnect, OverflowBlock:
+		return p, nil
+	default:
+		return "", fmt.Errorf("invalid overflow policy %s", s)
+	}
+}
+
+// BufferConfig is the configuration of the buffer of each subscription.
+type BufferConfig struct {
+	Size   int
+	Policy OverflowPolicy
+}
+
+type pushResult int
+
+const (
+	pushOK pushResult = iota
+	// pushDropped means that the oldest message was dropped to add the new one.
+	pushDropped
+	// pushOverflow means that the buffer was full and it was closed.
+	pushOverflow
+	// pushClosed means that the buffer is closed and the message was not added.
+	pushClosed
+)
+
+// subscriptionBuffer is the bounded buffer of the messages of a subscription. The messages are
+// pushed by the dispatch loop and sent by the subscription handler, so a slow subscriber
+// only fills its own buffer.
+type subscriptionBuffer[T any] struct {
+	mu         sync.Mutex
+	messages   []T
+	size       int
+	policy     OverflowPolicy
+	dropped    uint64
+	closed     bool
+	overflowed bool
+	// ready is signaled when a message is pushed, and space when a message is removed.
+	ready chan struct{}
+	space chan struct{}
+	done  chan struct{}
+}
+
+func newSubscriptionBuffer[T any](config BufferConfig) *subscriptionBuffer[T] {
+	size := config.Size
+	if size < 1 {
+		size = 1
+	}
+	return &subscriptionBuffer[T]{
+		size:   size,
+		policy: config.Policy,
+		ready:  make(chan struct{}, 1),
+		space:  make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+}
+
+// push adds a message to the buffer and returns the result and the number of buffered messages.
+// With the block policy it waits until there is room, the buffer is closed or the context is done.
+func (b *subscriptionBuffer[T]) push(ctx context.Context, msg T) (pushResult, int) {
+	for {
+		b.mu.Lock()
+		if b.closed {
+			b.mu.Unlock()
+			return pushClosed, 0
+		}
+		if len(b.messages) < b.size {
+			b.messages = append(b.messages, msg)
+			lag := len(b.messages)
+			b.mu.Unlock()
+			signal(b.ready)
+			return pushOK, lag
+		}
+
+		switch b.policy {
+		case OverflowBlock:
+			b.mu.Unlock()
+			select {
+			case <-ctx.Done():
+				return pushClosed, 0
+			case <-b.done:
+				return pushClosed, 0
+			case <-b.space:
+			}
+		case OverflowDisconnect:
+			b.overflowed = true
+			b.mu.Unlock()
+			b.close()
+			return pushOverflow, 0
+		default:
+			var zero T
+			b.messages[0] = zero
+			b.messages = append(b.messages[1:], msg)
+			b.dropped++
+			lag := len(b.messages)
+			b.mu.Unlock()
+			signal(b.ready)
+			return pushDropped, lag
+		}
+	}
+}
+
+// next waits for the next message and returns it with the number of messages left in the buffer.
+func (b *subscriptionBuffer[T]) next(ctx context.Context) (T, int, error) {
+	var zero T
+	for {
+		b.mu.Lock()
+		if b.overflowed {
+			b.mu.Unlock()
+			return zero, 0, errBufferOverflow
+		}
+		if len(b.messages) > 0 {
+			msg := b.messages[0]
+			b.messages[0] = zero
+			b.messages = b.messages[1:]
+			lag := len(b.messages)
+			b.mu.Unlock()
+			signal(b.space)
+			return msg, lag, nil
+		}
+		closed := b.closed
+		b.mu.Unlock()
+		if closed {
+			return zero, 0, errBufferClosed
+		}
+
+		select {
+		case <-ctx.Done():
+			return zero, 0, ctx.Err()
+		case <-b.done:
+		case <-b.ready:
+		}
+	}
+}
+
+// close ends the buffer, the messages pushed after it are discarded.
+func (b *subscriptionBuffer[T]) close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.closed {
+		b.closed = true
+		close(b.done)
+	}
+}
+
+// setPolicy changes the overflow policy of the buffer.
+func (b *subscriptionBuffer[T]) setPolicy(policy OverflowPolicy) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.policy = policy
+}
+
+// isOverflowed checks if the buffer was closed because it overflowed.
+func (b *subscriptionBuffer[T]) isOverflowed() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.overflowed
+}
+
+// stats returns the number of buffered and dropped messages.
+func (b *subscriptionBuffer[T]) stats() (int, uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.messages), b.dropped
+}
+
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,123 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testBufferConfig = BufferConfig{Size: 16, Policy: OverflowDropOldest}
+
+func TestParseOverflowPolicy(t *testing.T) {
+	for _, s := range []string{"drop-oldest", "disconnect", "block"} {
+		p, err := ParseOverflowPolicy(s)
+		assert.Nil(t, err)
+		assert.Equal(t, OverflowPolicy(s), p)
+	}
+	_, err := ParseOverflowPolicy("drop-newest")
+	assert.NotNil(t, err)
+}
+
+func TestSubscriptionBuffer_DropOldest(t *testing.T) {
+	ctx := context.Background()
+	b := newSubscriptionBuffer[int](BufferConfig{Size: 2, Policy: OverflowDropOldest})
+
+	result, lag := b.push(ctx, 1)
+	assert.Equal(t, pushOK, result)
+	assert.Equal(t, 1, lag)
+	result, _ = b.push(ctx, 2)
+	assert.Equal(t, pushOK, result)
+	result, lag = b.push(ctx, 3)
+	assert.Equal(t, pushDropped, result)
+	assert.Equal(t, 2, lag)
+
+	buffered, dropped := b.stats()
+	assert.Equal(t, 2, buffered)
+	assert.Equal(t, uint64(1), dropped)
+
+	msg, lag, err := b.next(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, msg)
+	assert.Equal(t, 1, lag)
+	msg, _, err = b.next(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, msg)
+}
+
+func TestSubscriptionBuffer_Disconnect(t *testing.T) {
+	ctx := context.Background()
+	b := newSubscriptionBuffer[int](BufferConfig{Size: 1, Policy: OverflowDisconnect})
+
+	result, _ := b.push(ctx, 1)
+	assert.Equal(t, pushOK, result)
+	result, _ = b.push(ctx, 2)
+	assert.Equal(t, pushOverflow, result)
+	result, _ = b.push(ctx, 3)
+	assert.Equal(t, pushClosed, result)
+
+	_, _, err := b.next(ctx)
+	assert.ErrorIs(t, err, errBufferOverflow)
+}
+
+func TestSubscriptionBuffer_Block(t *testing.T) {
+	ctx := context.Background()
+	b := newSubscriptionBuffer[int](BufferConfig{Size: 1, Policy: OverflowBlock})
+
+	result, _ := b.push(ctx, 1)
+	assert.Equal(t, pushOK, result)
+
+	pushed := make(chan pushResult)
+	go func() {
+		result, _ := b.push(ctx, 2)
+		pushed <- result
+	}()
+
+	select {
+	case <-pushed:
+		t.Fatal("push must block while the buffer is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	msg, _, err := b.next(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, msg)
+
+	select {
+	case result := <-pushed:
+		assert.Equal(t, pushOK, result)
+	case <-time.After(time.Second):
+		t.Fatal("push must continue when there is room in the buffer")
+	}
+	msg, _, err = b.next(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, msg)
+}
+
+func TestSubscriptionBuffer_BlockClosed(t *testing.T) {
+	ctx := context.Background()
+	b := newSubscriptionBuffer[int](BufferConfig{Size: 1, Policy: OverflowBlock})
+	b.push(ctx, 1)
+
+	pushed := make(chan pushResult)
+	go func() {
+		result, _ := b.push(ctx, 2)
+		pushed <- result
+	}()
+	b.close()
+
+	select {
+	case result := <-pushed:
+		assert.Equal(t, pushClosed, result)
+	case <-time.After(time.Second):
+		t.Fatal("push must be released when the buffer is closed")
+	}
+
+	// the buffered messages are still delivered after the buffer is closed.
+	msg, _, err := b.next(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, msg)
+	_, _, err = b.next(ctx)
+	assert.ErrorIs(t, err, errBufferClosed)
+}
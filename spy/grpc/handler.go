@@ -28,42 +28,47 @@ func NewHandler(svs *SignedVaaSubscribers, logger *zap.Logger) *Handler {
 // SubscribeSignedVAA implements the suscriptions of signed VAA.
 func (h *Handler) SubscribeSignedVAA(req *spyv1.SubscribeSignedVAARequest, resp spyv1.SpyRPCService_SubscribeSignedVAAServer) error {
 	h.logger.Info("Receiving new subscriber in signed VAA")
-	var fi []filterSignedVaa
-	if req.Filters != nil {
-		for _, f := range req.Filters {
-			switch t := f.Filter.(type) {
-			case *spyv1.FilterEntry_EmitterFilter:
-				addr, err := vaa.StringToAddress(t.EmitterFilter.EmitterAddress)
-				if err != nil {
-					h.logger.Error("Decoding emitter address", zap.Error(err))
-					return status.Error(codes.InvalidArgument, fmt.Sprintf("failed to decode emitter address: %v", err))
-				}
-				fi = append(fi, filterSignedVaa{
-					chainId:     vaa.ChainID(t.EmitterFilter.ChainId),
-					emitterAddr: addr,
-				})
-			default:
-				h.logger.Error("Unsupported filter type", zap.Any("filter", t))
-				return status.Error(codes.InvalidArgument, "unsupported filter type")
-			}
-		}
+	fi, err := parseSignedVaaFilters(req.Filters)
+	if err != nil {
+		h.logger.Error("Decoding signed VAA filters", zap.Error(err))
+		return err
 	}
 
 	subscriber := h.svs.Register(fi)
 	defer h.svs.Unregister(subscriber)
 
 	for {
-		select {
-		case <-resp.Context().Done():
-			h.logger.Error("Context done", zap.String("id", subscriber.id), zap.Error(resp.Context().Err()))
-			return resp.Context().Err()
-		case msg := <-subscriber.ch:
-			if err := resp.Send(&spyv1.SubscribeSignedVAAResponse{
-				VaaBytes: msg.vaaBytes,
-			}); err != nil {
-				h.logger.Error("Sending vaas", zap.String("id", subscriber.id), zap.Error(err))
-				return err
+		msg, err := h.svs.Next(resp.Context(), subscriber)
+		if err != nil {
+			h.logger.Error("Receiving vaas", zap.String("id", subscriber.id), zap.Error(err))
+			return err
+		}
+		if err := resp.Send(&spyv1.SubscribeSignedVAAResponse{
+			VaaBytes: msg.vaaBytes,
+		}); err != nil {
+			h.logger.Error("Sending vaas", zap.String("id", subscriber.id), zap.Error(err))
+			return err
+		}
+	}
+}
+
+// parseSignedVaaFilters converts the filters of a subscription request. Only the emitter filters are supported.
+func parseSignedVaaFilters(filters []*spyv1.FilterEntry) ([]filterSignedVaa, error) {
+	var fi []filterSignedVaa
+	for _, f := range filters {
+		switch t := f.Filter.(type) {
+		case *spyv1.FilterEntry_EmitterFilter:
+			addr, err := vaa.StringToAddress(t.EmitterFilter.EmitterAddress)
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("failed to decode emitter address: %v", err))
 			}
+			fi = append(fi, filterSignedVaa{
+				chainId:     vaa.ChainID(t.EmitterFilter.ChainId),
+				emitterAddr: addr,
+			})
+		default:
+			return nil, status.Error(codes.InvalidArgument, "unsupported filter type")
 		}
 	}
+	return fi, nil
 }
@@ -10,6 +10,7 @@ import (
 	publicrpcv1 "github.com/certusone/wormhole/node/pkg/proto/publicrpc/v1"
 	spyv1 "github.com/certusone/wormhole/node/pkg/proto/spy/v1"
 	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/spy/internal/metrics"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
@@ -44,7 +45,7 @@ func createGRPCServer(handler *Handler, logger *zap.Logger) (context.Context, *g
 
 func TestSubscribeSignedVAA_OK(t *testing.T) {
 	logger := zaptest.NewLogger(t)
-	svs := NewSignedVaaSubscribers(logger)
+	svs := NewSignedVaaSubscribers(testBufferConfig, metrics.NewDummyMetrics(), logger)
 	handler := NewHandler(svs, logger)
 
 	_, _, client := createGRPCServer(handler, logger)
@@ -81,7 +82,7 @@ func TestSubscribeSignedVAA_OK(t *testing.T) {
 
 func TestSubscribeSignedVAA_Failed(t *testing.T) {
 	logger := zaptest.NewLogger(t)
-	svs := NewSignedVaaSubscribers(logger)
+	svs := NewSignedVaaSubscribers(testBufferConfig, metrics.NewDummyMetrics(), logger)
 	handler := NewHandler(svs, logger)
 
 	ctx, _, client := createGRPCServer(handler, logger)
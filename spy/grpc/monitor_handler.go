@@ -0,0 +1,113 @@
+package grpc
+
+import (
+	"fmt"
+
+	monitorv1 "github.com/wormhole-foundation/wormhole-explorer/spy/proto/monitor/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MonitorHandler represents a GRPC subscription service handler of the guardian monitoring messages.
+type MonitorHandler struct {
+	monitorv1.UnimplementedMonitorRPCServiceServer
+	ms     *MonitorSubscribers
+	logger *zap.Logger
+}
+
+// NewMonitorHandler creates a new handler of monitor suscriptions.
+func NewMonitorHandler(ms *MonitorSubscribers, logger *zap.Logger) *MonitorHandler {
+	return &MonitorHandler{
+		ms:     ms,
+		logger: logger,
+	}
+}
+
+// SubscribeSignedObservations implements the suscriptions of signed observations.
+func (h *MonitorHandler) SubscribeSignedObservations(req *monitorv1.SubscribeSignedObservationsRequest, resp monitorv1.MonitorRPCService_SubscribeSignedObservationsServer) error {
+	h.logger.Info("Receiving new subscriber in signed observations")
+	fi, err := h.guardianFilter(req.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	subscriber := h.ms.observations.register(fi)
+	defer h.ms.observations.unregister(subscriber)
+
+	for {
+		o, err := h.ms.observations.next(resp.Context(), subscriber)
+		if err != nil {
+			h.logger.Error("Receiving signed observations", zap.String("id", subscriber.id), zap.Error(err))
+			return err
+		}
+		if err := resp.Send(&monitorv1.SubscribeSignedObservationsResponse{
+			SignedObservation: o,
+		}); err != nil {
+			h.logger.Error("Sending signed observations", zap.String("id", subscriber.id), zap.Error(err))
+			return err
+		}
+	}
+}
+
+// SubscribeHeartbeats implements the suscriptions of heartbeats.
+func (h *MonitorHandler) SubscribeHeartbeats(req *monitorv1.SubscribeHeartbeatsRequest, resp monitorv1.MonitorRPCService_SubscribeHeartbeatsServer) error {
+	h.logger.Info("Receiving new subscriber in heartbeats")
+	fi, err := h.guardianFilter(req.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	subscriber := h.ms.heartbeats.register(fi)
+	defer h.ms.heartbeats.unregister(subscriber)
+
+	for {
+		hb, err := h.ms.heartbeats.next(resp.Context(), subscriber)
+		if err != nil {
+			h.logger.Error("Receiving heartbeats", zap.String("id", subscriber.id), zap.Error(err))
+			return err
+		}
+		if err := resp.Send(&monitorv1.SubscribeHeartbeatsResponse{
+			Heartbeat: hb,
+		}); err != nil {
+			h.logger.Error("Sending heartbeats", zap.String("id", subscriber.id), zap.Error(err))
+			return err
+		}
+	}
+}
+
+// SubscribeGovernorStatus implements the suscriptions of governor status.
+func (h *MonitorHandler) SubscribeGovernorStatus(req *monitorv1.SubscribeGovernorStatusRequest, resp monitorv1.MonitorRPCService_SubscribeGovernorStatusServer) error {
+	h.logger.Info("Receiving new subscriber in governor status")
+	fi, err := h.guardianFilter(req.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	subscriber := h.ms.governorStatus.register(fi)
+	defer h.ms.governorStatus.unregister(subscriber)
+
+	for {
+		s, err := h.ms.governorStatus.next(resp.Context(), subscriber)
+		if err != nil {
+			h.logger.Error("Receiving governor status", zap.String("id", subscriber.id), zap.Error(err))
+			return err
+		}
+		if err := resp.Send(&monitorv1.SubscribeGovernorStatusResponse{
+			SignedStatus: s.signed,
+			Status:       s.status,
+		}); err != nil {
+			h.logger.Error("Sending governor status", zap.String("id", subscriber.id), zap.Error(err))
+			return err
+		}
+	}
+}
+
+func (h *MonitorHandler) guardianFilter(f *monitorv1.GuardianFilter) (guardianFilter, error) {
+	fi, err := newGuardianFilter(f.GetGuardianAddresses(), f.GetChainIds())
+	if err != nil {
+		h.logger.Error("Decoding guardian filter", zap.Error(err))
+		return fi, status.Error(codes.InvalidArgument, fmt.Sprintf("failed to decode guardian filter: %v", err))
+	}
+	return fi, nil
+}
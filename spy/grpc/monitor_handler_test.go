@@ -0,0 +1,154 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/spy/internal/metrics"
+	monitorv1 "github.com/wormhole-foundation/wormhole-explorer/spy/proto/monitor/v1"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+func createMonitorGRPCServer(t *testing.T, handler *MonitorHandler, logger *zap.Logger) (context.Context, monitorv1.MonitorRPCServiceClient) {
+	listen := bufconn.Listen(1024 * 1024)
+	grpcServer := common.NewInstrumentedGRPCServer(logger, common.GrpcLogDetailMinimal)
+	monitorv1.RegisterMonitorRPCServiceServer(grpcServer, handler)
+	go func() {
+		if err := grpcServer.Serve(listen); err != nil {
+			logger.Fatal("Server exited with error", zap.Error(err))
+		}
+	}()
+	ctx := context.Background()
+	creds := grpc.WithTransportCredentials(insecure.NewCredentials())
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(
+			func(context.Context, string) (net.Conn, error) {
+				return listen.Dial()
+			}), creds)
+	if err != nil {
+		logger.Fatal("Failed to dial bufnet", zap.Error(err))
+	}
+	// the server is stopped before the next test creates its server, which updates the global grpc metrics.
+	t.Cleanup(func() {
+		conn.Close()
+		grpcServer.GracefulStop()
+	})
+
+	return ctx, monitorv1.NewMonitorRPCServiceClient(conn)
+}
+
+func TestSubscribeHeartbeats_OK(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	ms := NewMonitorSubscribers(testBufferConfig, metrics.NewDummyMetrics(), logger)
+	handler := NewMonitorHandler(ms, logger)
+
+	_, client := createMonitorGRPCServer(t, handler, logger)
+
+	doneMs := make(chan bool)
+	ctx, cancel := context.WithCancel(context.TODO())
+	go func() {
+		defer close(doneMs)
+		ms.Start(ctx)
+	}()
+
+	req := &monitorv1.SubscribeHeartbeatsRequest{
+		Filter: &monitorv1.GuardianFilter{GuardianAddresses: []string{guardianAddrHex}},
+	}
+	stream, err := client.SubscribeHeartbeats(ctx, req)
+	assert.Nil(t, err)
+	doneCh := make(chan bool)
+	go func() {
+		defer close(doneCh)
+		resp, err := stream.Recv()
+		assert.Nil(t, err)
+		assert.Equal(t, "guardian", resp.GetHeartbeat().GetNodeName())
+	}()
+	waitForMonitorSubscription(handler)
+
+	// the heartbeat of another guardian is not sent.
+	ms.HandleHeartbeat(&gossipv1.Heartbeat{NodeName: "other", GuardianAddr: "0x0000000000000000000000000000000000000001"})
+	ms.HandleHeartbeat(&gossipv1.Heartbeat{NodeName: "guardian", GuardianAddr: guardianAddrHex})
+	<-doneCh
+	cancel()
+	<-doneMs
+}
+
+func TestSubscribeGovernorStatus_OK(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	ms := NewMonitorSubscribers(testBufferConfig, metrics.NewDummyMetrics(), logger)
+	handler := NewMonitorHandler(ms, logger)
+
+	_, client := createMonitorGRPCServer(t, handler, logger)
+
+	doneMs := make(chan bool)
+	ctx, cancel := context.WithCancel(context.TODO())
+	go func() {
+		defer close(doneMs)
+		ms.Start(ctx)
+	}()
+
+	status := &gossipv1.ChainGovernorStatus{
+		NodeName: "guardian",
+		Chains:   []*gossipv1.ChainGovernorStatus_Chain{{ChainId: 1}, {ChainId: 2}},
+	}
+	statusBytes, _ := proto.Marshal(status)
+	signed := &gossipv1.SignedChainGovernorStatus{Status: statusBytes, GuardianAddr: guardianAddr}
+
+	req := &monitorv1.SubscribeGovernorStatusRequest{
+		Filter: &monitorv1.GuardianFilter{ChainIds: []uint32{2}},
+	}
+	stream, err := client.SubscribeGovernorStatus(ctx, req)
+	assert.Nil(t, err)
+	doneCh := make(chan bool)
+	go func() {
+		defer close(doneCh)
+		resp, err := stream.Recv()
+		assert.Nil(t, err)
+		assert.Equal(t, statusBytes, resp.GetSignedStatus().GetStatus())
+		assert.Equal(t, 1, len(resp.GetStatus().GetChains()))
+		assert.Equal(t, uint32(2), resp.GetStatus().GetChains()[0].GetChainId())
+	}()
+	waitForMonitorSubscription(handler)
+
+	ms.HandleGovernorStatus(signed)
+	<-doneCh
+	cancel()
+	<-doneMs
+}
+
+func TestSubscribeSignedObservations_Failed(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	ms := NewMonitorSubscribers(testBufferConfig, metrics.NewDummyMetrics(), logger)
+	handler := NewMonitorHandler(ms, logger)
+
+	ctx, client := createMonitorGRPCServer(t, handler, logger)
+
+	t.Run("invalid guardian address", func(t *testing.T) {
+		req := &monitorv1.SubscribeSignedObservationsRequest{
+			Filter: &monitorv1.GuardianFilter{GuardianAddresses: []string{"bad-address"}},
+		}
+		c, err := client.SubscribeSignedObservations(ctx, req)
+		assert.Nil(t, err)
+		_, err = c.Recv()
+		assert.NotNil(t, err)
+	})
+}
+
+func waitForMonitorSubscription(handler *MonitorHandler) {
+	tk := time.NewTicker(time.Millisecond * 100)
+	for range tk.C {
+		if len(handler.ms.Subscriptions()) > 0 {
+			return
+		}
+	}
+}
@@ -0,0 +1,323 @@
+package grpc
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/wormhole-foundation/wormhole-explorer/spy/internal/metrics"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	signedObservationsSubscriptionType = "signed-observations"
+	heartbeatsSubscriptionType         = "heartbeats"
+	governorStatusSubscriptionType     = "governor-status"
+)
+
+// guardianFilter selects the messages of a set of guardians and chains. An empty set matches everything.
+type guardianFilter struct {
+	guardians map[string]struct{}
+	chains    map[vaa.ChainID]struct{}
+}
+
+// newGuardianFilter creates a guardian filter from hex encoded guardian addresses and chain ids.
+func newGuardianFilter(guardianAddresses []string, chainIDs []uint32) (guardianFilter, error) {
+	fi := guardianFilter{
+		guardians: make(map[string]struct{}),
+		chains:    make(map[vaa.ChainID]struct{}),
+	}
+	for _, a := range guardianAddresses {
+		addr, err := hex.DecodeString(strings.TrimPrefix(a, "0x"))
+		if err != nil || len(addr) != 20 {
+			return fi, fmt.Errorf("invalid guardian address %s", a)
+		}
+		fi.guardians[hex.EncodeToString(addr)] = struct{}{}
+	}
+	for _, c := range chainIDs {
+		fi.chains[vaa.ChainID(c)] = struct{}{}
+	}
+	return fi, nil
+}
+
+func (f guardianFilter) matchGuardian(addr []byte) bool {
+	if len(f.guardians) == 0 {
+		return true
+	}
+	_, ok := f.guardians[hex.EncodeToString(addr)]
+	return ok
+}
+
+func (f guardianFilter) matchChain(chainID vaa.ChainID) bool {
+	if len(f.chains) == 0 {
+		return true
+	}
+	_, ok := f.chains[chainID]
+	return ok
+}
+
+// governorStatusMessage is a signed governor status with its status already unmarshalled.
+type governorStatusMessage struct {
+	signed *gossipv1.SignedChainGovernorStatus
+	status *gossipv1.ChainGovernorStatus
+}
+
+// filterSignedObservation applies the filter to the guardian and the emitter chain of the observation.
+func filterSignedObservation(f guardianFilter, o *gossipv1.SignedObservation) (*gossipv1.SignedObservation, bool) {
+	if !f.matchGuardian(o.Addr) {
+		return nil, false
+	}
+	if len(f.chains) == 0 {
+		return o, true
+	}
+	// the message id is chain/emitter/sequence.
+	chain, _, _ := strings.Cut(o.MessageId, "/")
+	chainID, err := strconv.ParseUint(chain, 10, 16)
+	if err != nil || !f.matchChain(vaa.ChainID(chainID)) {
+		return nil, false
+	}
+	return o, true
+}
+
+// filterHeartbeat applies the filter to the guardian of the heartbeat and keeps the networks of the filtered chains.
+func filterHeartbeat(f guardianFilter, hb *gossipv1.Heartbeat) (*gossipv1.Heartbeat, bool) {
+	addr, err := hex.DecodeString(strings.TrimPrefix(hb.GuardianAddr, "0x"))
+	if err != nil || !f.matchGuardian(addr) {
+		return nil, false
+	}
+	if len(f.chains) == 0 {
+		return hb, true
+	}
+	var networks []*gossipv1.Heartbeat_Network
+	for _, n := range hb.Networks {
+		if f.matchChain(vaa.ChainID(n.Id)) {
+			networks = append(networks, n)
+		}
+	}
+	if len(networks) == 0 {
+		return nil, false
+	}
+	filtered := proto.Clone(hb).(*gossipv1.Heartbeat)
+	filtered.Networks = networks
+	return filtered, true
+}
+
+// filterGovernorStatus applies the filter to the guardian of the governor status and keeps the filtered chains.
+// The signed status is not modified, so that its signature can be verified.
+func filterGovernorStatus(f guardianFilter, s *governorStatusMessage) (*governorStatusMessage, bool) {
+	if !f.matchGuardian(s.signed.GuardianAddr) {
+		return nil, false
+	}
+	if len(f.chains) == 0 {
+		return s, true
+	}
+	var chains []*gossipv1.ChainGovernorStatus_Chain
+	for _, c := range s.status.Chains {
+		if f.matchChain(vaa.ChainID(c.ChainId)) {
+			chains = append(chains, c)
+		}
+	}
+	if len(chains) == 0 {
+		return nil, false
+	}
+	status := proto.Clone(s.status).(*gossipv1.ChainGovernorStatus)
+	status.Chains = chains
+	return &governorStatusMessage{signed: s.signed, status: status}, true
+}
+
+type monitorSubscription[T any] struct {
+	id        string
+	filter    guardianFilter
+	buffer    *subscriptionBuffer[T]
+	createdAt time.Time
+}
+
+// monitorSubscribers dispatches the messages of a type to the subscribers whose filter matches.
+type monitorSubscribers[T any] struct {
+	name             string
+	subscriptionType string
+	source           chan T
+	// subscribers is only modified by the dispatch loop, mu allows to list them from other goroutines.
+	mu               sync.RWMutex
+	subscribers      map[string]*monitorSubscription[T]
+	addSubscriber    chan *monitorSubscription[T]
+	removeSubscriber chan *monitorSubscription[T]
+	filter           func(guardianFilter, T) (T, bool)
+	bufferConfig     BufferConfig
+	metrics          metrics.Metrics
+	logger           *zap.Logger
+}
+
+func newMonitorSubscribers[T any](name, subscriptionType string, filter func(guardianFilter, T) (T, bool),
+	bufferConfig BufferConfig, metrics metrics.Metrics, logger *zap.Logger) *monitorSubscribers[T] {
+	return &monitorSubscribers[T]{
+		name:             name,
+		subscriptionType: subscriptionType,
+		source:           make(chan T, 1),
+		subscribers:      make(map[string]*monitorSubscription[T]),
+		addSubscriber:    make(chan *monitorSubscription[T], 1),
+		removeSubscriber: make(chan *monitorSubscription[T], 1),
+		filter:           filter,
+		bufferConfig:     bufferConfig,
+		metrics:          metrics,
+		logger:           logger,
+	}
+}
+
+func (s *monitorSubscribers[T]) register(fi guardianFilter) *monitorSubscription[T] {
+	sub := &monitorSubscription[T]{
+		id:        subscriptionId(),
+		filter:    fi,
+		buffer:    newSubscriptionBuffer[T](s.bufferConfig),
+		createdAt: time.Now(),
+	}
+	s.logger.Info("Registering subscriber in "+s.name+" ...", zap.String("id", sub.id))
+	s.addSubscriber <- sub
+	return sub
+}
+
+func (s *monitorSubscribers[T]) unregister(sub *monitorSubscription[T]) {
+	s.logger.Info("Unregistering subscriber in "+s.name+" ...", zap.String("id", sub.id))
+	// the buffer is closed first to release the dispatch loop if it is blocked on this subscriber.
+	sub.buffer.close()
+	s.removeSubscriber <- sub
+}
+
+// next waits for the next message of a subscriber.
+func (s *monitorSubscribers[T]) next(ctx context.Context, sub *monitorSubscription[T]) (T, error) {
+	msg, lag, err := sub.buffer.next(ctx)
+	if err != nil {
+		return msg, subscriptionError(err, s.name)
+	}
+	s.metrics.SetSubscriberLag(s.subscriptionType, sub.id, lag)
+	return msg, nil
+}
+
+func (s *monitorSubscribers[T]) subscriptions() []SubscriptionInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	subscriptions := make([]SubscriptionInfo, 0, len(s.subscribers))
+	for _, sub := range s.subscribers {
+		info := SubscriptionInfo{
+			ID:         sub.id,
+			Type:       s.subscriptionType,
+			CreatedAt:  sub.createdAt,
+			BufferSize: sub.buffer.size,
+		}
+		info.Buffered, info.Dropped = sub.buffer.stats()
+		for g := range sub.filter.guardians {
+			info.Guardians = append(info.Guardians, "0x"+g)
+		}
+		for c := range sub.filter.chains {
+			info.Chains = append(info.Chains, uint16(c))
+		}
+		subscriptions = append(subscriptions, info)
+	}
+	return subscriptions
+}
+
+func (s *monitorSubscribers[T]) start(ctx context.Context) {
+	defer func() {
+		for _, sub := range s.subscribers {
+			sub.buffer.close()
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sub := <-s.addSubscriber:
+			s.mu.Lock()
+			s.subscribers[sub.id] = sub
+			s.mu.Unlock()
+			s.logger.Info("New subscriber registered in "+s.name, zap.String("id", sub.id))
+		case sub := <-s.removeSubscriber:
+			if subscriber, exists := s.subscribers[sub.id]; exists {
+				subscriber.buffer.close()
+				s.mu.Lock()
+				delete(s.subscribers, sub.id)
+				s.mu.Unlock()
+				s.metrics.DeleteSubscriber(s.subscriptionType, sub.id)
+				s.logger.Info("Subscriber unregistered in "+s.name, zap.String("id", sub.id))
+			}
+		case msg := <-s.source:
+			for _, sub := range s.subscribers {
+				filtered, ok := s.filter(sub.filter, msg)
+				if !ok {
+					continue
+				}
+				result, lag := sub.buffer.push(ctx, filtered)
+				recordPush(s.metrics, s.logger, s.subscriptionType, sub.id, result, lag)
+			}
+		}
+	}
+}
+
+// MonitorSubscribers represents the subscribers of signed observations, heartbeats and governor status.
+type MonitorSubscribers struct {
+	observations   *monitorSubscribers[*gossipv1.SignedObservation]
+	heartbeats     *monitorSubscribers[*gossipv1.Heartbeat]
+	governorStatus *monitorSubscribers[*governorStatusMessage]
+	logger         *zap.Logger
+}
+
+// NewMonitorSubscribers creates the monitor subscribers.
+func NewMonitorSubscribers(bufferConfig BufferConfig, metrics metrics.Metrics, logger *zap.Logger) *MonitorSubscribers {
+	return &MonitorSubscribers{
+		observations: newMonitorSubscribers("signed observations", signedObservationsSubscriptionType,
+			filterSignedObservation, bufferConfig, metrics, logger),
+		heartbeats: newMonitorSubscribers("heartbeats", heartbeatsSubscriptionType,
+			filterHeartbeat, bufferConfig, metrics, logger),
+		governorStatus: newMonitorSubscribers("governor status", governorStatusSubscriptionType,
+			filterGovernorStatus, bufferConfig, metrics, logger),
+		logger: logger,
+	}
+}
+
+// Subscriptions returns the active subscriptions of signed observations, heartbeats and governor status.
+func (m *MonitorSubscribers) Subscriptions() []SubscriptionInfo {
+	subscriptions := m.observations.subscriptions()
+	subscriptions = append(subscriptions, m.heartbeats.subscriptions()...)
+	return append(subscriptions, m.governorStatus.subscriptions()...)
+}
+
+// Start dispatches the messages to the subscribers until the context is done.
+func (m *MonitorSubscribers) Start(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, start := range []func(context.Context){m.observations.start, m.heartbeats.start, m.governorStatus.start} {
+		wg.Add(1)
+		go func(start func(context.Context)) {
+			defer wg.Done()
+			start(ctx)
+		}(start)
+	}
+	wg.Wait()
+}
+
+// HandleSignedObservation sends a signed observation to the subscribers that filters apply the conditions.
+func (m *MonitorSubscribers) HandleSignedObservation(o *gossipv1.SignedObservation) {
+	m.observations.source <- o
+}
+
+// HandleHeartbeat sends a heartbeat to the subscribers that filters apply the conditions.
+func (m *MonitorSubscribers) HandleHeartbeat(hb *gossipv1.Heartbeat) {
+	m.heartbeats.source <- hb
+}
+
+// HandleGovernorStatus sends a governor status to the subscribers that filters apply the conditions.
+func (m *MonitorSubscribers) HandleGovernorStatus(s *gossipv1.SignedChainGovernorStatus) {
+	var status gossipv1.ChainGovernorStatus
+	if err := proto.Unmarshal(s.Status, &status); err != nil {
+		m.logger.Error("Unmarshal governor status in monitor subscribers", zap.Error(err))
+		return
+	}
+	m.governorStatus.source <- &governorStatusMessage{signed: s, status: &status}
+}
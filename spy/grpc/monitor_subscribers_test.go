@@ -0,0 +1,88 @@
+package grpc
+
+import (
+	"testing"
+
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+var guardianAddr = []byte{0x58, 0xcc, 0x3a, 0xe5, 0xc0, 0x97, 0xb2, 0x13, 0xce, 0x3c, 0x81, 0x97, 0x9e, 0x1b, 0x9f, 0x95, 0x70, 0x74, 0x6a, 0xa5}
+
+const guardianAddrHex = "0x58CC3AE5C097b213cE3c81979e1B9f9570746AA5"
+
+func TestNewGuardianFilter(t *testing.T) {
+
+	t.Run("valid filter", func(t *testing.T) {
+		fi, err := newGuardianFilter([]string{guardianAddrHex}, []uint32{2})
+		assert.Nil(t, err)
+		assert.True(t, fi.matchGuardian(guardianAddr))
+		assert.False(t, fi.matchGuardian([]byte{0x1}))
+		assert.True(t, fi.matchChain(vaa.ChainIDEthereum))
+		assert.False(t, fi.matchChain(vaa.ChainIDSolana))
+	})
+
+	t.Run("empty filter", func(t *testing.T) {
+		fi, err := newGuardianFilter(nil, nil)
+		assert.Nil(t, err)
+		assert.True(t, fi.matchGuardian(guardianAddr))
+		assert.True(t, fi.matchChain(vaa.ChainIDSolana))
+	})
+
+	t.Run("invalid guardian address", func(t *testing.T) {
+		_, err := newGuardianFilter([]string{"0x1234"}, nil)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestFilterSignedObservation(t *testing.T) {
+	fi, _ := newGuardianFilter([]string{guardianAddrHex}, []uint32{2})
+
+	o := &gossipv1.SignedObservation{Addr: guardianAddr, MessageId: "2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/1"}
+	filtered, ok := filterSignedObservation(fi, o)
+	assert.True(t, ok)
+	assert.Equal(t, o, filtered)
+
+	o = &gossipv1.SignedObservation{Addr: guardianAddr, MessageId: "1/ec7372995d5cc8732397fb0ad35c0121e0eaa90d26f828a534cab54391b3a4f5/1"}
+	_, ok = filterSignedObservation(fi, o)
+	assert.False(t, ok)
+}
+
+func TestFilterHeartbeat(t *testing.T) {
+	fi, _ := newGuardianFilter([]string{guardianAddrHex}, []uint32{2})
+
+	hb := &gossipv1.Heartbeat{
+		GuardianAddr: guardianAddrHex,
+		Networks:     []*gossipv1.Heartbeat_Network{{Id: 1}, {Id: 2}},
+	}
+	filtered, ok := filterHeartbeat(fi, hb)
+	assert.True(t, ok)
+	assert.Equal(t, 1, len(filtered.Networks))
+	assert.Equal(t, uint32(2), filtered.Networks[0].Id)
+	// the original heartbeat is sent to the other subscribers.
+	assert.Equal(t, 2, len(hb.Networks))
+
+	hb = &gossipv1.Heartbeat{
+		GuardianAddr: guardianAddrHex,
+		Networks:     []*gossipv1.Heartbeat_Network{{Id: 1}},
+	}
+	_, ok = filterHeartbeat(fi, hb)
+	assert.False(t, ok)
+}
+
+func TestFilterGovernorStatus(t *testing.T) {
+	fi, _ := newGuardianFilter(nil, []uint32{2})
+
+	s := &governorStatusMessage{
+		signed: &gossipv1.SignedChainGovernorStatus{GuardianAddr: guardianAddr},
+		status: &gossipv1.ChainGovernorStatus{
+			Chains: []*gossipv1.ChainGovernorStatus_Chain{{ChainId: 1}, {ChainId: 2}},
+		},
+	}
+	filtered, ok := filterGovernorStatus(fi, s)
+	assert.True(t, ok)
+	assert.Equal(t, s.signed, filtered.signed)
+	assert.Equal(t, 1, len(filtered.status.Chains))
+	assert.Equal(t, 2, len(s.status.Chains))
+}
@@ -0,0 +1,222 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	spyv1 "github.com/certusone/wormhole/node/pkg/proto/spy/v1"
+	replayv1 "github.com/wormhole-foundation/wormhole-explorer/spy/proto/replay/v1"
+	"github.com/wormhole-foundation/wormhole-explorer/spy/storage"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// replayOverlap is the time a VAA may be received after it was indexed. The VAAs replayed that were
+// indexed within this time before the subscription may also be received, so they are discarded.
+const replayOverlap = time.Minute
+
+// VaaRepository finds the signed VAAs to replay.
+type VaaRepository interface {
+	FindVaas(ctx context.Context, q storage.VaaQuery, handler func(*storage.VaaDoc) error) error
+	HasVaasIndexedBefore(ctx context.Context, e storage.EmitterSequence, before time.Time) (bool, error)
+}
+
+// ReplayHandler represents a GRPC subscription service handler of signed VAA with replay of the backlog.
+type ReplayHandler struct {
+	replayv1.UnimplementedReplayRPCServiceServer
+	svs        *SignedVaaSubscribers
+	repository VaaRepository
+	maxAge     time.Duration
+	logger     *zap.Logger
+}
+
+// NewReplayHandler creates a new handler of replay suscriptions. The backlog is limited to the VAAs indexed within maxAge.
+func NewReplayHandler(svs *SignedVaaSubscribers, repository VaaRepository, maxAge time.Duration, logger *zap.Logger) *ReplayHandler {
+	return &ReplayHandler{
+		svs:        svs,
+		repository: repository,
+		maxAge:     maxAge,
+		logger:     logger,
+	}
+}
+
+// SubscribeSignedVAA implements the suscriptions of signed VAA from a starting point.
+// The subscription is registered before reading the backlog, so that the VAAs received meanwhile are kept
+// in its buffer and sent after the backlog without the ones already replayed.
+func (h *ReplayHandler) SubscribeSignedVAA(req *replayv1.SubscribeSignedVAARequest, resp replayv1.ReplayRPCService_SubscribeSignedVAAServer) error {
+	h.logger.Info("Receiving new subscriber in signed VAA replay")
+	fi, err := parseSignedVaaFilters(req.Filters)
+	if err != nil {
+		h.logger.Error("Decoding signed VAA filters", zap.Error(err))
+		return err
+	}
+	query, err := h.replayQuery(req)
+	if err != nil {
+		h.logger.Error("Decoding replay starting point", zap.Error(err))
+		return err
+	}
+
+	ctx := resp.Context()
+	if query != nil {
+		if err := h.checkReplayWindow(ctx, query); err != nil {
+			h.logger.Error("Checking replay starting point", zap.Error(err))
+			return err
+		}
+	}
+	if query == nil {
+		subscriber := h.svs.Register(fi)
+		defer h.svs.Unregister(subscriber)
+		return h.sendLive(ctx, subscriber, resp, newReplayedVaas())
+	}
+
+	subscriber := h.svs.RegisterReplay(fi)
+	defer h.svs.Unregister(subscriber)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-subscriber.registered:
+	}
+	registeredAt := time.Now()
+
+	replayed := newReplayedVaas()
+	var count int
+	err = h.repository.FindVaas(ctx, *query, func(doc *storage.VaaDoc) error {
+		// the VAAs received while replaying did not fit in the buffer, so the rest of the backlog is useless.
+		if subscriber.buffer.isOverflowed() {
+			return subscriptionError(errBufferOverflow, "signed VAA")
+		}
+		if len(fi) > 0 {
+			v, err := vaa.Unmarshal(doc.Vaas)
+			if err != nil {
+				h.logger.Error("Unmarshal replayed vaa", zap.String("vaaId", doc.ID), zap.Error(err))
+				return nil
+			}
+			if !matchSignedVaa(fi, v) {
+				return nil
+			}
+		}
+		if doc.IndexedAt.After(registeredAt.Add(-replayOverlap)) {
+			replayed.add(doc.ID)
+		}
+		count++
+		return resp.Send(&spyv1.SubscribeSignedVAAResponse{VaaBytes: doc.Vaas})
+	})
+	if err != nil {
+		h.logger.Error("Replaying signed VAAs", zap.String("id", subscriber.id), zap.Error(err))
+		return err
+	}
+
+	h.svs.EndReplay(subscriber)
+	h.logger.Info("Signed VAAs replayed", zap.String("id", subscriber.id), zap.Int("replayed", count))
+	return h.sendLive(ctx, subscriber, resp, replayed)
+}
+
+// sendLive sends the received VAAs until the subscription ends. The VAAs replayed are discarded
+// during the replay overlap, when they may still be received.
+func (h *ReplayHandler) sendLive(ctx context.Context, subscriber *subscriptionSignedVaa, resp replayv1.ReplayRPCService_SubscribeSignedVAAServer, replayed *replayedVaas) error {
+	overlapEnd := time.Now().Add(replayOverlap)
+	for {
+		msg, err := h.svs.Next(ctx, subscriber)
+		if err != nil {
+			h.logger.Error("Receiving vaas", zap.String("id", subscriber.id), zap.Error(err))
+			return err
+		}
+		if time.Now().Before(overlapEnd) && replayed.discard(msg.vaaBytes) {
+			continue
+		}
+		if err := resp.Send(&spyv1.SubscribeSignedVAAResponse{
+			VaaBytes: msg.vaaBytes,
+		}); err != nil {
+			h.logger.Error("Sending vaas", zap.String("id", subscriber.id), zap.Error(err))
+			return err
+		}
+	}
+}
+
+// replayQuery creates the query of the backlog of a subscription. It is nil when there is no starting point.
+func (h *ReplayHandler) replayQuery(req *replayv1.SubscribeSignedVAARequest) (*storage.VaaQuery, error) {
+	oldest := time.Now().Add(-h.maxAge)
+	switch t := req.Start.(type) {
+	case nil:
+		return nil, nil
+	case *replayv1.SubscribeSignedVAARequest_StartTime:
+		if err := t.StartTime.CheckValid(); err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid start time: %v", err))
+		}
+		startTime := t.StartTime.AsTime()
+		if startTime.Before(oldest) {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("start time is older than the replay window of %s", h.maxAge))
+		}
+		return &storage.VaaQuery{IndexedFrom: startTime}, nil
+	case *replayv1.SubscribeSignedVAARequest_StartSequences:
+		sequences := t.StartSequences.GetSequences()
+		if len(sequences) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "empty start sequences")
+		}
+		// the backlog of the emitters must be within the replay window, which is checked before the replay.
+		query := storage.VaaQuery{IndexedFrom: oldest}
+		for _, s := range sequences {
+			addr, err := vaa.StringToAddress(s.EmitterAddress)
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("failed to decode emitter address: %v", err))
+			}
+			query.EmitterSequences = append(query.EmitterSequences, storage.EmitterSequence{
+				ChainID:  vaa.ChainID(s.ChainId),
+				Address:  addr,
+				Sequence: s.Sequence,
+			})
+		}
+		return &query, nil
+	default:
+		return nil, status.Error(codes.InvalidArgument, "unsupported start type")
+	}
+}
+
+// checkReplayWindow checks that the backlog of each emitter sequence is within the replay window,
+// so that no VAA of the emitters is skipped because it was indexed before the window.
+func (h *ReplayHandler) checkReplayWindow(ctx context.Context, query *storage.VaaQuery) error {
+	for _, e := range query.EmitterSequences {
+		outOfRange, err := h.repository.HasVaasIndexedBefore(ctx, e, query.IndexedFrom)
+		if err != nil {
+			return err
+		}
+		if outOfRange {
+			return status.Error(codes.OutOfRange, fmt.Sprintf("sequence %d of emitter %d/%s is older than the replay window of %s",
+				e.Sequence, e.ChainID, e.Address, h.maxAge))
+		}
+	}
+	return nil
+}
+
+// replayedVaas are the ids of the VAAs replayed that may also be received.
+type replayedVaas struct {
+	ids map[string]struct{}
+}
+
+func newReplayedVaas() *replayedVaas {
+	return &replayedVaas{ids: make(map[string]struct{})}
+}
+
+func (r *replayedVaas) add(id string) {
+	r.ids[id] = struct{}{}
+}
+
+// discard checks if a received VAA was replayed. A VAA is only received once, so it is removed.
+func (r *replayedVaas) discard(vaaBytes []byte) bool {
+	if len(r.ids) == 0 {
+		return false
+	}
+	v, err := vaa.Unmarshal(vaaBytes)
+	if err != nil {
+		return false
+	}
+	id := v.MessageID()
+	if _, ok := r.ids[id]; !ok {
+		return false
+	}
+	delete(r.ids, id)
+	return true
+}
@@ -0,0 +1,266 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/spy/internal/metrics"
+	replayv1 "github.com/wormhole-foundation/wormhole-explorer/spy/proto/replay/v1"
+	"github.com/wormhole-foundation/wormhole-explorer/spy/storage"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type vaaRepositoryMock struct {
+	docs []*storage.VaaDoc
+	// beforeFind is called before the backlog is read.
+	beforeFind func()
+	query      storage.VaaQuery
+	// indexedBefore is the result of HasVaasIndexedBefore.
+	indexedBefore bool
+	before        time.Time
+}
+
+func (m *vaaRepositoryMock) FindVaas(_ context.Context, q storage.VaaQuery, handler func(*storage.VaaDoc) error) error {
+	m.query = q
+	if m.beforeFind != nil {
+		m.beforeFind()
+	}
+	for _, doc := range m.docs {
+		if err := handler(doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *vaaRepositoryMock) HasVaasIndexedBefore(_ context.Context, _ storage.EmitterSequence, before time.Time) (bool, error) {
+	m.before = before
+	return m.indexedBefore, nil
+}
+
+func createReplayGRPCServer(t *testing.T, handler *ReplayHandler, logger *zap.Logger) (context.Context, replayv1.ReplayRPCServiceClient) {
+	listen := bufconn.Listen(1024 * 1024)
+	grpcServer := common.NewInstrumentedGRPCServer(logger, common.GrpcLogDetailMinimal)
+	replayv1.RegisterReplayRPCServiceServer(grpcServer, handler)
+	go func() {
+		if err := grpcServer.Serve(listen); err != nil {
+			logger.Fatal("Server exited with error", zap.Error(err))
+		}
+	}()
+	ctx := context.Background()
+	creds := grpc.WithTransportCredentials(insecure.NewCredentials())
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(
+			func(context.Context, string) (net.Conn, error) {
+				return listen.Dial()
+			}), creds)
+	if err != nil {
+		logger.Fatal("Failed to dial bufnet", zap.Error(err))
+	}
+	// the server is stopped before the next test creates its server, which updates the global grpc metrics.
+	t.Cleanup(func() {
+		conn.Close()
+		grpcServer.GracefulStop()
+	})
+
+	return ctx, replayv1.NewReplayRPCServiceClient(conn)
+}
+
+func createVAABytes(sequence uint64) []byte {
+	v := createVAA(vaa.ChainIDEthereum, emitterAddr)
+	v.Sequence = sequence
+	vaaBytes, _ := v.MarshalBinary()
+	return vaaBytes
+}
+
+func TestReplaySubscribeSignedVAA_OK(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	svs := NewSignedVaaSubscribers(testBufferConfig, metrics.NewDummyMetrics(), logger)
+
+	vaa1, vaa2, vaa3 := createVAABytes(1), createVAABytes(2), createVAABytes(3)
+	now := time.Now()
+	repository := &vaaRepositoryMock{
+		docs: []*storage.VaaDoc{
+			{ID: "2/0000000000000000000000000000000000000000000000000000000000000004/1", Vaas: vaa1, IndexedAt: now.Add(-time.Hour)},
+			{ID: "2/0000000000000000000000000000000000000000000000000000000000000004/2", Vaas: vaa2, IndexedAt: now},
+		},
+	}
+	// the vaa 2 is stored and received while the backlog is read, the vaa 3 is only received.
+	repository.beforeFind = func() {
+		_ = svs.HandleVAA(vaa2)
+		_ = svs.HandleVAA(vaa3)
+	}
+	handler := NewReplayHandler(svs, repository, 24*time.Hour, logger)
+
+	_, client := createReplayGRPCServer(t, handler, logger)
+
+	doneSvs := make(chan bool)
+	ctx, cancel := context.WithCancel(context.TODO())
+	go func() {
+		defer close(doneSvs)
+		svs.Start(ctx)
+	}()
+
+	req := &replayv1.SubscribeSignedVAARequest{
+		Start: &replayv1.SubscribeSignedVAARequest_StartTime{StartTime: timestamppb.New(now.Add(-2 * time.Hour))},
+	}
+	stream, err := client.SubscribeSignedVAA(ctx, req)
+	assert.Nil(t, err)
+	for _, expected := range [][]byte{vaa1, vaa2, vaa3} {
+		resp, err := stream.Recv()
+		assert.Nil(t, err)
+		assert.Equal(t, expected, resp.VaaBytes)
+	}
+	assert.Equal(t, now.Add(-2*time.Hour).UnixNano(), repository.query.IndexedFrom.UnixNano())
+	cancel()
+	<-doneSvs
+}
+
+func TestReplaySubscribeSignedVAA_StartSequences(t *testing.T) {
+	var tests = []struct {
+		name          string
+		indexedBefore bool
+		expectedCode  codes.Code
+	}{
+		{
+			name:         "within the replay window",
+			expectedCode: codes.OK,
+		},
+		{
+			name:          "older than the replay window",
+			indexedBefore: true,
+			expectedCode:  codes.OutOfRange,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := zaptest.NewLogger(t)
+			svs := NewSignedVaaSubscribers(testBufferConfig, metrics.NewDummyMetrics(), logger)
+			vaa1 := createVAABytes(1)
+			repository := &vaaRepositoryMock{
+				docs: []*storage.VaaDoc{
+					{ID: "2/0000000000000000000000000000000000000000000000000000000000000004/1", Vaas: vaa1, IndexedAt: time.Now().Add(-time.Hour)},
+				},
+				indexedBefore: tc.indexedBefore,
+			}
+			handler := NewReplayHandler(svs, repository, 24*time.Hour, logger)
+
+			_, client := createReplayGRPCServer(t, handler, logger)
+
+			doneSvs := make(chan bool)
+			ctx, cancel := context.WithCancel(context.TODO())
+			go func() {
+				defer close(doneSvs)
+				svs.Start(ctx)
+			}()
+
+			req := &replayv1.SubscribeSignedVAARequest{
+				Start: &replayv1.SubscribeSignedVAARequest_StartSequences{
+					StartSequences: &replayv1.EmitterSequences{
+						Sequences: []*replayv1.EmitterSequence{{ChainId: 2, EmitterAddress: emitterAddr.String(), Sequence: 1}},
+					},
+				},
+			}
+			stream, err := client.SubscribeSignedVAA(ctx, req)
+			assert.Nil(t, err)
+			resp, err := stream.Recv()
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			if tc.expectedCode == codes.OK {
+				assert.Equal(t, vaa1, resp.VaaBytes)
+				// the window checked is the one of the query.
+				assert.Equal(t, repository.query.IndexedFrom, repository.before)
+			}
+			cancel()
+			<-doneSvs
+		})
+	}
+}
+
+func TestReplaySubscribeSignedVAA_Overflow(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	svs := NewSignedVaaSubscribers(BufferConfig{Size: 2, Policy: OverflowDropOldest}, metrics.NewDummyMetrics(), logger)
+
+	now := time.Now()
+	repository := &vaaRepositoryMock{
+		docs: []*storage.VaaDoc{
+			{ID: "2/0000000000000000000000000000000000000000000000000000000000000004/1", Vaas: createVAABytes(1), IndexedAt: now.Add(-time.Hour)},
+		},
+	}
+	// more VAAs than the buffer size are received while the backlog is read.
+	repository.beforeFind = func() {
+		for sequence := uint64(2); sequence <= 4; sequence++ {
+			_ = svs.HandleVAA(createVAABytes(sequence))
+		}
+	}
+	handler := NewReplayHandler(svs, repository, 24*time.Hour, logger)
+
+	_, client := createReplayGRPCServer(t, handler, logger)
+
+	doneSvs := make(chan bool)
+	ctx, cancel := context.WithCancel(context.TODO())
+	go func() {
+		defer close(doneSvs)
+		svs.Start(ctx)
+	}()
+
+	req := &replayv1.SubscribeSignedVAARequest{
+		Start: &replayv1.SubscribeSignedVAARequest_StartTime{StartTime: timestamppb.New(now.Add(-2 * time.Hour))},
+	}
+	stream, err := client.SubscribeSignedVAA(ctx, req)
+	assert.Nil(t, err)
+	// the stream fails instead of dropping the VAAs, so the client can resume without a gap.
+	for {
+		_, err = stream.Recv()
+		if err != nil {
+			break
+		}
+	}
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	cancel()
+	<-doneSvs
+}
+
+func TestReplaySubscribeSignedVAA_Failed(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	svs := NewSignedVaaSubscribers(testBufferConfig, metrics.NewDummyMetrics(), logger)
+	handler := NewReplayHandler(svs, &vaaRepositoryMock{}, 24*time.Hour, logger)
+
+	ctx, client := createReplayGRPCServer(t, handler, logger)
+
+	t.Run("start time older than the replay window", func(t *testing.T) {
+		req := &replayv1.SubscribeSignedVAARequest{
+			Start: &replayv1.SubscribeSignedVAARequest_StartTime{StartTime: timestamppb.New(time.Now().Add(-48 * time.Hour))},
+		}
+		c, err := client.SubscribeSignedVAA(ctx, req)
+		assert.Nil(t, err)
+		_, err = c.Recv()
+		assert.NotNil(t, err)
+	})
+
+	t.Run("invalid emitter address", func(t *testing.T) {
+		req := &replayv1.SubscribeSignedVAARequest{
+			Start: &replayv1.SubscribeSignedVAARequest_StartSequences{
+				StartSequences: &replayv1.EmitterSequences{
+					Sequences: []*replayv1.EmitterSequence{{EmitterAddress: "bad-address", Sequence: 1}},
+				},
+			},
+		}
+		c, err := client.SubscribeSignedVAA(ctx, req)
+		assert.Nil(t, err)
+		_, err = c.Recv()
+		assert.NotNil(t, err)
+	})
+}
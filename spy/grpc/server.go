@@ -7,6 +7,8 @@ import (
 	"github.com/certusone/wormhole/node/pkg/common"
 	spyv1 "github.com/certusone/wormhole/node/pkg/proto/spy/v1"
 	"github.com/certusone/wormhole/node/pkg/supervisor"
+	monitorv1 "github.com/wormhole-foundation/wormhole-explorer/spy/proto/monitor/v1"
+	replayv1 "github.com/wormhole-foundation/wormhole-explorer/spy/proto/replay/v1"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 )
@@ -17,7 +19,7 @@ type Server struct {
 }
 
 // NewServer creates a GRPC server.
-func NewServer(h *Handler, logger *zap.Logger, listenAddr string) (*Server, error) {
+func NewServer(h *Handler, mh *MonitorHandler, rh *ReplayHandler, logger *zap.Logger, listenAddr string) (*Server, error) {
 	l, err := net.Listen("tcp", listenAddr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to listen: %w", err)
@@ -27,6 +29,8 @@ func NewServer(h *Handler, logger *zap.Logger, listenAddr string) (*Server, erro
 
 	grpcServer := common.NewInstrumentedGRPCServer(logger, common.GrpcLogDetailMinimal)
 	spyv1.RegisterSpyRPCServiceServer(grpcServer, h)
+	monitorv1.RegisterMonitorRPCServiceServer(grpcServer, mh)
+	replayv1.RegisterReplayRPCServiceServer(grpcServer, rh)
 
 	runnale := supervisor.GRPCServer(grpcServer, l, false)
 	return &Server{Runnable: runnale, srv: grpcServer}, nil
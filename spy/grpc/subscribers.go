@@ -2,12 +2,17 @@ package grpc
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/wormhole-foundation/wormhole-explorer/spy/internal/metrics"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
 )
 
+const signedVaaSubscriptionType = "signed-vaa"
+
 type message struct {
 	vaaBytes []byte
 }
@@ -17,9 +22,15 @@ type filterSignedVaa struct {
 	emitterAddr vaa.Address
 }
 type subscriptionSignedVaa struct {
-	id      string
-	filters []filterSignedVaa
-	ch      chan message
+	id        string
+	filters   []filterSignedVaa
+	buffer    *subscriptionBuffer[message]
+	createdAt time.Time
+	// registered is closed when the subscription is added to the subscribers.
+	registered chan struct{}
+	// while the backlog of the subscription is replayed, the received VAAs are kept in the buffer
+	// and the subscription is disconnected if they do not fit.
+	replaying bool
 }
 
 func subscriptionId() string {
@@ -28,32 +39,57 @@ func subscriptionId() string {
 
 // SignedVaaSubscribers represents signed VAA subscribers.
 type SignedVaaSubscribers struct {
-	source           chan []byte
+	source chan []byte
+	// subscribers is only modified by the dispatch loop, mu allows to list them from other goroutines.
+	mu               sync.RWMutex
 	subscribers      map[string]*subscriptionSignedVaa
 	addSubscriber    chan *subscriptionSignedVaa
 	removeSubscriber chan *subscriptionSignedVaa
+	bufferConfig     BufferConfig
+	metrics          metrics.Metrics
 	logger           *zap.Logger
 }
 
 // NewSignedVaaSubscribers creates a signed VAA subscribers.
-func NewSignedVaaSubscribers(logger *zap.Logger) *SignedVaaSubscribers {
+func NewSignedVaaSubscribers(bufferConfig BufferConfig, metrics metrics.Metrics, logger *zap.Logger) *SignedVaaSubscribers {
 	return &SignedVaaSubscribers{
 		subscribers:      make(map[string]*subscriptionSignedVaa),
 		addSubscriber:    make(chan *subscriptionSignedVaa, 1),
 		removeSubscriber: make(chan *subscriptionSignedVaa, 1),
 		source:           make(chan []byte, 1),
+		bufferConfig:     bufferConfig,
+		metrics:          metrics,
 		logger:           logger,
 	}
 }
 
 // Register registers a new subscriber with a list of filters.
 func (s *SignedVaaSubscribers) Register(fi []filterSignedVaa) *subscriptionSignedVaa {
+	return s.register(fi, false)
+}
+
+// RegisterReplay registers a new subscriber with a list of filters whose backlog is going to be replayed.
+// The VAAs received meanwhile are kept in the buffer of the subscriber. Until the replay ends, the buffer
+// uses the disconnect policy whatever the configured one is, so that the client is notified of the lost
+// VAAs and can resume from the last one received instead of having a gap.
+func (s *SignedVaaSubscribers) RegisterReplay(fi []filterSignedVaa) *subscriptionSignedVaa {
+	return s.register(fi, true)
+}
+
+func (s *SignedVaaSubscribers) register(fi []filterSignedVaa, replaying bool) *subscriptionSignedVaa {
+	bufferConfig := s.bufferConfig
+	if replaying {
+		bufferConfig.Policy = OverflowDisconnect
+	}
 	sub := &subscriptionSignedVaa{
-		id:      subscriptionId(),
-		ch:      make(chan message, 1),
-		filters: fi,
+		id:         subscriptionId(),
+		buffer:     newSubscriptionBuffer[message](bufferConfig),
+		filters:    fi,
+		createdAt:  time.Now(),
+		registered: make(chan struct{}),
+		replaying:  replaying,
 	}
-	s.logger.Info("Registering subscriber in signed VAAs ...", zap.String("id", sub.id))
+	s.logger.Info("Registering subscriber in signed VAAs ...", zap.String("id", sub.id), zap.Bool("replaying", replaying))
 	s.addSubscriber <- sub
 	return sub
 }
@@ -61,20 +97,70 @@ func (s *SignedVaaSubscribers) Register(fi []filterSignedVaa) *subscriptionSigne
 // Unregister removes a subscriber.
 func (s *SignedVaaSubscribers) Unregister(sub *subscriptionSignedVaa) {
 	s.logger.Info("Unregistering subscriber in signed VAAs ...", zap.String("id", sub.id))
+	// the buffer is closed first to release the dispatch loop if it is blocked on this subscriber.
+	sub.buffer.close()
 	s.removeSubscriber <- sub
 }
 
+// Next waits for the next VAA of a subscriber. It fails when the subscriber overflowed its buffer
+// with the disconnect policy, or when the subscribers are stopped.
+func (s *SignedVaaSubscribers) Next(ctx context.Context, sub *subscriptionSignedVaa) (message, error) {
+	msg, lag, err := sub.buffer.next(ctx)
+	if err != nil {
+		return msg, subscriptionError(err, "signed VAA")
+	}
+	s.metrics.SetSubscriberLag(signedVaaSubscriptionType, sub.id, lag)
+	return msg, nil
+}
+
+// EndReplay marks the end of the backlog of a replaying subscriber and restores the configured overflow
+// policy of its buffer. The VAAs received while it was replaying are the first ones returned by Next.
+func (s *SignedVaaSubscribers) EndReplay(sub *subscriptionSignedVaa) {
+	s.mu.Lock()
+	sub.replaying = false
+	s.mu.Unlock()
+	sub.buffer.setPolicy(s.bufferConfig.Policy)
+	buffered, dropped := sub.buffer.stats()
+	s.logger.Info("Subscriber replay finished in signed VAAs",
+		zap.String("id", sub.id), zap.Int("pending", buffered), zap.Uint64("dropped", dropped))
+}
+
 // HandleVAA sends a VAA to subscribers that filters apply the conditions.
 func (s *SignedVaaSubscribers) HandleVAA(vaas []byte) error {
 	s.source <- vaas
 	return nil
 }
 
+// Subscriptions returns the active subscriptions.
+func (s *SignedVaaSubscribers) Subscriptions() []SubscriptionInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	subscriptions := make([]SubscriptionInfo, 0, len(s.subscribers))
+	for _, sub := range s.subscribers {
+		info := SubscriptionInfo{
+			ID:         sub.id,
+			Type:       signedVaaSubscriptionType,
+			CreatedAt:  sub.createdAt,
+			Replaying:  sub.replaying,
+			BufferSize: sub.buffer.size,
+		}
+		info.Buffered, info.Dropped = sub.buffer.stats()
+		for _, fi := range sub.filters {
+			info.Emitters = append(info.Emitters, EmitterFilterInfo{
+				ChainID:        uint16(fi.chainId),
+				EmitterAddress: fi.emitterAddr.String(),
+			})
+		}
+		subscriptions = append(subscriptions, info)
+	}
+	return subscriptions
+}
+
 func (s *SignedVaaSubscribers) Start(ctx context.Context) {
 	defer func() {
 		for _, subscriberByID := range s.subscribers {
 			if subscriberByID != nil {
-				close(subscriberByID.ch)
+				subscriberByID.buffer.close()
 			}
 		}
 	}()
@@ -84,12 +170,18 @@ func (s *SignedVaaSubscribers) Start(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case newSubscriber := <-s.addSubscriber:
+			s.mu.Lock()
 			s.subscribers[newSubscriber.id] = newSubscriber
+			s.mu.Unlock()
+			close(newSubscriber.registered)
 			s.logger.Info("New subscriber registered in signed VAAs", zap.String("id", newSubscriber.id))
 		case subscriberToRemove := <-s.removeSubscriber:
 			if subscriber, exists := s.subscribers[subscriberToRemove.id]; exists {
-				close(subscriber.ch)
+				subscriber.buffer.close()
+				s.mu.Lock()
 				delete(s.subscribers, subscriberToRemove.id)
+				s.mu.Unlock()
+				s.metrics.DeleteSubscriber(signedVaaSubscriptionType, subscriber.id)
 				s.logger.Info("Subscriber unregistered in signed VAAs", zap.String("id", subscriber.id))
 			}
 		case vaas, ok := <-s.source:
@@ -99,33 +191,33 @@ func (s *SignedVaaSubscribers) Start(ctx context.Context) {
 			var v *vaa.VAA
 
 			for _, sub := range s.subscribers {
-				if len(sub.filters) == 0 {
-					select {
-					case sub.ch <- message{vaaBytes: vaas}:
-					default:
-					}
-					continue
-				}
-
-				if v == nil {
-					var err error
-					v, err = vaa.Unmarshal(vaas)
-					if err != nil {
-						s.logger.Error("Unmarshal vaa in signed VAAs", zap.Error(err))
-						break
-					}
-				}
-
-				for _, fi := range sub.filters {
-					if fi.chainId == v.EmitterChain && fi.emitterAddr == v.EmitterAddress {
-						select {
-						case sub.ch <- message{vaaBytes: vaas}:
-						default:
+				if len(sub.filters) > 0 {
+					if v == nil {
+						var err error
+						v, err = vaa.Unmarshal(vaas)
+						if err != nil {
+							s.logger.Error("Unmarshal vaa in signed VAAs", zap.Error(err))
+							break
 						}
 					}
+					if !matchSignedVaa(sub.filters, v) {
+						continue
+					}
 				}
 
+				result, lag := sub.buffer.push(ctx, message{vaaBytes: vaas})
+				recordPush(s.metrics, s.logger, signedVaaSubscriptionType, sub.id, result, lag)
 			}
 		}
 	}
 }
+
+// matchSignedVaa checks if a VAA applies the conditions of any of the filters.
+func matchSignedVaa(filters []filterSignedVaa, v *vaa.VAA) bool {
+	for _, fi := range filters {
+		if fi.chainId == v.EmitterChain && fi.emitterAddr == v.EmitterAddress {
+			return true
+		}
+	}
+	return false
+}
@@ -1,12 +1,16 @@
 package grpc
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/spy/internal/metrics"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var emitterAddr = vaa.Address{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 4}
@@ -33,7 +37,7 @@ func createVAA(chainID vaa.ChainID, emitterAddr vaa.Address) *vaa.VAA {
 func TestSignedVaaSubscribers_Register(t *testing.T) {
 	logger := zaptest.NewLogger(t)
 	var fi []filterSignedVaa
-	svs := NewSignedVaaSubscribers(logger)
+	svs := NewSignedVaaSubscribers(testBufferConfig, metrics.NewDummyMetrics(), logger)
 	sub := svs.Register(fi)
 	assert.NotNil(t, sub)
 	assert.NotEmpty(t, sub.id)
@@ -42,7 +46,7 @@ func TestSignedVaaSubscribers_Register(t *testing.T) {
 func TestSignedVaaSubscribers_Unregister(t *testing.T) {
 	logger := zaptest.NewLogger(t)
 	var fi []filterSignedVaa
-	svs := NewSignedVaaSubscribers(logger)
+	svs := NewSignedVaaSubscribers(testBufferConfig, metrics.NewDummyMetrics(), logger)
 	sub := svs.Register(fi)
 	assert.Equal(t, 1, len(svs.addSubscriber))
 	svs.Unregister(sub)
@@ -54,7 +58,7 @@ func TestSignedVaaSubscribers_HandleVAA(t *testing.T) {
 	t.Run("empty filters", func(t *testing.T) {
 		logger := zaptest.NewLogger(t)
 		var fi []filterSignedVaa
-		svs := NewSignedVaaSubscribers(logger)
+		svs := NewSignedVaaSubscribers(testBufferConfig, metrics.NewDummyMetrics(), logger)
 		svs.Register(fi)
 
 		vaas := []byte{0x0, 0x1, 0x2, 0x3}
@@ -72,7 +76,7 @@ func TestSignedVaaSubscribers_HandleVAA(t *testing.T) {
 				emitterAddr: vaa.Address{0x0, 0x1},
 			},
 		}
-		svs := NewSignedVaaSubscribers(logger)
+		svs := NewSignedVaaSubscribers(testBufferConfig, metrics.NewDummyMetrics(), logger)
 		_ = svs.Register(fi)
 
 		vaas := []byte{0x0, 0x1, 0x2, 0x3}
@@ -88,12 +92,129 @@ func TestSignedVaaSubscribers_HandleVAA(t *testing.T) {
 				emitterAddr: vaa.Address{0x0, 0x1},
 			},
 		}
-		svs := NewSignedVaaSubscribers(logger)
+		svs := NewSignedVaaSubscribers(testBufferConfig, metrics.NewDummyMetrics(), logger)
 		sub := svs.Register(fi)
 		vaa := createVAA(vaa.ChainIDEthereum, emitterAddr)
 		vaaBytes, _ := vaa.MarshalBinary()
 		err := svs.HandleVAA(vaaBytes)
 		assert.Nil(t, err)
-		assert.Equal(t, 0, len(sub.ch))
+		buffered, _ := sub.buffer.stats()
+		assert.Equal(t, 0, buffered)
 	})
 }
+
+func TestSignedVaaSubscribers_Subscriptions(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	fi := []filterSignedVaa{
+		{
+			chainId:     vaa.ChainIDEthereum,
+			emitterAddr: emitterAddr,
+		},
+	}
+	svs := NewSignedVaaSubscribers(testBufferConfig, metrics.NewDummyMetrics(), logger)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go svs.Start(ctx)
+
+	sub := svs.Register(fi)
+	<-sub.registered
+
+	subscriptions := svs.Subscriptions()
+	assert.Equal(t, 1, len(subscriptions))
+	assert.Equal(t, sub.id, subscriptions[0].ID)
+	assert.Equal(t, "signed-vaa", subscriptions[0].Type)
+	assert.Equal(t, testBufferConfig.Size, subscriptions[0].BufferSize)
+	assert.Equal(t, []EmitterFilterInfo{{ChainID: uint16(vaa.ChainIDEthereum), EmitterAddress: emitterAddr.String()}},
+		subscriptions[0].Emitters)
+}
+
+func TestSignedVaaSubscribers_Replay(t *testing.T) {
+	var tests = []struct {
+		name             string
+		policy           OverflowPolicy
+		received         uint64
+		expectedErr      bool
+		expectedSequence []uint64
+	}{
+		{
+			name:             "within the buffer",
+			policy:           OverflowDropOldest,
+			received:         2,
+			expectedSequence: []uint64{1, 2},
+		},
+		{
+			name:        "drop oldest overflow",
+			policy:      OverflowDropOldest,
+			received:    5,
+			expectedErr: true,
+		},
+		{
+			name:        "disconnect overflow",
+			policy:      OverflowDisconnect,
+			received:    5,
+			expectedErr: true,
+		},
+		{
+			name:        "block overflow",
+			policy:      OverflowBlock,
+			received:    5,
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := zaptest.NewLogger(t)
+			svs := NewSignedVaaSubscribers(BufferConfig{Size: 2, Policy: tc.policy}, metrics.NewDummyMetrics(), logger)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go svs.Start(ctx)
+
+			sub := svs.RegisterReplay(nil)
+			<-sub.registered
+
+			// the VAAs received while replaying must fit in the buffer of the subscriber, whatever its policy is.
+			for sequence := uint64(1); sequence <= tc.received; sequence++ {
+				assert.Nil(t, svs.HandleVAA(createVAABytes(sequence)))
+			}
+			assert.Eventually(t, func() bool {
+				select {
+				case <-sub.buffer.done:
+					return true
+				default:
+					buffered, _ := sub.buffer.stats()
+					return uint64(buffered) == tc.received
+				}
+			}, time.Second, 10*time.Millisecond)
+
+			subscriptions := svs.Subscriptions()
+			assert.True(t, subscriptions[0].Replaying)
+			svs.EndReplay(sub)
+			subscriptions = svs.Subscriptions()
+			assert.False(t, subscriptions[0].Replaying)
+			assert.Equal(t, uint64(0), subscriptions[0].Dropped)
+
+			if tc.expectedErr {
+				_, err := svs.Next(ctx, sub)
+				assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+				return
+			}
+			for _, sequence := range tc.expectedSequence {
+				msg, err := svs.Next(ctx, sub)
+				assert.Nil(t, err)
+				v, err := vaa.Unmarshal(msg.vaaBytes)
+				assert.Nil(t, err)
+				assert.Equal(t, sequence, v.Sequence)
+			}
+
+			// the configured policy applies once the replay ends.
+			for sequence := uint64(3); sequence <= 5; sequence++ {
+				assert.Nil(t, svs.HandleVAA(createVAABytes(sequence)))
+			}
+			assert.Eventually(t, func() bool {
+				_, dropped := sub.buffer.stats()
+				return dropped == 1
+			}, time.Second, 10*time.Millisecond)
+		})
+	}
+}
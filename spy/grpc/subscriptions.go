@@ -0,0 +1,58 @@
+package grpc
+
+import (
+	"errors"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/spy/internal/metrics"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SubscriptionInfo describes an active subscription.
+type SubscriptionInfo struct {
+	ID         string              `json:"id"`
+	Type       string              `json:"type"`
+	Emitters   []EmitterFilterInfo `json:"emitters,omitempty"`
+	Guardians  []string            `json:"guardians,omitempty"`
+	Chains     []uint16            `json:"chains,omitempty"`
+	Replaying  bool                `json:"replaying"`
+	Buffered   int                 `json:"buffered"`
+	BufferSize int                 `json:"bufferSize"`
+	Dropped    uint64              `json:"dropped"`
+	CreatedAt  time.Time           `json:"createdAt"`
+}
+
+// EmitterFilterInfo is an emitter filter of a signed VAA subscription.
+type EmitterFilterInfo struct {
+	ChainID        uint16 `json:"chainId"`
+	EmitterAddress string `json:"emitterAddress"`
+}
+
+// recordPush updates the metrics of a subscriber with the result of pushing a message to its buffer.
+func recordPush(m metrics.Metrics, logger *zap.Logger, subscriptionType, id string, result pushResult, lag int) {
+	switch result {
+	case pushOK:
+		m.SetSubscriberLag(subscriptionType, id, lag)
+	case pushDropped:
+		m.SetSubscriberLag(subscriptionType, id, lag)
+		m.IncSubscriberDropped(subscriptionType, id)
+	case pushOverflow:
+		m.IncSubscriberDisconnected(subscriptionType)
+		logger.Warn("Subscriber buffer overflow, disconnecting",
+			zap.String("type", subscriptionType), zap.String("id", id))
+	}
+}
+
+// subscriptionError converts the error of reading the buffer of a subscription to a GRPC status.
+func subscriptionError(err error, name string) error {
+	switch {
+	case errors.Is(err, errBufferOverflow):
+		return status.Error(codes.ResourceExhausted, name+" subscription buffer overflow")
+	case errors.Is(err, errBufferClosed):
+		return status.Error(codes.Unavailable, name+" subscription closed")
+	default:
+		return err
+	}
+}
@@ -5,19 +5,24 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/wormhole-foundation/wormhole-explorer/common/health"
+	"github.com/wormhole-foundation/wormhole-explorer/spy/grpc"
 	"go.uber.org/zap"
 )
 
+// SubscriptionsFunc returns the active subscriptions.
+type SubscriptionsFunc func() []grpc.SubscriptionInfo
+
 // Controller definition.
 // Controller definition.
 type Controller struct {
-	checks []health.Check
-	logger *zap.Logger
+	checks        []health.Check
+	subscriptions SubscriptionsFunc
+	logger        *zap.Logger
 }
 
 // NewController creates a Controller instance.
-func NewController(checks []health.Check, logger *zap.Logger) *Controller {
-	return &Controller{checks: checks, logger: logger}
+func NewController(checks []health.Check, subscriptions SubscriptionsFunc, logger *zap.Logger) *Controller {
+	return &Controller{checks: checks, subscriptions: subscriptions, logger: logger}
 }
 
 // HealthCheck handler for the endpoint /health.
@@ -45,3 +50,12 @@ func (c *Controller) ReadyCheck(ctx *fiber.Ctx) error {
 	}{Ready: "OK"})
 
 }
+
+// Subscribers handler for the endpoint /subscribers.
+func (c *Controller) Subscribers(ctx *fiber.Ctx) error {
+	subscriptions := c.subscriptions()
+	return ctx.JSON(struct {
+		Count         int                     `json:"count"`
+		Subscriptions []grpc.SubscriptionInfo `json:"subscriptions"`
+	}{Count: len(subscriptions), Subscriptions: subscriptions})
+}
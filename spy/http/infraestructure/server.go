@@ -2,7 +2,9 @@ package infraestructure
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/pprof"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/wormhole-foundation/wormhole-explorer/common/health"
 	"go.uber.org/zap"
 )
@@ -13,13 +15,16 @@ type Server struct {
 	logger *zap.Logger
 }
 
-func NewServer(logger *zap.Logger, port string, pprofEnabled bool, checks ...health.Check) *Server {
-	ctrl := NewController(checks, logger)
+func NewServer(logger *zap.Logger, port string, pprofEnabled bool, subscriptions SubscriptionsFunc, checks ...health.Check) *Server {
+	ctrl := NewController(checks, subscriptions, logger)
 	app := fiber.New(fiber.Config{DisableStartupMessage: true})
 	if pprofEnabled {
 		app.Use(pprof.New())
 	}
 
+	app.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
+	app.Get("/subscribers", ctrl.Subscribers)
+
 	api := app.Group("/api")
 	api.Get("/health", ctrl.HealthCheck)
 	api.Get("/ready", ctrl.ReadyCheck)
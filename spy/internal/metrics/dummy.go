@@ -0,0 +1,21 @@
+package metrics
+
+// DummyMetrics is a dummy implementation of Metric interface.
+type DummyMetrics struct{}
+
+// NewDummyMetrics returns a new instance of DummyMetrics.
+func NewDummyMetrics() *DummyMetrics {
+	return &DummyMetrics{}
+}
+
+// SetSubscriberLag dummy implementation.
+func (d *DummyMetrics) SetSubscriberLag(subscriptionType string, id string, lag int) {}
+
+// IncSubscriberDropped dummy implementation.
+func (d *DummyMetrics) IncSubscriberDropped(subscriptionType string, id string) {}
+
+// IncSubscriberDisconnected dummy implementation.
+func (d *DummyMetrics) IncSubscriberDisconnected(subscriptionType string) {}
+
+// DeleteSubscriber dummy implementation.
+func (d *DummyMetrics) DeleteSubscriber(subscriptionType string, id string) {}
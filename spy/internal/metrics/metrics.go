@@ -0,0 +1,11 @@
+package metrics
+
+const serviceName = "wormscan-spy"
+
+// Metrics is the metrics of the spy subscriptions.
+type Metrics interface {
+	SetSubscriberLag(subscriptionType string, id string, lag int)
+	IncSubscriberDropped(subscriptionType string, id string)
+	IncSubscriberDisconnected(subscriptionType string)
+	DeleteSubscriber(subscriptionType string, id string)
+}
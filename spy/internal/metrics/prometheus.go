@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// PrometheusMetrics is a Prometheus implementation of Metric interface.
+type PrometheusMetrics struct {
+	subscriberLag          *prometheus.GaugeVec
+	subscriberDroppedCount *prometheus.CounterVec
+	subscriberDisconnected *prometheus.CounterVec
+}
+
+// NewPrometheusMetrics returns a new instance of PrometheusMetrics.
+func NewPrometheusMetrics(environment string) *PrometheusMetrics {
+	return &PrometheusMetrics{
+		subscriberLag: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "wormscan_spy_subscriber_lag",
+				Help: "The number of messages buffered and not sent yet to a subscriber",
+				ConstLabels: map[string]string{
+					"environment": environment,
+					"service":     serviceName,
+				},
+			}, []string{"type", "subscription"}),
+		subscriberDroppedCount: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "wormscan_spy_subscriber_dropped_count",
+				Help: "The total number of messages dropped because the buffer of a subscriber was full",
+				ConstLabels: map[string]string{
+					"environment": environment,
+					"service":     serviceName,
+				},
+			}, []string{"type", "subscription"}),
+		subscriberDisconnected: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "wormscan_spy_subscriber_disconnected_count",
+				Help: "The total number of subscribers disconnected because their buffer was full",
+				ConstLabels: map[string]string{
+					"environment": environment,
+					"service":     serviceName,
+				},
+			}, []string{"type"}),
+	}
+}
+
+// SetSubscriberLag sets the number of messages buffered for a subscriber.
+func (m *PrometheusMetrics) SetSubscriberLag(subscriptionType string, id string, lag int) {
+	m.subscriberLag.WithLabelValues(subscriptionType, id).Set(float64(lag))
+}
+
+// IncSubscriberDropped increments the number of messages dropped for a subscriber.
+func (m *PrometheusMetrics) IncSubscriberDropped(subscriptionType string, id string) {
+	m.subscriberDroppedCount.WithLabelValues(subscriptionType, id).Inc()
+}
+
+// IncSubscriberDisconnected increments the number of subscribers disconnected.
+func (m *PrometheusMetrics) IncSubscriberDisconnected(subscriptionType string) {
+	m.subscriberDisconnected.WithLabelValues(subscriptionType).Inc()
+}
+
+// DeleteSubscriber removes the metrics of a subscriber, so that the series of the finished subscriptions are not kept.
+func (m *PrometheusMetrics) DeleteSubscriber(subscriptionType string, id string) {
+	m.subscriberLag.DeleteLabelValues(subscriptionType, id)
+	m.subscriberDroppedCount.DeleteLabelValues(subscriptionType, id)
+}
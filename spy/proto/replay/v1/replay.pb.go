@@ -0,0 +1,388 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        (unknown)
+// source: replay/v1/replay.proto
+
+package replayv1
+
+import (
+	v1 "github.com/certusone/wormhole/node/pkg/proto/publicrpc/v1"
+	v11 "github.com/certusone/wormhole/node/pkg/proto/spy/v1"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// EmitterSequence is the first sequence of an emitter to replay.
+type EmitterSequence struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChainId v1.ChainID `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3,enum=publicrpc.v1.ChainID" json:"chain_id,omitempty"`
+	// Hex encoded emitter address.
+	EmitterAddress string `protobuf:"bytes,2,opt,name=emitter_address,json=emitterAddress,proto3" json:"emitter_address,omitempty"`
+	Sequence       uint64 `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+}
+
+func (x *EmitterSequence) Reset() {
+	*x = EmitterSequence{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_replay_v1_replay_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EmitterSequence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmitterSequence) ProtoMessage() {}
+
+func (x *EmitterSequence) ProtoReflect() protoreflect.Message {
+	mi := &file_replay_v1_replay_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmitterSequence.ProtoReflect.Descriptor instead.
+func (*EmitterSequence) Descriptor() ([]byte, []int) {
+	return file_replay_v1_replay_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EmitterSequence) GetChainId() v1.ChainID {
+	if x != nil {
+		return x.ChainId
+	}
+	return v1.ChainID(0)
+}
+
+func (x *EmitterSequence) GetEmitterAddress() string {
+	if x != nil {
+		return x.EmitterAddress
+	}
+	return ""
+}
+
+func (x *EmitterSequence) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type EmitterSequences struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sequences []*EmitterSequence `protobuf:"bytes,1,rep,name=sequences,proto3" json:"sequences,omitempty"`
+}
+
+func (x *EmitterSequences) Reset() {
+	*x = EmitterSequences{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_replay_v1_replay_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EmitterSequences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmitterSequences) ProtoMessage() {}
+
+func (x *EmitterSequences) ProtoReflect() protoreflect.Message {
+	mi := &file_replay_v1_replay_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmitterSequences.ProtoReflect.Descriptor instead.
+func (*EmitterSequences) Descriptor() ([]byte, []int) {
+	return file_replay_v1_replay_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EmitterSequences) GetSequences() []*EmitterSequence {
+	if x != nil {
+		return x.Sequences
+	}
+	return nil
+}
+
+type SubscribeSignedVAARequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The filters apply to the replayed and the received signed VAAs. Empty matches every VAA.
+	Filters []*v11.FilterEntry `protobuf:"bytes,1,rep,name=filters,proto3" json:"filters,omitempty"`
+	// Without a starting point only the received signed VAAs are sent.
+	//
+	// Types that are assignable to Start:
+	//	*SubscribeSignedVAARequest_StartTime
+	//	*SubscribeSignedVAARequest_StartSequences
+	Start isSubscribeSignedVAARequest_Start `protobuf_oneof:"start"`
+}
+
+func (x *SubscribeSignedVAARequest) Reset() {
+	*x = SubscribeSignedVAARequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_replay_v1_replay_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeSignedVAARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeSignedVAARequest) ProtoMessage() {}
+
+func (x *SubscribeSignedVAARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_replay_v1_replay_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeSignedVAARequest.ProtoReflect.Descriptor instead.
+func (*SubscribeSignedVAARequest) Descriptor() ([]byte, []int) {
+	return file_replay_v1_replay_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SubscribeSignedVAARequest) GetFilters() []*v11.FilterEntry {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (m *SubscribeSignedVAARequest) GetStart() isSubscribeSignedVAARequest_Start {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (x *SubscribeSignedVAARequest) GetStartTime() *timestamppb.Timestamp {
+	if x, ok := x.GetStart().(*SubscribeSignedVAARequest_StartTime); ok {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *SubscribeSignedVAARequest) GetStartSequences() *EmitterSequences {
+	if x, ok := x.GetStart().(*SubscribeSignedVAARequest_StartSequences); ok {
+		return x.StartSequences
+	}
+	return nil
+}
+
+type isSubscribeSignedVAARequest_Start interface {
+	isSubscribeSignedVAARequest_Start()
+}
+
+type SubscribeSignedVAARequest_StartTime struct {
+	// The signed VAAs stored since this time are replayed.
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3,oneof"`
+}
+
+type SubscribeSignedVAARequest_StartSequences struct {
+	// The signed VAAs of each emitter from its sequence are replayed. The other emitters are not replayed.
+	// The signed VAAs are replayed in the order they were stored, which may differ from the order of the
+	// sequences of an emitter. The stream fails with OUT_OF_RANGE if a sequence is older than the replay window.
+	StartSequences *EmitterSequences `protobuf:"bytes,3,opt,name=start_sequences,json=startSequences,proto3,oneof"`
+}
+
+func (*SubscribeSignedVAARequest_StartTime) isSubscribeSignedVAARequest_Start() {}
+
+func (*SubscribeSignedVAARequest_StartSequences) isSubscribeSignedVAARequest_Start() {}
+
+var File_replay_v1_replay_proto protoreflect.FileDescriptor
+
+var file_replay_v1_replay_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x65, 0x70, 0x6c,
+	0x61, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x79,
+	0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2f,
+	0x76, 0x31, 0x2f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x10, 0x73, 0x70, 0x79, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x70, 0x79, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x88, 0x01, 0x0a, 0x0f, 0x45, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72,
+	0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x30, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x69,
+	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x70, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x49,
+	0x44, 0x52, 0x07, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x6d,
+	0x69, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x22,
+	0x4c, 0x0a, 0x10, 0x45, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e,
+	0x63, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x09, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e,
+	0x63, 0x65, 0x52, 0x09, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x22, 0xd8, 0x01,
+	0x0a, 0x19, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x65,
+	0x64, 0x56, 0x41, 0x41, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x07, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x73,
+	0x70, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x3b, 0x0a, 0x0a, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x48, 0x00, 0x52, 0x09, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x46, 0x0a, 0x0f, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x5f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x69,
+	0x74, 0x74, 0x65, 0x72, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x48, 0x00, 0x52,
+	0x0e, 0x73, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x42,
+	0x07, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x32, 0x74, 0x0a, 0x10, 0x52, 0x65, 0x70, 0x6c,
+	0x61, 0x79, 0x52, 0x50, 0x43, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x60, 0x0a, 0x12,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x56,
+	0x41, 0x41, 0x12, 0x24, 0x2e, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x56, 0x41,
+	0x41, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x73, 0x70, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x65,
+	0x64, 0x56, 0x41, 0x41, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x42, 0x4f,
+	0x5a, 0x4d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x77, 0x6f, 0x72,
+	0x6d, 0x68, 0x6f, 0x6c, 0x65, 0x2d, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2f, 0x77, 0x6f, 0x72, 0x6d, 0x68, 0x6f, 0x6c, 0x65, 0x2d, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72,
+	0x65, 0x72, 0x2f, 0x73, 0x70, 0x79, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x65, 0x70,
+	0x6c, 0x61, 0x79, 0x2f, 0x76, 0x31, 0x3b, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x76, 0x31, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_replay_v1_replay_proto_rawDescOnce sync.Once
+	file_replay_v1_replay_proto_rawDescData = file_replay_v1_replay_proto_rawDesc
+)
+
+func file_replay_v1_replay_proto_rawDescGZIP() []byte {
+	file_replay_v1_replay_proto_rawDescOnce.Do(func() {
+		file_replay_v1_replay_proto_rawDescData = protoimpl.X.CompressGZIP(file_replay_v1_replay_proto_rawDescData)
+	})
+	return file_replay_v1_replay_proto_rawDescData
+}
+
+var file_replay_v1_replay_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_replay_v1_replay_proto_goTypes = []interface{}{
+	(*EmitterSequence)(nil),                // 0: replay.v1.EmitterSequence
+	(*EmitterSequences)(nil),               // 1: replay.v1.EmitterSequences
+	(*SubscribeSignedVAARequest)(nil),      // 2: replay.v1.SubscribeSignedVAARequest
+	(v1.ChainID)(0),                        // 3: publicrpc.v1.ChainID
+	(*v11.FilterEntry)(nil),                // 4: spy.v1.FilterEntry
+	(*timestamppb.Timestamp)(nil),          // 5: google.protobuf.Timestamp
+	(*v11.SubscribeSignedVAAResponse)(nil), // 6: spy.v1.SubscribeSignedVAAResponse
+}
+var file_replay_v1_replay_proto_depIdxs = []int32{
+	3, // 0: replay.v1.EmitterSequence.chain_id:type_name -> publicrpc.v1.ChainID
+	0, // 1: replay.v1.EmitterSequences.sequences:type_name -> replay.v1.EmitterSequence
+	4, // 2: replay.v1.SubscribeSignedVAARequest.filters:type_name -> spy.v1.FilterEntry
+	5, // 3: replay.v1.SubscribeSignedVAARequest.start_time:type_name -> google.protobuf.Timestamp
+	1, // 4: replay.v1.SubscribeSignedVAARequest.start_sequences:type_name -> replay.v1.EmitterSequences
+	2, // 5: replay.v1.ReplayRPCService.SubscribeSignedVAA:input_type -> replay.v1.SubscribeSignedVAARequest
+	6, // 6: replay.v1.ReplayRPCService.SubscribeSignedVAA:output_type -> spy.v1.SubscribeSignedVAAResponse
+	6, // [6:7] is the sub-list for method output_type
+	5, // [5:6] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_replay_v1_replay_proto_init() }
+func file_replay_v1_replay_proto_init() {
+	if File_replay_v1_replay_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_replay_v1_replay_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EmitterSequence); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_replay_v1_replay_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EmitterSequences); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_replay_v1_replay_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeSignedVAARequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_replay_v1_replay_proto_msgTypes[2].OneofWrappers = []interface{}{
+		(*SubscribeSignedVAARequest_StartTime)(nil),
+		(*SubscribeSignedVAARequest_StartSequences)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_replay_v1_replay_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_replay_v1_replay_proto_goTypes,
+		DependencyIndexes: file_replay_v1_replay_proto_depIdxs,
+		MessageInfos:      file_replay_v1_replay_proto_msgTypes,
+	}.Build()
+	File_replay_v1_replay_proto = out.File
+	file_replay_v1_replay_proto_rawDesc = nil
+	file_replay_v1_replay_proto_goTypes = nil
+	file_replay_v1_replay_proto_depIdxs = nil
+}
@@ -0,0 +1,163 @@
+package source
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+const gossipWatcherRetryDelay = 5 * time.Second
+
+// GossipHandlers are the functions to send the gossip messages stored by fly.
+type GossipHandlers struct {
+	SignedObservation func(*gossipv1.SignedObservation)
+	Heartbeat         func(*gossipv1.Heartbeat)
+	GovernorStatus    func(*gossipv1.SignedChainGovernorStatus)
+}
+
+// GossipWatcher is a listener of the observations, heartbeats and governor status stored by fly.
+// Like a guardian spy, it only sends the live messages, so the stream is not resumed from a checkpoint.
+type GossipWatcher struct {
+	db       *mongo.Database
+	dbName   string
+	handlers GossipHandlers
+	logger   *zap.Logger
+}
+
+type gossipChangeEvent struct {
+	Ns struct {
+		Coll string `bson:"coll"`
+	} `bson:"ns"`
+	FullDocument bson.Raw `bson:"fullDocument"`
+}
+
+// observationDoc is the observation document stored by fly.
+type observationDoc struct {
+	MessageID    string `bson:"messageId"`
+	Hash         []byte `bson:"hash"`
+	TxHash       []byte `bson:"txHash"`
+	GuardianAddr string `bson:"guardianAddr"`
+	Signature    []byte `bson:"signature"`
+}
+
+// NewGossipWatcher creates a new watcher of the gossip messages stored by fly.
+func NewGossipWatcher(db *mongo.Database, dbName string, handlers GossipHandlers, logger *zap.Logger) *GossipWatcher {
+	return &GossipWatcher{
+		db:       db,
+		dbName:   dbName,
+		handlers: handlers,
+		logger:   logger,
+	}
+}
+
+// Start opens the change stream and consumes it in background until the context is done.
+func (w *GossipWatcher) Start(ctx context.Context) error {
+	stream, err := w.open(ctx)
+	if err != nil {
+		return err
+	}
+	go w.run(ctx, stream)
+	return nil
+}
+
+func (w *GossipWatcher) open(ctx context.Context) (*mongo.ChangeStream, error) {
+	var namespaces bson.A
+	for _, coll := range []string{repository.Observations, repository.Heartbeats, repository.GovernorStatus} {
+		namespaces = append(namespaces, bson.D{{Key: "db", Value: w.dbName}, {Key: "coll", Value: coll}})
+	}
+	pipeline := []bson.D{{{Key: "$match", Value: bson.D{
+		{Key: "operationType", Value: bson.D{{Key: "$in", Value: bson.A{"insert", "update", "replace"}}}},
+		{Key: "ns", Value: bson.D{{Key: "$in", Value: namespaces}}},
+	}}}}
+
+	// fly upserts the heartbeats and the governor status, so the full document of the updates is required.
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	return w.db.Watch(ctx, pipeline, opts)
+}
+
+func (w *GossipWatcher) run(ctx context.Context, stream *mongo.ChangeStream) {
+	for {
+		err := w.consume(ctx, stream)
+		if ctx.Err() != nil {
+			return
+		}
+		w.logger.Error("gossip change stream stopped, restarting", zap.Error(err))
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(gossipWatcherRetryDelay):
+			}
+			stream, err = w.open(ctx)
+			if err == nil {
+				break
+			}
+			w.logger.Error("cannot restart gossip change stream", zap.Error(err))
+		}
+	}
+}
+
+func (w *GossipWatcher) consume(ctx context.Context, stream *mongo.ChangeStream) error {
+	defer stream.Close(context.Background())
+	for stream.Next(ctx) {
+		var e gossipChangeEvent
+		if err := stream.Decode(&e); err != nil {
+			w.logger.Error("Error unmarshalling gossip change event", zap.Error(err))
+			continue
+		}
+		// the document was deleted before the update lookup.
+		if e.FullDocument == nil {
+			continue
+		}
+		w.handle(e.Ns.Coll, e.FullDocument)
+	}
+	return stream.Err()
+}
+
+// handle sends a document as the gossip message it was created from. The heartbeats and the governor status
+// are stored by fly with the default field names of the gossip messages, so they are decoded into them.
+func (w *GossipWatcher) handle(coll string, doc bson.Raw) {
+	switch coll {
+	case repository.Observations:
+		var o observationDoc
+		if err := bson.Unmarshal(doc, &o); err != nil {
+			w.logger.Error("Error unmarshalling observation", zap.Error(err))
+			return
+		}
+		addr, err := hex.DecodeString(strings.TrimPrefix(o.GuardianAddr, "0x"))
+		if err != nil {
+			w.logger.Error("Error decoding observation guardian address",
+				zap.String("guardianAddr", o.GuardianAddr), zap.Error(err))
+			return
+		}
+		w.handlers.SignedObservation(&gossipv1.SignedObservation{
+			Addr:      addr,
+			Hash:      o.Hash,
+			Signature: o.Signature,
+			TxHash:    o.TxHash,
+			MessageId: o.MessageID,
+		})
+	case repository.Heartbeats:
+		var hb gossipv1.Heartbeat
+		if err := bson.Unmarshal(doc, &hb); err != nil {
+			w.logger.Error("Error unmarshalling heartbeat", zap.Error(err))
+			return
+		}
+		w.handlers.Heartbeat(&hb)
+	case repository.GovernorStatus:
+		var s gossipv1.SignedChainGovernorStatus
+		if err := bson.Unmarshal(doc, &s); err != nil {
+			w.logger.Error("Error unmarshalling governor status", zap.Error(err))
+			return
+		}
+		w.handlers.GovernorStatus(&s)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
@@ -13,6 +15,7 @@ import (
 type MongoWatcher struct {
 	db      *mongo.Database
 	dbName  string
+	id      string
 	handler WatcherFunc
 	logger  *zap.Logger
 }
@@ -20,15 +23,6 @@ type MongoWatcher struct {
 // WatcherFunc is a function to send database changes.
 type WatcherFunc func(*Event)
 
-type watchEvent struct {
-	DocumentKey    documentKey `bson:"documentKey"`
-	OperationType  string      `bson:"operationType"`
-	DbFullDocument Event       `bson:"fullDocument"`
-}
-type documentKey struct {
-	ID string `bson:"_id"`
-}
-
 // Event represents a database change.
 type Event struct {
 	ID   string `bson:"_id"`
@@ -47,10 +41,12 @@ const queryTemplate = `
 `
 
 // NewWatcher creates a new database event watcher.
-func NewMongoWatcher(db *mongo.Database, dbName string, handler WatcherFunc, logger *zap.Logger) *MongoWatcher {
+// The id identifies the watcher in the change stream checkpoints, so it must be unique for each spy instance.
+func NewMongoWatcher(db *mongo.Database, dbName string, id string, handler WatcherFunc, logger *zap.Logger) *MongoWatcher {
 	return &MongoWatcher{
 		db:      db,
 		dbName:  dbName,
+		id:      id,
 		handler: handler,
 		logger:  logger,
 	}
@@ -65,22 +61,21 @@ func (w *MongoWatcher) Start(ctx context.Context) error {
 		return err
 	}
 
-	stream, err := w.db.Watch(ctx, steps)
-	if err != nil {
-		return err
+	// the stream is resumed from the last checkpoint and the vaas inserted while it was stopped are also sent.
+	params := dbutil.ChangeStreamParams{
+		ID:                w.id,
+		Pipeline:          steps,
+		GapFillCollection: repository.Vaas,
 	}
-	go func() {
-		for stream.Next(ctx) {
-			var e watchEvent
-			if err := stream.Decode(&e); err != nil {
-				w.logger.Error("Error unmarshalling event", zap.Error(err))
-				continue
-			}
-			w.handler(&Event{
-				ID:   e.DbFullDocument.ID,
-				Vaas: e.DbFullDocument.Vaas,
-			})
-		}
-	}()
-	return nil
+	stream := dbutil.NewResumableChangeStream(w.db, params, w.handle, w.logger)
+	return stream.Start(ctx)
+}
+
+func (w *MongoWatcher) handle(_ context.Context, doc bson.Raw) {
+	var e Event
+	if err := bson.Unmarshal(doc, &e); err != nil {
+		w.logger.Error("Error unmarshalling event", zap.Error(err))
+		return
+	}
+	w.handler(&e)
 }
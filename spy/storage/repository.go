@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Repository is the repository of the signed VAAs stored by fly.
+type Repository struct {
+	db     *mongo.Database
+	logger *zap.Logger
+	vaas   *mongo.Collection
+}
+
+// VaaDoc is a signed VAA stored by fly.
+type VaaDoc struct {
+	ID        string    `bson:"_id"`
+	Vaas      []byte    `bson:"vaas"`
+	IndexedAt time.Time `bson:"indexedAt"`
+}
+
+// EmitterSequence is the first sequence of an emitter.
+type EmitterSequence struct {
+	ChainID  vaa.ChainID
+	Address  vaa.Address
+	Sequence uint64
+}
+
+// VaaQuery selects the signed VAAs indexed since a time and, when there are emitter sequences,
+// the VAAs of those emitters from their sequence.
+type VaaQuery struct {
+	IndexedFrom      time.Time
+	EmitterSequences []EmitterSequence
+}
+
+// NewRepository creates a new repository.
+func NewRepository(db *mongo.Database, logger *zap.Logger) *Repository {
+	return &Repository{
+		db:     db,
+		logger: logger,
+		vaas:   db.Collection(repository.Vaas),
+	}
+}
+
+// FindVaas calls the handler with the signed VAAs of the query in the order they were indexed.
+// With emitter sequences, the VAAs of an emitter are also in the order they were indexed, which may
+// differ from the order of their sequences. The iteration stops at the first error of the handler.
+func (r *Repository) FindVaas(ctx context.Context, q VaaQuery, handler func(*VaaDoc) error) error {
+	filter := bson.D{{Key: "indexedAt", Value: bson.D{{Key: "$gte", Value: q.IndexedFrom}}}}
+	if len(q.EmitterSequences) > 0 {
+		emitters := bson.A{}
+		for _, e := range q.EmitterSequences {
+			emitters = append(emitters, emitterSequenceFilter(e))
+		}
+		filter = append(filter, bson.E{Key: "$or", Value: emitters})
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "indexedAt", Value: 1}, {Key: "_id", Value: 1}}).
+		SetProjection(bson.D{{Key: "vaas", Value: 1}, {Key: "indexedAt", Value: 1}})
+	cur, err := r.vaas.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("failed to find vaas", zap.Error(err))
+		return err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var doc VaaDoc
+		if err := cur.Decode(&doc); err != nil {
+			r.logger.Error("failed to decode vaa", zap.Error(err))
+			return err
+		}
+		if err := handler(&doc); err != nil {
+			return err
+		}
+	}
+	return cur.Err()
+}
+
+// HasVaasIndexedBefore checks if an emitter has signed VAAs from a sequence that were indexed before a time.
+func (r *Repository) HasVaasIndexedBefore(ctx context.Context, e EmitterSequence, before time.Time) (bool, error) {
+	filter := append(emitterSequenceFilter(e), bson.E{Key: "indexedAt", Value: bson.D{{Key: "$lt", Value: before}}})
+	err := r.vaas.FindOne(ctx, filter, options.FindOne().SetProjection(bson.D{{Key: "_id", Value: 1}})).Err()
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return false, nil
+	}
+	if err != nil {
+		r.logger.Error("failed to find vaas indexed before", zap.Error(err))
+		return false, err
+	}
+	return true, nil
+}
+
+// emitterSequenceFilter selects the signed VAAs of an emitter from a sequence.
+func emitterSequenceFilter(e EmitterSequence) bson.D {
+	// the sequence is stored as a string, so it is converted to compare it.
+	return bson.D{
+		{Key: "emitterChain", Value: e.ChainID},
+		{Key: "emitterAddr", Value: e.Address.String()},
+		{Key: "$expr", Value: bson.D{{Key: "$gte", Value: bson.A{
+			bson.D{{Key: "$toDecimal", Value: "$sequence"}},
+			bson.D{{Key: "$toDecimal", Value: strconv.FormatUint(e.Sequence, 10)}},
+		}}}},
+	}
+}